@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fethcher/internal/config"
+	"fethcher/internal/migrate"
+	"fethcher/pkg/log"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+var errMigrateUsage = errors.New("usage: fethcher migrate status|up|down|to <version>")
+
+// Migrate runs the migrate command: status lists the migrations, up applies the pending ones, down rolls back the most
+// recently applied one and to migrates up or down to the given version, 0 rolling back every migration.
+func Migrate(args []string) error {
+	if len(args) == 0 {
+		return errMigrateUsage
+	}
+
+	logger := log.NewZapLogger("fethcher-migrate", zapcore.InfoLevel)
+
+	config, err := config.NewMigrateConfig()
+	if err != nil {
+		logger.Errorw("failed to create config", "error", err)
+		return err
+	}
+
+	_, gormDB, err := openDatabase(config.DBDriver, config.DBConnectionString)
+	if err != nil {
+		logger.Errorw("failed to connect to database", "error", err, "driver", config.DBDriver)
+		return err
+	}
+
+	migrator, err := migrate.NewMigrator(logger, gormDB)
+	if err != nil {
+		logger.Errorw("failed to load migrations", "error", err)
+		return err
+	}
+
+	ctx := context.Background()
+	switch {
+	case args[0] == "status" && len(args) == 1:
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		return printStatus(statuses)
+	case args[0] == "up" && len(args) == 1:
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		logger.Infow("database migrated", "applied", applied)
+		return nil
+	case args[0] == "down" && len(args) == 1:
+		return migrator.Down(ctx)
+	case args[0] == "to" && len(args) == 2:
+		version, err := strconv.ParseUint(args[1], 10, 32)
+		if err != nil {
+			return fmt.Errorf("parse version: %w", err)
+		}
+		changed, err := migrator.To(ctx, uint(version))
+		if err != nil {
+			return err
+		}
+		logger.Infow("database migrated", "version", version, "changed", changed)
+		return nil
+	default:
+		return errMigrateUsage
+	}
+}
+
+func printStatus(statuses []migrate.Status) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+	for _, status := range statuses {
+		appliedAt := "pending"
+		if status.AppliedAt != nil {
+			appliedAt = status.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+	}
+	return w.Flush()
+}
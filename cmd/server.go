@@ -2,25 +2,35 @@ package cmd
 
 import (
 	"context"
+	"fethcher/internal/cache"
+	"fethcher/internal/chain"
 	"fethcher/internal/config"
 	"fethcher/internal/core"
 	"fethcher/internal/db"
+	"fethcher/internal/decoder"
 	"fethcher/internal/ethereum"
 	"fethcher/internal/http/handler"
 	"fethcher/internal/http/handler/middleware"
 	"fethcher/internal/http/payload"
 	"fethcher/internal/http/server"
+	"fethcher/internal/indexer"
+	"fethcher/internal/migrate"
+	"fethcher/internal/queue"
 	"fethcher/internal/repository"
 	"fethcher/pkg/jwt"
 	"fethcher/pkg/log"
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
+	"time"
 
-	"github.com/ethereum/go-ethereum/ethclient"
+	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"gorm.io/gorm"
 )
 
 func Start() error {
@@ -32,53 +42,190 @@ func Start() error {
 		return err
 	}
 
-	dbConn, err := db.NewPostgresDB(config.DBConnectionString)
+	storage, gormDB, err := openDatabase(config.DBDriver, config.DBConnectionString)
 	if err != nil {
-		logger.Errorw("failed to connect to database", "error", err)
+		logger.Errorw("failed to connect to database", "error", err, "driver", config.DBDriver)
 		return err
 	}
 
 	// jwt service
 	jwtService := jwt.NewJWTService([]byte(config.JWTSecret))
 
-	// repository
-	repo := repository.NewTransactionRepository(dbConn)
-
-	err = repo.MigrateTables(
-		&repository.Transaction{},
-		&repository.User{},
-		&repository.UserTransaction{})
+	// schema migrations
+	migrator, err := migrate.NewMigrator(logger, gormDB)
 	if err != nil {
-		logger.Errorw("failed to migrate tables to database", "error", err)
+		logger.Errorw("failed to load migrations", "error", err)
 		return err
 	}
 
-	err = repo.SeedUserTable(context.Background())
+	if config.MigrateOnStart {
+		applied, err := migrator.Up(context.Background())
+		if err != nil {
+			logger.Errorw("failed to migrate database", "error", err)
+			return err
+		}
+		logger.Infow("database migrated", "applied", applied)
+	} else {
+		pending, err := migrator.Pending(context.Background())
+		if err != nil {
+			logger.Errorw("failed to check migrations", "error", err)
+			return err
+		}
+		if len(pending) > 0 {
+			err = fmt.Errorf("%d migrations are pending, run the migrate command", len(pending))
+			logger.Errorw("database is not migrated", "error", err)
+			return err
+		}
+	}
+
+	// users are shared by all chains
+	err = repository.NewTransactionRepository(storage, 0).SeedUserTable(context.Background())
 	if err != nil {
 		logger.Errorw("failed to seed user table", "error", err)
 		return err
 	}
 
-	client, err := ethclient.Dial(config.NodeURL)
-	if err != nil {
-		logger.Errorw("infura connection failed", "error", err)
-		return err
+	// write queue, drained by run on shutdown
+	writes := queue.NewQueue(
+		logger,
+		config.WriteQueueSize,
+		config.WriteWorkers,
+		config.WriteAttempts,
+		config.WriteBackoff)
+	writes.Start()
+
+	// finalized transactions of all chains are served from memory before the database is queried
+	transactionCache := cache.NewLRU(config.CacheMaxEntries, config.CacheMaxBytes)
+
+	// reconcile tentative transactions, watch pending ones and backfill in the background until the server stops; every
+	// producer of writes is stopped by stopBackground before the write queue is drained
+	reconcilerCtx, stopReconciler := context.WithCancel(context.Background())
+	defer stopReconciler()
+	var background sync.WaitGroup
+	blockIndexers := make([]*indexer.Indexer, 0, len(config.Chains))
+	stopBackground := func() {
+		stopReconciler()
+		for _, blockIndexer := range blockIndexers {
+			_ = blockIndexer.Pause()
+		}
+		background.Wait()
 	}
 
-	ethService := ethereum.NewEthService(client)
+	// every chain gets its own nodes, repository, fethcher and indexer, the first one serves requests that do not name a chain
+	services := chain.NewRegistry[handler.TransactionService]()
+	indexers := chain.NewRegistry[handler.Indexer]()
+	nodePools := chain.NewRegistry[handler.NodePool]()
+	for i, chainConfig := range config.Chains {
+		ethService, nodePool, err := newEthService(context.Background(), chainConfig, config, logger.With("chain", chainConfig.Name))
+		if err != nil {
+			return err
+		}
 
-	// fethcher
-	fethcher := core.NewFethcher(
-		logger,
-		repo,
-		jwtService,
-		ethService)
+		id, name, err := identifyChain(context.Background(), ethService, chainConfig.Name)
+		if err != nil {
+			logger.Errorw("failed to identify chain", "error", err, "chain", chainConfig.Name)
+			return err
+		}
+		chainLogger := logger.With("chain", name)
+
+		// repository
+		repo := repository.NewTransactionRepository(storage, id)
+
+		// rows cached before chains were told apart belong to the default chain
+		if i == 0 {
+			claimed, err := repo.ClaimUnassignedRows(context.Background())
+			if err != nil {
+				chainLogger.Errorw("failed to claim unassigned rows", "error", err)
+				return err
+			}
+			chainLogger.Infow("unassigned rows claimed", "count", claimed)
+		}
+
+		// abi registry
+		abiRegistry := decoder.NewRegistry()
+		if config.SignaturesFile != "" {
+			loaded, err := abiRegistry.LoadSignaturesFile(config.SignaturesFile)
+			if err != nil {
+				chainLogger.Errorw("failed to load signatures file", "error", err)
+				return err
+			}
+			chainLogger.Infow("signatures loaded", "count", loaded)
+		}
+
+		// fethcher
+		fethcher := core.NewFethcher(
+			chainLogger,
+			cache.NewRepository(repo, transactionCache, id),
+			jwtService,
+			ethService,
+			abiRegistry,
+			writes,
+			config.ConfirmationDepth)
+
+		loadedABIs, err := fethcher.LoadContractABIs(context.Background())
+		if err != nil {
+			chainLogger.Errorw("failed to load contract abis", "error", err)
+			return err
+		}
+		chainLogger.Infow("contract abis loaded", "count", loadedABIs)
+
+		background.Add(3)
+		go func() {
+			defer background.Done()
+			fethcher.RunReconciler(reconcilerCtx, config.ReconcileInterval)
+		}()
+		go func() {
+			defer background.Done()
+			fethcher.RunPendingWatcher(reconcilerCtx, config.PendingInterval)
+		}()
+
+		// fill in the type, gas and fee fields of transactions that were cached before they were captured, and of the ones
+		// the node could not return on an earlier run
+		go func() {
+			defer background.Done()
+			fethcher.RunBackfill(reconcilerCtx, config.ReconcileInterval)
+		}()
+
+		// block indexer
+		blockIndexer := indexer.NewIndexer(
+			chainLogger,
+			fethcher,
+			repo,
+			chainConfig.IndexerFromBlock,
+			chainConfig.IndexerToBlock,
+			config.IndexerPoll)
+		defer func() {
+			_ = blockIndexer.Pause()
+		}()
+		blockIndexers = append(blockIndexers, blockIndexer)
+
+		if config.IndexerAutostart {
+			if err := blockIndexer.Start(context.Background()); err != nil {
+				chainLogger.Errorw("failed to start block indexer", "error", err)
+				return err
+			}
+		}
+
+		if err := services.Add(id, name, fethcher); err != nil {
+			chainLogger.Errorw("failed to register chain", "error", err)
+			return err
+		}
+		if err := indexers.Add(id, name, blockIndexer); err != nil {
+			chainLogger.Errorw("failed to register chain", "error", err)
+			return err
+		}
+		if err := nodePools.Add(id, name, nodePool); err != nil {
+			chainLogger.Errorw("failed to register chain", "error", err)
+			return err
+		}
+		chainLogger.Infow("chain configured", "chain_id", id, "default", i == 0)
+	}
 
 	// handler
 	fethHlr := handler.NewFethHandler(
 		logger,
 		payload.Decoder{},
-		fethcher)
+		services)
 
 	// middleware
 	mux := http.NewServeMux()
@@ -89,14 +236,116 @@ func Start() error {
 	mux.HandleFunc(handler.Authenticate, fethHlr.HandleAuthenticate)
 	mux.HandleFunc(handler.GetTransactions, fethHlr.HandleGetTransactions)
 	mux.HandleFunc(handler.GetTransactionsRLP, fethHlr.HandleGetTransactionsRLP)
+	mux.HandleFunc(handler.GetTransactionTrace, fethHlr.HandleGetTransactionTrace)
 	mux.HandleFunc(handler.GetMyTransactions, fethHlr.HandleGetMyTransactions)
+	mux.HandleFunc(handler.DeleteMyTransaction, fethHlr.HandleDeleteMyTransaction)
+	mux.HandleFunc(handler.ClearMyTransactions, fethHlr.HandleClearMyTransactions)
 	mux.HandleFunc(handler.GetAllTransactions, fethHlr.HandleGetAllTransactions)
+	mux.HandleFunc(handler.GetLogs, fethHlr.HandleGetLogs)
+	mux.HandleFunc(handler.GetTransfers, fethHlr.HandleGetTransfers)
+	mux.HandleFunc(handler.GetBlock, fethHlr.HandleGetBlock)
+	mux.HandleFunc(handler.GetAddressTransactions, fethHlr.HandleGetAddressTransactions)
+	mux.HandleFunc(handler.GetChains, fethHlr.HandleGetChains)
+
+	// admin routes are only served when an admin token is configured
+	if config.AdminToken != "" {
+		adminHlr := handler.NewAdminHandler(logger, indexers, nodePools, writes, transactionCache)
+		adminAuth := middleware.NewAdminAuthMiddleware(logger, config.AdminToken)
+
+		mux.Handle(handler.StartIndexer, adminAuth.AdminAuth(http.HandlerFunc(adminHlr.HandleStartIndexer)))
+		mux.Handle(handler.PauseIndexer, adminAuth.AdminAuth(http.HandlerFunc(adminHlr.HandlePauseIndexer)))
+		mux.Handle(handler.GetIndexerStatus, adminAuth.AdminAuth(http.HandlerFunc(adminHlr.HandleGetIndexerStatus)))
+		mux.Handle(handler.GetWriteQueue, adminAuth.AdminAuth(http.HandlerFunc(adminHlr.HandleGetWriteQueue)))
+		mux.Handle(handler.GetCache, adminAuth.AdminAuth(http.HandlerFunc(adminHlr.HandleGetCache)))
+		mux.Handle(handler.GetNodes, adminAuth.AdminAuth(http.HandlerFunc(adminHlr.HandleGetNodes)))
+
+		// uploaded abis change how the transactions of every user are decoded
+		mux.Handle(handler.PutContractABI, adminAuth.AdminAuth(http.HandlerFunc(fethHlr.HandlePutContractABI)))
+	} else {
+		logger.Infow("ADMIN_TOKEN is not set, admin routes are disabled")
+	}
 
 	srv := server.NewHTTP(logger, hdlr, config.Port)
-	return run(srv)
+	return run(srv, writes, stopBackground, config.ShutdownTimeout)
+}
+
+// openDatabase connects to the database of the given driver and returns it together with its gorm connection, which the schema
+// migrations run on.
+func openDatabase(driver string, conn string) (repository.Storage, *gorm.DB, error) {
+	if driver == config.DriverSQLite {
+		sqliteDB, err := db.NewSQLiteDB(conn)
+		if err != nil {
+			return nil, nil, err
+		}
+		return sqliteDB, sqliteDB.DB, nil
+	}
+
+	postgresDB, err := db.NewPostgresDB(conn)
+	if err != nil {
+		return nil, nil, err
+	}
+	return postgresDB, postgresDB.DB, nil
+}
+
+// newEthService connects to the nodes of the given chain and returns the service that reads the chain through them together
+// with the pool of its nodes.
+func newEthService(ctx context.Context, chainConfig config.ChainConfig, appConfig config.AppConfig, logger *zap.SugaredLogger) (*ethereum.EthService, *ethereum.NodePool, error) {
+	nodes := make([]ethereum.Node, 0, len(chainConfig.NodeURLs))
+	for i, nodeURL := range chainConfig.NodeURLs {
+		client, err := ethereum.DialNode(ctx, nodeURL)
+		if err != nil {
+			logger.Errorw("ethereum node connection failed", "error", err, "node", i)
+			return nil, nil, err
+		}
+		nodes = append(nodes, ethereum.Node{Name: nodeName(i, nodeURL), Client: client})
+	}
+
+	nodePool, err := ethereum.NewNodePool(nodes, appConfig.NodeQuorum, appConfig.NodeFailures, appConfig.NodeCooldown)
+	if err != nil {
+		logger.Errorw("failed to create ethereum node pool", "error", err)
+		return nil, nil, err
+	}
+
+	family, err := ethereum.ParseFamily(chainConfig.Family)
+	if err != nil {
+		logger.Errorw("failed to parse chain family", "error", err)
+		return nil, nil, err
+	}
+
+	return ethereum.NewEthService(nodePool, family, appConfig.VerifyReceipts, appConfig.RPCBatchSize, appConfig.RPCWorkers), nodePool, nil
 }
 
-func run(server *server.HTTPServer) error {
+// identifyChain returns the chain ID the nodes serve and the name the chain is selected by: the configured name, which must match
+// the chain ID when it is well-known, or the well-known name of the chain ID for the default chain.
+func identifyChain(ctx context.Context, ethService *ethereum.EthService, name string) (uint64, string, error) {
+	id, err := ethService.ChainID(ctx)
+	if err != nil {
+		return 0, "", err
+	}
+
+	if name == "" {
+		return id, chain.Name(id), nil
+	}
+	if known, ok := chain.ID(name); ok && known != id {
+		return 0, "", fmt.Errorf("identify chain %s: nodes serve chain %d, expected %d", name, id, known)
+	}
+	return id, name, nil
+}
+
+// nodeName identifies a node by its position and host so that API keys embedded in the URL never end up in logs.
+func nodeName(index int, nodeURL string) string {
+	parsed, err := url.Parse(nodeURL)
+	if err != nil || parsed.Host == "" {
+		return fmt.Sprintf("node-%d", index)
+	}
+	return fmt.Sprintf("node-%d(%s)", index, parsed.Host)
+}
+
+// run serves until a shutdown signal or a server error and then shuts down gracefully: the server stops accepting requests and
+// finishes the ones in flight, stopBackground stops the background work that queues writes and only then the write queue is
+// drained, so that no queued write is lost. Finishing the requests and draining the queue are together given at most
+// shutdownTimeout.
+func run(server *server.HTTPServer, writes *queue.Queue, stopBackground func(), shutdownTimeout time.Duration) error {
 	// expect a signal to gracefully shutdown the server
 	sig := make(chan os.Signal, 1)
 	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
@@ -109,10 +358,19 @@ func run(server *server.HTTPServer) error {
 	case err = <-errChan:
 	}
 
-	sdErr := server.Shutdown()
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	sdErr := server.Shutdown(ctx)
 	if err == http.ErrServerClosed && sdErr != nil {
 		return fmt.Errorf("server shutdown: %w", sdErr)
 	}
 
+	stopBackground()
+
+	if drainErr := writes.Shutdown(ctx); drainErr != nil && err == nil {
+		return fmt.Errorf("write queue shutdown: %w", drainErr)
+	}
+
 	return err
 }
@@ -0,0 +1,203 @@
+// Package cache keeps hot finalized transactions in memory in front of the database, so that they are served without a
+// database round trip.
+package cache
+
+import (
+	"container/list"
+	"fethcher/internal/repository"
+	"sync"
+)
+
+const (
+	// entryOverhead approximates the memory held by an entry besides the variable length fields of its transaction.
+	entryOverhead = 512
+	// maxTombstones bounds the number of invalidated transactions that are remembered. A read that started before the oldest
+	// forgotten invalidation is not cached at all.
+	maxTombstones = 4096
+)
+
+// key identifies a transaction across chains.
+type key struct {
+	chainID uint64
+	hash    string
+}
+
+type entry struct {
+	key         key
+	transaction repository.Transaction
+	size        int64
+}
+
+// tombstone records the generation at which a transaction was invalidated.
+type tombstone struct {
+	key        key
+	generation uint64
+}
+
+// LRU holds transactions of every chain up to maxEntries entries and maxBytes approximate bytes, evicting the least recently
+// used ones first. A maxEntries of 0 disables it. The cached transactions are shared with every caller and must not be
+// modified. It is safe for concurrent use.
+type LRU struct {
+	maxEntries int
+	maxBytes   int64
+
+	mu    sync.Mutex
+	order *list.List
+	items map[key]*list.Element
+	bytes int64
+
+	// generation changes with every invalidation and tombstones hold the generation at which each transaction was last
+	// invalidated, so that a transaction read from the database before its invalidation is not cached after it while the other
+	// transactions of the same read still are. Tombstones are forgotten oldest first beyond maxTombstones, and forgotten is the
+	// generation of the last one forgotten.
+	generation     uint64
+	tombstones     map[key]uint64
+	tombstoneOrder *list.List
+	forgotten      uint64
+
+	hits      uint64
+	misses    uint64
+	evictions uint64
+}
+
+// NewLRU is a constructor function for the LRU type.
+func NewLRU(maxEntries int, maxBytes int64) *LRU {
+	return &LRU{
+		maxEntries:     maxEntries,
+		maxBytes:       maxBytes,
+		order:          list.New(),
+		items:          make(map[key]*list.Element),
+		tombstones:     make(map[key]uint64),
+		tombstoneOrder: list.New(),
+	}
+}
+
+// Get returns the cached transaction of the chain with the given hash and marks it as recently used.
+func (c *LRU) Get(chainID uint64, hash string) (repository.Transaction, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key{chainID: chainID, hash: hash}]
+	if !ok {
+		c.misses++
+		return repository.Transaction{}, false
+	}
+
+	c.hits++
+	c.order.MoveToFront(elem)
+	return elem.Value.(*entry).transaction, true
+}
+
+// Generation returns the current generation, to be passed to Add for the transactions read from the database afterwards.
+func (c *LRU) Generation() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generation
+}
+
+// Add caches the transaction under its chain and hash unless the transaction was invalidated since the given generation or the
+// transaction alone exceeds the byte limit.
+func (c *LRU) Add(transaction repository.Transaction, generation uint64) {
+	size := sizeOf(transaction)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	k := key{chainID: transaction.ChainID, hash: transaction.TransactionHash}
+	if c.maxEntries <= 0 || c.invalidatedSince(k, generation) || (c.maxBytes > 0 && size > c.maxBytes) {
+		return
+	}
+
+	if elem, ok := c.items[k]; ok {
+		c.bytes -= elem.Value.(*entry).size
+		c.order.Remove(elem)
+	}
+
+	c.items[k] = c.order.PushFront(&entry{key: k, transaction: transaction, size: size})
+	c.bytes += size
+
+	for c.order.Len() > c.maxEntries || (c.maxBytes > 0 && c.bytes > c.maxBytes) {
+		c.removeElement(c.order.Back())
+		c.evictions++
+	}
+}
+
+// Remove invalidates the transactions of the chain with the given hashes.
+func (c *LRU) Remove(chainID uint64, hashes ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	for _, hash := range hashes {
+		k := key{chainID: chainID, hash: hash}
+		if elem, ok := c.items[k]; ok {
+			c.removeElement(elem)
+		}
+
+		c.tombstones[k] = c.generation
+		c.tombstoneOrder.PushBack(tombstone{key: k, generation: c.generation})
+	}
+
+	for c.tombstoneOrder.Len() > maxTombstones {
+		forgotten := c.tombstoneOrder.Remove(c.tombstoneOrder.Front()).(tombstone)
+		if c.tombstones[forgotten.key] == forgotten.generation {
+			delete(c.tombstones, forgotten.key)
+		}
+		c.forgotten = forgotten.generation
+	}
+}
+
+// invalidatedSince reports whether the transaction may have been invalidated after the given generation.
+func (c *LRU) invalidatedSince(k key, generation uint64) bool {
+	if generation < c.forgotten {
+		return true
+	}
+	return c.tombstones[k] > generation
+}
+
+// Stats returns a snapshot of the cache.
+func (c *LRU) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return Stats{
+		Entries:    c.order.Len(),
+		MaxEntries: c.maxEntries,
+		Bytes:      c.bytes,
+		MaxBytes:   c.maxBytes,
+		Hits:       c.hits,
+		Misses:     c.misses,
+		Evictions:  c.evictions,
+	}
+}
+
+func (c *LRU) removeElement(elem *list.Element) {
+	e := c.order.Remove(elem).(*entry)
+	delete(c.items, e.key)
+	c.bytes -= e.size
+}
+
+// sizeOf approximates the memory held by the entry of the transaction.
+func sizeOf(tx repository.Transaction) int64 {
+	size := entryOverhead + len(tx.TransactionHash) + len(tx.BlockHash) + len(tx.From) + len(tx.Input) + len(tx.Value)
+	for _, s := range []*string{tx.To, tx.ContractAddress, tx.EffectiveGasPrice, tx.GasPrice, tx.MaxFeePerGas,
+		tx.MaxPriorityFeePerGas, tx.MaxFeePerBlobGas, tx.BlobGasPrice, tx.L1Fee, tx.L1GasPrice, tx.L1BlobBaseFee, tx.SourceHash,
+		tx.Mint, tx.RequestID, tx.RevertMessage, tx.RevertData} {
+		if s != nil {
+			size += len(*s)
+		}
+	}
+	for _, tuple := range tx.AccessList {
+		size += len(tuple.Address)
+		for _, key := range tuple.StorageKeys {
+			size += len(key)
+		}
+	}
+	for _, hash := range tx.BlobVersionedHashes {
+		size += len(hash)
+	}
+	for _, auth := range tx.AuthorizationList {
+		size += len(auth.ChainID) + len(auth.Address) + len(auth.R) + len(auth.S) + 16
+	}
+	return int64(size)
+}
@@ -0,0 +1,114 @@
+package cache_test
+
+import (
+	"fmt"
+	"strings"
+
+	"fethcher/internal/cache"
+	"fethcher/internal/repository"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LRU", func() {
+	var lru *cache.LRU
+
+	BeforeEach(func() {
+		lru = cache.NewLRU(2, 0)
+	})
+
+	It("should return added transactions and count hits and misses", func() {
+		lru.Add(repository.Transaction{ChainID: 1, TransactionHash: "0x1"}, lru.Generation())
+
+		tx, ok := lru.Get(1, "0x1")
+		Expect(ok).To(BeTrue())
+		Expect(tx.TransactionHash).To(Equal("0x1"))
+
+		_, ok = lru.Get(1, "0x2")
+		Expect(ok).To(BeFalse())
+
+		stats := lru.Stats()
+		Expect(stats.Entries).To(Equal(1))
+		Expect(stats.Hits).To(Equal(uint64(1)))
+		Expect(stats.Misses).To(Equal(uint64(1)))
+		Expect(stats.Bytes).To(BeNumerically(">", 0))
+	})
+
+	It("should evict the least recently used transaction when the entry limit is reached", func() {
+		lru.Add(repository.Transaction{ChainID: 1, TransactionHash: "0x1"}, lru.Generation())
+		lru.Add(repository.Transaction{ChainID: 1, TransactionHash: "0x2"}, lru.Generation())
+		_, _ = lru.Get(1, "0x1")
+		lru.Add(repository.Transaction{ChainID: 1, TransactionHash: "0x3"}, lru.Generation())
+
+		_, ok := lru.Get(1, "0x2")
+		Expect(ok).To(BeFalse())
+		_, ok = lru.Get(1, "0x1")
+		Expect(ok).To(BeTrue())
+		_, ok = lru.Get(1, "0x3")
+		Expect(ok).To(BeTrue())
+		Expect(lru.Stats().Evictions).To(Equal(uint64(1)))
+	})
+
+	It("should evict transactions when the byte limit is reached", func() {
+		lru = cache.NewLRU(10, 1500)
+		input := "0x" + strings.Repeat("ab", 256)
+
+		lru.Add(repository.Transaction{ChainID: 1, TransactionHash: "0x1", Input: input}, lru.Generation())
+		lru.Add(repository.Transaction{ChainID: 1, TransactionHash: "0x2", Input: input}, lru.Generation())
+
+		stats := lru.Stats()
+		Expect(stats.Entries).To(Equal(1))
+		Expect(stats.Bytes).To(BeNumerically("<=", 1500))
+		Expect(stats.Evictions).To(Equal(uint64(1)))
+	})
+
+	It("should not cache a transaction larger than the byte limit", func() {
+		lru = cache.NewLRU(10, 100)
+		lru.Add(repository.Transaction{ChainID: 1, TransactionHash: "0x1"}, lru.Generation())
+
+		Expect(lru.Stats().Entries).To(BeZero())
+	})
+
+	It("should not cache anything when it is disabled", func() {
+		lru = cache.NewLRU(0, 0)
+		lru.Add(repository.Transaction{ChainID: 1, TransactionHash: "0x1"}, lru.Generation())
+
+		Expect(lru.Stats().Entries).To(BeZero())
+	})
+
+	It("should remove invalidated transactions and only skip those read before their invalidation", func() {
+		lru.Add(repository.Transaction{ChainID: 1, TransactionHash: "0x1"}, lru.Generation())
+		generation := lru.Generation()
+
+		lru.Remove(1, "0x1")
+		_, ok := lru.Get(1, "0x1")
+		Expect(ok).To(BeFalse())
+
+		lru.Add(repository.Transaction{ChainID: 1, TransactionHash: "0x1"}, generation)
+		lru.Add(repository.Transaction{ChainID: 1, TransactionHash: "0x2"}, generation)
+		lru.Add(repository.Transaction{ChainID: 2, TransactionHash: "0x1"}, generation)
+
+		_, ok = lru.Get(1, "0x1")
+		Expect(ok).To(BeFalse())
+		_, ok = lru.Get(1, "0x2")
+		Expect(ok).To(BeTrue())
+		_, ok = lru.Get(2, "0x1")
+		Expect(ok).To(BeTrue())
+
+		lru.Add(repository.Transaction{ChainID: 1, TransactionHash: "0x1"}, lru.Generation())
+		_, ok = lru.Get(1, "0x1")
+		Expect(ok).To(BeTrue())
+	})
+
+	It("should skip every transaction read before an invalidation it no longer remembers", func() {
+		generation := lru.Generation()
+		for i := range 5000 {
+			lru.Remove(1, fmt.Sprintf("0x%x", i+100))
+		}
+
+		lru.Add(repository.Transaction{ChainID: 1, TransactionHash: "0x1"}, generation)
+		_, ok := lru.Get(1, "0x1")
+		Expect(ok).To(BeFalse())
+	})
+})
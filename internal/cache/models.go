@@ -0,0 +1,13 @@
+package cache
+
+// Stats is a snapshot of the cache. Hits and misses count looked up transactions and evictions count the entries dropped to
+// stay within the limits. Bytes is an approximation of the memory held by the entries.
+type Stats struct {
+	Entries    int    `json:"entries"`
+	MaxEntries int    `json:"maxEntries"`
+	Bytes      int64  `json:"bytes"`
+	MaxBytes   int64  `json:"maxBytes"`
+	Hits       uint64 `json:"hits"`
+	Misses     uint64 `json:"misses"`
+	Evictions  uint64 `json:"evictions"`
+}
@@ -0,0 +1,106 @@
+package cache
+
+import (
+	"context"
+	"fethcher/internal/core"
+	"fethcher/internal/repository"
+	"fmt"
+)
+
+// Repository is a core.Repository of a single chain that serves the finalized transactions it read before from an LRU, which
+// may be shared with the repositories of other chains, and delegates everything else to the repository it wraps. Tentative
+// transactions are never cached, since the reconciler may still move or evict them, and every write to a transaction
+// invalidates its cached copy.
+type Repository struct {
+	core.Repository
+	cache   *LRU
+	chainID uint64
+}
+
+// NewRepository is a constructor function for the Repository type.
+func NewRepository(repo core.Repository, cache *LRU, chainID uint64) *Repository {
+	return &Repository{
+		Repository: repo,
+		cache:      cache,
+		chainID:    chainID,
+	}
+}
+
+// GetTransactionsByHash returns the cached transactions with the given hashes and reads the other ones from the database.
+func (r *Repository) GetTransactionsByHash(ctx context.Context, txHashes []string) ([]repository.Transaction, error) {
+	generation := r.cache.Generation()
+
+	transactions := make([]repository.Transaction, 0, len(txHashes))
+	missing := make([]string, 0, len(txHashes))
+	seen := make(map[string]struct{}, len(txHashes))
+	for _, hash := range txHashes {
+		if _, ok := seen[hash]; ok {
+			continue
+		}
+		seen[hash] = struct{}{}
+
+		if tx, ok := r.cache.Get(r.chainID, hash); ok {
+			transactions = append(transactions, tx)
+			continue
+		}
+		missing = append(missing, hash)
+	}
+
+	if len(missing) == 0 {
+		return transactions, nil
+	}
+
+	stored, err := r.Repository.GetTransactionsByHash(ctx, missing)
+	if err != nil {
+		return nil, fmt.Errorf("get transactions by hash: %w", err)
+	}
+
+	for _, tx := range stored {
+		if !tx.Tentative {
+			r.cache.Add(tx, generation)
+		}
+	}
+	return append(transactions, stored...), nil
+}
+
+// SaveTransactions saves the transactions and invalidates their cached copies.
+func (r *Repository) SaveTransactions(ctx context.Context, transactions []repository.Transaction) error {
+	hashes := make([]string, 0, len(transactions))
+	for _, tx := range transactions {
+		hashes = append(hashes, tx.TransactionHash)
+	}
+
+	return r.invalidate(hashes, func() error {
+		return r.Repository.SaveTransactions(ctx, transactions)
+	})
+}
+
+// UpdateTransaction updates the transaction and invalidates its cached copy.
+func (r *Repository) UpdateTransaction(ctx context.Context, transaction repository.Transaction) error {
+	return r.invalidate([]string{transaction.TransactionHash}, func() error {
+		return r.Repository.UpdateTransaction(ctx, transaction)
+	})
+}
+
+// ReplaceTransaction replaces the transaction together with its logs and token transfers and invalidates its cached copy.
+func (r *Repository) ReplaceTransaction(ctx context.Context, transaction repository.Transaction, logs []repository.TransactionLog, transfers []repository.TokenTransfer) error {
+	return r.invalidate([]string{transaction.TransactionHash}, func() error {
+		return r.Repository.ReplaceTransaction(ctx, transaction, logs, transfers)
+	})
+}
+
+// DeleteTransactions deletes the transactions and invalidates their cached copies.
+func (r *Repository) DeleteTransactions(ctx context.Context, txHashes []string) error {
+	return r.invalidate(txHashes, func() error {
+		return r.Repository.DeleteTransactions(ctx, txHashes)
+	})
+}
+
+// invalidate runs the write with the given transactions invalidated both before and after it, so that neither a copy cached
+// before the write nor one read while it was in progress survives it.
+func (r *Repository) invalidate(hashes []string, write func() error) error {
+	r.cache.Remove(r.chainID, hashes...)
+	defer r.cache.Remove(r.chainID, hashes...)
+
+	return write()
+}
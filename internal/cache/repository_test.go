@@ -0,0 +1,150 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+
+	"fethcher/internal/cache"
+	"fethcher/internal/core/fake"
+	"fethcher/internal/repository"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Repository", func() {
+	var (
+		ctx      context.Context
+		fakeRepo *fake.Repository
+		lru      *cache.LRU
+		repo     *cache.Repository
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeRepo = new(fake.Repository)
+		fakeRepo.GetTransactionsByHashStub = func(_ context.Context, hashes []string) ([]repository.Transaction, error) {
+			transactions := make([]repository.Transaction, 0, len(hashes))
+			for _, hash := range hashes {
+				transactions = append(transactions, repository.Transaction{ChainID: 1, TransactionHash: hash, Tentative: hash == "0xtentative"})
+			}
+			return transactions, nil
+		}
+		lru = cache.NewLRU(10, 0)
+		repo = cache.NewRepository(fakeRepo, lru, 1)
+	})
+
+	Describe("GetTransactionsByHash", func() {
+		It("should serve finalized transactions from the cache after the first read", func() {
+			transactions, err := repo.GetTransactionsByHash(ctx, []string{"0x1", "0x2"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(transactions).To(HaveLen(2))
+
+			transactions, err = repo.GetTransactionsByHash(ctx, []string{"0x1", "0x2", "0x3"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(transactions).To(HaveLen(3))
+
+			Expect(fakeRepo.GetTransactionsByHashCallCount()).To(Equal(2))
+			_, hashes := fakeRepo.GetTransactionsByHashArgsForCall(1)
+			Expect(hashes).To(Equal([]string{"0x3"}))
+			Expect(lru.Stats().Hits).To(Equal(uint64(2)))
+		})
+
+		It("should not query the database when every transaction is cached", func() {
+			_, err := repo.GetTransactionsByHash(ctx, []string{"0x1"})
+			Expect(err).NotTo(HaveOccurred())
+
+			transactions, err := repo.GetTransactionsByHash(ctx, []string{"0x1", "0x1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(transactions).To(HaveLen(1))
+			Expect(fakeRepo.GetTransactionsByHashCallCount()).To(Equal(1))
+		})
+
+		It("should not cache tentative transactions", func() {
+			_, err := repo.GetTransactionsByHash(ctx, []string{"0xtentative"})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = repo.GetTransactionsByHash(ctx, []string{"0xtentative"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeRepo.GetTransactionsByHashCallCount()).To(Equal(2))
+			Expect(lru.Stats().Entries).To(BeZero())
+		})
+
+		It("should keep the transactions of the chains that share the cache apart", func() {
+			otherRepo := new(fake.Repository)
+			otherRepo.GetTransactionsByHashReturns([]repository.Transaction{{ChainID: 10, TransactionHash: "0x1"}}, nil)
+			other := cache.NewRepository(otherRepo, lru, 10)
+
+			_, err := repo.GetTransactionsByHash(ctx, []string{"0x1"})
+			Expect(err).NotTo(HaveOccurred())
+
+			transactions, err := other.GetTransactionsByHash(ctx, []string{"0x1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(transactions).To(Equal([]repository.Transaction{{ChainID: 10, TransactionHash: "0x1"}}))
+			Expect(otherRepo.GetTransactionsByHashCallCount()).To(Equal(1))
+			Expect(lru.Stats().Entries).To(Equal(2))
+		})
+
+		It("should return the database error", func() {
+			fakeRepo.GetTransactionsByHashStub = nil
+			fakeRepo.GetTransactionsByHashReturns(nil, errors.New("db error"))
+
+			_, err := repo.GetTransactionsByHash(ctx, []string{"0x1"})
+			Expect(err).To(MatchError(ContainSubstring("db error")))
+		})
+	})
+
+	Describe("writes", func() {
+		BeforeEach(func() {
+			_, err := repo.GetTransactionsByHash(ctx, []string{"0x1", "0x2"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lru.Stats().Entries).To(Equal(2))
+		})
+
+		It("should invalidate an updated transaction", func() {
+			err := repo.UpdateTransaction(ctx, repository.Transaction{ChainID: 1, TransactionHash: "0x1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeRepo.UpdateTransactionCallCount()).To(Equal(1))
+
+			_, ok := lru.Get(1, "0x1")
+			Expect(ok).To(BeFalse())
+			_, ok = lru.Get(1, "0x2")
+			Expect(ok).To(BeTrue())
+		})
+
+		It("should invalidate a replaced transaction", func() {
+			err := repo.ReplaceTransaction(ctx, repository.Transaction{ChainID: 1, TransactionHash: "0x1"}, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeRepo.ReplaceTransactionCallCount()).To(Equal(1))
+
+			_, ok := lru.Get(1, "0x1")
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should invalidate deleted transactions", func() {
+			err := repo.DeleteTransactions(ctx, []string{"0x1", "0x2"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeRepo.DeleteTransactionsCallCount()).To(Equal(1))
+			Expect(lru.Stats().Entries).To(BeZero())
+		})
+
+		It("should invalidate saved transactions", func() {
+			err := repo.SaveTransactions(ctx, []repository.Transaction{{TransactionHash: "0x2"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeRepo.SaveTransactionsCallCount()).To(Equal(1))
+
+			_, ok := lru.Get(1, "0x2")
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should invalidate the transaction even when the write fails", func() {
+			fakeRepo.UpdateTransactionReturns(errors.New("db error"))
+
+			err := repo.UpdateTransaction(ctx, repository.Transaction{ChainID: 1, TransactionHash: "0x1"})
+			Expect(err).To(HaveOccurred())
+
+			_, ok := lru.Get(1, "0x1")
+			Expect(ok).To(BeFalse())
+		})
+	})
+})
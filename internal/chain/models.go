@@ -0,0 +1,22 @@
+package chain
+
+// Chain identifies a configured network by its EIP-155 chain ID and the name requests select it by.
+type Chain struct {
+	ID      uint64 `json:"id"`
+	Name    string `json:"name"`
+	Default bool   `json:"default"`
+}
+
+// knownChains maps the chain IDs of well-known networks to the names they are configured and selected by.
+var knownChains = map[uint64]string{
+	1:        "mainnet",
+	10:       "optimism",
+	137:      "polygon",
+	8453:     "base",
+	17000:    "holesky",
+	42161:    "arbitrum",
+	84532:    "base-sepolia",
+	421614:   "arbitrum-sepolia",
+	11155111: "sepolia",
+	11155420: "optimism-sepolia",
+}
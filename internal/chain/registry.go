@@ -0,0 +1,94 @@
+// Package chain keeps the per chain instances of a service, so that requests are routed to the chain they name.
+package chain
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var ErrUnknownChain = errors.New("unknown chain")
+
+// Name returns the well-known name of the chain with the given ID, or chain-<id> for a chain that is not well-known.
+func Name(id uint64) string {
+	if name, ok := knownChains[id]; ok {
+		return name
+	}
+	return fmt.Sprintf("chain-%d", id)
+}
+
+// ID returns the chain ID of the well-known chain with the given name.
+func ID(name string) (uint64, bool) {
+	for id, known := range knownChains {
+		if strings.EqualFold(known, name) {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+// Registry holds a value, e.g. a service, per chain. The first chain added is the default one, which serves the requests that do
+// not name a chain. It is safe for concurrent use.
+type Registry[T any] struct {
+	mu     sync.RWMutex
+	chains []Chain
+	values map[uint64]T
+}
+
+// NewRegistry is a constructor function for the Registry type.
+func NewRegistry[T any]() *Registry[T] {
+	return &Registry[T]{
+		values: make(map[uint64]T),
+	}
+}
+
+// Add registers the value of the chain with the given ID and name. Neither may be registered already.
+func (r *Registry[T]) Add(id uint64, name string, value T) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, c := range r.chains {
+		if c.ID == id || strings.EqualFold(c.Name, name) {
+			return fmt.Errorf("add chain %d (%s): already registered as %d (%s)", id, name, c.ID, c.Name)
+		}
+	}
+
+	r.chains = append(r.chains, Chain{ID: id, Name: name, Default: len(r.chains) == 0})
+	r.values[id] = value
+	return nil
+}
+
+// Get returns the value of the chain selected by chain, which is either a chain ID, a chain name or empty for the default chain.
+func (r *Registry[T]) Get(chain string) (T, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var zero T
+	if len(r.chains) == 0 {
+		return zero, fmt.Errorf("%w: no chains are configured", ErrUnknownChain)
+	}
+
+	if chain == "" {
+		return r.values[r.chains[0].ID], nil
+	}
+
+	id, err := strconv.ParseUint(chain, 10, 64)
+	for _, c := range r.chains {
+		if (err == nil && c.ID == id) || strings.EqualFold(c.Name, chain) {
+			return r.values[c.ID], nil
+		}
+	}
+	return zero, fmt.Errorf("%w: %q", ErrUnknownChain, chain)
+}
+
+// Chains returns the registered chains, the default one first.
+func (r *Registry[T]) Chains() []Chain {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	chains := make([]Chain, len(r.chains))
+	copy(chains, r.chains)
+	return chains
+}
@@ -0,0 +1,63 @@
+package chain_test
+
+import (
+	"fethcher/internal/chain"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Registry", func() {
+	var registry *chain.Registry[string]
+
+	BeforeEach(func() {
+		registry = chain.NewRegistry[string]()
+		Expect(registry.Add(1, "mainnet", "l1")).To(Succeed())
+		Expect(registry.Add(8453, "base", "l2")).To(Succeed())
+	})
+
+	It("should select the default chain when no chain is given", func() {
+		value, err := registry.Get("")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(value).To(Equal("l1"))
+	})
+
+	It("should select a chain by ID or by name regardless of case", func() {
+		value, err := registry.Get("8453")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(value).To(Equal("l2"))
+
+		value, err = registry.Get("Base")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(value).To(Equal("l2"))
+	})
+
+	It("should return ErrUnknownChain for a chain that is not registered", func() {
+		_, err := registry.Get("sepolia")
+		Expect(err).To(MatchError(chain.ErrUnknownChain))
+
+		_, err = registry.Get("10")
+		Expect(err).To(MatchError(chain.ErrUnknownChain))
+	})
+
+	It("should reject a chain ID or name that is already registered", func() {
+		Expect(registry.Add(1, "other", "x")).NotTo(Succeed())
+		Expect(registry.Add(10, "BASE", "x")).NotTo(Succeed())
+	})
+
+	It("should list the chains with the default one first", func() {
+		Expect(registry.Chains()).To(Equal([]chain.Chain{
+			{ID: 1, Name: "mainnet", Default: true},
+			{ID: 8453, Name: "base"},
+		}))
+	})
+
+	It("should name well-known chains and fall back to the chain ID", func() {
+		Expect(chain.Name(11155111)).To(Equal("sepolia"))
+		Expect(chain.Name(999)).To(Equal("chain-999"))
+
+		id, ok := chain.ID("Arbitrum")
+		Expect(ok).To(BeTrue())
+		Expect(id).To(Equal(uint64(42161)))
+	})
+})
@@ -4,22 +4,114 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 var errEnvVarNotFound error = errors.New("environment variable not found")
 
+// The database drivers, selected by the scheme of DB_CONNECTION_URL.
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
+// sqliteScheme prefixes the path of an SQLite database in DB_CONNECTION_URL, e.g. sqlite://fethcher.db or sqlite://:memory:.
+const sqliteScheme = "sqlite://"
+
 const (
 	apiPortEnvKey   = "API_PORT"
 	ethNodeEnvKey   = "ETH_NODE_URL"
 	dbConnEnvKey    = "DB_CONNECTION_URL"
 	jwtSecretEnvKey = "JWT_SECRET"
+
+	verifyReceiptsEnvKey    = "VERIFY_RECEIPTS"
+	confirmationDepthEnvKey = "CONFIRMATION_DEPTH"
+	reconcileIntervalEnvKey = "RECONCILE_INTERVAL"
+	pendingIntervalEnvKey   = "PENDING_WATCH_INTERVAL"
+	nodeQuorumEnvKey        = "NODE_QUORUM"
+	nodeFailuresEnvKey      = "NODE_FAILURE_THRESHOLD"
+	nodeCooldownEnvKey      = "NODE_COOLDOWN"
+	rpcBatchSizeEnvKey      = "RPC_BATCH_SIZE"
+	rpcWorkersEnvKey        = "RPC_WORKERS"
+	signaturesFileEnvKey    = "SIGNATURES_FILE"
+	adminTokenEnvKey        = "ADMIN_TOKEN"
+	indexerFromEnvKey       = "INDEXER_FROM_BLOCK"
+	indexerToEnvKey         = "INDEXER_TO_BLOCK"
+	indexerPollEnvKey       = "INDEXER_POLL_INTERVAL"
+	indexerAutostartEnvKey  = "INDEXER_AUTOSTART"
+	writeQueueSizeEnvKey    = "WRITE_QUEUE_SIZE"
+	writeWorkersEnvKey      = "WRITE_WORKERS"
+	writeAttemptsEnvKey     = "WRITE_MAX_ATTEMPTS"
+	writeBackoffEnvKey      = "WRITE_RETRY_BACKOFF"
+	shutdownTimeoutEnvKey   = "SHUTDOWN_TIMEOUT"
+	cacheEntriesEnvKey      = "CACHE_MAX_ENTRIES"
+	cacheBytesEnvKey        = "CACHE_MAX_BYTES"
+	migrateOnStartEnvKey    = "MIGRATE_ON_START"
+	chainsEnvKey            = "CHAINS"
+	chainFamilyEnvKey       = "CHAIN_FAMILY"
+
+	defaultConfirmationDepth = 12
+	defaultReconcileInterval = time.Minute
+	defaultPendingInterval   = 15 * time.Second
+	defaultNodeFailures      = 3
+	defaultNodeCooldown      = 30 * time.Second
+	defaultRPCBatchSize      = 100
+	defaultRPCWorkers        = 4
+	defaultIndexerPoll       = 12 * time.Second
+	defaultWriteQueueSize    = 10000
+	defaultWriteWorkers      = 2
+	defaultWriteAttempts     = 5
+	defaultWriteBackoff      = 500 * time.Millisecond
+	defaultShutdownTimeout   = 30 * time.Second
+	defaultCacheEntries      = 10000
+	defaultCacheBytes        = 64 << 20
 )
 
+// ChainConfig is the configuration of a chain fethcher serves. The default chain has no name and is named from its chain ID. An
+// empty Family is detected from the chain ID.
+type ChainConfig struct {
+	Name             string
+	Family           string
+	NodeURLs         []string
+	IndexerFromBlock uint64
+	IndexerToBlock   uint64
+}
+
 type AppConfig struct {
 	Port               string
-	NodeURL            string
+	Chains             []ChainConfig
+	DBDriver           string
 	DBConnectionString string
 	JWTSecret          string
+	VerifyReceipts     bool
+	ConfirmationDepth  uint64
+	ReconcileInterval  time.Duration
+	PendingInterval    time.Duration
+	NodeQuorum         int
+	NodeFailures       int
+	NodeCooldown       time.Duration
+	RPCBatchSize       int
+	RPCWorkers         int
+	SignaturesFile     string
+	AdminToken         string
+	IndexerPoll        time.Duration
+	IndexerAutostart   bool
+	WriteQueueSize     int
+	WriteWorkers       int
+	WriteAttempts      int
+	WriteBackoff       time.Duration
+	ShutdownTimeout    time.Duration
+	CacheMaxEntries    int
+	CacheMaxBytes      int64
+	MigrateOnStart     bool
+}
+
+// MigrateConfig is the configuration of the migrate command, which only needs the database.
+type MigrateConfig struct {
+	DBDriver           string
+	DBConnectionString string
 }
 
 func NewAppConfig() (AppConfig, error) {
@@ -29,11 +121,6 @@ func NewAppConfig() (AppConfig, error) {
 		return AppConfig{}, fmt.Errorf("%w: %s", errEnvVarNotFound, apiPortEnvKey)
 	}
 
-	nodeURL, ok := os.LookupEnv(ethNodeEnvKey)
-	if !ok {
-		return AppConfig{}, fmt.Errorf("%w: %s", errEnvVarNotFound, ethNodeEnvKey)
-	}
-
 	dbConn, ok := os.LookupEnv(dbConnEnvKey)
 	if !ok {
 		return AppConfig{}, fmt.Errorf("%w: %s", errEnvVarNotFound, dbConnEnvKey)
@@ -44,10 +131,262 @@ func NewAppConfig() (AppConfig, error) {
 		return AppConfig{}, fmt.Errorf("%w: %s", errEnvVarNotFound, jwtSecretEnvKey)
 	}
 
+	verifyReceipts, err := lookupBool(verifyReceiptsEnvKey, false)
+	if err != nil {
+		return AppConfig{}, err
+	}
+
+	confirmationDepth, err := lookupUint(confirmationDepthEnvKey, defaultConfirmationDepth)
+	if err != nil {
+		return AppConfig{}, err
+	}
+
+	reconcileInterval, err := lookupDuration(reconcileIntervalEnvKey, defaultReconcileInterval)
+	if err != nil {
+		return AppConfig{}, err
+	}
+
+	pendingInterval, err := lookupDuration(pendingIntervalEnvKey, defaultPendingInterval)
+	if err != nil {
+		return AppConfig{}, err
+	}
+
+	nodeQuorum, err := lookupUint(nodeQuorumEnvKey, 0)
+	if err != nil {
+		return AppConfig{}, err
+	}
+
+	nodeFailures, err := lookupUint(nodeFailuresEnvKey, defaultNodeFailures)
+	if err != nil {
+		return AppConfig{}, err
+	}
+
+	nodeCooldown, err := lookupDuration(nodeCooldownEnvKey, defaultNodeCooldown)
+	if err != nil {
+		return AppConfig{}, err
+	}
+
+	rpcBatchSize, err := lookupUint(rpcBatchSizeEnvKey, defaultRPCBatchSize)
+	if err != nil {
+		return AppConfig{}, err
+	}
+
+	rpcWorkers, err := lookupUint(rpcWorkersEnvKey, defaultRPCWorkers)
+	if err != nil {
+		return AppConfig{}, err
+	}
+
+	chains, err := lookupChains()
+	if err != nil {
+		return AppConfig{}, err
+	}
+
+	indexerPoll, err := lookupDuration(indexerPollEnvKey, defaultIndexerPoll)
+	if err != nil {
+		return AppConfig{}, err
+	}
+
+	indexerAutostart, err := lookupBool(indexerAutostartEnvKey, false)
+	if err != nil {
+		return AppConfig{}, err
+	}
+
+	writeQueueSize, err := lookupUint(writeQueueSizeEnvKey, defaultWriteQueueSize)
+	if err != nil {
+		return AppConfig{}, err
+	}
+
+	writeWorkers, err := lookupUint(writeWorkersEnvKey, defaultWriteWorkers)
+	if err != nil {
+		return AppConfig{}, err
+	}
+
+	writeAttempts, err := lookupUint(writeAttemptsEnvKey, defaultWriteAttempts)
+	if err != nil {
+		return AppConfig{}, err
+	}
+
+	writeBackoff, err := lookupDuration(writeBackoffEnvKey, defaultWriteBackoff)
+	if err != nil {
+		return AppConfig{}, err
+	}
+
+	shutdownTimeout, err := lookupDuration(shutdownTimeoutEnvKey, defaultShutdownTimeout)
+	if err != nil {
+		return AppConfig{}, err
+	}
+
+	cacheEntries, err := lookupUint(cacheEntriesEnvKey, defaultCacheEntries)
+	if err != nil {
+		return AppConfig{}, err
+	}
+
+	cacheBytes, err := lookupUint(cacheBytesEnvKey, defaultCacheBytes)
+	if err != nil {
+		return AppConfig{}, err
+	}
+
+	migrateOnStart, err := lookupBool(migrateOnStartEnvKey, true)
+	if err != nil {
+		return AppConfig{}, err
+	}
+
+	dbDriver, dbConn := parseDBConnection(dbConn)
+
 	return AppConfig{
 		Port:               port,
-		NodeURL:            nodeURL,
+		Chains:             chains,
+		DBDriver:           dbDriver,
 		DBConnectionString: dbConn,
 		JWTSecret:          jwtSecret,
+		VerifyReceipts:     verifyReceipts,
+		ConfirmationDepth:  confirmationDepth,
+		ReconcileInterval:  reconcileInterval,
+		PendingInterval:    pendingInterval,
+		NodeQuorum:         int(nodeQuorum),
+		NodeFailures:       int(nodeFailures),
+		NodeCooldown:       nodeCooldown,
+		RPCBatchSize:       int(rpcBatchSize),
+		RPCWorkers:         int(rpcWorkers),
+		SignaturesFile:     os.Getenv(signaturesFileEnvKey),
+		AdminToken:         os.Getenv(adminTokenEnvKey),
+		IndexerPoll:        indexerPoll,
+		IndexerAutostart:   indexerAutostart,
+		WriteQueueSize:     int(writeQueueSize),
+		WriteWorkers:       int(writeWorkers),
+		WriteAttempts:      int(writeAttempts),
+		WriteBackoff:       writeBackoff,
+		ShutdownTimeout:    shutdownTimeout,
+		CacheMaxEntries:    int(cacheEntries),
+		CacheMaxBytes:      int64(cacheBytes),
+		MigrateOnStart:     migrateOnStart,
+	}, nil
+}
+
+func NewMigrateConfig() (MigrateConfig, error) {
+	dbConn, ok := os.LookupEnv(dbConnEnvKey)
+	if !ok {
+		return MigrateConfig{}, fmt.Errorf("%w: %s", errEnvVarNotFound, dbConnEnvKey)
+	}
+
+	dbDriver, dbConn := parseDBConnection(dbConn)
+
+	return MigrateConfig{
+		DBDriver:           dbDriver,
+		DBConnectionString: dbConn,
 	}, nil
 }
+
+// lookupChains reads the default chain from ETH_NODE_URL, INDEXER_FROM_BLOCK and INDEXER_TO_BLOCK followed by every chain named
+// in CHAINS, which reads the same variables prefixed with its upper-cased name, e.g. BASE_ETH_NODE_URL for base.
+func lookupChains() ([]ChainConfig, error) {
+	defaultChain, err := lookupChain("", "")
+	if err != nil {
+		return nil, err
+	}
+
+	chains := []ChainConfig{defaultChain}
+	for _, name := range splitList(os.Getenv(chainsEnvKey)) {
+		prefix := strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+		chain, err := lookupChain(name, prefix)
+		if err != nil {
+			return nil, err
+		}
+		chains = append(chains, chain)
+	}
+	return chains, nil
+}
+
+// lookupChain reads the configuration of the chain with the given name from the environment variables with the given prefix.
+func lookupChain(name string, prefix string) (ChainConfig, error) {
+	nodeURLs, ok := os.LookupEnv(prefix + ethNodeEnvKey)
+	if !ok {
+		return ChainConfig{}, fmt.Errorf("%w: %s", errEnvVarNotFound, prefix+ethNodeEnvKey)
+	}
+
+	indexerFrom, err := lookupUint(prefix+indexerFromEnvKey, 0)
+	if err != nil {
+		return ChainConfig{}, err
+	}
+
+	indexerTo, err := lookupUint(prefix+indexerToEnvKey, 0)
+	if err != nil {
+		return ChainConfig{}, err
+	}
+	if indexerTo != 0 && indexerTo < indexerFrom {
+		return ChainConfig{}, fmt.Errorf("parse %s: must not be lower than %s", prefix+indexerToEnvKey, prefix+indexerFromEnvKey)
+	}
+
+	return ChainConfig{
+		Name:             name,
+		Family:           os.Getenv(prefix + chainFamilyEnvKey),
+		NodeURLs:         splitList(nodeURLs),
+		IndexerFromBlock: indexerFrom,
+		IndexerToBlock:   indexerTo,
+	}, nil
+}
+
+// parseDBConnection returns the driver selected by the scheme of the database connection URL together with what the driver
+// connects to: the path of an SQLite database or the whole URL for PostgreSQL.
+func parseDBConnection(value string) (string, string) {
+	if path, ok := strings.CutPrefix(value, sqliteScheme); ok {
+		return DriverSQLite, path
+	}
+	return DriverPostgres, value
+}
+
+// splitList splits a comma separated environment variable value into its trimmed, non-empty items.
+func splitList(value string) []string {
+	items := make([]string, 0)
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// lookupBool reads an optional boolean environment variable, falling back to def when it is not set.
+func lookupBool(key string, def bool) (bool, error) {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return def, nil
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("parse %s: %w", key, err)
+	}
+	return parsed, nil
+}
+
+// lookupUint reads an optional unsigned integer environment variable, falling back to def when it is not set.
+func lookupUint(key string, def uint64) (uint64, error) {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return def, nil
+	}
+
+	parsed, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parse %s: %w", key, err)
+	}
+	return parsed, nil
+}
+
+// lookupDuration reads an optional duration environment variable (e.g. "30s"), falling back to def when it is not set.
+func lookupDuration(key string, def time.Duration) (time.Duration, error) {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return def, nil
+	}
+
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("parse %s: %w", key, err)
+	}
+	if parsed <= 0 {
+		return 0, fmt.Errorf("parse %s: duration must be positive", key)
+	}
+	return parsed, nil
+}
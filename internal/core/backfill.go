@@ -0,0 +1,78 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// backfillBatchSize is the number of incomplete transactions that are read from the database and refetched from the node at
+// once.
+const backfillBatchSize = 100
+
+// BackfillTransactionFields refetches the cached transactions that were stored before their block timestamp or their type, gas
+// and fee fields were captured and replaces them, together with their logs and token transfers, with the complete data. The
+// transactions are paged through backfillBatchSize at a time. Transactions the node cannot return are left untouched and
+// retried on the next run of RunBackfill.
+func (f *Fethcher) BackfillTransactionFields(ctx context.Context) error {
+	checked, updated := 0, 0
+	after := ""
+	for {
+		incomplete, err := f.repo.GetIncompleteTransactions(ctx, after, backfillBatchSize)
+		if err != nil {
+			return fmt.Errorf("get incomplete transactions: %w", err)
+		}
+		if len(incomplete) == 0 {
+			break
+		}
+
+		hashes := make([]string, 0, len(incomplete))
+		for _, cached := range incomplete {
+			hashes = append(hashes, cached.TransactionHash)
+		}
+		checked += len(hashes)
+		after = hashes[len(hashes)-1]
+
+		for _, result := range f.getTransactionsFromNode(ctx, hashes) {
+			if result.Transaction == nil {
+				f.logs.Errorw("failed to refetch transaction for backfill", "transaction", result.TransactionHash, "status", result.Status, "reason", result.Reason)
+				continue
+			}
+
+			record := *result.Transaction
+			err := f.repo.ReplaceTransaction(ctx, recordToTransaction(record), recordToLogs(record), recordToTransfers(record))
+			if err != nil {
+				f.logs.Errorw("failed to backfill transaction", "error", err, "transaction", result.TransactionHash)
+				continue
+			}
+			updated++
+		}
+
+		if len(incomplete) < backfillBatchSize {
+			break
+		}
+	}
+
+	if checked > 0 {
+		f.logs.Infow("transaction fields backfilled", "incomplete", checked, "updated", updated)
+	}
+	return nil
+}
+
+// RunBackfill backfills the incomplete transactions once right away and then every interval until the context is cancelled.
+func (f *Fethcher) RunBackfill(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := f.BackfillTransactionFields(ctx); err != nil {
+			f.logs.Errorw("failed to backfill transaction fields", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package core_test
+
+import (
+	"context"
+	"errors"
+	"fethcher/internal/core"
+	"fethcher/internal/core/fake"
+	"fethcher/internal/ethereum"
+	"fethcher/internal/repository"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+)
+
+var _ = Describe("Backfill", func() {
+	var (
+		fakeRepo *fake.Repository
+		fakeEth  *fake.EthereumService
+		ctx      context.Context
+		fetcher  *core.Fethcher
+		fakeErr  error
+		err      error
+	)
+
+	BeforeEach(func() {
+		fakeRepo = new(fake.Repository)
+		fakeEth = new(fake.EthereumService)
+		fakeEth.ReplayTransactionReturns(&ethereum.Revert{Reproduced: true, Message: "execution reverted", Data: "0x"}, nil)
+		ctx = context.Background()
+		fakeErr = errors.New("fake error")
+
+		fetcher = core.NewFethcher(zap.NewNop().Sugar(), fakeRepo, new(fake.JWTIssuer), fakeEth, new(fake.ABIRegistry), new(fake.WriteQueue), 12)
+
+		fakeRepo.GetIncompleteTransactionsReturns([]repository.Transaction{
+			{TransactionHash: "0x1"},
+			{TransactionHash: "0x2"},
+		}, nil)
+	})
+
+	JustBeforeEach(func() {
+		err = fetcher.BackfillTransactionFields(ctx)
+	})
+
+	When("the node returns the transactions", func() {
+		BeforeEach(func() {
+			maxFee, priorityFee := "30", "2"
+			fakeEth.FetchTransactionsReturns([]*ethereum.TxResult{
+				{Hash: "0x1", Transaction: &ethereum.Transaction{
+					TransactionHash:      "0x1",
+					Type:                 2,
+					Nonce:                7,
+					GasLimit:             21000,
+					GasUsed:              21000,
+					EffectiveGasPrice:    "12",
+					MaxFeePerGas:         &maxFee,
+					MaxPriorityFeePerGas: &priorityFee,
+					AccessList:           []ethereum.AccessTuple{},
+					Confirmations:        50,
+				}},
+				{Hash: "0x2", Error: ethereum.ErrTransactionNotFound},
+			}, nil)
+		})
+
+		It("stores the missing fields of the returned ones", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeRepo.GetIncompleteTransactionsCallCount()).To(Equal(1))
+			_, after, limit := fakeRepo.GetIncompleteTransactionsArgsForCall(0)
+			Expect(after).To(BeEmpty())
+			Expect(limit).To(Equal(100))
+			_, argHashes := fakeEth.FetchTransactionsArgsForCall(0)
+			Expect(argHashes).To(Equal([]string{"0x1", "0x2"}))
+
+			Expect(fakeRepo.ReplaceTransactionCallCount()).To(Equal(1))
+			_, updated, _, _ := fakeRepo.ReplaceTransactionArgsForCall(0)
+			Expect(updated.TransactionHash).To(Equal("0x1"))
+			Expect(*updated.TxType).To(Equal(uint8(2)))
+			Expect(*updated.Nonce).To(Equal(uint64(7)))
+			Expect(*updated.EffectiveGasPrice).To(Equal("12"))
+			Expect(*updated.MaxFeePerGas).To(Equal("30"))
+			Expect(*updated.MaxPriorityFeePerGas).To(Equal("2"))
+			Expect(updated.GasPrice).To(BeNil())
+			Expect(updated.AccessList).To(BeEmpty())
+		})
+	})
+
+	When("there are more incomplete transactions than fit in a batch", func() {
+		BeforeEach(func() {
+			fakeRepo.GetIncompleteTransactionsStub = func(_ context.Context, after string, limit int) ([]repository.Transaction, error) {
+				if after != "" {
+					return []repository.Transaction{{TransactionHash: "0xlast"}}, nil
+				}
+				page := make([]repository.Transaction, limit)
+				for i := range page {
+					page[i].TransactionHash = fmt.Sprintf("0x%d", i)
+				}
+				return page, nil
+			}
+		})
+
+		It("pages through them a batch at a time", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeRepo.GetIncompleteTransactionsCallCount()).To(Equal(2))
+			_, after, _ := fakeRepo.GetIncompleteTransactionsArgsForCall(1)
+			Expect(after).To(Equal("0x99"))
+
+			Expect(fakeEth.FetchTransactionsCallCount()).To(Equal(2))
+			_, argHashes := fakeEth.FetchTransactionsArgsForCall(0)
+			Expect(argHashes).To(HaveLen(100))
+			_, argHashes = fakeEth.FetchTransactionsArgsForCall(1)
+			Expect(argHashes).To(Equal([]string{"0xlast"}))
+		})
+	})
+
+	When("no transaction needs a backfill", func() {
+		BeforeEach(func() {
+			fakeRepo.GetIncompleteTransactionsReturns([]repository.Transaction{}, nil)
+		})
+
+		It("does not query the node", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeEth.FetchTransactionsCallCount()).To(Equal(0))
+		})
+	})
+
+	When("getting the incomplete transactions fails", func() {
+		BeforeEach(func() {
+			fakeRepo.GetIncompleteTransactionsReturns(nil, fakeErr)
+		})
+
+		It("returns the error", func() {
+			Expect(err).To(MatchError(fakeErr))
+		})
+	})
+})
@@ -0,0 +1,94 @@
+package core
+
+import (
+	"context"
+	"slices"
+)
+
+// flight is a node lookup of a transaction that is in progress. Its result is set before done is closed.
+type flight struct {
+	done   chan struct{}
+	result TransactionResult
+}
+
+// fetchTransactionsCoalesced fetches the transactions with the given hashes from the node, in the order of the hashes. A
+// transaction that is already being fetched for a concurrent request is not fetched again; the request waits for that lookup
+// and shares its result instead. The transactions this call fetched itself are returned separately, so that every fetched
+// transaction is cached by a single request. The lookups are not cancelled with ctx, so that a request that goes away does not
+// fail the requests that wait for its lookups; it only stops waiting itself.
+func (f *Fethcher) fetchTransactionsCoalesced(ctx context.Context, transactionsHashes []string) ([]TransactionResult, []TransactionRecord) {
+	led := make([]string, 0, len(transactionsHashes))
+	flights := make(map[string]*flight, len(transactionsHashes))
+
+	f.flightsMu.Lock()
+	for _, transactionHash := range transactionsHashes {
+		if fl, ok := f.flights[transactionHash]; ok {
+			flights[transactionHash] = fl
+			continue
+		}
+		fl := &flight{done: make(chan struct{})}
+		f.flights[transactionHash] = fl
+		flights[transactionHash] = fl
+		led = append(led, transactionHash)
+	}
+	f.flightsMu.Unlock()
+
+	fetched := make([]TransactionRecord, 0, len(led))
+	if len(led) > 0 {
+		f.logs.Infow("fetching transactions from node", "count", len(led), "joined", len(transactionsHashes)-len(led))
+		fetched = f.land(led, flights, f.getTransactionsFromNode(context.WithoutCancel(ctx), led))
+	}
+
+	results := make([]TransactionResult, 0, len(transactionsHashes))
+	for _, transactionHash := range transactionsHashes {
+		fl := flights[transactionHash]
+		select {
+		case <-fl.done:
+			results = append(results, cloneResult(fl.result))
+		case <-ctx.Done():
+			results = append(results, TransactionResult{
+				TransactionHash: transactionHash,
+				Status:          StatusError,
+				Reason:          ctx.Err().Error(),
+			})
+		}
+	}
+	return results, fetched
+}
+
+// land publishes the results of the lookups led by the caller to the requests waiting for them and returns the fetched
+// transactions.
+func (f *Fethcher) land(led []string, flights map[string]*flight, results []TransactionResult) []TransactionRecord {
+	fetched := make([]TransactionRecord, 0, len(results))
+	for i, transactionHash := range led {
+		fl := flights[transactionHash]
+		fl.result = cloneResult(results[i])
+		if fl.result.Transaction != nil {
+			fetched = append(fetched, *cloneResult(results[i]).Transaction)
+		}
+	}
+
+	f.flightsMu.Lock()
+	for _, transactionHash := range led {
+		delete(f.flights, transactionHash)
+	}
+	f.flightsMu.Unlock()
+
+	for _, transactionHash := range led {
+		close(flights[transactionHash].done)
+	}
+	return fetched
+}
+
+// cloneResult copies the result together with its transaction and logs, which are modified when a transaction is decoded, so
+// that the requests sharing a result do not modify each other's copy.
+func cloneResult(result TransactionResult) TransactionResult {
+	if result.Transaction == nil {
+		return result
+	}
+
+	record := *result.Transaction
+	record.Logs = slices.Clone(record.Logs)
+	result.Transaction = &record
+	return result
+}
@@ -0,0 +1,121 @@
+package core_test
+
+import (
+	"context"
+	"fethcher/internal/core"
+	"fethcher/internal/core/fake"
+	"fethcher/internal/ethereum"
+	"fethcher/internal/repository"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+)
+
+var _ = Describe("Coalescing", func() {
+	var (
+		fakeRepo   *fake.Repository
+		fakeEth    *fake.EthereumService
+		fakeWrites *fake.WriteQueue
+		fetcher    *core.Fethcher
+		release    chan struct{}
+	)
+
+	BeforeEach(func() {
+		fakeRepo = new(fake.Repository)
+		fakeEth = new(fake.EthereumService)
+		fakeEth.ReplayTransactionReturns(&ethereum.Revert{Reproduced: true, Message: "execution reverted", Data: "0x"}, nil)
+		fakeWrites = new(fake.WriteQueue)
+		release = make(chan struct{})
+
+		fetcher = core.NewFethcher(zap.NewNop().Sugar(), fakeRepo, new(fake.JWTIssuer), fakeEth, new(fake.ABIRegistry), fakeWrites, 12)
+
+		fakeRepo.GetTransactionsByHashReturns([]repository.Transaction{}, nil)
+		fakeEth.FetchTransactionsStub = func(ctx context.Context, hashes []string) ([]*ethereum.TxResult, error) {
+			<-release
+			results := make([]*ethereum.TxResult, 0, len(hashes))
+			for _, hash := range hashes {
+				results = append(results, &ethereum.TxResult{
+					Hash: hash,
+					Transaction: &ethereum.Transaction{
+						TransactionHash: hash,
+						Confirmations:   12,
+						Logs:            []ethereum.Log{{Address: "0xc", Topics: []string{"0xt"}}},
+					},
+				})
+			}
+			return results, nil
+		}
+	})
+
+	// lookup requests the transaction in the background and sends the outcome to the returned channel.
+	lookup := func(ctx context.Context) <-chan []core.TransactionResult {
+		out := make(chan []core.TransactionResult, 1)
+		go func() {
+			defer GinkgoRecover()
+			results, err := fetcher.GetTransactions(ctx, []string{"0x1"}, core.IncludeOptions{Logs: true})
+			Expect(err).NotTo(HaveOccurred())
+			out <- results
+		}()
+		return out
+	}
+
+	// joined waits until the given number of requests missed the database and had the time to join the lookup in progress.
+	joined := func(requests int) {
+		Eventually(fakeRepo.GetTransactionsByHashCallCount).Should(Equal(requests))
+		Consistently(fakeEth.FetchTransactionsCallCount, 50*time.Millisecond).Should(Equal(1))
+	}
+
+	It("fetches and caches a transaction requested concurrently only once", func() {
+		const requests = 20
+
+		outs := make([]<-chan []core.TransactionResult, 0, requests)
+		for range requests {
+			outs = append(outs, lookup(context.Background()))
+		}
+		joined(requests)
+		close(release)
+
+		for _, out := range outs {
+			var results []core.TransactionResult
+			Eventually(out).Should(Receive(&results))
+			Expect(results).To(HaveLen(1))
+			Expect(results[0].Status).To(Equal(core.StatusFetched))
+			Expect(results[0].Transaction.TransactionHash).To(Equal("0x1"))
+			Expect(results[0].Transaction.Logs).To(HaveLen(1))
+		}
+
+		Expect(fakeEth.FetchTransactionsCallCount()).To(Equal(1))
+		Expect(fakeWrites.EnqueueCallCount()).To(Equal(1))
+	})
+
+	It("lets a request stop waiting without failing the lookup", func() {
+		leader := lookup(context.Background())
+		Eventually(fakeEth.FetchTransactionsCallCount).Should(Equal(1))
+
+		waiterCtx, cancel := context.WithCancel(context.Background())
+		waiter := lookup(waiterCtx)
+		joined(2)
+		cancel()
+
+		var results []core.TransactionResult
+		Eventually(waiter).Should(Receive(&results))
+		Expect(results[0].Status).To(Equal(core.StatusError))
+		Expect(results[0].Reason).To(Equal(context.Canceled.Error()))
+
+		close(release)
+		Eventually(leader).Should(Receive(&results))
+		Expect(results[0].Status).To(Equal(core.StatusFetched))
+	})
+
+	It("fetches a transaction again once the lookup is over", func() {
+		close(release)
+
+		Eventually(lookup(context.Background())).Should(Receive())
+		Eventually(lookup(context.Background())).Should(Receive())
+
+		Expect(fakeEth.FetchTransactionsCallCount()).To(Equal(2))
+		Expect(fakeWrites.EnqueueCallCount()).To(Equal(2))
+	})
+})
@@ -2,12 +2,20 @@ package core
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/hex"
 	"errors"
+	"fethcher/internal/ethereum"
 	"fethcher/internal/repository"
 	tokenIssuer "fethcher/pkg/jwt"
 	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rlp"
 	"go.uber.org/zap"
 	"golang.org/x/crypto/bcrypt"
@@ -15,22 +23,51 @@ import (
 
 var ErrIncorrectPassword error = errors.New("incorrect password")
 var ErrUserNotFound error = errors.New("user not found")
+var ErrBlockNotFound error = errors.New("block not found")
+var ErrInvalidCursor error = errors.New("invalid cursor")
+var ErrHistoryEntryNotFound error = errors.New("history entry not found")
+var ErrTransactionNotFound error = errors.New("transaction not found")
+var ErrTracingUnsupported error = errors.New("call tracing is not supported by the node")
 
 // Fethcher is a struct that provides methods to interact with the Ethereum node and the database.
 type Fethcher struct {
-	logs       *zap.SugaredLogger
-	repo       Repository
-	jwtIssuer  JWTIssuer
-	ethService EthereumService
+	logs              *zap.SugaredLogger
+	repo              Repository
+	jwtIssuer         JWTIssuer
+	ethService        EthereumService
+	abiRegistry       ABIRegistry
+	writes            WriteQueue
+	confirmationDepth uint64
+
+	// flights holds the node lookups in progress by transaction hash, see fetchTransactionsCoalesced.
+	flightsMu sync.Mutex
+	flights   map[string]*flight
+
+	// watches holds the pending transactions that were looked up by transaction hash, see CheckPendingTransactions.
+	watchesMu sync.Mutex
+	watches   map[string]*pendingWatch
+
+	// missing holds the tentative transactions that the node did not know on the last reconciliation, see
+	// ReconcileTentativeTransactions, which is never run concurrently.
+	missing map[string]struct{}
 }
 
-// NewFethcher is a constructor function for the Fethcher type.
-func NewFethcher(logger *zap.SugaredLogger, repo Repository, jwt JWTIssuer, ethereumService EthereumService) *Fethcher {
+// NewFethcher is a constructor function for the Fethcher type. Transactions with fewer than confirmationDepth confirmations
+// are cached as tentative until the reconciler confirms that they are still part of the canonical chain. The inputs and logs
+// of returned transactions are decoded with the abiRegistry. Transactions and blocks fetched from the node and the user history
+// are written to the database through the writes queue, so that the writes outlive the requests that trigger them.
+func NewFethcher(logger *zap.SugaredLogger, repo Repository, jwt JWTIssuer, ethereumService EthereumService, abiRegistry ABIRegistry, writes WriteQueue, confirmationDepth uint64) *Fethcher {
 	return &Fethcher{
-		logs:       logger,
-		repo:       repo,
-		jwtIssuer:  jwt,
-		ethService: ethereumService,
+		logs:              logger,
+		repo:              repo,
+		jwtIssuer:         jwt,
+		ethService:        ethereumService,
+		abiRegistry:       abiRegistry,
+		writes:            writes,
+		confirmationDepth: confirmationDepth,
+		flights:           make(map[string]*flight),
+		watches:           make(map[string]*pendingWatch),
+		missing:           make(map[string]struct{}),
 	}
 }
 
@@ -62,57 +99,107 @@ func (f *Fethcher) Authenticate(ctx context.Context, msg AuthMessage) (string, e
 	return signed, nil
 }
 
-// GetTransactions retrieves transactions by their hashes. It first checks the database for existing transactions,
-func (f *Fethcher) GetTransactions(ctx context.Context, transactionsHashes []string) ([]TransactionRecord, error) {
-
-	records := make([]TransactionRecord, 0, len(transactionsHashes))
+// GetTransactions retrieves transactions by their hashes and reports the outcome of every hash, in the order of the hashes. It
+// first checks the database for cached transactions, fetches the missing ones from the Ethereum node and caches them together
+// with their logs and token transfers. Concurrent requests for the same missing transaction share a single node lookup and only
+// one of them caches it. The logs and transfers are only returned when include asks for them. Pending transactions are returned
+// without being cached and watched until they are mined, see CheckPendingTransactions.
+func (f *Fethcher) GetTransactions(ctx context.Context, transactionsHashes []string, include IncludeOptions) ([]TransactionResult, error) {
 	dbTxs, err := f.getTransactionsFromDB(ctx, transactionsHashes)
 	if err != nil {
 		return nil, fmt.Errorf("get transactions from db: %w", err)
 	}
 
-	f.logs.Infow("transactions fetched from db", "count", len(dbTxs))
-
-	records = append(records, dbTxs...)
-
-	recordsMap := make(map[string]struct{})
-	for _, rec := range records {
-		recordsMap[rec.TransactionHash] = struct{}{}
+	if include.Logs && len(dbTxs) > 0 {
+		if err := f.attachLogs(ctx, dbTxs); err != nil {
+			return nil, fmt.Errorf("attach logs: %w", err)
+		}
 	}
 
-	if len(records) == len(transactionsHashes) {
-		f.logs.Infow("all transactions found in DB", "count", len(records))
-		return records, nil
+	if include.Transfers && len(dbTxs) > 0 {
+		if err := f.attachTransfers(ctx, dbTxs); err != nil {
+			return nil, fmt.Errorf("attach transfers: %w", err)
+		}
 	}
 
-	missingTransactions := make([]string, 0, len(transactionsHashes)-len(records))
+	f.logs.Infow("transactions fetched from db", "count", len(dbTxs))
 
+	cached := make(map[string]TransactionRecord, len(dbTxs))
+	for _, rec := range dbTxs {
+		cached[rec.TransactionHash] = rec
+	}
+
+	missingTransactions := make([]string, 0, len(transactionsHashes))
+	missingSet := make(map[string]struct{})
 	for _, transactionHash := range transactionsHashes {
-		if _, ok := recordsMap[transactionHash]; !ok {
+		if _, ok := cached[transactionHash]; ok {
+			continue
+		}
+		if _, ok := missingSet[transactionHash]; !ok {
+			missingSet[transactionHash] = struct{}{}
 			missingTransactions = append(missingTransactions, transactionHash)
 		}
 	}
 
-	nodeTxs, err := f.getTransactionsFromNode(ctx, missingTransactions)
-	if err != nil {
-		f.logs.Errorw("getting transactions from node", "error", err)
+	fetched := make(map[string]TransactionResult, len(missingTransactions))
+	var nodeTxs []TransactionRecord
+	if len(missingTransactions) > 0 {
+		var nodeResults []TransactionResult
+		nodeResults, nodeTxs = f.fetchTransactionsCoalesced(ctx, missingTransactions)
+		for _, result := range nodeResults {
+			if result.Transaction != nil {
+				if !include.Logs {
+					result.Transaction.Logs = nil
+				}
+				if !include.Transfers {
+					result.Transaction.Transfers = nil
+				}
+				f.unwatchPending(result.TransactionHash)
+			} else {
+				result = f.trackPending(result)
+				f.logs.Errorw("getting transaction from node", "transaction", result.TransactionHash, "status", result.Status, "reason", result.Reason)
+			}
+			fetched[result.TransactionHash] = result
+		}
+	} else {
+		f.logs.Infow("all transactions found in DB", "count", len(dbTxs))
+	}
+
+	results := make([]TransactionResult, 0, len(transactionsHashes))
+	for _, transactionHash := range transactionsHashes {
+		if rec, ok := cached[transactionHash]; ok {
+			results = append(results, TransactionResult{
+				TransactionHash: transactionHash,
+				Status:          StatusCached,
+				Transaction:     &rec,
+			})
+			continue
+		}
+		results = append(results, fetched[transactionHash])
 	}
 
-	records = append(records, nodeTxs...)
+	for _, result := range results {
+		if result.Transaction != nil {
+			f.decode(result.Transaction)
+		}
+	}
 
-	f.logs.Infow("caching transactions from eth node to DB", "transactions", nodeTxs)
+	if len(nodeTxs) > 0 {
+		f.logs.Infow("caching transactions from eth node to DB", "transactions", nodeTxs)
 
-	go func() {
-		err = f.saveTransactionsToDB(ctx, nodeTxs)
+		err := f.writes.Enqueue("cache transactions", func(ctx context.Context) error {
+			return f.saveTransactionsToDB(ctx, nodeTxs)
+		})
 		if err != nil {
-			f.logs.Errorw("failed to save transactions to DB", "error", err, "count", len(nodeTxs))
+			f.logs.Errorw("failed to queue transactions for caching", "error", err, "count", len(nodeTxs))
 		}
-	}()
+	}
 
-	return records, nil
+	return results, nil
 }
 
-// SaveUserTransactionsHistory saves the transaction history for a user based on the provided JWT token and transaction hashes.
+// SaveUserTransactionsHistory queues the transaction hashes to be saved to the history of the user based on the provided JWT
+// token. It only returns once the token is validated and the write is queued, not once the history is saved.
 func (f *Fethcher) SaveUserTransactionsHistory(ctx context.Context, token string, transactionsHashes []string) error {
 	if len(transactionsHashes) == 0 {
 		return nil
@@ -125,52 +212,331 @@ func (f *Fethcher) SaveUserTransactionsHistory(ctx context.Context, token string
 
 	userId := claims["sub"].(string)
 
-	err = f.repo.SaveUserHistory(ctx, userId, transactionsHashes)
+	err = f.writes.Enqueue("save user history", func(ctx context.Context) error {
+		if err := f.repo.SaveUserHistory(ctx, userId, transactionsHashes); err != nil {
+			return fmt.Errorf("save user history: %w", err)
+		}
+		f.logs.Infow("user history saved", "userId", userId, "transactions", transactionsHashes)
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("save user history: %w", err)
+		return fmt.Errorf("queue user history: %w", err)
 	}
-
-	f.logs.Infow("user history saved", "userId", userId, "transactions", transactionsHashes)
 	return nil
 }
 
-// GetUserTransactionsHistory retrieves the transaction history for a user based on the provided JWT token.
-func (f *Fethcher) GetUserTransactionsHistory(ctx context.Context, token string) ([]TransactionRecord, error) {
+// GetUserTransactionsHistory retrieves a page of the transaction history for a user based on the provided JWT token, most
+// recently queried first. It returns ErrInvalidCursor when the cursor was not returned by a previous call.
+func (f *Fethcher) GetUserTransactionsHistory(ctx context.Context, token string, filter HistoryFilter) (HistoryPage, error) {
 	claims, err := f.jwtIssuer.Validate(token)
 	if err != nil {
-		return []TransactionRecord{}, fmt.Errorf("validate jwt token: %w", err)
+		return HistoryPage{}, fmt.Errorf("validate jwt token: %w", err)
 	}
 
 	userId := claims["sub"].(string)
 
 	f.logs.Infow("getting user transactions history", "userId", userId)
 
-	transactionsHashes, err := f.repo.GetUserHistory(ctx, userId)
+	repoFilter := repository.HistoryFilter{
+		UserID:      userId,
+		QueriedFrom: filter.QueriedFrom,
+		QueriedTo:   filter.QueriedTo,
+		Limit:       filter.Limit + 1,
+	}
+	if filter.Cursor != "" {
+		cursor, err := decodeHistoryCursor(filter.Cursor)
+		if err != nil {
+			return HistoryPage{}, err
+		}
+		repoFilter.After = &cursor
+	}
+
+	userTransactions, err := f.repo.GetUserHistory(ctx, repoFilter)
+	if err != nil {
+		return HistoryPage{}, fmt.Errorf("get user history: %w", err)
+	}
+
+	page := HistoryPage{}
+	if filter.Limit > 0 && len(userTransactions) > filter.Limit {
+		userTransactions = userTransactions[:filter.Limit]
+		last := userTransactions[len(userTransactions)-1]
+		page.NextCursor = encodeHistoryCursor(repository.HistoryCursor{
+			QueriedAt:       last.QueriedAt,
+			TransactionHash: last.TransactionHash,
+		})
+	}
+
+	transactionsHashes := make([]string, 0, len(userTransactions))
+	for _, tx := range userTransactions {
+		transactionsHashes = append(transactionsHashes, tx.TransactionHash)
+	}
+
+	txRecords := []TransactionRecord{}
+	if len(transactionsHashes) > 0 {
+		txRecords, err = f.getTransactionsFromDB(ctx, transactionsHashes)
+		if err != nil {
+			return HistoryPage{}, fmt.Errorf("get transactions by hash: %w", err)
+		}
+	}
+
+	recordsByHash := make(map[string]*TransactionRecord, len(txRecords))
+	for i := range txRecords {
+		f.decode(&txRecords[i])
+		recordsByHash[txRecords[i].TransactionHash] = &txRecords[i]
+	}
+
+	page.Entries = make([]HistoryEntry, 0, len(userTransactions))
+	for _, tx := range userTransactions {
+		page.Entries = append(page.Entries, HistoryEntry{
+			TransactionHash: tx.TransactionHash,
+			QueriedAt:       tx.QueriedAt,
+			QueryCount:      tx.QueryCount,
+			Transaction:     recordsByHash[tx.TransactionHash],
+		})
+	}
+
+	f.logs.Infow("user transactions history fetched from DB", "userId", userId, "entriesCount", len(page.Entries))
+
+	return page, nil
+}
+
+// DeleteUserHistoryEntry removes a transaction from the history of the user based on the provided JWT token. It returns
+// ErrHistoryEntryNotFound when the transaction is not in the history.
+func (f *Fethcher) DeleteUserHistoryEntry(ctx context.Context, token string, transactionHash string) error {
+	claims, err := f.jwtIssuer.Validate(token)
+	if err != nil {
+		return fmt.Errorf("validate jwt token: %w", err)
+	}
+
+	userId := claims["sub"].(string)
+
+	deleted, err := f.repo.DeleteUserHistory(ctx, userId, []string{strings.ToLower(transactionHash)})
 	if err != nil {
-		return nil, fmt.Errorf("get user history: %w", err)
+		return fmt.Errorf("delete user history: %w", err)
+	}
+	if deleted == 0 {
+		return ErrHistoryEntryNotFound
 	}
 
-	txRecords, err := f.getTransactionsFromDB(ctx, transactionsHashes)
+	f.logs.Infow("user history entry deleted", "userId", userId, "transaction", transactionHash)
+	return nil
+}
+
+// ClearUserTransactionsHistory removes the whole transaction history of the user based on the provided JWT token and returns how
+// many entries were removed.
+func (f *Fethcher) ClearUserTransactionsHistory(ctx context.Context, token string) (int64, error) {
+	claims, err := f.jwtIssuer.Validate(token)
 	if err != nil {
-		return nil, fmt.Errorf("get transactions by hash: %w", err)
+		return 0, fmt.Errorf("validate jwt token: %w", err)
+	}
+
+	userId := claims["sub"].(string)
+
+	deleted, err := f.repo.ClearUserHistory(ctx, userId)
+	if err != nil {
+		return 0, fmt.Errorf("clear user history: %w", err)
 	}
 
-	f.logs.Infow("user transactions history fetched from DB", "userId", userId, "transactionsCount", len(txRecords))
+	f.logs.Infow("user history cleared", "userId", userId, "entriesCount", deleted)
+	return deleted, nil
+}
+
+// GetAllDBTransactions retrieves a page of the cached transactions that match the filter. It returns ErrInvalidCursor when the
+// cursor was not returned by a previous call.
+func (f *Fethcher) GetAllDBTransactions(ctx context.Context, filter TransactionFilter) (TransactionPage, error) {
+	repoFilter := repository.TransactionFilter{
+		Status:           filter.Status,
+		FromBlock:        filter.FromBlock,
+		ToBlock:          filter.ToBlock,
+		ContractCreation: filter.ContractCreation,
+		MinValue:         filter.MinValue,
+		Ascending:        filter.Ascending,
+		Limit:            filter.Limit + 1,
+	}
+	if filter.From != "" {
+		repoFilter.From = common.HexToAddress(filter.From).Hex()
+	}
+	if filter.To != "" {
+		repoFilter.To = common.HexToAddress(filter.To).Hex()
+	}
+	if filter.Cursor != "" {
+		cursor, err := decodeCursor(filter.Cursor)
+		if err != nil {
+			return TransactionPage{}, err
+		}
+		repoFilter.After = &cursor
+	}
+
+	transactions, err := f.repo.FindTransactions(ctx, repoFilter)
+	if err != nil {
+		return TransactionPage{}, fmt.Errorf("find transactions: %w", err)
+	}
+
+	return f.transactionPage(transactions, filter.Limit), nil
+}
+
+// GetLogs retrieves the cached logs that match the filter, oldest first.
+func (f *Fethcher) GetLogs(ctx context.Context, filter LogFilter) ([]LogRecord, error) {
+	repoFilter := repository.LogFilter{Limit: filter.Limit}
+	if filter.Address != "" {
+		repoFilter.Address = common.HexToAddress(filter.Address).Hex()
+	}
+	if filter.Topic0 != "" {
+		repoFilter.Topic0 = common.HexToHash(filter.Topic0).Hex()
+	}
+
+	logs, err := f.repo.FindLogs(ctx, repoFilter)
+	if err != nil {
+		return nil, fmt.Errorf("find logs: %w", err)
+	}
 
-	return txRecords, nil
+	records := make([]LogRecord, 0, len(logs))
+	for _, l := range logs {
+		record := repoLogToRecord(l)
+		record.Decoded = f.abiRegistry.DecodeLog(record.Address, record.Topics, record.Data)
+		records = append(records, record)
+	}
+	return records, nil
 }
 
-// GetAllDBTransactions retrieves all transactions from the database and returns them as a slice of TransactionRecord.
-func (f *Fethcher) GetAllDBTransactions(ctx context.Context) ([]TransactionRecord, error) {
-	transactions, err := f.repo.GetAllTransactions(ctx)
+// GetTokenTransfers retrieves the cached token transfers that match the filter, oldest first.
+func (f *Fethcher) GetTokenTransfers(ctx context.Context, filter TransferFilter) ([]TransferRecord, error) {
+	repoFilter := repository.TransferFilter{Limit: filter.Limit}
+	if filter.Token != "" {
+		repoFilter.Token = common.HexToAddress(filter.Token).Hex()
+	}
+	if filter.Holder != "" {
+		repoFilter.Holder = common.HexToAddress(filter.Holder).Hex()
+	}
+
+	transfers, err := f.repo.FindTokenTransfers(ctx, repoFilter)
 	if err != nil {
-		return nil, fmt.Errorf("getting all transactions: %w", err)
+		return nil, fmt.Errorf("find token transfers: %w", err)
 	}
-	records := f.repoTransactionToRecord(transactions)
 
+	records := make([]TransferRecord, 0, len(transfers))
+	for _, t := range transfers {
+		records = append(records, repoTransferToRecord(t))
+	}
 	return records, nil
 }
 
+// GetAddressTransactions retrieves a page of the cached transactions that match the filter, newest first. It returns
+// ErrInvalidCursor when the cursor was not returned by a previous call.
+func (f *Fethcher) GetAddressTransactions(ctx context.Context, filter AddressFilter) (TransactionPage, error) {
+	repoFilter := repository.AddressFilter{
+		Address:   common.HexToAddress(filter.Address).Hex(),
+		Sent:      filter.Direction != DirectionIn,
+		Received:  filter.Direction != DirectionOut,
+		FromBlock: filter.FromBlock,
+		ToBlock:   filter.ToBlock,
+		Limit:     filter.Limit + 1,
+	}
+	if filter.Cursor != "" {
+		cursor, err := decodeCursor(filter.Cursor)
+		if err != nil {
+			return TransactionPage{}, err
+		}
+		repoFilter.After = &cursor
+	}
+
+	transactions, err := f.repo.FindAddressTransactions(ctx, repoFilter)
+	if err != nil {
+		return TransactionPage{}, fmt.Errorf("find address transactions: %w", err)
+	}
+
+	return f.transactionPage(transactions, filter.Limit), nil
+}
+
+// GetBlock retrieves a block by its number or hash. Cached blocks are returned from the database, other blocks are fetched from
+// the Ethereum node and cached once they reached the confirmation depth, so that a block number only ever resolves to a final
+// block from the cache. It returns ErrBlockNotFound when the node does not know the block.
+func (f *Fethcher) GetBlock(ctx context.Context, numberOrHash string) (BlockRecord, error) {
+	byHash := strings.HasPrefix(numberOrHash, "0x")
+
+	var (
+		number uint64
+		cached repository.Block
+		err    error
+	)
+	if byHash {
+		numberOrHash = common.HexToHash(numberOrHash).Hex()
+		cached, err = f.repo.GetBlockByHash(ctx, numberOrHash)
+	} else {
+		number, err = strconv.ParseUint(numberOrHash, 10, 64)
+		if err != nil {
+			return BlockRecord{}, fmt.Errorf("parse block number: %w", err)
+		}
+		cached, err = f.repo.GetBlockByNumber(ctx, number)
+	}
+	if err == nil {
+		return repoBlockToRecord(cached), nil
+	}
+	if !errors.Is(err, repository.ErrBlockNotFound) {
+		return BlockRecord{}, fmt.Errorf("get block from db: %w", err)
+	}
+
+	var block *ethereum.Block
+	if byHash {
+		block, err = f.ethService.FetchBlockByHash(ctx, numberOrHash)
+	} else {
+		block, err = f.ethService.FetchBlockByNumber(ctx, number)
+	}
+	if err != nil {
+		if errors.Is(err, ethereum.ErrBlockNotFound) {
+			return BlockRecord{}, ErrBlockNotFound
+		}
+		return BlockRecord{}, fmt.Errorf("fetch block from node: %w", err)
+	}
+
+	record := blockToRecord(block)
+
+	if block.Confirmations >= f.confirmationDepth {
+		err := f.writes.Enqueue("cache block", func(ctx context.Context) error {
+			return f.repo.SaveBlock(ctx, recordToBlock(record))
+		})
+		if err != nil {
+			f.logs.Errorw("failed to queue block for caching", "error", err, "block", record.Hash)
+		}
+	}
+
+	return record, nil
+}
+
+// SaveContractABI validates the JSON ABI of the contract at address, stores it and starts decoding the transactions and logs of
+// the contract with it.
+func (f *Fethcher) SaveContractABI(ctx context.Context, address string, abiJSON string) error {
+	if err := f.abiRegistry.Register(address, abiJSON); err != nil {
+		return fmt.Errorf("register abi: %w", err)
+	}
+
+	contractABI := repository.ContractABI{
+		Address: common.HexToAddress(address).Hex(),
+		ABI:     abiJSON,
+	}
+	if err := f.repo.SaveContractABI(ctx, contractABI); err != nil {
+		return fmt.Errorf("save contract abi: %w", err)
+	}
+
+	f.logs.Infow("contract abi saved", "address", contractABI.Address)
+	return nil
+}
+
+// LoadContractABIs registers every stored contract ABI and returns how many were loaded.
+func (f *Fethcher) LoadContractABIs(ctx context.Context) (int, error) {
+	abis, err := f.repo.GetContractABIs(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("get contract abis: %w", err)
+	}
+
+	for _, contractABI := range abis {
+		if err := f.abiRegistry.Register(contractABI.Address, contractABI.ABI); err != nil {
+			return 0, fmt.Errorf("register abi of %q: %w", contractABI.Address, err)
+		}
+	}
+	return len(abis), nil
+}
+
 // ParseRLP decodes a hex-encoded RLP string into a slice of transaction hashes.
 func (f *Fethcher) ParseRLP(rlphex string) ([]string, error) {
 	data, err := hex.DecodeString(rlphex)
@@ -193,23 +559,87 @@ func (f *Fethcher) ParseRLP(rlphex string) ([]string, error) {
 func (f *Fethcher) saveTransactionsToDB(ctx context.Context, transactionRecords []TransactionRecord) error {
 	transactions := make([]repository.Transaction, 0, len(transactionRecords))
 	for _, tx := range transactionRecords {
-		transactions = append(transactions, repository.Transaction{
-			TransactionHash:   tx.TransactionHash,
-			TransactionStatus: tx.TransactionStatus,
-			BlockHash:         tx.BlockHash,
-			BlockNumber:       tx.BlockNumber,
-			From:              tx.From,
-			To:                tx.To,
-			ContractAddress:   tx.ContractAddress,
-			LogsCount:         tx.LogsCount,
-			Input:             tx.Input,
-			Value:             tx.Value,
-		})
+		transactions = append(transactions, recordToTransaction(tx))
 	}
 
 	if err := f.repo.SaveTransactions(ctx, transactions); err != nil {
 		return fmt.Errorf("repo save transactions: %w", err)
 	}
+
+	var logs []repository.TransactionLog
+	for _, tx := range transactionRecords {
+		logs = append(logs, recordToLogs(tx)...)
+	}
+
+	if err := f.repo.SaveTransactionLogs(ctx, logs); err != nil {
+		return fmt.Errorf("repo save transaction logs: %w", err)
+	}
+
+	var transfers []repository.TokenTransfer
+	for _, tx := range transactionRecords {
+		transfers = append(transfers, recordToTransfers(tx)...)
+	}
+
+	if err := f.repo.SaveTokenTransfers(ctx, transfers); err != nil {
+		return fmt.Errorf("repo save token transfers: %w", err)
+	}
+	return nil
+}
+
+// decode sets the decoded input, logs and revert data of the record.
+func (f *Fethcher) decode(record *TransactionRecord) {
+	record.DecodedInput = f.abiRegistry.DecodeInput(record.To, record.Input)
+	for i := range record.Logs {
+		record.Logs[i].Decoded = f.abiRegistry.DecodeLog(record.Logs[i].Address, record.Logs[i].Topics, record.Logs[i].Data)
+	}
+	if record.Failure != nil {
+		record.Failure = f.describeFailure(*record.Failure, record.To)
+	}
+}
+
+// attachLogs loads the cached logs of the given records and sets them on every record.
+func (f *Fethcher) attachLogs(ctx context.Context, records []TransactionRecord) error {
+	hashes := make([]string, 0, len(records))
+	for _, rec := range records {
+		hashes = append(hashes, rec.TransactionHash)
+	}
+
+	logs, err := f.repo.GetTransactionLogs(ctx, hashes)
+	if err != nil {
+		return fmt.Errorf("get transaction logs: %w", err)
+	}
+
+	byHash := make(map[string][]LogRecord, len(records))
+	for _, l := range logs {
+		byHash[l.TransactionHash] = append(byHash[l.TransactionHash], repoLogToRecord(l))
+	}
+
+	for i := range records {
+		records[i].Logs = byHash[records[i].TransactionHash]
+	}
+	return nil
+}
+
+// attachTransfers loads the cached token transfers of the given records and sets them on every record.
+func (f *Fethcher) attachTransfers(ctx context.Context, records []TransactionRecord) error {
+	hashes := make([]string, 0, len(records))
+	for _, rec := range records {
+		hashes = append(hashes, rec.TransactionHash)
+	}
+
+	transfers, err := f.repo.GetTokenTransfers(ctx, hashes)
+	if err != nil {
+		return fmt.Errorf("get token transfers: %w", err)
+	}
+
+	byHash := make(map[string][]TransferRecord, len(records))
+	for _, t := range transfers {
+		byHash[t.TransactionHash] = append(byHash[t.TransactionHash], repoTransferToRecord(t))
+	}
+
+	for i := range records {
+		records[i].Transfers = byHash[records[i].TransactionHash]
+	}
 	return nil
 }
 
@@ -232,36 +662,335 @@ func (f *Fethcher) repoTransactionToRecord(transactions []repository.Transaction
 			TransactionStatus: tx.TransactionStatus,
 			BlockHash:         tx.BlockHash,
 			BlockNumber:       tx.BlockNumber,
+			BlockTimestamp:    tx.BlockTimestamp,
 			From:              tx.From,
 			To:                tx.To,
 			ContractAddress:   tx.ContractAddress,
 			LogsCount:         tx.LogsCount,
 			Input:             tx.Input,
 			Value:             tx.Value,
+			Verified:          tx.Verified,
+			Confirmations:     tx.Confirmations,
+			Tentative:         tx.Tentative,
+			Failure:           failure(tx.RevertMessage, tx.RevertData),
 		}
+		setRepoTypeFields(&records[i], tx)
 	}
 	return records
 }
 
-func (f *Fethcher) getTransactionsFromNode(ctx context.Context, transactionsHashes []string) ([]TransactionRecord, error) {
+// getTransactionsFromNode fetches the transactions from the Ethereum node and returns one result per hash, in the order of the
+// hashes.
+func (f *Fethcher) getTransactionsFromNode(ctx context.Context, transactionsHashes []string) []TransactionResult {
+	results := make([]TransactionResult, len(transactionsHashes))
 
-	transactions, err := f.ethService.FetchTransactions(ctx, transactionsHashes)
+	txResults, err := f.ethService.FetchTransactions(ctx, transactionsHashes)
+	if err != nil {
+		for i, transactionHash := range transactionsHashes {
+			results[i] = TransactionResult{
+				TransactionHash: transactionHash,
+				Status:          StatusError,
+				Reason:          err.Error(),
+			}
+		}
+		return results
+	}
 
-	records := make([]TransactionRecord, len(transactions))
-	for i, tx := range transactions {
-		records[i] = TransactionRecord{
-			TransactionHash:   tx.TransactionHash,
-			TransactionStatus: tx.TransactionStatus,
-			BlockHash:         tx.BlockHash,
-			BlockNumber:       tx.BlockNumber,
-			From:              tx.From,
-			To:                tx.To,
-			ContractAddress:   tx.ContractAddress,
-			LogsCount:         tx.LogsCount,
-			Input:             tx.Input,
-			Value:             tx.Value,
+	for i, res := range txResults {
+		results[i] = TransactionResult{TransactionHash: res.Hash}
+		switch {
+		case res.Error == nil:
+			record := f.transactionToRecord(res.Transaction)
+			if record.TransactionStatus == types.ReceiptStatusFailed {
+				record.Failure = f.replayFailure(ctx, res.Transaction)
+			}
+			results[i].Status = StatusFetched
+			results[i].Transaction = &record
+		case errors.Is(res.Error, ethereum.ErrTransactionNotFound):
+			results[i].Status = StatusNotFound
+			results[i].Reason = res.Error.Error()
+		case errors.Is(res.Error, ethereum.ErrTransactionPending):
+			results[i].Status = StatusPending
+			results[i].Reason = res.Error.Error()
+			if res.Pending != nil {
+				results[i].Pending = pendingToRecord(res.Pending)
+			}
+		default:
+			results[i].Status = StatusError
+			results[i].Reason = res.Error.Error()
 		}
 	}
 
-	return records, err
+	return results
+}
+
+func (f *Fethcher) transactionToRecord(tx *ethereum.Transaction) TransactionRecord {
+	logs := make([]LogRecord, 0, len(tx.Logs))
+	for _, l := range tx.Logs {
+		logs = append(logs, LogRecord{
+			TransactionHash: tx.TransactionHash,
+			BlockNumber:     tx.BlockNumber,
+			LogIndex:        l.LogIndex,
+			Address:         l.Address,
+			Topics:          l.Topics,
+			Data:            l.Data,
+		})
+	}
+
+	transfers := make([]TransferRecord, 0, len(tx.TokenTransfers))
+	for _, t := range tx.TokenTransfers {
+		transfers = append(transfers, TransferRecord{
+			TransactionHash: tx.TransactionHash,
+			BlockNumber:     tx.BlockNumber,
+			LogIndex:        t.LogIndex,
+			BatchIndex:      t.BatchIndex,
+			Token:           t.Token,
+			From:            t.From,
+			To:              t.To,
+			Amount:          t.Amount,
+			TokenID:         t.TokenID,
+			Standard:        t.Standard,
+		})
+	}
+
+	record := TransactionRecord{
+		TransactionHash:   tx.TransactionHash,
+		TransactionStatus: tx.TransactionStatus,
+		BlockHash:         tx.BlockHash,
+		BlockNumber:       tx.BlockNumber,
+		BlockTimestamp:    tx.BlockTimestamp,
+		From:              tx.From,
+		To:                tx.To,
+		ContractAddress:   tx.ContractAddress,
+		LogsCount:         tx.LogsCount,
+		Input:             tx.Input,
+		Value:             tx.Value,
+		Verified:          tx.Verified,
+		Confirmations:     tx.Confirmations,
+		Tentative:         tx.Confirmations < f.confirmationDepth,
+		Logs:              logs,
+		Transfers:         transfers,
+	}
+	setNodeTypeFields(&record, tx)
+	return record
+}
+
+func recordToTransaction(tx TransactionRecord) repository.Transaction {
+	transaction := repository.Transaction{
+		TransactionHash:   tx.TransactionHash,
+		TransactionStatus: tx.TransactionStatus,
+		BlockHash:         tx.BlockHash,
+		BlockNumber:       tx.BlockNumber,
+		BlockTimestamp:    tx.BlockTimestamp,
+		From:              tx.From,
+		To:                tx.To,
+		ContractAddress:   tx.ContractAddress,
+		LogsCount:         tx.LogsCount,
+		Input:             tx.Input,
+		Value:             tx.Value,
+		Verified:          tx.Verified,
+		Confirmations:     tx.Confirmations,
+		Tentative:         tx.Tentative,
+	}
+	if tx.Failure != nil {
+		transaction.RevertMessage = &tx.Failure.Message
+		transaction.RevertData = &tx.Failure.Data
+	}
+	setTransactionTypeFields(&transaction, tx)
+	return transaction
+}
+
+// recordToLogs converts the logs of the record to their repository representation. Topics beyond the fourth cannot be emitted
+// by the EVM and are therefore not stored.
+func recordToLogs(tx TransactionRecord) []repository.TransactionLog {
+	logs := make([]repository.TransactionLog, 0, len(tx.Logs))
+	for _, l := range tx.Logs {
+		topics := make([]*string, 4)
+		for i := range min(len(l.Topics), len(topics)) {
+			topics[i] = &l.Topics[i]
+		}
+
+		logs = append(logs, repository.TransactionLog{
+			TransactionHash: tx.TransactionHash,
+			LogIndex:        l.LogIndex,
+			BlockNumber:     tx.BlockNumber,
+			Address:         l.Address,
+			Topic0:          topics[0],
+			Topic1:          topics[1],
+			Topic2:          topics[2],
+			Topic3:          topics[3],
+			Data:            l.Data,
+		})
+	}
+	return logs
+}
+
+func repoLogToRecord(l repository.TransactionLog) LogRecord {
+	topics := make([]string, 0, 4)
+	for _, topic := range []*string{l.Topic0, l.Topic1, l.Topic2, l.Topic3} {
+		if topic == nil {
+			break
+		}
+		topics = append(topics, *topic)
+	}
+
+	return LogRecord{
+		TransactionHash: l.TransactionHash,
+		BlockNumber:     l.BlockNumber,
+		LogIndex:        l.LogIndex,
+		Address:         l.Address,
+		Topics:          topics,
+		Data:            l.Data,
+	}
+}
+
+func recordToTransfers(tx TransactionRecord) []repository.TokenTransfer {
+	transfers := make([]repository.TokenTransfer, 0, len(tx.Transfers))
+	for _, t := range tx.Transfers {
+		transfers = append(transfers, repository.TokenTransfer{
+			TransactionHash: tx.TransactionHash,
+			LogIndex:        t.LogIndex,
+			BatchIndex:      t.BatchIndex,
+			BlockNumber:     tx.BlockNumber,
+			Token:           t.Token,
+			FromAddress:     t.From,
+			ToAddress:       t.To,
+			Amount:          t.Amount,
+			TokenID:         t.TokenID,
+			Standard:        t.Standard,
+		})
+	}
+	return transfers
+}
+
+func repoTransferToRecord(t repository.TokenTransfer) TransferRecord {
+	return TransferRecord{
+		TransactionHash: t.TransactionHash,
+		BlockNumber:     t.BlockNumber,
+		LogIndex:        t.LogIndex,
+		BatchIndex:      t.BatchIndex,
+		Token:           t.Token,
+		From:            t.FromAddress,
+		To:              t.ToAddress,
+		Amount:          t.Amount,
+		TokenID:         t.TokenID,
+		Standard:        t.Standard,
+	}
+}
+
+func blockToRecord(b *ethereum.Block) BlockRecord {
+	return BlockRecord{
+		Number:            b.Number,
+		Hash:              b.Hash,
+		ParentHash:        b.ParentHash,
+		Timestamp:         b.Timestamp,
+		Miner:             b.Miner,
+		BaseFee:           b.BaseFee,
+		GasUsed:           b.GasUsed,
+		GasLimit:          b.GasLimit,
+		StateRoot:         b.StateRoot,
+		TransactionsRoot:  b.TransactionsRoot,
+		ReceiptsRoot:      b.ReceiptsRoot,
+		TransactionHashes: b.TransactionHashes,
+	}
+}
+
+func recordToBlock(b BlockRecord) repository.Block {
+	return repository.Block{
+		Hash:              b.Hash,
+		Number:            b.Number,
+		ParentHash:        b.ParentHash,
+		Timestamp:         b.Timestamp,
+		Miner:             b.Miner,
+		BaseFee:           b.BaseFee,
+		GasUsed:           b.GasUsed,
+		GasLimit:          b.GasLimit,
+		StateRoot:         b.StateRoot,
+		TransactionsRoot:  b.TransactionsRoot,
+		ReceiptsRoot:      b.ReceiptsRoot,
+		TransactionHashes: b.TransactionHashes,
+	}
+}
+
+func repoBlockToRecord(b repository.Block) BlockRecord {
+	return BlockRecord{
+		Number:            b.Number,
+		Hash:              b.Hash,
+		ParentHash:        b.ParentHash,
+		Timestamp:         b.Timestamp,
+		Miner:             b.Miner,
+		BaseFee:           b.BaseFee,
+		GasUsed:           b.GasUsed,
+		GasLimit:          b.GasLimit,
+		StateRoot:         b.StateRoot,
+		TransactionsRoot:  b.TransactionsRoot,
+		ReceiptsRoot:      b.ReceiptsRoot,
+		TransactionHashes: b.TransactionHashes,
+	}
+}
+
+// transactionPage builds a page of at most limit transactions out of the given ones. One transaction more than the limit is
+// requested from the repository, so that its presence tells whether there is a next page.
+func (f *Fethcher) transactionPage(transactions []repository.Transaction, limit int) TransactionPage {
+	page := TransactionPage{}
+	if limit > 0 && len(transactions) > limit {
+		transactions = transactions[:limit]
+		last := transactions[len(transactions)-1]
+		page.NextCursor = encodeCursor(repository.TransactionCursor{
+			BlockNumber:     last.BlockNumber,
+			TransactionHash: last.TransactionHash,
+		})
+	}
+
+	page.Transactions = f.repoTransactionToRecord(transactions)
+	for i := range page.Transactions {
+		f.decode(&page.Transactions[i])
+	}
+	return page
+}
+
+// encodeCursor encodes the position of a transaction as an opaque page cursor.
+func encodeCursor(cursor repository.TransactionCursor) string {
+	return base64.RawURLEncoding.EncodeToString(fmt.Appendf(nil, "%d:%s", cursor.BlockNumber, cursor.TransactionHash))
+}
+
+func decodeCursor(encoded string) (repository.TransactionCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return repository.TransactionCursor{}, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+
+	number, hash, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return repository.TransactionCursor{}, fmt.Errorf("%w: missing separator", ErrInvalidCursor)
+	}
+
+	blockNumber, err := strconv.ParseUint(number, 10, 64)
+	if err != nil {
+		return repository.TransactionCursor{}, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	return repository.TransactionCursor{BlockNumber: blockNumber, TransactionHash: hash}, nil
+}
+
+// encodeHistoryCursor encodes the position of a history entry as an opaque page cursor.
+func encodeHistoryCursor(cursor repository.HistoryCursor) string {
+	return base64.RawURLEncoding.EncodeToString(fmt.Appendf(nil, "%d:%s", cursor.QueriedAt.UnixNano(), cursor.TransactionHash))
+}
+
+func decodeHistoryCursor(encoded string) (repository.HistoryCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return repository.HistoryCursor{}, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+
+	nanos, hash, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return repository.HistoryCursor{}, fmt.Errorf("%w: missing separator", ErrInvalidCursor)
+	}
+
+	queriedAt, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return repository.HistoryCursor{}, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	return repository.HistoryCursor{QueriedAt: time.Unix(0, queriedAt).UTC(), TransactionHash: hash}, nil
 }
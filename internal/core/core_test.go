@@ -5,9 +5,12 @@ import (
 	"errors"
 	"fethcher/internal/core"
 	"fethcher/internal/core/fake"
+	"fethcher/internal/decoder"
 	"fethcher/internal/ethereum"
 	"fethcher/internal/repository"
 	tokenIssuer "fethcher/pkg/jwt"
+	"fmt"
+	"time"
 
 	"github.com/golang-jwt/jwt"
 	"github.com/google/uuid"
@@ -21,6 +24,8 @@ var _ = Describe("Fethcher", func() {
 		fakeRepo   *fake.Repository
 		fakeJWT    *fake.JWTIssuer
 		fakeEth    *fake.EthereumService
+		fakeABI    *fake.ABIRegistry
+		fakeWrites *fake.WriteQueue
 		fakeLogger *zap.SugaredLogger
 		ctx        context.Context
 
@@ -33,10 +38,19 @@ var _ = Describe("Fethcher", func() {
 		fakeRepo = new(fake.Repository)
 		fakeJWT = new(fake.JWTIssuer)
 		fakeEth = new(fake.EthereumService)
+		fakeEth.ReplayTransactionReturns(&ethereum.Revert{Reproduced: true, Message: "execution reverted", Data: "0x"}, nil)
+		fakeABI = new(fake.ABIRegistry)
+		fakeWrites = new(fake.WriteQueue)
 		fakeLogger = zap.NewNop().Sugar()
 		ctx = context.Background()
 
-		fetcher = core.NewFethcher(fakeLogger, fakeRepo, fakeJWT, fakeEth)
+		// queued writes are run right away, as a queue with an idle worker would
+		fakeWrites.EnqueueStub = func(name string, write func(ctx context.Context) error) error {
+			_ = write(context.Background())
+			return nil
+		}
+
+		fetcher = core.NewFethcher(fakeLogger, fakeRepo, fakeJWT, fakeEth, fakeABI, fakeWrites, 12)
 
 		fakeErr = errors.New("fake error")
 	})
@@ -146,17 +160,19 @@ var _ = Describe("Fethcher", func() {
 
 	Describe("GetTransactions", func() {
 		var (
-			txHashes  []string
-			txRecords []core.TransactionRecord
-			err       error
+			txHashes []string
+			include  core.IncludeOptions
+			results  []core.TransactionResult
+			err      error
 		)
 
 		BeforeEach(func() {
 			txHashes = []string{"0x1", "0x2"}
+			include = core.IncludeOptions{}
 		})
 
 		JustBeforeEach(func() {
-			txRecords, err = fetcher.GetTransactions(ctx, txHashes)
+			results, err = fetcher.GetTransactions(ctx, txHashes, include)
 		})
 
 		When("transactions exist in DB", func() {
@@ -169,7 +185,9 @@ var _ = Describe("Fethcher", func() {
 
 			It("should return transactions from DB", func() {
 				Expect(err).NotTo(HaveOccurred())
-				Expect(txRecords).To(HaveLen(2))
+				Expect(results).To(HaveLen(2))
+				Expect(results[0].Status).To(Equal(core.StatusCached))
+				Expect(results[1].Status).To(Equal(core.StatusCached))
 				Expect(fakeRepo.GetTransactionsByHashCallCount()).To(Equal(1))
 				_, argTxs := fakeRepo.GetTransactionsByHashArgsForCall(0)
 				Expect(argTxs).To(Equal(txHashes))
@@ -179,212 +197,1480 @@ var _ = Describe("Fethcher", func() {
 
 		When("one or more transactions missing from DB", func() {
 			BeforeEach(func() {
+				txHashes = []string{"0x2", "0x1"}
 				fakeRepo.GetTransactionsByHashReturns([]repository.Transaction{
 					{TransactionHash: "0x1"},
 				}, nil)
-				fakeEth.FetchTransactionsReturns([]*ethereum.Transaction{
-					{TransactionHash: "0x2"},
+				fakeEth.FetchTransactionsReturns([]*ethereum.TxResult{
+					{Hash: "0x2", Transaction: &ethereum.Transaction{TransactionHash: "0x2"}},
 				}, nil)
 			})
 
 			It("fetches missing transactions from ethereum node", func() {
 				Expect(err).NotTo(HaveOccurred())
-				Expect(txRecords).To(HaveLen(2))
 				Expect(fakeRepo.GetTransactionsByHashCallCount()).To(Equal(1))
 				Expect(fakeEth.FetchTransactionsCallCount()).To(Equal(1))
 				_, argTxs := fakeEth.FetchTransactionsArgsForCall(0)
 				Expect(argTxs).To(Equal([]string{"0x2"}))
 			})
+
+			It("keeps the order of the requested hashes", func() {
+				Expect(results).To(HaveLen(2))
+				Expect(results[0].TransactionHash).To(Equal("0x2"))
+				Expect(results[0].Status).To(Equal(core.StatusFetched))
+				Expect(results[0].Transaction.TransactionHash).To(Equal("0x2"))
+				Expect(results[1].TransactionHash).To(Equal("0x1"))
+				Expect(results[1].Status).To(Equal(core.StatusCached))
+				Expect(results[1].Transaction.TransactionHash).To(Equal("0x1"))
+			})
 		})
 
-		When("getting txs from db fails", func() {
+		When("the same hash is requested more than once", func() {
 			BeforeEach(func() {
-				fakeRepo.GetTransactionsByHashReturns(nil, fakeErr)
+				txHashes = []string{"0x1", "0x1"}
+				fakeRepo.GetTransactionsByHashReturns([]repository.Transaction{}, nil)
+				fakeEth.FetchTransactionsReturns([]*ethereum.TxResult{
+					{Hash: "0x1", Transaction: &ethereum.Transaction{TransactionHash: "0x1"}},
+				}, nil)
 			})
 
-			It("should return error", func() {
-				Expect(err).To(MatchError(fakeErr))
+			It("fetches it once and reports it for every occurrence", func() {
+				Expect(err).NotTo(HaveOccurred())
+				_, argTxs := fakeEth.FetchTransactionsArgsForCall(0)
+				Expect(argTxs).To(Equal([]string{"0x1"}))
+				Expect(results).To(HaveLen(2))
+				Expect(results[1].Status).To(Equal(core.StatusFetched))
 			})
 		})
 
-		When("node fetch fails", func() {
+		When("fetched transactions cannot be queued for caching", func() {
+			BeforeEach(func() {
+				fakeRepo.GetTransactionsByHashReturns([]repository.Transaction{}, nil)
+				fakeEth.FetchTransactionsReturns([]*ethereum.TxResult{
+					{Hash: "0x1", Transaction: &ethereum.Transaction{TransactionHash: "0x1", Confirmations: 12}},
+				}, nil)
+				fakeWrites.EnqueueStub = nil
+				fakeWrites.EnqueueReturns(fakeErr)
+			})
+
+			It("still returns them", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(results).To(HaveLen(2))
+				Expect(results[0].Status).To(Equal(core.StatusFetched))
+				Expect(fakeWrites.EnqueueCallCount()).To(Equal(1))
+				Expect(fakeRepo.SaveTransactionsCallCount()).To(Equal(0))
+			})
+		})
+
+		When("fetched transactions have not reached the confirmation depth", func() {
+			BeforeEach(func() {
+				fakeRepo.GetTransactionsByHashReturns([]repository.Transaction{}, nil)
+				fakeEth.FetchTransactionsReturns([]*ethereum.TxResult{
+					{Hash: "0x1", Transaction: &ethereum.Transaction{TransactionHash: "0x1", Confirmations: 3}},
+					{Hash: "0x2", Transaction: &ethereum.Transaction{TransactionHash: "0x2", Confirmations: 12}},
+				}, nil)
+			})
+
+			It("caches them as tentative", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(results).To(HaveLen(2))
+				Expect(results[0].Transaction.Tentative).To(BeTrue())
+				Expect(results[1].Transaction.Tentative).To(BeFalse())
+
+				Eventually(fakeRepo.SaveTransactionsCallCount).Should(Equal(1))
+				_, saved := fakeRepo.SaveTransactionsArgsForCall(0)
+				Expect(saved).To(HaveLen(2))
+				Expect(saved[0].Tentative).To(BeTrue())
+				Expect(saved[0].Confirmations).To(Equal(uint64(3)))
+				Expect(saved[1].Tentative).To(BeFalse())
+			})
+		})
+
+		When("the node cannot return some transactions", func() {
 			BeforeEach(func() {
+				txHashes = []string{"0x1", "0x2", "0x3", "0x4"}
+				fakeRepo.GetTransactionsByHashReturns([]repository.Transaction{}, nil)
+				fakeEth.FetchTransactionsReturns([]*ethereum.TxResult{
+					{Hash: "0x1", Error: ethereum.ErrTransactionNotFound},
+					{Hash: "0x2", Error: ethereum.ErrTransactionPending},
+					{Hash: "0x3", Error: fakeErr},
+					{Hash: "0x4", Transaction: &ethereum.Transaction{TransactionHash: "0x4", TransactionStatus: 1}},
+				}, nil)
+			})
+
+			It("reports the status and reason of every hash", func() {
+				var (
+					txType   uint8
+					zero     uint64
+					gasPrice string
+				)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(results).To(Equal([]core.TransactionResult{
+					{TransactionHash: "0x1", Status: core.StatusNotFound, Reason: ethereum.ErrTransactionNotFound.Error()},
+					{TransactionHash: "0x2", Status: core.StatusPending, Reason: ethereum.ErrTransactionPending.Error()},
+					{TransactionHash: "0x3", Status: core.StatusError, Reason: fakeErr.Error()},
+					{TransactionHash: "0x4", Status: core.StatusFetched, Transaction: &core.TransactionRecord{
+						TransactionHash:   "0x4",
+						TransactionStatus: 1,
+						Tentative:         true,
+						Type:              &txType,
+						Nonce:             &zero,
+						GasLimit:          &zero,
+						GasUsed:           &zero,
+						EffectiveGasPrice: &gasPrice,
+					}},
+				}))
+			})
+
+			It("only caches the fetched transactions", func() {
+				Eventually(fakeRepo.SaveTransactionsCallCount).Should(Equal(1))
+				_, saved := fakeRepo.SaveTransactionsArgsForCall(0)
+				Expect(saved).To(HaveLen(1))
+				Expect(saved[0].TransactionHash).To(Equal("0x4"))
+			})
+		})
+
+		When("a fetched transaction failed", func() {
+			var revert *decoder.Revert
+
+			BeforeEach(func() {
+				to := "0xc0ffee"
+				revert = &decoder.Revert{Kind: decoder.RevertError, Selector: "0x08c379a0", Reason: "insufficient balance", Source: decoder.SourceBuiltin}
+				fakeRepo.GetTransactionsByHashReturns([]repository.Transaction{}, nil)
+				fakeEth.FetchTransactionsReturns([]*ethereum.TxResult{
+					{Hash: "0x1", Transaction: &ethereum.Transaction{TransactionHash: "0x1", To: &to, TransactionStatus: 0}},
+					{Hash: "0x2", Transaction: &ethereum.Transaction{TransactionHash: "0x2", To: &to, TransactionStatus: 1}},
+				}, nil)
+				fakeEth.ReplayTransactionReturns(&ethereum.Revert{Reproduced: true, Message: "execution reverted: insufficient balance", Data: "0x08c379a0"}, nil)
+				fakeABI.DecodeRevertReturns(revert)
+			})
+
+			It("replays it and decodes the revert data", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeEth.ReplayTransactionCallCount()).To(Equal(1))
+				_, replayed := fakeEth.ReplayTransactionArgsForCall(0)
+				Expect(replayed.TransactionHash).To(Equal("0x1"))
+				to, data := fakeABI.DecodeRevertArgsForCall(0)
+				Expect(to).To(HaveValue(Equal("0xc0ffee")))
+				Expect(data).To(Equal("0x08c379a0"))
+
+				Expect(results[0].Transaction.Failure).To(Equal(&core.Failure{
+					Reproduced: true,
+					Reason:     "insufficient balance",
+					Message:    "execution reverted: insufficient balance",
+					Data:       "0x08c379a0",
+					Revert:     revert,
+				}))
+				Expect(results[1].Transaction.Failure).To(BeNil())
+			})
+
+			It("caches the failure with the transaction", func() {
+				Eventually(fakeRepo.SaveTransactionsCallCount).Should(Equal(1))
+				_, saved := fakeRepo.SaveTransactionsArgsForCall(0)
+				Expect(saved[0].RevertMessage).To(HaveValue(Equal("execution reverted: insufficient balance")))
+				Expect(saved[0].RevertData).To(HaveValue(Equal("0x08c379a0")))
+				Expect(saved[1].RevertMessage).To(BeNil())
+			})
+
+			When("the transaction does not fail when replayed", func() {
+				BeforeEach(func() {
+					fakeEth.ReplayTransactionReturns(&ethereum.Revert{Data: "0x"}, nil)
+				})
+
+				It("reports that the failure was not reproduced", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(results[0].Transaction.Failure.Reproduced).To(BeFalse())
+					Expect(results[0].Transaction.Failure.Revert).To(BeNil())
+					Expect(fakeABI.DecodeRevertCallCount()).To(BeZero())
+				})
+			})
+
+			When("the transaction fails without revert data", func() {
+				BeforeEach(func() {
+					fakeEth.ReplayTransactionReturns(&ethereum.Revert{Reproduced: true, Message: "out of gas", Data: "0x"}, nil)
+					fakeABI.DecodeRevertReturns(nil)
+				})
+
+				It("gives the message of the node as the reason", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(results[0].Transaction.Failure.Reason).To(Equal("out of gas"))
+				})
+			})
+
+			When("the replay fails", func() {
+				BeforeEach(func() {
+					fakeEth.ReplayTransactionReturns(nil, fakeErr)
+				})
+
+				It("returns the transaction without a failure", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(results[0].Status).To(Equal(core.StatusFetched))
+					Expect(results[0].Transaction.Failure).To(BeNil())
+				})
+			})
+		})
+
+		When("a cached transaction failed", func() {
+			BeforeEach(func() {
+				message, data := "execution reverted", "0xdeadbeef"
 				fakeRepo.GetTransactionsByHashReturns([]repository.Transaction{
-					{TransactionHash: "0x1"},
+					{TransactionHash: "0x1", RevertMessage: &message, RevertData: &data},
+					{TransactionHash: "0x2"},
 				}, nil)
-				fakeEth.FetchTransactionsReturns(nil, fakeErr)
+				fakeABI.DecodeRevertReturns(&decoder.Revert{Kind: decoder.RevertCustom, Selector: "0xdeadbeef", Source: decoder.SourceSelector})
 			})
 
-			It("should return partial results with error", func() {
-				Expect(txRecords).To(HaveLen(1))
+			It("decodes the stored revert data", func() {
 				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeEth.ReplayTransactionCallCount()).To(BeZero())
+				Expect(results[0].Transaction.Failure.Reproduced).To(BeTrue())
+				Expect(results[0].Transaction.Failure.Reason).To(Equal("unknown custom error 0xdeadbeef"))
+				Expect(results[1].Transaction.Failure).To(BeNil())
 			})
 		})
-	})
 
-	Describe("ParseRLP", func() {
-		var (
-			transactions []string
-			err          error
-			rlphex       string
-			parsedSlice  []string
-		)
+		When("fetched transactions emitted logs", func() {
+			BeforeEach(func() {
+				fakeRepo.GetTransactionsByHashReturns([]repository.Transaction{}, nil)
+				fakeEth.FetchTransactionsReturns([]*ethereum.TxResult{
+					{Hash: "0x1", Transaction: &ethereum.Transaction{
+						TransactionHash: "0x1",
+						BlockNumber:     100,
+						LogsCount:       1,
+						Logs:            []ethereum.Log{{Address: "0xc0ffee", Topics: []string{"0xt0", "0xt1"}, Data: "0xcafe", LogIndex: 4}},
+					}},
+					{Hash: "0x2", Transaction: &ethereum.Transaction{TransactionHash: "0x2"}},
+				}, nil)
+			})
 
-		BeforeEach(func() {
-			rlphex = "f90110b842307866633262336236646233386135316462336239636239356465323962373139646538646562393936333036323665346234623939646630353666666237663265b842307834383630336637616466663766626663326131306232326136373130333331656536386632653464316364373361353834643537633838323164663739333536b842307863626339323065376262383963626362353430613436396131363232366266313035373832353238336162386561633366343564303038313165656638613634b842307836643630346666633634346132383266636138636238653737386531653366383234356438626431643439333236653330313661336338373862613063626264"
-			parsedSlice = []string{
-				"0x307866633262336236646233386135316462336239636239356465323962373139646538646562393936333036323665346234623939646630353666666237663265",
-				"0x307834383630336637616466663766626663326131306232326136373130333331656536386632653464316364373361353834643537633838323164663739333536",
-				"0x307863626339323065376262383963626362353430613436396131363232366266313035373832353238336162386561633366343564303038313165656638613634",
-				"0x307836643630346666633634346132383266636138636238653737386531653366383234356438626431643439333236653330313661336338373862613063626264",
-			}
+			It("caches the logs", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(fakeRepo.SaveTransactionLogsCallCount).Should(Equal(1))
+				_, saved := fakeRepo.SaveTransactionLogsArgsForCall(0)
+				Expect(saved).To(HaveLen(1))
+				Expect(saved[0].TransactionHash).To(Equal("0x1"))
+				Expect(saved[0].BlockNumber).To(Equal(uint64(100)))
+				Expect(saved[0].LogIndex).To(Equal(uint(4)))
+				Expect(*saved[0].Topic0).To(Equal("0xt0"))
+				Expect(*saved[0].Topic1).To(Equal("0xt1"))
+				Expect(saved[0].Topic2).To(BeNil())
+			})
+
+			It("does not return the logs unless asked to", func() {
+				Expect(results[0].Transaction.Logs).To(BeNil())
+			})
+
+			When("the logs are included", func() {
+				BeforeEach(func() {
+					include.Logs = true
+				})
+
+				It("returns the logs", func() {
+					Expect(results[0].Transaction.Logs).To(Equal([]core.LogRecord{{
+						TransactionHash: "0x1",
+						BlockNumber:     100,
+						LogIndex:        4,
+						Address:         "0xc0ffee",
+						Topics:          []string{"0xt0", "0xt1"},
+						Data:            "0xcafe",
+					}}))
+				})
+			})
 		})
 
-		JustBeforeEach(func() {
-			transactions, err = fetcher.ParseRLP(rlphex)
+		When("the logs of cached transactions are included", func() {
+			BeforeEach(func() {
+				include.Logs = true
+				topic := "0xt0"
+				fakeRepo.GetTransactionsByHashReturns([]repository.Transaction{
+					{TransactionHash: "0x1"},
+					{TransactionHash: "0x2"},
+				}, nil)
+				fakeRepo.GetTransactionLogsReturns([]repository.TransactionLog{
+					{TransactionHash: "0x2", LogIndex: 0, Address: "0xc0ffee", Topic0: &topic},
+					{TransactionHash: "0x2", LogIndex: 1, Address: "0xc0ffee"},
+				}, nil)
+			})
+
+			It("loads them from the DB", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeRepo.GetTransactionLogsCallCount()).To(Equal(1))
+				_, argHashes := fakeRepo.GetTransactionLogsArgsForCall(0)
+				Expect(argHashes).To(Equal([]string{"0x1", "0x2"}))
+
+				Expect(results[0].Transaction.Logs).To(BeEmpty())
+				Expect(results[1].Transaction.Logs).To(HaveLen(2))
+				Expect(results[1].Transaction.Logs[0].Topics).To(Equal([]string{"0xt0"}))
+				Expect(results[1].Transaction.Logs[1].Topics).To(BeEmpty())
+			})
+
+			When("loading the logs fails", func() {
+				BeforeEach(func() {
+					fakeRepo.GetTransactionLogsReturns(nil, fakeErr)
+				})
+
+				It("returns the error", func() {
+					Expect(err).To(MatchError(fakeErr))
+				})
+			})
 		})
 
-		When("RLP is valid", func() {
-			It("returns the transaction hashes", func() {
+		When("fetched transactions moved tokens", func() {
+			BeforeEach(func() {
+				tokenID := "42"
+				fakeRepo.GetTransactionsByHashReturns([]repository.Transaction{}, nil)
+				fakeEth.FetchTransactionsReturns([]*ethereum.TxResult{
+					{Hash: "0x1", Transaction: &ethereum.Transaction{
+						TransactionHash: "0x1",
+						BlockNumber:     100,
+						TokenTransfers: []ethereum.TokenTransfer{
+							{Token: "0x70ce", From: "0xa11ce", To: "0xb0b", Amount: "1", TokenID: &tokenID, Standard: ethereum.StandardERC721, LogIndex: 2},
+						},
+					}},
+					{Hash: "0x2", Transaction: &ethereum.Transaction{TransactionHash: "0x2"}},
+				}, nil)
+			})
+
+			It("caches the transfers", func() {
 				Expect(err).NotTo(HaveOccurred())
-				Expect(transactions).To(Equal(parsedSlice))
+				Eventually(fakeRepo.SaveTokenTransfersCallCount).Should(Equal(1))
+				_, saved := fakeRepo.SaveTokenTransfersArgsForCall(0)
+				Expect(saved).To(HaveLen(1))
+				Expect(saved[0].TransactionHash).To(Equal("0x1"))
+				Expect(saved[0].BlockNumber).To(Equal(uint64(100)))
+				Expect(saved[0].FromAddress).To(Equal("0xa11ce"))
+				Expect(saved[0].ToAddress).To(Equal("0xb0b"))
+				Expect(*saved[0].TokenID).To(Equal("42"))
+			})
+
+			It("does not return the transfers unless asked to", func() {
+				Expect(results[0].Transaction.Transfers).To(BeNil())
+			})
+
+			When("the transfers are included", func() {
+				BeforeEach(func() {
+					include.Transfers = true
+				})
+
+				It("returns the transfers", func() {
+					Expect(results[0].Transaction.Transfers).To(HaveLen(1))
+					Expect(results[0].Transaction.Transfers[0].Standard).To(Equal(ethereum.StandardERC721))
+					Expect(results[1].Transaction.Transfers).To(BeEmpty())
+				})
 			})
 		})
 
-		When("RLP is NOT valid", func() {
+		When("the transfers of cached transactions are included", func() {
 			BeforeEach(func() {
-				rlphex = "f90110b842307866633262336236646233386135"
+				include.Transfers = true
+				fakeRepo.GetTransactionsByHashReturns([]repository.Transaction{
+					{TransactionHash: "0x1"},
+					{TransactionHash: "0x2"},
+				}, nil)
+				fakeRepo.GetTokenTransfersReturns([]repository.TokenTransfer{
+					{TransactionHash: "0x2", Token: "0x70ce", FromAddress: "0xa11ce", ToAddress: "0xb0b", Amount: "100", Standard: "erc20"},
+				}, nil)
 			})
 
-			It("returns an error", func() {
-				Expect(err).To(HaveOccurred())
-				Expect(transactions).To(BeNil())
+			It("loads them from the DB", func() {
+				Expect(err).NotTo(HaveOccurred())
+				_, argHashes := fakeRepo.GetTokenTransfersArgsForCall(0)
+				Expect(argHashes).To(Equal([]string{"0x1", "0x2"}))
+
+				Expect(results[0].Transaction.Transfers).To(BeEmpty())
+				Expect(results[1].Transaction.Transfers).To(Equal([]core.TransferRecord{{
+					TransactionHash: "0x2",
+					Token:           "0x70ce",
+					From:            "0xa11ce",
+					To:              "0xb0b",
+					Amount:          "100",
+					Standard:        "erc20",
+				}}))
+				Expect(fakeRepo.GetTransactionLogsCallCount()).To(Equal(0))
+			})
+
+			When("loading the transfers fails", func() {
+				BeforeEach(func() {
+					fakeRepo.GetTokenTransfersReturns(nil, fakeErr)
+				})
+
+				It("returns the error", func() {
+					Expect(err).To(MatchError(fakeErr))
+				})
 			})
 		})
-	})
 
-	Describe("SaveUserTransactionsHistory", func() {
-		var (
-			token    string
-			txHashes []string
-			err      error
-			userId   string
-		)
+		When("cached transactions have type-specific fields", func() {
+			BeforeEach(func() {
+				txType, blobGasUsed := uint8(3), uint64(131072)
+				maxFee, priorityFee, maxBlobFee, blobGasPrice := "30", "2", "5", "3"
+				fakeRepo.GetTransactionsByHashReturns([]repository.Transaction{
+					{TransactionHash: "0x1"},
+					{
+						TransactionHash:      "0x2",
+						TxType:               &txType,
+						MaxFeePerGas:         &maxFee,
+						MaxPriorityFeePerGas: &priorityFee,
+						AccessList:           []repository.AccessTuple{{Address: "0xc0ffee", StorageKeys: []string{"0x01"}}},
+						BlobVersionedHashes:  []string{"0x0101"},
+						MaxFeePerBlobGas:     &maxBlobFee,
+						BlobGasUsed:          &blobGasUsed,
+						BlobGasPrice:         &blobGasPrice,
+					},
+				}, nil)
+			})
 
-		BeforeEach(func() {
-			token = "valid.token"
-			userId = "user123"
-			txHashes = []string{"0x1", "0x2"}
+			It("returns them in their sections", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(results[0].Transaction.Type).To(BeNil())
+				Expect(results[0].Transaction.DynamicFee).To(BeNil())
+
+				blobTx := results[1].Transaction
+				Expect(*blobTx.Type).To(Equal(uint8(3)))
+				Expect(blobTx.Legacy).To(BeNil())
+				Expect(blobTx.DynamicFee).To(Equal(&core.DynamicFees{MaxFeePerGas: "30", MaxPriorityFeePerGas: "2"}))
+				Expect(blobTx.AccessList).To(Equal([]core.AccessTuple{{Address: "0xc0ffee", StorageKeys: []string{"0x01"}}}))
+				Expect(blobTx.Blob).To(Equal(&core.BlobFields{
+					BlobVersionedHashes: []string{"0x0101"},
+					MaxFeePerBlobGas:    "5",
+					BlobGasUsed:         131072,
+					BlobGasPrice:        "3",
+				}))
+				Expect(blobTx.AuthorizationList).To(BeNil())
+			})
 		})
 
-		JustBeforeEach(func() {
-			err = fetcher.SaveUserTransactionsHistory(ctx, token, txHashes)
+		When("cached transactions have L1 fees", func() {
+			BeforeEach(func() {
+				gasUsed, l1GasUsed, gasUsedForL1 := uint64(46835), uint64(1600), uint64(40000)
+				opPrice, arbGasUsed, arbPrice := "1001187", uint64(107188), "10000000"
+				l1Fee, l1GasPrice, l1BlobBaseFee := "5472000000000", "5000000000", "1"
+				fakeRepo.GetTransactionsByHashReturns([]repository.Transaction{
+					{
+						TransactionHash:   "0x1",
+						GasUsed:           &gasUsed,
+						EffectiveGasPrice: &opPrice,
+						L1Fee:             &l1Fee,
+						L1GasUsed:         &l1GasUsed,
+						L1GasPrice:        &l1GasPrice,
+						L1BlobBaseFee:     &l1BlobBaseFee,
+					},
+					{
+						TransactionHash:   "0x2",
+						GasUsed:           &arbGasUsed,
+						EffectiveGasPrice: &arbPrice,
+						GasUsedForL1:      &gasUsedForL1,
+					},
+				}, nil)
+			})
+
+			It("adds the OP-stack L1 fee to the execution fee", func() {
+				Expect(err).NotTo(HaveOccurred())
+				opTx := results[0].Transaction
+				Expect(opTx.L1Fees.L1Fee).To(HaveValue(Equal("5472000000000")))
+				Expect(opTx.L1Fees.L1GasUsed).To(HaveValue(Equal(uint64(1600))))
+				Expect(opTx.L1Fees.L1GasPrice).To(HaveValue(Equal("5000000000")))
+				Expect(opTx.L1Fees.L1BlobBaseFee).To(HaveValue(Equal("1")))
+				Expect(opTx.L1Fees.GasUsedForL1).To(BeNil())
+				Expect(opTx.Cost).To(Equal(&core.CostBreakdown{
+					ExecutionFee: "46890593145",
+					L1Fee:        "5472000000000",
+					BlobFee:      "0",
+					TotalFee:     "5518890593145",
+				}))
+			})
+
+			It("takes the Arbitrum gas used for L1 out of the execution fee", func() {
+				arbTx := results[1].Transaction
+				Expect(arbTx.L1Fees.GasUsedForL1).To(HaveValue(Equal(uint64(40000))))
+				Expect(arbTx.L1Fees.L1Fee).To(BeNil())
+				Expect(arbTx.Cost).To(Equal(&core.CostBreakdown{
+					ExecutionFee: "671880000000",
+					L1Fee:        "400000000000",
+					BlobFee:      "0",
+					TotalFee:     "1071880000000",
+				}))
+			})
 		})
 
-		When("token is valid and save succeeds", func() {
+		When("a fetched transaction is an L2 deposit", func() {
 			BeforeEach(func() {
-				fakeJWT.ValidateReturns(jwt.MapClaims{"sub": userId}, nil)
-				fakeRepo.SaveUserHistoryReturns(nil)
+				sourceHash, mint := "0x8f4a", "10000000000000000"
+				fakeRepo.GetTransactionsByHashReturns([]repository.Transaction{}, nil)
+				fakeEth.FetchTransactionsReturns([]*ethereum.TxResult{
+					{Hash: "0x1", Transaction: &ethereum.Transaction{
+						TransactionHash:   "0x1",
+						Type:              ethereum.OPDepositTxType,
+						GasUsed:           21000,
+						EffectiveGasPrice: "0",
+						SourceHash:        &sourceHash,
+						Mint:              &mint,
+					}},
+					{Hash: "0x2", Transaction: &ethereum.Transaction{TransactionHash: "0x2", GasUsed: 21000, EffectiveGasPrice: "2"}},
+				}, nil)
 			})
 
-			It("should save user history", func() {
+			It("returns and caches the deposit fields", func() {
 				Expect(err).NotTo(HaveOccurred())
-				Expect(fakeJWT.ValidateCallCount()).To(Equal(1))
-				argToken := fakeJWT.ValidateArgsForCall(0)
-				Expect(argToken).To(Equal(token))
-				Expect(fakeRepo.SaveUserHistoryCallCount()).To(Equal(1))
-				_, argUserId, argTxHashes := fakeRepo.SaveUserHistoryArgsForCall(0)
-				Expect(argUserId).To(Equal(userId))
-				Expect(argTxHashes).To(Equal(txHashes))
+				deposit := results[0].Transaction.Deposit
+				Expect(deposit.SourceHash).To(HaveValue(Equal("0x8f4a")))
+				Expect(deposit.Mint).To(HaveValue(Equal("10000000000000000")))
+				Expect(deposit.RequestID).To(BeNil())
+				Expect(results[0].Transaction.L1Fees).To(BeNil())
+				Expect(results[0].Transaction.Cost.TotalFee).To(Equal("0"))
+				Expect(results[1].Transaction.Deposit).To(BeNil())
+				Expect(results[1].Transaction.Cost.TotalFee).To(Equal("42000"))
+
+				Eventually(fakeRepo.SaveTransactionsCallCount).Should(Equal(1))
+				_, saved := fakeRepo.SaveTransactionsArgsForCall(0)
+				Expect(saved[0].SourceHash).To(HaveValue(Equal("0x8f4a")))
+				Expect(saved[0].Mint).To(HaveValue(Equal("10000000000000000")))
+				Expect(saved[1].SourceHash).To(BeNil())
 			})
 		})
 
-		When("token is invalid", func() {
+		When("the input of a transaction can be decoded", func() {
 			BeforeEach(func() {
-				fakeJWT.ValidateReturns(nil, fakeErr)
+				to := "0xc0ffee"
+				fakeRepo.GetTransactionsByHashReturns([]repository.Transaction{
+					{TransactionHash: "0x1", To: &to, Input: "0xa9059cbb"},
+					{TransactionHash: "0x2"},
+				}, nil)
+				fakeABI.DecodeInputStub = func(to *string, input string) *decoder.Call {
+					if to == nil {
+						return nil
+					}
+					return &decoder.Call{Selector: input, Method: "transfer", Source: decoder.SourceABI}
+				}
 			})
 
-			It("should return validation error", func() {
-				Expect(err).To(MatchError(fakeErr))
+			It("returns the decoded input", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(results[0].Transaction.DecodedInput).To(Equal(&decoder.Call{Selector: "0xa9059cbb", Method: "transfer", Source: decoder.SourceABI}))
+				Expect(results[1].Transaction.DecodedInput).To(BeNil())
+				Expect(fakeABI.DecodeInputCallCount()).To(Equal(2))
 			})
 		})
 
-		When("save fails", func() {
+		When("getting txs from db fails", func() {
 			BeforeEach(func() {
-				fakeJWT.ValidateReturns(jwt.MapClaims{"sub": "user123"}, nil)
-				fakeRepo.SaveUserHistoryReturns(fakeErr)
+				fakeRepo.GetTransactionsByHashReturns(nil, fakeErr)
 			})
 
-			It("should return save error", func() {
+			It("should return error", func() {
 				Expect(err).To(MatchError(fakeErr))
 			})
 		})
 
-		When("transactions are empty", func() {
+		When("node fetch fails", func() {
 			BeforeEach(func() {
-				txHashes = []string{}
+				fakeRepo.GetTransactionsByHashReturns([]repository.Transaction{
+					{TransactionHash: "0x1"},
+				}, nil)
+				fakeEth.FetchTransactionsReturns(nil, fakeErr)
 			})
 
-			It("should skip saving", func() {
+			It("should return partial results with the error of the missing transactions", func() {
 				Expect(err).NotTo(HaveOccurred())
-				Expect(fakeRepo.SaveUserHistoryCallCount()).To(Equal(0))
+				Expect(results).To(HaveLen(2))
+				Expect(results[0].Status).To(Equal(core.StatusCached))
+				Expect(results[1].Status).To(Equal(core.StatusError))
+				Expect(results[1].Reason).To(Equal(fakeErr.Error()))
+				Consistently(fakeRepo.SaveTransactionsCallCount).Should(Equal(0))
 			})
 		})
 	})
 
-	Describe("GetUserTransactionsHistory", func() {
+	Describe("GetLogs", func() {
 		var (
-			token     string
-			txRecords []core.TransactionRecord
-			err       error
+			filter core.LogFilter
+			logs   []core.LogRecord
+			err    error
 		)
 
 		BeforeEach(func() {
-			token = "valid.token"
+			filter = core.LogFilter{
+				Address: "0x00000000000000000000000000000000c0ffee00",
+				Topic0:  "0xDDF252AD1BE2C89B69C2B068FC378DAA952BA7F163C4A11628F55A4DF523B3EF",
+				Limit:   10,
+			}
+			fakeRepo.FindLogsReturns([]repository.TransactionLog{{TransactionHash: "0x1", Address: "0xc0ffee"}}, nil)
 		})
 
 		JustBeforeEach(func() {
-			txRecords, err = fetcher.GetUserTransactionsHistory(ctx, token)
+			logs, err = fetcher.GetLogs(ctx, filter)
 		})
 
-		When("user has transaction history", func() {
+		It("looks up the logs by normalized address and topic", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(logs).To(HaveLen(1))
+			Expect(fakeABI.DecodeLogCallCount()).To(Equal(1))
+			_, repoFilter := fakeRepo.FindLogsArgsForCall(0)
+			Expect(repoFilter).To(Equal(repository.LogFilter{
+				Address: "0x00000000000000000000000000000000C0FfeE00",
+				Topic0:  "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef",
+				Limit:   10,
+			}))
+		})
+
+		When("only the topic is given", func() {
 			BeforeEach(func() {
-				fakeJWT.ValidateReturns(jwt.MapClaims{"sub": "user123"}, nil)
-				fakeRepo.GetUserHistoryReturns([]string{"0x1", "0x2"}, nil)
-				fakeRepo.GetTransactionsByHashReturns([]repository.Transaction{
-					{TransactionHash: "0x1"},
-					{TransactionHash: "0x2"},
-				}, nil)
+				filter.Address = ""
 			})
 
-			It("should return user transactions", func() {
-				Expect(err).NotTo(HaveOccurred())
-				Expect(txRecords).To(HaveLen(2))
-				Expect(fakeJWT.ValidateCallCount()).To(Equal(1))
-				Expect(fakeRepo.GetUserHistoryCallCount()).To(Equal(1))
-				Expect(fakeRepo.GetTransactionsByHashCallCount()).To(Equal(1))
+			It("does not filter by address", func() {
+				_, repoFilter := fakeRepo.FindLogsArgsForCall(0)
+				Expect(repoFilter.Address).To(BeEmpty())
 			})
 		})
 
-		When("user has no history", func() {
+		When("the lookup fails", func() {
 			BeforeEach(func() {
-				fakeJWT.ValidateReturns(jwt.MapClaims{"sub": "user123"}, nil)
-				fakeRepo.GetUserHistoryReturns(nil, repository.ErrUserNotFound)
+				fakeRepo.FindLogsReturns(nil, fakeErr)
 			})
 
-			It("should return not found error", func() {
-				Expect(err).To(MatchError(repository.ErrUserNotFound))
+			It("returns the error", func() {
+				Expect(err).To(MatchError(fakeErr))
 			})
 		})
+	})
 
-		When("token is invalid", func() {
+	Describe("GetTokenTransfers", func() {
+		var (
+			filter    core.TransferFilter
+			transfers []core.TransferRecord
+			err       error
+		)
+
+		BeforeEach(func() {
+			filter = core.TransferFilter{
+				Token:  "0x00000000000000000000000000000000c0ffee00",
+				Holder: "0x00000000000000000000000000000000000b0b00",
+				Limit:  10,
+			}
+			fakeRepo.FindTokenTransfersReturns([]repository.TokenTransfer{{TransactionHash: "0x1", FromAddress: "0xa11ce"}}, nil)
+		})
+
+		JustBeforeEach(func() {
+			transfers, err = fetcher.GetTokenTransfers(ctx, filter)
+		})
+
+		It("looks up the transfers by normalized token and holder", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(transfers).To(Equal([]core.TransferRecord{{TransactionHash: "0x1", From: "0xa11ce"}}))
+			_, repoFilter := fakeRepo.FindTokenTransfersArgsForCall(0)
+			Expect(repoFilter).To(Equal(repository.TransferFilter{
+				Token:  "0x00000000000000000000000000000000C0FfeE00",
+				Holder: "0x00000000000000000000000000000000000B0b00",
+				Limit:  10,
+			}))
+		})
+
+		When("only the holder is given", func() {
 			BeforeEach(func() {
-				fakeJWT.ValidateReturns(nil, fakeErr)
+				filter.Token = ""
 			})
 
-			It("should return validation error", func() {
+			It("does not filter by token", func() {
+				_, repoFilter := fakeRepo.FindTokenTransfersArgsForCall(0)
+				Expect(repoFilter.Token).To(BeEmpty())
+			})
+		})
+
+		When("the lookup fails", func() {
+			BeforeEach(func() {
+				fakeRepo.FindTokenTransfersReturns(nil, fakeErr)
+			})
+
+			It("returns the error", func() {
+				Expect(err).To(MatchError(fakeErr))
+			})
+		})
+	})
+
+	Describe("GetAllDBTransactions", func() {
+		var (
+			filter core.TransactionFilter
+			page   core.TransactionPage
+			err    error
+		)
+
+		BeforeEach(func() {
+			filter = core.TransactionFilter{
+				From:      "0x00000000000000000000000000000000c0ffee00",
+				MinValue:  "1000",
+				Ascending: true,
+				Limit:     1,
+			}
+			fakeRepo.FindTransactionsReturns([]repository.Transaction{
+				{TransactionHash: "0x1", BlockNumber: 10},
+				{TransactionHash: "0x2", BlockNumber: 20},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			page, err = fetcher.GetAllDBTransactions(ctx, filter)
+		})
+
+		It("passes the normalized filter on and returns a page", func() {
+			Expect(err).NotTo(HaveOccurred())
+			_, repoFilter := fakeRepo.FindTransactionsArgsForCall(0)
+			Expect(repoFilter).To(Equal(repository.TransactionFilter{
+				From:      "0x00000000000000000000000000000000C0FfeE00",
+				MinValue:  "1000",
+				Ascending: true,
+				Limit:     2,
+			}))
+			Expect(page.Transactions).To(HaveLen(1))
+			Expect(page.Transactions[0].TransactionHash).To(Equal("0x1"))
+			Expect(page.NextCursor).NotTo(BeEmpty())
+		})
+
+		When("the cursor is invalid", func() {
+			BeforeEach(func() {
+				filter.Cursor = "%%%"
+			})
+
+			It("returns ErrInvalidCursor", func() {
+				Expect(err).To(MatchError(core.ErrInvalidCursor))
+			})
+		})
+
+		When("the lookup fails", func() {
+			BeforeEach(func() {
+				fakeRepo.FindTransactionsReturns(nil, fakeErr)
+			})
+
+			It("returns the error", func() {
+				Expect(err).To(MatchError(fakeErr))
+			})
+		})
+	})
+
+	Describe("GetAddressTransactions", func() {
+		var (
+			filter core.AddressFilter
+			page   core.TransactionPage
+			err    error
+		)
+
+		BeforeEach(func() {
+			filter = core.AddressFilter{Address: "0x00000000000000000000000000000000c0ffee00", Limit: 2}
+			fakeRepo.FindAddressTransactionsReturns([]repository.Transaction{
+				{TransactionHash: "0x3", BlockNumber: 30},
+				{TransactionHash: "0x2", BlockNumber: 20},
+				{TransactionHash: "0x1", BlockNumber: 10},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			page, err = fetcher.GetAddressTransactions(ctx, filter)
+		})
+
+		It("looks up one transaction more than the limit by normalized address in both directions", func() {
+			Expect(err).NotTo(HaveOccurred())
+			_, repoFilter := fakeRepo.FindAddressTransactionsArgsForCall(0)
+			Expect(repoFilter).To(Equal(repository.AddressFilter{
+				Address:  "0x00000000000000000000000000000000C0FfeE00",
+				Sent:     true,
+				Received: true,
+				Limit:    3,
+			}))
+		})
+
+		It("returns a page with a cursor to the next one", func() {
+			Expect(page.Transactions).To(HaveLen(2))
+			Expect(page.Transactions[1].TransactionHash).To(Equal("0x2"))
+			Expect(page.NextCursor).NotTo(BeEmpty())
+		})
+
+		When("the next page is requested", func() {
+			BeforeEach(func() {
+				first, err := fetcher.GetAddressTransactions(ctx, filter)
+				Expect(err).NotTo(HaveOccurred())
+				filter.Cursor = first.NextCursor
+				fakeRepo.FindAddressTransactionsReturns([]repository.Transaction{{TransactionHash: "0x1", BlockNumber: 10}}, nil)
+			})
+
+			It("continues after the last transaction of the previous page", func() {
+				Expect(err).NotTo(HaveOccurred())
+				_, repoFilter := fakeRepo.FindAddressTransactionsArgsForCall(1)
+				Expect(repoFilter.After).To(Equal(&repository.TransactionCursor{BlockNumber: 20, TransactionHash: "0x2"}))
+				Expect(page.Transactions).To(HaveLen(1))
+				Expect(page.NextCursor).To(BeEmpty())
+			})
+		})
+
+		When("only outgoing transactions are requested", func() {
+			BeforeEach(func() {
+				filter.Direction = core.DirectionOut
+			})
+
+			It("only matches sent transactions", func() {
+				_, repoFilter := fakeRepo.FindAddressTransactionsArgsForCall(0)
+				Expect(repoFilter.Sent).To(BeTrue())
+				Expect(repoFilter.Received).To(BeFalse())
+			})
+		})
+
+		When("the cursor is invalid", func() {
+			BeforeEach(func() {
+				filter.Cursor = "not a cursor"
+			})
+
+			It("returns ErrInvalidCursor", func() {
+				Expect(err).To(MatchError(core.ErrInvalidCursor))
+				Expect(fakeRepo.FindAddressTransactionsCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the lookup fails", func() {
+			BeforeEach(func() {
+				fakeRepo.FindAddressTransactionsReturns(nil, fakeErr)
+			})
+
+			It("returns the error", func() {
+				Expect(err).To(MatchError(fakeErr))
+			})
+		})
+	})
+
+	Describe("GetBlock", func() {
+		var (
+			numberOrHash string
+			block        core.BlockRecord
+			err          error
+			hash         string
+		)
+
+		BeforeEach(func() {
+			hash = "0x00000000000000000000000000000000000000000000000000000000000000aa"
+			numberOrHash = "100"
+			fakeRepo.GetBlockByNumberReturns(repository.Block{}, repository.ErrBlockNotFound)
+			fakeRepo.GetBlockByHashReturns(repository.Block{}, repository.ErrBlockNotFound)
+			fakeEth.FetchBlockByNumberReturns(&ethereum.Block{Number: 100, Hash: hash, Confirmations: 12}, nil)
+			fakeEth.FetchBlockByHashReturns(&ethereum.Block{Number: 100, Hash: hash, Confirmations: 12}, nil)
+		})
+
+		JustBeforeEach(func() {
+			block, err = fetcher.GetBlock(ctx, numberOrHash)
+		})
+
+		When("the block is cached", func() {
+			BeforeEach(func() {
+				fakeRepo.GetBlockByNumberReturns(repository.Block{Number: 100, Hash: hash, TransactionHashes: []string{"0x1"}}, nil)
+			})
+
+			It("returns it without asking the node", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(block).To(Equal(core.BlockRecord{Number: 100, Hash: hash, TransactionHashes: []string{"0x1"}}))
+				Expect(fakeEth.FetchBlockByNumberCallCount()).To(Equal(0))
+			})
+		})
+
+		When("a final block is not cached", func() {
+			It("fetches and caches it", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(block.Hash).To(Equal(hash))
+				_, number := fakeEth.FetchBlockByNumberArgsForCall(0)
+				Expect(number).To(Equal(uint64(100)))
+				Eventually(fakeRepo.SaveBlockCallCount).Should(Equal(1))
+				_, saved := fakeRepo.SaveBlockArgsForCall(0)
+				Expect(saved.Hash).To(Equal(hash))
+			})
+		})
+
+		When("the block is not final yet", func() {
+			BeforeEach(func() {
+				fakeEth.FetchBlockByNumberReturns(&ethereum.Block{Number: 100, Hash: hash, Confirmations: 11}, nil)
+			})
+
+			It("does not cache it", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Consistently(fakeRepo.SaveBlockCallCount).Should(Equal(0))
+			})
+		})
+
+		When("the block is requested by hash", func() {
+			BeforeEach(func() {
+				numberOrHash = "0x00000000000000000000000000000000000000000000000000000000000000AA"
+			})
+
+			It("looks it up by the normalized hash", func() {
+				Expect(err).NotTo(HaveOccurred())
+				_, cachedHash := fakeRepo.GetBlockByHashArgsForCall(0)
+				Expect(cachedHash).To(Equal(hash))
+				_, fetchedHash := fakeEth.FetchBlockByHashArgsForCall(0)
+				Expect(fetchedHash).To(Equal(hash))
+			})
+		})
+
+		When("the node does not know the block", func() {
+			BeforeEach(func() {
+				fakeEth.FetchBlockByNumberReturns(nil, ethereum.ErrBlockNotFound)
+			})
+
+			It("returns ErrBlockNotFound", func() {
+				Expect(err).To(MatchError(core.ErrBlockNotFound))
+			})
+		})
+
+		When("the db lookup fails", func() {
+			BeforeEach(func() {
+				fakeRepo.GetBlockByNumberReturns(repository.Block{}, fakeErr)
+			})
+
+			It("returns the error", func() {
+				Expect(err).To(MatchError(fakeErr))
+				Expect(fakeEth.FetchBlockByNumberCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("GetCallTrace", func() {
+		var (
+			hash   string
+			frames []ethereum.CallFrame
+			trace  core.TraceRecord
+			err    error
+		)
+
+		BeforeEach(func() {
+			hash = "0x00000000000000000000000000000000000000000000000000000000000000aa"
+			frames = []ethereum.CallFrame{
+				{TraceAddress: []int{}, Type: "CALL", From: "0xa", To: "0xb", Value: "100"},
+				{TraceAddress: []int{0}, Type: "CALL", From: "0xb", To: "0xc", Value: "60"},
+				{TraceAddress: []int{1}, Type: "CALL", From: "0xb", To: "0xd", Value: "0"},
+				{TraceAddress: []int{2}, Type: "CALL", From: "0xb", To: "0xe", Value: "30", Error: "execution reverted", Reverted: true},
+				{TraceAddress: []int{3}, Type: "DELEGATECALL", From: "0xb", To: "0xf", Value: "100"},
+				{TraceAddress: []int{4}, Type: "CALL", From: "0xb", To: "0xa", Value: "15"},
+			}
+			fakeRepo.GetCallTracesReturns([]repository.CallTrace{}, nil)
+			fakeRepo.GetTransactionsByHashReturns([]repository.Transaction{{TransactionHash: hash}}, nil)
+			fakeEth.FetchCallTraceReturns(frames, nil)
+		})
+
+		JustBeforeEach(func() {
+			trace, err = fetcher.GetCallTrace(ctx, hash)
+		})
+
+		When("the trace is not cached", func() {
+			It("traces the transaction on the node", func() {
+				Expect(err).NotTo(HaveOccurred())
+				_, tracedHash := fakeEth.FetchCallTraceArgsForCall(0)
+				Expect(tracedHash).To(Equal(hash))
+				Expect(trace.TransactionHash).To(Equal(hash))
+				Expect(trace.Calls).To(HaveLen(6))
+				Expect(trace.Calls[3].Reverted).To(BeTrue())
+			})
+
+			It("summarizes the value moved by the calls of contracts that were not reverted", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(trace.InternalTransfers).To(Equal([]core.InternalTransfer{
+					{TraceAddress: []int{0}, From: "0xb", To: "0xc", Value: "60"},
+					{TraceAddress: []int{4}, From: "0xb", To: "0xa", Value: "15"},
+				}))
+				Expect(trace.InternalValue).To(Equal("75"))
+			})
+
+			It("caches the trace of a final transaction", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(fakeRepo.SaveCallTracesCallCount).Should(Equal(1))
+				_, traces := fakeRepo.SaveCallTracesArgsForCall(0)
+				Expect(traces).To(HaveLen(6))
+				Expect(traces[4]).To(Equal(repository.CallTrace{
+					TransactionHash: hash,
+					TraceIndex:      4,
+					TraceAddress:    []int{3},
+					CallType:        "DELEGATECALL",
+					FromAddress:     "0xb",
+					ToAddress:       "0xf",
+					Value:           "100",
+				}))
+			})
+		})
+
+		When("the transaction is tentative", func() {
+			BeforeEach(func() {
+				fakeRepo.GetTransactionsByHashReturns([]repository.Transaction{{TransactionHash: hash, Tentative: true}}, nil)
+			})
+
+			It("does not cache the trace", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Consistently(fakeRepo.SaveCallTracesCallCount).Should(Equal(0))
+			})
+		})
+
+		When("the transaction is not cached", func() {
+			BeforeEach(func() {
+				fakeRepo.GetTransactionsByHashReturns(nil, nil)
+			})
+
+			It("does not cache the trace", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Consistently(fakeRepo.SaveCallTracesCallCount).Should(Equal(0))
+			})
+		})
+
+		When("the trace is cached", func() {
+			BeforeEach(func() {
+				fakeRepo.GetCallTracesReturns([]repository.CallTrace{
+					{TransactionHash: hash, TraceIndex: 0, TraceAddress: []int{}, CallType: "CALL", Value: "100"},
+					{TransactionHash: hash, TraceIndex: 1, TraceAddress: []int{0}, CallType: "CALL", FromAddress: "0xb", ToAddress: "0xc", Value: "60"},
+				}, nil)
+			})
+
+			It("returns it without asking the node", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeEth.FetchCallTraceCallCount()).To(Equal(0))
+				Expect(trace.Calls).To(HaveLen(2))
+				Expect(trace.InternalValue).To(Equal("60"))
+			})
+		})
+
+		When("the node does not serve call traces", func() {
+			BeforeEach(func() {
+				fakeEth.FetchCallTraceReturns(nil, fmt.Errorf("%w: method not found", ethereum.ErrTracingUnsupported))
+			})
+
+			It("returns ErrTracingUnsupported", func() {
+				Expect(err).To(MatchError(core.ErrTracingUnsupported))
+			})
+		})
+
+		When("the node does not know the transaction", func() {
+			BeforeEach(func() {
+				fakeEth.FetchCallTraceReturns(nil, ethereum.ErrTransactionNotFound)
+			})
+
+			It("returns ErrTransactionNotFound", func() {
+				Expect(err).To(MatchError(core.ErrTransactionNotFound))
+			})
+		})
+
+		When("the db lookup fails", func() {
+			BeforeEach(func() {
+				fakeRepo.GetCallTracesReturns(nil, fakeErr)
+			})
+
+			It("returns the error", func() {
+				Expect(err).To(MatchError(fakeErr))
+				Expect(fakeEth.FetchCallTraceCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("SaveContractABI", func() {
+		var err error
+
+		JustBeforeEach(func() {
+			err = fetcher.SaveContractABI(ctx, "0x00000000000000000000000000000000c0ffee00", "[]")
+		})
+
+		When("the abi is valid", func() {
+			It("registers and stores it under the checksummed address", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeABI.RegisterCallCount()).To(Equal(1))
+				Expect(fakeRepo.SaveContractABICallCount()).To(Equal(1))
+				_, saved := fakeRepo.SaveContractABIArgsForCall(0)
+				Expect(saved).To(Equal(repository.ContractABI{Address: "0x00000000000000000000000000000000C0FfeE00", ABI: "[]"}))
+			})
+		})
+
+		When("the abi is invalid", func() {
+			BeforeEach(func() {
+				fakeABI.RegisterReturns(decoder.ErrInvalidABI)
+			})
+
+			It("does not store it", func() {
+				Expect(err).To(MatchError(decoder.ErrInvalidABI))
+				Expect(fakeRepo.SaveContractABICallCount()).To(Equal(0))
+			})
+		})
+
+		When("storing fails", func() {
+			BeforeEach(func() {
+				fakeRepo.SaveContractABIReturns(fakeErr)
+			})
+
+			It("returns the error", func() {
+				Expect(err).To(MatchError(fakeErr))
+			})
+		})
+	})
+
+	Describe("LoadContractABIs", func() {
+		var (
+			loaded int
+			err    error
+		)
+
+		BeforeEach(func() {
+			fakeRepo.GetContractABIsReturns([]repository.ContractABI{
+				{Address: "0x1", ABI: "[]"},
+				{Address: "0x2", ABI: "[]"},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			loaded, err = fetcher.LoadContractABIs(ctx)
+		})
+
+		It("registers every stored abi", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(loaded).To(Equal(2))
+			Expect(fakeABI.RegisterCallCount()).To(Equal(2))
+			address, abiJSON := fakeABI.RegisterArgsForCall(1)
+			Expect(address).To(Equal("0x2"))
+			Expect(abiJSON).To(Equal("[]"))
+		})
+
+		When("loading fails", func() {
+			BeforeEach(func() {
+				fakeRepo.GetContractABIsReturns(nil, fakeErr)
+			})
+
+			It("returns the error", func() {
+				Expect(err).To(MatchError(fakeErr))
+			})
+		})
+	})
+
+	Describe("ParseRLP", func() {
+		var (
+			transactions []string
+			err          error
+			rlphex       string
+			parsedSlice  []string
+		)
+
+		BeforeEach(func() {
+			rlphex = "f90110b842307866633262336236646233386135316462336239636239356465323962373139646538646562393936333036323665346234623939646630353666666237663265b842307834383630336637616466663766626663326131306232326136373130333331656536386632653464316364373361353834643537633838323164663739333536b842307863626339323065376262383963626362353430613436396131363232366266313035373832353238336162386561633366343564303038313165656638613634b842307836643630346666633634346132383266636138636238653737386531653366383234356438626431643439333236653330313661336338373862613063626264"
+			parsedSlice = []string{
+				"0x307866633262336236646233386135316462336239636239356465323962373139646538646562393936333036323665346234623939646630353666666237663265",
+				"0x307834383630336637616466663766626663326131306232326136373130333331656536386632653464316364373361353834643537633838323164663739333536",
+				"0x307863626339323065376262383963626362353430613436396131363232366266313035373832353238336162386561633366343564303038313165656638613634",
+				"0x307836643630346666633634346132383266636138636238653737386531653366383234356438626431643439333236653330313661336338373862613063626264",
+			}
+		})
+
+		JustBeforeEach(func() {
+			transactions, err = fetcher.ParseRLP(rlphex)
+		})
+
+		When("RLP is valid", func() {
+			It("returns the transaction hashes", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(transactions).To(Equal(parsedSlice))
+			})
+		})
+
+		When("RLP is NOT valid", func() {
+			BeforeEach(func() {
+				rlphex = "f90110b842307866633262336236646233386135"
+			})
+
+			It("returns an error", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(transactions).To(BeNil())
+			})
+		})
+	})
+
+	Describe("SaveUserTransactionsHistory", func() {
+		var (
+			token    string
+			txHashes []string
+			err      error
+			userId   string
+		)
+
+		BeforeEach(func() {
+			token = "valid.token"
+			userId = "user123"
+			txHashes = []string{"0x1", "0x2"}
+		})
+
+		JustBeforeEach(func() {
+			err = fetcher.SaveUserTransactionsHistory(ctx, token, txHashes)
+		})
+
+		When("token is valid and save succeeds", func() {
+			BeforeEach(func() {
+				fakeJWT.ValidateReturns(jwt.MapClaims{"sub": userId}, nil)
+				fakeRepo.SaveUserHistoryReturns(nil)
+			})
+
+			It("should save user history", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeJWT.ValidateCallCount()).To(Equal(1))
+				argToken := fakeJWT.ValidateArgsForCall(0)
+				Expect(argToken).To(Equal(token))
+				Expect(fakeRepo.SaveUserHistoryCallCount()).To(Equal(1))
+				_, argUserId, argTxHashes := fakeRepo.SaveUserHistoryArgsForCall(0)
+				Expect(argUserId).To(Equal(userId))
+				Expect(argTxHashes).To(Equal(txHashes))
+
+				Expect(fakeWrites.EnqueueCallCount()).To(Equal(1))
+				name, _ := fakeWrites.EnqueueArgsForCall(0)
+				Expect(name).To(Equal("save user history"))
+			})
+		})
+
+		When("token is invalid", func() {
+			BeforeEach(func() {
+				fakeJWT.ValidateReturns(nil, fakeErr)
+			})
+
+			It("should return validation error", func() {
+				Expect(err).To(MatchError(fakeErr))
+			})
+		})
+
+		When("the history cannot be queued", func() {
+			BeforeEach(func() {
+				fakeJWT.ValidateReturns(jwt.MapClaims{"sub": "user123"}, nil)
+				fakeWrites.EnqueueStub = nil
+				fakeWrites.EnqueueReturns(fakeErr)
+			})
+
+			It("should return the queue error", func() {
+				Expect(err).To(MatchError(fakeErr))
+				Expect(fakeRepo.SaveUserHistoryCallCount()).To(Equal(0))
+			})
+		})
+
+		When("transactions are empty", func() {
+			BeforeEach(func() {
+				txHashes = []string{}
+			})
+
+			It("should skip saving", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeRepo.SaveUserHistoryCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("GetUserTransactionsHistory", func() {
+		var (
+			token    string
+			filter   core.HistoryFilter
+			page     core.HistoryPage
+			err      error
+			queried1 time.Time
+			queried2 time.Time
+		)
+
+		BeforeEach(func() {
+			token = "valid.token"
+			filter = core.HistoryFilter{Limit: 2}
+			queried1 = time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+			queried2 = time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+		})
+
+		JustBeforeEach(func() {
+			page, err = fetcher.GetUserTransactionsHistory(ctx, token, filter)
+		})
+
+		When("user has transaction history", func() {
+			BeforeEach(func() {
+				fakeJWT.ValidateReturns(jwt.MapClaims{"sub": "user123"}, nil)
+				fakeRepo.GetUserHistoryReturns([]repository.UserTransaction{
+					{UserID: "user123", TransactionHash: "0x1", QueriedAt: queried1, QueryCount: 2},
+					{UserID: "user123", TransactionHash: "0x2", QueriedAt: queried2, QueryCount: 1},
+				}, nil)
+				fakeRepo.GetTransactionsByHashReturns([]repository.Transaction{
+					{TransactionHash: "0x2"},
+					{TransactionHash: "0x1"},
+				}, nil)
+			})
+
+			It("should return the entries with their transactions", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(page.NextCursor).To(BeEmpty())
+				Expect(page.Entries).To(HaveLen(2))
+				Expect(page.Entries[0].TransactionHash).To(Equal("0x1"))
+				Expect(page.Entries[0].QueriedAt).To(Equal(queried1))
+				Expect(page.Entries[0].QueryCount).To(Equal(uint(2)))
+				Expect(page.Entries[0].Transaction.TransactionHash).To(Equal("0x1"))
+				Expect(page.Entries[1].Transaction.TransactionHash).To(Equal("0x2"))
+
+				Expect(fakeJWT.ValidateCallCount()).To(Equal(1))
+				_, repoFilter := fakeRepo.GetUserHistoryArgsForCall(0)
+				Expect(repoFilter.UserID).To(Equal("user123"))
+				Expect(repoFilter.Limit).To(Equal(3))
+				Expect(repoFilter.After).To(BeNil())
+			})
+		})
+
+		When("there are more entries than the limit", func() {
+			BeforeEach(func() {
+				filter.Limit = 1
+				fakeJWT.ValidateReturns(jwt.MapClaims{"sub": "user123"}, nil)
+				fakeRepo.GetUserHistoryReturns([]repository.UserTransaction{
+					{TransactionHash: "0x1", QueriedAt: queried1},
+					{TransactionHash: "0x2", QueriedAt: queried2},
+				}, nil)
+				fakeRepo.GetTransactionsByHashReturns([]repository.Transaction{{TransactionHash: "0x1"}}, nil)
+			})
+
+			It("should return a cursor that continues after the last entry", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(page.Entries).To(HaveLen(1))
+				Expect(page.NextCursor).NotTo(BeEmpty())
+
+				filter.Cursor = page.NextCursor
+				_, err = fetcher.GetUserTransactionsHistory(ctx, token, filter)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, repoFilter := fakeRepo.GetUserHistoryArgsForCall(1)
+				Expect(repoFilter.After).To(Equal(&repository.HistoryCursor{QueriedAt: queried1, TransactionHash: "0x1"}))
+			})
+		})
+
+		When("a transaction is no longer cached", func() {
+			BeforeEach(func() {
+				fakeJWT.ValidateReturns(jwt.MapClaims{"sub": "user123"}, nil)
+				fakeRepo.GetUserHistoryReturns([]repository.UserTransaction{{TransactionHash: "0x1"}}, nil)
+				fakeRepo.GetTransactionsByHashReturns([]repository.Transaction{}, nil)
+			})
+
+			It("should return the entry without its transaction", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(page.Entries).To(HaveLen(1))
+				Expect(page.Entries[0].Transaction).To(BeNil())
+			})
+		})
+
+		When("user has no history", func() {
+			BeforeEach(func() {
+				fakeJWT.ValidateReturns(jwt.MapClaims{"sub": "user123"}, nil)
+				fakeRepo.GetUserHistoryReturns([]repository.UserTransaction{}, nil)
+			})
+
+			It("should return an empty page", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(page.Entries).To(BeEmpty())
+				Expect(fakeRepo.GetTransactionsByHashCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the cursor is invalid", func() {
+			BeforeEach(func() {
+				filter.Cursor = "not a cursor"
+				fakeJWT.ValidateReturns(jwt.MapClaims{"sub": "user123"}, nil)
+			})
+
+			It("should return ErrInvalidCursor", func() {
+				Expect(err).To(MatchError(core.ErrInvalidCursor))
+				Expect(fakeRepo.GetUserHistoryCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the history cannot be read", func() {
+			BeforeEach(func() {
+				fakeJWT.ValidateReturns(jwt.MapClaims{"sub": "user123"}, nil)
+				fakeRepo.GetUserHistoryReturns(nil, fakeErr)
+			})
+
+			It("should return the error", func() {
+				Expect(err).To(MatchError(fakeErr))
+			})
+		})
+
+		When("token is invalid", func() {
+			BeforeEach(func() {
+				fakeJWT.ValidateReturns(nil, fakeErr)
+			})
+
+			It("should return validation error", func() {
+				Expect(err).To(MatchError(fakeErr))
+				Expect(fakeJWT.ValidateCallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Describe("DeleteUserHistoryEntry", func() {
+		var err error
+
+		BeforeEach(func() {
+			fakeJWT.ValidateReturns(jwt.MapClaims{"sub": "user123"}, nil)
+		})
+
+		JustBeforeEach(func() {
+			err = fetcher.DeleteUserHistoryEntry(ctx, "valid.token", "0xABC")
+		})
+
+		When("the transaction is in the history", func() {
+			BeforeEach(func() {
+				fakeRepo.DeleteUserHistoryReturns(1, nil)
+			})
+
+			It("should delete it", func() {
+				Expect(err).NotTo(HaveOccurred())
+				_, userID, hashes := fakeRepo.DeleteUserHistoryArgsForCall(0)
+				Expect(userID).To(Equal("user123"))
+				Expect(hashes).To(Equal([]string{"0xabc"}))
+			})
+		})
+
+		When("the transaction is not in the history", func() {
+			BeforeEach(func() {
+				fakeRepo.DeleteUserHistoryReturns(0, nil)
+			})
+
+			It("should return ErrHistoryEntryNotFound", func() {
+				Expect(err).To(MatchError(core.ErrHistoryEntryNotFound))
+			})
+		})
+
+		When("token is invalid", func() {
+			BeforeEach(func() {
+				fakeJWT.ValidateReturns(nil, fakeErr)
+			})
+
+			It("should return validation error", func() {
+				Expect(err).To(MatchError(fakeErr))
+				Expect(fakeRepo.DeleteUserHistoryCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("ClearUserTransactionsHistory", func() {
+		var (
+			deleted int64
+			err     error
+		)
+
+		BeforeEach(func() {
+			fakeJWT.ValidateReturns(jwt.MapClaims{"sub": "user123"}, nil)
+		})
+
+		JustBeforeEach(func() {
+			deleted, err = fetcher.ClearUserTransactionsHistory(ctx, "valid.token")
+		})
+
+		When("the history is cleared", func() {
+			BeforeEach(func() {
+				fakeRepo.ClearUserHistoryReturns(4, nil)
+			})
+
+			It("should return how many entries were removed", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(deleted).To(Equal(int64(4)))
+				_, userID := fakeRepo.ClearUserHistoryArgsForCall(0)
+				Expect(userID).To(Equal("user123"))
+			})
+		})
+
+		When("the history cannot be cleared", func() {
+			BeforeEach(func() {
+				fakeRepo.ClearUserHistoryReturns(0, fakeErr)
+			})
+
+			It("should return the error", func() {
 				Expect(err).To(MatchError(fakeErr))
-				Expect(fakeJWT.ValidateCallCount()).To(Equal(1))
 			})
 		})
 	})
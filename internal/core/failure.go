@@ -0,0 +1,57 @@
+package core
+
+import (
+	"context"
+	"fethcher/internal/decoder"
+	"fethcher/internal/ethereum"
+)
+
+// replayFailure replays the failed transaction on the node to find out why it failed. The failure is left unknown when the
+// replay fails, e.g. because the node no longer holds the state of the parent block.
+func (f *Fethcher) replayFailure(ctx context.Context, tx *ethereum.Transaction) *Failure {
+	revert, err := f.ethService.ReplayTransaction(ctx, tx)
+	if err != nil {
+		f.logs.Errorw("failed to replay failed transaction", "error", err, "transaction", tx.TransactionHash)
+		return nil
+	}
+
+	return &Failure{
+		Reproduced: revert.Reproduced,
+		Message:    revert.Message,
+		Data:       revert.Data,
+	}
+}
+
+// describeFailure returns a copy of the failure with its revert data decoded and its reason set. A copy is returned since the
+// records fetched for coalesced requests share their failure.
+func (f *Fethcher) describeFailure(failure Failure, to *string) *Failure {
+	if !failure.Reproduced {
+		failure.Reason = "the transaction did not fail when replayed on the state of its parent block"
+		return &failure
+	}
+
+	failure.Revert = f.abiRegistry.DecodeRevert(to, failure.Data)
+	switch {
+	case failure.Revert == nil:
+		failure.Reason = failure.Message
+	case failure.Revert.Kind == decoder.RevertCustom && failure.Revert.Name == "":
+		failure.Reason = "unknown custom error " + failure.Revert.Selector
+	case failure.Revert.Kind == decoder.RevertCustom:
+		failure.Reason = failure.Revert.Name
+	default:
+		failure.Reason = failure.Revert.Reason
+	}
+	return &failure
+}
+
+// failure returns the failure stored with a cached transaction, nil when it was not replayed.
+func failure(message *string, data *string) *Failure {
+	if message == nil || data == nil {
+		return nil
+	}
+	return &Failure{
+		Reproduced: *message != "",
+		Message:    *message,
+		Data:       *data,
+	}
+}
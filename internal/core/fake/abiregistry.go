@@ -0,0 +1,348 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"fethcher/internal/core"
+	"fethcher/internal/decoder"
+	"sync"
+)
+
+type ABIRegistry struct {
+	DecodeInputStub        func(*string, string) *decoder.Call
+	decodeInputMutex       sync.RWMutex
+	decodeInputArgsForCall []struct {
+		arg1 *string
+		arg2 string
+	}
+	decodeInputReturns struct {
+		result1 *decoder.Call
+	}
+	decodeInputReturnsOnCall map[int]struct {
+		result1 *decoder.Call
+	}
+	DecodeLogStub        func(string, []string, string) *decoder.Event
+	decodeLogMutex       sync.RWMutex
+	decodeLogArgsForCall []struct {
+		arg1 string
+		arg2 []string
+		arg3 string
+	}
+	decodeLogReturns struct {
+		result1 *decoder.Event
+	}
+	decodeLogReturnsOnCall map[int]struct {
+		result1 *decoder.Event
+	}
+	DecodeRevertStub        func(*string, string) *decoder.Revert
+	decodeRevertMutex       sync.RWMutex
+	decodeRevertArgsForCall []struct {
+		arg1 *string
+		arg2 string
+	}
+	decodeRevertReturns struct {
+		result1 *decoder.Revert
+	}
+	decodeRevertReturnsOnCall map[int]struct {
+		result1 *decoder.Revert
+	}
+	RegisterStub        func(string, string) error
+	registerMutex       sync.RWMutex
+	registerArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	registerReturns struct {
+		result1 error
+	}
+	registerReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *ABIRegistry) DecodeInput(arg1 *string, arg2 string) *decoder.Call {
+	fake.decodeInputMutex.Lock()
+	ret, specificReturn := fake.decodeInputReturnsOnCall[len(fake.decodeInputArgsForCall)]
+	fake.decodeInputArgsForCall = append(fake.decodeInputArgsForCall, struct {
+		arg1 *string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.DecodeInputStub
+	fakeReturns := fake.decodeInputReturns
+	fake.recordInvocation("DecodeInput", []interface{}{arg1, arg2})
+	fake.decodeInputMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *ABIRegistry) DecodeInputCallCount() int {
+	fake.decodeInputMutex.RLock()
+	defer fake.decodeInputMutex.RUnlock()
+	return len(fake.decodeInputArgsForCall)
+}
+
+func (fake *ABIRegistry) DecodeInputCalls(stub func(*string, string) *decoder.Call) {
+	fake.decodeInputMutex.Lock()
+	defer fake.decodeInputMutex.Unlock()
+	fake.DecodeInputStub = stub
+}
+
+func (fake *ABIRegistry) DecodeInputArgsForCall(i int) (*string, string) {
+	fake.decodeInputMutex.RLock()
+	defer fake.decodeInputMutex.RUnlock()
+	argsForCall := fake.decodeInputArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *ABIRegistry) DecodeInputReturns(result1 *decoder.Call) {
+	fake.decodeInputMutex.Lock()
+	defer fake.decodeInputMutex.Unlock()
+	fake.DecodeInputStub = nil
+	fake.decodeInputReturns = struct {
+		result1 *decoder.Call
+	}{result1}
+}
+
+func (fake *ABIRegistry) DecodeInputReturnsOnCall(i int, result1 *decoder.Call) {
+	fake.decodeInputMutex.Lock()
+	defer fake.decodeInputMutex.Unlock()
+	fake.DecodeInputStub = nil
+	if fake.decodeInputReturnsOnCall == nil {
+		fake.decodeInputReturnsOnCall = make(map[int]struct {
+			result1 *decoder.Call
+		})
+	}
+	fake.decodeInputReturnsOnCall[i] = struct {
+		result1 *decoder.Call
+	}{result1}
+}
+
+func (fake *ABIRegistry) DecodeLog(arg1 string, arg2 []string, arg3 string) *decoder.Event {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.decodeLogMutex.Lock()
+	ret, specificReturn := fake.decodeLogReturnsOnCall[len(fake.decodeLogArgsForCall)]
+	fake.decodeLogArgsForCall = append(fake.decodeLogArgsForCall, struct {
+		arg1 string
+		arg2 []string
+		arg3 string
+	}{arg1, arg2Copy, arg3})
+	stub := fake.DecodeLogStub
+	fakeReturns := fake.decodeLogReturns
+	fake.recordInvocation("DecodeLog", []interface{}{arg1, arg2Copy, arg3})
+	fake.decodeLogMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *ABIRegistry) DecodeLogCallCount() int {
+	fake.decodeLogMutex.RLock()
+	defer fake.decodeLogMutex.RUnlock()
+	return len(fake.decodeLogArgsForCall)
+}
+
+func (fake *ABIRegistry) DecodeLogCalls(stub func(string, []string, string) *decoder.Event) {
+	fake.decodeLogMutex.Lock()
+	defer fake.decodeLogMutex.Unlock()
+	fake.DecodeLogStub = stub
+}
+
+func (fake *ABIRegistry) DecodeLogArgsForCall(i int) (string, []string, string) {
+	fake.decodeLogMutex.RLock()
+	defer fake.decodeLogMutex.RUnlock()
+	argsForCall := fake.decodeLogArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *ABIRegistry) DecodeLogReturns(result1 *decoder.Event) {
+	fake.decodeLogMutex.Lock()
+	defer fake.decodeLogMutex.Unlock()
+	fake.DecodeLogStub = nil
+	fake.decodeLogReturns = struct {
+		result1 *decoder.Event
+	}{result1}
+}
+
+func (fake *ABIRegistry) DecodeLogReturnsOnCall(i int, result1 *decoder.Event) {
+	fake.decodeLogMutex.Lock()
+	defer fake.decodeLogMutex.Unlock()
+	fake.DecodeLogStub = nil
+	if fake.decodeLogReturnsOnCall == nil {
+		fake.decodeLogReturnsOnCall = make(map[int]struct {
+			result1 *decoder.Event
+		})
+	}
+	fake.decodeLogReturnsOnCall[i] = struct {
+		result1 *decoder.Event
+	}{result1}
+}
+
+func (fake *ABIRegistry) DecodeRevert(arg1 *string, arg2 string) *decoder.Revert {
+	fake.decodeRevertMutex.Lock()
+	ret, specificReturn := fake.decodeRevertReturnsOnCall[len(fake.decodeRevertArgsForCall)]
+	fake.decodeRevertArgsForCall = append(fake.decodeRevertArgsForCall, struct {
+		arg1 *string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.DecodeRevertStub
+	fakeReturns := fake.decodeRevertReturns
+	fake.recordInvocation("DecodeRevert", []interface{}{arg1, arg2})
+	fake.decodeRevertMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *ABIRegistry) DecodeRevertCallCount() int {
+	fake.decodeRevertMutex.RLock()
+	defer fake.decodeRevertMutex.RUnlock()
+	return len(fake.decodeRevertArgsForCall)
+}
+
+func (fake *ABIRegistry) DecodeRevertCalls(stub func(*string, string) *decoder.Revert) {
+	fake.decodeRevertMutex.Lock()
+	defer fake.decodeRevertMutex.Unlock()
+	fake.DecodeRevertStub = stub
+}
+
+func (fake *ABIRegistry) DecodeRevertArgsForCall(i int) (*string, string) {
+	fake.decodeRevertMutex.RLock()
+	defer fake.decodeRevertMutex.RUnlock()
+	argsForCall := fake.decodeRevertArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *ABIRegistry) DecodeRevertReturns(result1 *decoder.Revert) {
+	fake.decodeRevertMutex.Lock()
+	defer fake.decodeRevertMutex.Unlock()
+	fake.DecodeRevertStub = nil
+	fake.decodeRevertReturns = struct {
+		result1 *decoder.Revert
+	}{result1}
+}
+
+func (fake *ABIRegistry) DecodeRevertReturnsOnCall(i int, result1 *decoder.Revert) {
+	fake.decodeRevertMutex.Lock()
+	defer fake.decodeRevertMutex.Unlock()
+	fake.DecodeRevertStub = nil
+	if fake.decodeRevertReturnsOnCall == nil {
+		fake.decodeRevertReturnsOnCall = make(map[int]struct {
+			result1 *decoder.Revert
+		})
+	}
+	fake.decodeRevertReturnsOnCall[i] = struct {
+		result1 *decoder.Revert
+	}{result1}
+}
+
+func (fake *ABIRegistry) Register(arg1 string, arg2 string) error {
+	fake.registerMutex.Lock()
+	ret, specificReturn := fake.registerReturnsOnCall[len(fake.registerArgsForCall)]
+	fake.registerArgsForCall = append(fake.registerArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.RegisterStub
+	fakeReturns := fake.registerReturns
+	fake.recordInvocation("Register", []interface{}{arg1, arg2})
+	fake.registerMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *ABIRegistry) RegisterCallCount() int {
+	fake.registerMutex.RLock()
+	defer fake.registerMutex.RUnlock()
+	return len(fake.registerArgsForCall)
+}
+
+func (fake *ABIRegistry) RegisterCalls(stub func(string, string) error) {
+	fake.registerMutex.Lock()
+	defer fake.registerMutex.Unlock()
+	fake.RegisterStub = stub
+}
+
+func (fake *ABIRegistry) RegisterArgsForCall(i int) (string, string) {
+	fake.registerMutex.RLock()
+	defer fake.registerMutex.RUnlock()
+	argsForCall := fake.registerArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *ABIRegistry) RegisterReturns(result1 error) {
+	fake.registerMutex.Lock()
+	defer fake.registerMutex.Unlock()
+	fake.RegisterStub = nil
+	fake.registerReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ABIRegistry) RegisterReturnsOnCall(i int, result1 error) {
+	fake.registerMutex.Lock()
+	defer fake.registerMutex.Unlock()
+	fake.RegisterStub = nil
+	if fake.registerReturnsOnCall == nil {
+		fake.registerReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.registerReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ABIRegistry) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.decodeInputMutex.RLock()
+	defer fake.decodeInputMutex.RUnlock()
+	fake.decodeLogMutex.RLock()
+	defer fake.decodeLogMutex.RUnlock()
+	fake.decodeRevertMutex.RLock()
+	defer fake.decodeRevertMutex.RUnlock()
+	fake.registerMutex.RLock()
+	defer fake.registerMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *ABIRegistry) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ core.ABIRegistry = new(ABIRegistry)
@@ -9,25 +9,432 @@ import (
 )
 
 type EthereumService struct {
-	FetchTransactionsStub        func(context.Context, []string) ([]*ethereum.Transaction, error)
+	FetchBlockByHashStub        func(context.Context, string) (*ethereum.Block, error)
+	fetchBlockByHashMutex       sync.RWMutex
+	fetchBlockByHashArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	fetchBlockByHashReturns struct {
+		result1 *ethereum.Block
+		result2 error
+	}
+	fetchBlockByHashReturnsOnCall map[int]struct {
+		result1 *ethereum.Block
+		result2 error
+	}
+	FetchBlockByNumberStub        func(context.Context, uint64) (*ethereum.Block, error)
+	fetchBlockByNumberMutex       sync.RWMutex
+	fetchBlockByNumberArgsForCall []struct {
+		arg1 context.Context
+		arg2 uint64
+	}
+	fetchBlockByNumberReturns struct {
+		result1 *ethereum.Block
+		result2 error
+	}
+	fetchBlockByNumberReturnsOnCall map[int]struct {
+		result1 *ethereum.Block
+		result2 error
+	}
+	FetchCallTraceStub        func(context.Context, string) ([]ethereum.CallFrame, error)
+	fetchCallTraceMutex       sync.RWMutex
+	fetchCallTraceArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	fetchCallTraceReturns struct {
+		result1 []ethereum.CallFrame
+		result2 error
+	}
+	fetchCallTraceReturnsOnCall map[int]struct {
+		result1 []ethereum.CallFrame
+		result2 error
+	}
+	FetchHeadNumberStub        func(context.Context) (uint64, error)
+	fetchHeadNumberMutex       sync.RWMutex
+	fetchHeadNumberArgsForCall []struct {
+		arg1 context.Context
+	}
+	fetchHeadNumberReturns struct {
+		result1 uint64
+		result2 error
+	}
+	fetchHeadNumberReturnsOnCall map[int]struct {
+		result1 uint64
+		result2 error
+	}
+	FetchNonceStub        func(context.Context, string) (uint64, error)
+	fetchNonceMutex       sync.RWMutex
+	fetchNonceArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	fetchNonceReturns struct {
+		result1 uint64
+		result2 error
+	}
+	fetchNonceReturnsOnCall map[int]struct {
+		result1 uint64
+		result2 error
+	}
+	FetchTransactionsStub        func(context.Context, []string) ([]*ethereum.TxResult, error)
 	fetchTransactionsMutex       sync.RWMutex
 	fetchTransactionsArgsForCall []struct {
 		arg1 context.Context
 		arg2 []string
 	}
 	fetchTransactionsReturns struct {
-		result1 []*ethereum.Transaction
+		result1 []*ethereum.TxResult
 		result2 error
 	}
 	fetchTransactionsReturnsOnCall map[int]struct {
-		result1 []*ethereum.Transaction
+		result1 []*ethereum.TxResult
+		result2 error
+	}
+	ReplayTransactionStub        func(context.Context, *ethereum.Transaction) (*ethereum.Revert, error)
+	replayTransactionMutex       sync.RWMutex
+	replayTransactionArgsForCall []struct {
+		arg1 context.Context
+		arg2 *ethereum.Transaction
+	}
+	replayTransactionReturns struct {
+		result1 *ethereum.Revert
+		result2 error
+	}
+	replayTransactionReturnsOnCall map[int]struct {
+		result1 *ethereum.Revert
 		result2 error
 	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *EthereumService) FetchTransactions(arg1 context.Context, arg2 []string) ([]*ethereum.Transaction, error) {
+func (fake *EthereumService) FetchBlockByHash(arg1 context.Context, arg2 string) (*ethereum.Block, error) {
+	fake.fetchBlockByHashMutex.Lock()
+	ret, specificReturn := fake.fetchBlockByHashReturnsOnCall[len(fake.fetchBlockByHashArgsForCall)]
+	fake.fetchBlockByHashArgsForCall = append(fake.fetchBlockByHashArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.FetchBlockByHashStub
+	fakeReturns := fake.fetchBlockByHashReturns
+	fake.recordInvocation("FetchBlockByHash", []interface{}{arg1, arg2})
+	fake.fetchBlockByHashMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *EthereumService) FetchBlockByHashCallCount() int {
+	fake.fetchBlockByHashMutex.RLock()
+	defer fake.fetchBlockByHashMutex.RUnlock()
+	return len(fake.fetchBlockByHashArgsForCall)
+}
+
+func (fake *EthereumService) FetchBlockByHashCalls(stub func(context.Context, string) (*ethereum.Block, error)) {
+	fake.fetchBlockByHashMutex.Lock()
+	defer fake.fetchBlockByHashMutex.Unlock()
+	fake.FetchBlockByHashStub = stub
+}
+
+func (fake *EthereumService) FetchBlockByHashArgsForCall(i int) (context.Context, string) {
+	fake.fetchBlockByHashMutex.RLock()
+	defer fake.fetchBlockByHashMutex.RUnlock()
+	argsForCall := fake.fetchBlockByHashArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *EthereumService) FetchBlockByHashReturns(result1 *ethereum.Block, result2 error) {
+	fake.fetchBlockByHashMutex.Lock()
+	defer fake.fetchBlockByHashMutex.Unlock()
+	fake.FetchBlockByHashStub = nil
+	fake.fetchBlockByHashReturns = struct {
+		result1 *ethereum.Block
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EthereumService) FetchBlockByHashReturnsOnCall(i int, result1 *ethereum.Block, result2 error) {
+	fake.fetchBlockByHashMutex.Lock()
+	defer fake.fetchBlockByHashMutex.Unlock()
+	fake.FetchBlockByHashStub = nil
+	if fake.fetchBlockByHashReturnsOnCall == nil {
+		fake.fetchBlockByHashReturnsOnCall = make(map[int]struct {
+			result1 *ethereum.Block
+			result2 error
+		})
+	}
+	fake.fetchBlockByHashReturnsOnCall[i] = struct {
+		result1 *ethereum.Block
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EthereumService) FetchBlockByNumber(arg1 context.Context, arg2 uint64) (*ethereum.Block, error) {
+	fake.fetchBlockByNumberMutex.Lock()
+	ret, specificReturn := fake.fetchBlockByNumberReturnsOnCall[len(fake.fetchBlockByNumberArgsForCall)]
+	fake.fetchBlockByNumberArgsForCall = append(fake.fetchBlockByNumberArgsForCall, struct {
+		arg1 context.Context
+		arg2 uint64
+	}{arg1, arg2})
+	stub := fake.FetchBlockByNumberStub
+	fakeReturns := fake.fetchBlockByNumberReturns
+	fake.recordInvocation("FetchBlockByNumber", []interface{}{arg1, arg2})
+	fake.fetchBlockByNumberMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *EthereumService) FetchBlockByNumberCallCount() int {
+	fake.fetchBlockByNumberMutex.RLock()
+	defer fake.fetchBlockByNumberMutex.RUnlock()
+	return len(fake.fetchBlockByNumberArgsForCall)
+}
+
+func (fake *EthereumService) FetchBlockByNumberCalls(stub func(context.Context, uint64) (*ethereum.Block, error)) {
+	fake.fetchBlockByNumberMutex.Lock()
+	defer fake.fetchBlockByNumberMutex.Unlock()
+	fake.FetchBlockByNumberStub = stub
+}
+
+func (fake *EthereumService) FetchBlockByNumberArgsForCall(i int) (context.Context, uint64) {
+	fake.fetchBlockByNumberMutex.RLock()
+	defer fake.fetchBlockByNumberMutex.RUnlock()
+	argsForCall := fake.fetchBlockByNumberArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *EthereumService) FetchBlockByNumberReturns(result1 *ethereum.Block, result2 error) {
+	fake.fetchBlockByNumberMutex.Lock()
+	defer fake.fetchBlockByNumberMutex.Unlock()
+	fake.FetchBlockByNumberStub = nil
+	fake.fetchBlockByNumberReturns = struct {
+		result1 *ethereum.Block
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EthereumService) FetchBlockByNumberReturnsOnCall(i int, result1 *ethereum.Block, result2 error) {
+	fake.fetchBlockByNumberMutex.Lock()
+	defer fake.fetchBlockByNumberMutex.Unlock()
+	fake.FetchBlockByNumberStub = nil
+	if fake.fetchBlockByNumberReturnsOnCall == nil {
+		fake.fetchBlockByNumberReturnsOnCall = make(map[int]struct {
+			result1 *ethereum.Block
+			result2 error
+		})
+	}
+	fake.fetchBlockByNumberReturnsOnCall[i] = struct {
+		result1 *ethereum.Block
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EthereumService) FetchCallTrace(arg1 context.Context, arg2 string) ([]ethereum.CallFrame, error) {
+	fake.fetchCallTraceMutex.Lock()
+	ret, specificReturn := fake.fetchCallTraceReturnsOnCall[len(fake.fetchCallTraceArgsForCall)]
+	fake.fetchCallTraceArgsForCall = append(fake.fetchCallTraceArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.FetchCallTraceStub
+	fakeReturns := fake.fetchCallTraceReturns
+	fake.recordInvocation("FetchCallTrace", []interface{}{arg1, arg2})
+	fake.fetchCallTraceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *EthereumService) FetchCallTraceCallCount() int {
+	fake.fetchCallTraceMutex.RLock()
+	defer fake.fetchCallTraceMutex.RUnlock()
+	return len(fake.fetchCallTraceArgsForCall)
+}
+
+func (fake *EthereumService) FetchCallTraceCalls(stub func(context.Context, string) ([]ethereum.CallFrame, error)) {
+	fake.fetchCallTraceMutex.Lock()
+	defer fake.fetchCallTraceMutex.Unlock()
+	fake.FetchCallTraceStub = stub
+}
+
+func (fake *EthereumService) FetchCallTraceArgsForCall(i int) (context.Context, string) {
+	fake.fetchCallTraceMutex.RLock()
+	defer fake.fetchCallTraceMutex.RUnlock()
+	argsForCall := fake.fetchCallTraceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *EthereumService) FetchCallTraceReturns(result1 []ethereum.CallFrame, result2 error) {
+	fake.fetchCallTraceMutex.Lock()
+	defer fake.fetchCallTraceMutex.Unlock()
+	fake.FetchCallTraceStub = nil
+	fake.fetchCallTraceReturns = struct {
+		result1 []ethereum.CallFrame
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EthereumService) FetchCallTraceReturnsOnCall(i int, result1 []ethereum.CallFrame, result2 error) {
+	fake.fetchCallTraceMutex.Lock()
+	defer fake.fetchCallTraceMutex.Unlock()
+	fake.FetchCallTraceStub = nil
+	if fake.fetchCallTraceReturnsOnCall == nil {
+		fake.fetchCallTraceReturnsOnCall = make(map[int]struct {
+			result1 []ethereum.CallFrame
+			result2 error
+		})
+	}
+	fake.fetchCallTraceReturnsOnCall[i] = struct {
+		result1 []ethereum.CallFrame
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EthereumService) FetchHeadNumber(arg1 context.Context) (uint64, error) {
+	fake.fetchHeadNumberMutex.Lock()
+	ret, specificReturn := fake.fetchHeadNumberReturnsOnCall[len(fake.fetchHeadNumberArgsForCall)]
+	fake.fetchHeadNumberArgsForCall = append(fake.fetchHeadNumberArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.FetchHeadNumberStub
+	fakeReturns := fake.fetchHeadNumberReturns
+	fake.recordInvocation("FetchHeadNumber", []interface{}{arg1})
+	fake.fetchHeadNumberMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *EthereumService) FetchHeadNumberCallCount() int {
+	fake.fetchHeadNumberMutex.RLock()
+	defer fake.fetchHeadNumberMutex.RUnlock()
+	return len(fake.fetchHeadNumberArgsForCall)
+}
+
+func (fake *EthereumService) FetchHeadNumberCalls(stub func(context.Context) (uint64, error)) {
+	fake.fetchHeadNumberMutex.Lock()
+	defer fake.fetchHeadNumberMutex.Unlock()
+	fake.FetchHeadNumberStub = stub
+}
+
+func (fake *EthereumService) FetchHeadNumberArgsForCall(i int) context.Context {
+	fake.fetchHeadNumberMutex.RLock()
+	defer fake.fetchHeadNumberMutex.RUnlock()
+	argsForCall := fake.fetchHeadNumberArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *EthereumService) FetchHeadNumberReturns(result1 uint64, result2 error) {
+	fake.fetchHeadNumberMutex.Lock()
+	defer fake.fetchHeadNumberMutex.Unlock()
+	fake.FetchHeadNumberStub = nil
+	fake.fetchHeadNumberReturns = struct {
+		result1 uint64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EthereumService) FetchHeadNumberReturnsOnCall(i int, result1 uint64, result2 error) {
+	fake.fetchHeadNumberMutex.Lock()
+	defer fake.fetchHeadNumberMutex.Unlock()
+	fake.FetchHeadNumberStub = nil
+	if fake.fetchHeadNumberReturnsOnCall == nil {
+		fake.fetchHeadNumberReturnsOnCall = make(map[int]struct {
+			result1 uint64
+			result2 error
+		})
+	}
+	fake.fetchHeadNumberReturnsOnCall[i] = struct {
+		result1 uint64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EthereumService) FetchNonce(arg1 context.Context, arg2 string) (uint64, error) {
+	fake.fetchNonceMutex.Lock()
+	ret, specificReturn := fake.fetchNonceReturnsOnCall[len(fake.fetchNonceArgsForCall)]
+	fake.fetchNonceArgsForCall = append(fake.fetchNonceArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.FetchNonceStub
+	fakeReturns := fake.fetchNonceReturns
+	fake.recordInvocation("FetchNonce", []interface{}{arg1, arg2})
+	fake.fetchNonceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *EthereumService) FetchNonceCallCount() int {
+	fake.fetchNonceMutex.RLock()
+	defer fake.fetchNonceMutex.RUnlock()
+	return len(fake.fetchNonceArgsForCall)
+}
+
+func (fake *EthereumService) FetchNonceCalls(stub func(context.Context, string) (uint64, error)) {
+	fake.fetchNonceMutex.Lock()
+	defer fake.fetchNonceMutex.Unlock()
+	fake.FetchNonceStub = stub
+}
+
+func (fake *EthereumService) FetchNonceArgsForCall(i int) (context.Context, string) {
+	fake.fetchNonceMutex.RLock()
+	defer fake.fetchNonceMutex.RUnlock()
+	argsForCall := fake.fetchNonceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *EthereumService) FetchNonceReturns(result1 uint64, result2 error) {
+	fake.fetchNonceMutex.Lock()
+	defer fake.fetchNonceMutex.Unlock()
+	fake.FetchNonceStub = nil
+	fake.fetchNonceReturns = struct {
+		result1 uint64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EthereumService) FetchNonceReturnsOnCall(i int, result1 uint64, result2 error) {
+	fake.fetchNonceMutex.Lock()
+	defer fake.fetchNonceMutex.Unlock()
+	fake.FetchNonceStub = nil
+	if fake.fetchNonceReturnsOnCall == nil {
+		fake.fetchNonceReturnsOnCall = make(map[int]struct {
+			result1 uint64
+			result2 error
+		})
+	}
+	fake.fetchNonceReturnsOnCall[i] = struct {
+		result1 uint64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EthereumService) FetchTransactions(arg1 context.Context, arg2 []string) ([]*ethereum.TxResult, error) {
 	var arg2Copy []string
 	if arg2 != nil {
 		arg2Copy = make([]string, len(arg2))
@@ -58,7 +465,7 @@ func (fake *EthereumService) FetchTransactionsCallCount() int {
 	return len(fake.fetchTransactionsArgsForCall)
 }
 
-func (fake *EthereumService) FetchTransactionsCalls(stub func(context.Context, []string) ([]*ethereum.Transaction, error)) {
+func (fake *EthereumService) FetchTransactionsCalls(stub func(context.Context, []string) ([]*ethereum.TxResult, error)) {
 	fake.fetchTransactionsMutex.Lock()
 	defer fake.fetchTransactionsMutex.Unlock()
 	fake.FetchTransactionsStub = stub
@@ -71,28 +478,93 @@ func (fake *EthereumService) FetchTransactionsArgsForCall(i int) (context.Contex
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *EthereumService) FetchTransactionsReturns(result1 []*ethereum.Transaction, result2 error) {
+func (fake *EthereumService) FetchTransactionsReturns(result1 []*ethereum.TxResult, result2 error) {
 	fake.fetchTransactionsMutex.Lock()
 	defer fake.fetchTransactionsMutex.Unlock()
 	fake.FetchTransactionsStub = nil
 	fake.fetchTransactionsReturns = struct {
-		result1 []*ethereum.Transaction
+		result1 []*ethereum.TxResult
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *EthereumService) FetchTransactionsReturnsOnCall(i int, result1 []*ethereum.Transaction, result2 error) {
+func (fake *EthereumService) FetchTransactionsReturnsOnCall(i int, result1 []*ethereum.TxResult, result2 error) {
 	fake.fetchTransactionsMutex.Lock()
 	defer fake.fetchTransactionsMutex.Unlock()
 	fake.FetchTransactionsStub = nil
 	if fake.fetchTransactionsReturnsOnCall == nil {
 		fake.fetchTransactionsReturnsOnCall = make(map[int]struct {
-			result1 []*ethereum.Transaction
+			result1 []*ethereum.TxResult
 			result2 error
 		})
 	}
 	fake.fetchTransactionsReturnsOnCall[i] = struct {
-		result1 []*ethereum.Transaction
+		result1 []*ethereum.TxResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EthereumService) ReplayTransaction(arg1 context.Context, arg2 *ethereum.Transaction) (*ethereum.Revert, error) {
+	fake.replayTransactionMutex.Lock()
+	ret, specificReturn := fake.replayTransactionReturnsOnCall[len(fake.replayTransactionArgsForCall)]
+	fake.replayTransactionArgsForCall = append(fake.replayTransactionArgsForCall, struct {
+		arg1 context.Context
+		arg2 *ethereum.Transaction
+	}{arg1, arg2})
+	stub := fake.ReplayTransactionStub
+	fakeReturns := fake.replayTransactionReturns
+	fake.recordInvocation("ReplayTransaction", []interface{}{arg1, arg2})
+	fake.replayTransactionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *EthereumService) ReplayTransactionCallCount() int {
+	fake.replayTransactionMutex.RLock()
+	defer fake.replayTransactionMutex.RUnlock()
+	return len(fake.replayTransactionArgsForCall)
+}
+
+func (fake *EthereumService) ReplayTransactionCalls(stub func(context.Context, *ethereum.Transaction) (*ethereum.Revert, error)) {
+	fake.replayTransactionMutex.Lock()
+	defer fake.replayTransactionMutex.Unlock()
+	fake.ReplayTransactionStub = stub
+}
+
+func (fake *EthereumService) ReplayTransactionArgsForCall(i int) (context.Context, *ethereum.Transaction) {
+	fake.replayTransactionMutex.RLock()
+	defer fake.replayTransactionMutex.RUnlock()
+	argsForCall := fake.replayTransactionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *EthereumService) ReplayTransactionReturns(result1 *ethereum.Revert, result2 error) {
+	fake.replayTransactionMutex.Lock()
+	defer fake.replayTransactionMutex.Unlock()
+	fake.ReplayTransactionStub = nil
+	fake.replayTransactionReturns = struct {
+		result1 *ethereum.Revert
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EthereumService) ReplayTransactionReturnsOnCall(i int, result1 *ethereum.Revert, result2 error) {
+	fake.replayTransactionMutex.Lock()
+	defer fake.replayTransactionMutex.Unlock()
+	fake.ReplayTransactionStub = nil
+	if fake.replayTransactionReturnsOnCall == nil {
+		fake.replayTransactionReturnsOnCall = make(map[int]struct {
+			result1 *ethereum.Revert
+			result2 error
+		})
+	}
+	fake.replayTransactionReturnsOnCall[i] = struct {
+		result1 *ethereum.Revert
 		result2 error
 	}{result1, result2}
 }
@@ -100,8 +572,20 @@ func (fake *EthereumService) FetchTransactionsReturnsOnCall(i int, result1 []*et
 func (fake *EthereumService) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.fetchBlockByHashMutex.RLock()
+	defer fake.fetchBlockByHashMutex.RUnlock()
+	fake.fetchBlockByNumberMutex.RLock()
+	defer fake.fetchBlockByNumberMutex.RUnlock()
+	fake.fetchCallTraceMutex.RLock()
+	defer fake.fetchCallTraceMutex.RUnlock()
+	fake.fetchHeadNumberMutex.RLock()
+	defer fake.fetchHeadNumberMutex.RUnlock()
+	fake.fetchNonceMutex.RLock()
+	defer fake.fetchNonceMutex.RUnlock()
 	fake.fetchTransactionsMutex.RLock()
 	defer fake.fetchTransactionsMutex.RUnlock()
+	fake.replayTransactionMutex.RLock()
+	defer fake.replayTransactionMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -9,19 +9,214 @@ import (
 )
 
 type Repository struct {
-	GetAllTransactionsStub        func(context.Context) ([]repository.Transaction, error)
-	getAllTransactionsMutex       sync.RWMutex
-	getAllTransactionsArgsForCall []struct {
+	ClearUserHistoryStub        func(context.Context, string) (int64, error)
+	clearUserHistoryMutex       sync.RWMutex
+	clearUserHistoryArgsForCall []struct {
 		arg1 context.Context
+		arg2 string
+	}
+	clearUserHistoryReturns struct {
+		result1 int64
+		result2 error
+	}
+	clearUserHistoryReturnsOnCall map[int]struct {
+		result1 int64
+		result2 error
+	}
+	DeleteTransactionsStub        func(context.Context, []string) error
+	deleteTransactionsMutex       sync.RWMutex
+	deleteTransactionsArgsForCall []struct {
+		arg1 context.Context
+		arg2 []string
+	}
+	deleteTransactionsReturns struct {
+		result1 error
+	}
+	deleteTransactionsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteUserHistoryStub        func(context.Context, string, []string) (int64, error)
+	deleteUserHistoryMutex       sync.RWMutex
+	deleteUserHistoryArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []string
+	}
+	deleteUserHistoryReturns struct {
+		result1 int64
+		result2 error
+	}
+	deleteUserHistoryReturnsOnCall map[int]struct {
+		result1 int64
+		result2 error
+	}
+	FindAddressTransactionsStub        func(context.Context, repository.AddressFilter) ([]repository.Transaction, error)
+	findAddressTransactionsMutex       sync.RWMutex
+	findAddressTransactionsArgsForCall []struct {
+		arg1 context.Context
+		arg2 repository.AddressFilter
+	}
+	findAddressTransactionsReturns struct {
+		result1 []repository.Transaction
+		result2 error
+	}
+	findAddressTransactionsReturnsOnCall map[int]struct {
+		result1 []repository.Transaction
+		result2 error
+	}
+	FindLogsStub        func(context.Context, repository.LogFilter) ([]repository.TransactionLog, error)
+	findLogsMutex       sync.RWMutex
+	findLogsArgsForCall []struct {
+		arg1 context.Context
+		arg2 repository.LogFilter
+	}
+	findLogsReturns struct {
+		result1 []repository.TransactionLog
+		result2 error
+	}
+	findLogsReturnsOnCall map[int]struct {
+		result1 []repository.TransactionLog
+		result2 error
+	}
+	FindTokenTransfersStub        func(context.Context, repository.TransferFilter) ([]repository.TokenTransfer, error)
+	findTokenTransfersMutex       sync.RWMutex
+	findTokenTransfersArgsForCall []struct {
+		arg1 context.Context
+		arg2 repository.TransferFilter
+	}
+	findTokenTransfersReturns struct {
+		result1 []repository.TokenTransfer
+		result2 error
+	}
+	findTokenTransfersReturnsOnCall map[int]struct {
+		result1 []repository.TokenTransfer
+		result2 error
+	}
+	FindTransactionsStub        func(context.Context, repository.TransactionFilter) ([]repository.Transaction, error)
+	findTransactionsMutex       sync.RWMutex
+	findTransactionsArgsForCall []struct {
+		arg1 context.Context
+		arg2 repository.TransactionFilter
+	}
+	findTransactionsReturns struct {
+		result1 []repository.Transaction
+		result2 error
+	}
+	findTransactionsReturnsOnCall map[int]struct {
+		result1 []repository.Transaction
+		result2 error
+	}
+	GetBlockByHashStub        func(context.Context, string) (repository.Block, error)
+	getBlockByHashMutex       sync.RWMutex
+	getBlockByHashArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getBlockByHashReturns struct {
+		result1 repository.Block
+		result2 error
+	}
+	getBlockByHashReturnsOnCall map[int]struct {
+		result1 repository.Block
+		result2 error
+	}
+	GetBlockByNumberStub        func(context.Context, uint64) (repository.Block, error)
+	getBlockByNumberMutex       sync.RWMutex
+	getBlockByNumberArgsForCall []struct {
+		arg1 context.Context
+		arg2 uint64
+	}
+	getBlockByNumberReturns struct {
+		result1 repository.Block
+		result2 error
+	}
+	getBlockByNumberReturnsOnCall map[int]struct {
+		result1 repository.Block
+		result2 error
+	}
+	GetCallTracesStub        func(context.Context, string) ([]repository.CallTrace, error)
+	getCallTracesMutex       sync.RWMutex
+	getCallTracesArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
 	}
-	getAllTransactionsReturns struct {
+	getCallTracesReturns struct {
+		result1 []repository.CallTrace
+		result2 error
+	}
+	getCallTracesReturnsOnCall map[int]struct {
+		result1 []repository.CallTrace
+		result2 error
+	}
+	GetContractABIsStub        func(context.Context) ([]repository.ContractABI, error)
+	getContractABIsMutex       sync.RWMutex
+	getContractABIsArgsForCall []struct {
+		arg1 context.Context
+	}
+	getContractABIsReturns struct {
+		result1 []repository.ContractABI
+		result2 error
+	}
+	getContractABIsReturnsOnCall map[int]struct {
+		result1 []repository.ContractABI
+		result2 error
+	}
+	GetIncompleteTransactionsStub        func(context.Context, string, int) ([]repository.Transaction, error)
+	getIncompleteTransactionsMutex       sync.RWMutex
+	getIncompleteTransactionsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 int
+	}
+	getIncompleteTransactionsReturns struct {
+		result1 []repository.Transaction
+		result2 error
+	}
+	getIncompleteTransactionsReturnsOnCall map[int]struct {
 		result1 []repository.Transaction
 		result2 error
 	}
-	getAllTransactionsReturnsOnCall map[int]struct {
+	GetTentativeTransactionsStub        func(context.Context) ([]repository.Transaction, error)
+	getTentativeTransactionsMutex       sync.RWMutex
+	getTentativeTransactionsArgsForCall []struct {
+		arg1 context.Context
+	}
+	getTentativeTransactionsReturns struct {
+		result1 []repository.Transaction
+		result2 error
+	}
+	getTentativeTransactionsReturnsOnCall map[int]struct {
 		result1 []repository.Transaction
 		result2 error
 	}
+	GetTokenTransfersStub        func(context.Context, []string) ([]repository.TokenTransfer, error)
+	getTokenTransfersMutex       sync.RWMutex
+	getTokenTransfersArgsForCall []struct {
+		arg1 context.Context
+		arg2 []string
+	}
+	getTokenTransfersReturns struct {
+		result1 []repository.TokenTransfer
+		result2 error
+	}
+	getTokenTransfersReturnsOnCall map[int]struct {
+		result1 []repository.TokenTransfer
+		result2 error
+	}
+	GetTransactionLogsStub        func(context.Context, []string) ([]repository.TransactionLog, error)
+	getTransactionLogsMutex       sync.RWMutex
+	getTransactionLogsArgsForCall []struct {
+		arg1 context.Context
+		arg2 []string
+	}
+	getTransactionLogsReturns struct {
+		result1 []repository.TransactionLog
+		result2 error
+	}
+	getTransactionLogsReturnsOnCall map[int]struct {
+		result1 []repository.TransactionLog
+		result2 error
+	}
 	GetTransactionsByHashStub        func(context.Context, []string) ([]repository.Transaction, error)
 	getTransactionsByHashMutex       sync.RWMutex
 	getTransactionsByHashArgsForCall []struct {
@@ -50,20 +245,120 @@ type Repository struct {
 		result1 repository.User
 		result2 error
 	}
-	GetUserHistoryStub        func(context.Context, string) ([]string, error)
+	GetUserHistoryStub        func(context.Context, repository.HistoryFilter) ([]repository.UserTransaction, error)
 	getUserHistoryMutex       sync.RWMutex
 	getUserHistoryArgsForCall []struct {
 		arg1 context.Context
-		arg2 string
+		arg2 repository.HistoryFilter
 	}
 	getUserHistoryReturns struct {
-		result1 []string
+		result1 []repository.UserTransaction
 		result2 error
 	}
 	getUserHistoryReturnsOnCall map[int]struct {
-		result1 []string
+		result1 []repository.UserTransaction
 		result2 error
 	}
+	ReplaceTokenTransfersStub        func(context.Context, string, []repository.TokenTransfer) error
+	replaceTokenTransfersMutex       sync.RWMutex
+	replaceTokenTransfersArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []repository.TokenTransfer
+	}
+	replaceTokenTransfersReturns struct {
+		result1 error
+	}
+	replaceTokenTransfersReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ReplaceTransactionStub        func(context.Context, repository.Transaction, []repository.TransactionLog, []repository.TokenTransfer) error
+	replaceTransactionMutex       sync.RWMutex
+	replaceTransactionArgsForCall []struct {
+		arg1 context.Context
+		arg2 repository.Transaction
+		arg3 []repository.TransactionLog
+		arg4 []repository.TokenTransfer
+	}
+	replaceTransactionReturns struct {
+		result1 error
+	}
+	replaceTransactionReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ReplaceTransactionLogsStub        func(context.Context, string, []repository.TransactionLog) error
+	replaceTransactionLogsMutex       sync.RWMutex
+	replaceTransactionLogsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []repository.TransactionLog
+	}
+	replaceTransactionLogsReturns struct {
+		result1 error
+	}
+	replaceTransactionLogsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SaveBlockStub        func(context.Context, repository.Block) error
+	saveBlockMutex       sync.RWMutex
+	saveBlockArgsForCall []struct {
+		arg1 context.Context
+		arg2 repository.Block
+	}
+	saveBlockReturns struct {
+		result1 error
+	}
+	saveBlockReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SaveCallTracesStub        func(context.Context, []repository.CallTrace) error
+	saveCallTracesMutex       sync.RWMutex
+	saveCallTracesArgsForCall []struct {
+		arg1 context.Context
+		arg2 []repository.CallTrace
+	}
+	saveCallTracesReturns struct {
+		result1 error
+	}
+	saveCallTracesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SaveContractABIStub        func(context.Context, repository.ContractABI) error
+	saveContractABIMutex       sync.RWMutex
+	saveContractABIArgsForCall []struct {
+		arg1 context.Context
+		arg2 repository.ContractABI
+	}
+	saveContractABIReturns struct {
+		result1 error
+	}
+	saveContractABIReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SaveTokenTransfersStub        func(context.Context, []repository.TokenTransfer) error
+	saveTokenTransfersMutex       sync.RWMutex
+	saveTokenTransfersArgsForCall []struct {
+		arg1 context.Context
+		arg2 []repository.TokenTransfer
+	}
+	saveTokenTransfersReturns struct {
+		result1 error
+	}
+	saveTokenTransfersReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SaveTransactionLogsStub        func(context.Context, []repository.TransactionLog) error
+	saveTransactionLogsMutex       sync.RWMutex
+	saveTransactionLogsArgsForCall []struct {
+		arg1 context.Context
+		arg2 []repository.TransactionLog
+	}
+	saveTransactionLogsReturns struct {
+		result1 error
+	}
+	saveTransactionLogsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SaveTransactionsStub        func(context.Context, []repository.Transaction) error
 	saveTransactionsMutex       sync.RWMutex
 	saveTransactionsArgsForCall []struct {
@@ -83,278 +378,1753 @@ type Repository struct {
 		arg2 string
 		arg3 []string
 	}
-	saveUserHistoryReturns struct {
+	saveUserHistoryReturns struct {
+		result1 error
+	}
+	saveUserHistoryReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateTransactionStub        func(context.Context, repository.Transaction) error
+	updateTransactionMutex       sync.RWMutex
+	updateTransactionArgsForCall []struct {
+		arg1 context.Context
+		arg2 repository.Transaction
+	}
+	updateTransactionReturns struct {
+		result1 error
+	}
+	updateTransactionReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *Repository) ClearUserHistory(arg1 context.Context, arg2 string) (int64, error) {
+	fake.clearUserHistoryMutex.Lock()
+	ret, specificReturn := fake.clearUserHistoryReturnsOnCall[len(fake.clearUserHistoryArgsForCall)]
+	fake.clearUserHistoryArgsForCall = append(fake.clearUserHistoryArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ClearUserHistoryStub
+	fakeReturns := fake.clearUserHistoryReturns
+	fake.recordInvocation("ClearUserHistory", []interface{}{arg1, arg2})
+	fake.clearUserHistoryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Repository) ClearUserHistoryCallCount() int {
+	fake.clearUserHistoryMutex.RLock()
+	defer fake.clearUserHistoryMutex.RUnlock()
+	return len(fake.clearUserHistoryArgsForCall)
+}
+
+func (fake *Repository) ClearUserHistoryCalls(stub func(context.Context, string) (int64, error)) {
+	fake.clearUserHistoryMutex.Lock()
+	defer fake.clearUserHistoryMutex.Unlock()
+	fake.ClearUserHistoryStub = stub
+}
+
+func (fake *Repository) ClearUserHistoryArgsForCall(i int) (context.Context, string) {
+	fake.clearUserHistoryMutex.RLock()
+	defer fake.clearUserHistoryMutex.RUnlock()
+	argsForCall := fake.clearUserHistoryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Repository) ClearUserHistoryReturns(result1 int64, result2 error) {
+	fake.clearUserHistoryMutex.Lock()
+	defer fake.clearUserHistoryMutex.Unlock()
+	fake.ClearUserHistoryStub = nil
+	fake.clearUserHistoryReturns = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Repository) ClearUserHistoryReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.clearUserHistoryMutex.Lock()
+	defer fake.clearUserHistoryMutex.Unlock()
+	fake.ClearUserHistoryStub = nil
+	if fake.clearUserHistoryReturnsOnCall == nil {
+		fake.clearUserHistoryReturnsOnCall = make(map[int]struct {
+			result1 int64
+			result2 error
+		})
+	}
+	fake.clearUserHistoryReturnsOnCall[i] = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Repository) DeleteTransactions(arg1 context.Context, arg2 []string) error {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.deleteTransactionsMutex.Lock()
+	ret, specificReturn := fake.deleteTransactionsReturnsOnCall[len(fake.deleteTransactionsArgsForCall)]
+	fake.deleteTransactionsArgsForCall = append(fake.deleteTransactionsArgsForCall, struct {
+		arg1 context.Context
+		arg2 []string
+	}{arg1, arg2Copy})
+	stub := fake.DeleteTransactionsStub
+	fakeReturns := fake.deleteTransactionsReturns
+	fake.recordInvocation("DeleteTransactions", []interface{}{arg1, arg2Copy})
+	fake.deleteTransactionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Repository) DeleteTransactionsCallCount() int {
+	fake.deleteTransactionsMutex.RLock()
+	defer fake.deleteTransactionsMutex.RUnlock()
+	return len(fake.deleteTransactionsArgsForCall)
+}
+
+func (fake *Repository) DeleteTransactionsCalls(stub func(context.Context, []string) error) {
+	fake.deleteTransactionsMutex.Lock()
+	defer fake.deleteTransactionsMutex.Unlock()
+	fake.DeleteTransactionsStub = stub
+}
+
+func (fake *Repository) DeleteTransactionsArgsForCall(i int) (context.Context, []string) {
+	fake.deleteTransactionsMutex.RLock()
+	defer fake.deleteTransactionsMutex.RUnlock()
+	argsForCall := fake.deleteTransactionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Repository) DeleteTransactionsReturns(result1 error) {
+	fake.deleteTransactionsMutex.Lock()
+	defer fake.deleteTransactionsMutex.Unlock()
+	fake.DeleteTransactionsStub = nil
+	fake.deleteTransactionsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Repository) DeleteTransactionsReturnsOnCall(i int, result1 error) {
+	fake.deleteTransactionsMutex.Lock()
+	defer fake.deleteTransactionsMutex.Unlock()
+	fake.DeleteTransactionsStub = nil
+	if fake.deleteTransactionsReturnsOnCall == nil {
+		fake.deleteTransactionsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteTransactionsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Repository) DeleteUserHistory(arg1 context.Context, arg2 string, arg3 []string) (int64, error) {
+	var arg3Copy []string
+	if arg3 != nil {
+		arg3Copy = make([]string, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.deleteUserHistoryMutex.Lock()
+	ret, specificReturn := fake.deleteUserHistoryReturnsOnCall[len(fake.deleteUserHistoryArgsForCall)]
+	fake.deleteUserHistoryArgsForCall = append(fake.deleteUserHistoryArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []string
+	}{arg1, arg2, arg3Copy})
+	stub := fake.DeleteUserHistoryStub
+	fakeReturns := fake.deleteUserHistoryReturns
+	fake.recordInvocation("DeleteUserHistory", []interface{}{arg1, arg2, arg3Copy})
+	fake.deleteUserHistoryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Repository) DeleteUserHistoryCallCount() int {
+	fake.deleteUserHistoryMutex.RLock()
+	defer fake.deleteUserHistoryMutex.RUnlock()
+	return len(fake.deleteUserHistoryArgsForCall)
+}
+
+func (fake *Repository) DeleteUserHistoryCalls(stub func(context.Context, string, []string) (int64, error)) {
+	fake.deleteUserHistoryMutex.Lock()
+	defer fake.deleteUserHistoryMutex.Unlock()
+	fake.DeleteUserHistoryStub = stub
+}
+
+func (fake *Repository) DeleteUserHistoryArgsForCall(i int) (context.Context, string, []string) {
+	fake.deleteUserHistoryMutex.RLock()
+	defer fake.deleteUserHistoryMutex.RUnlock()
+	argsForCall := fake.deleteUserHistoryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Repository) DeleteUserHistoryReturns(result1 int64, result2 error) {
+	fake.deleteUserHistoryMutex.Lock()
+	defer fake.deleteUserHistoryMutex.Unlock()
+	fake.DeleteUserHistoryStub = nil
+	fake.deleteUserHistoryReturns = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Repository) DeleteUserHistoryReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.deleteUserHistoryMutex.Lock()
+	defer fake.deleteUserHistoryMutex.Unlock()
+	fake.DeleteUserHistoryStub = nil
+	if fake.deleteUserHistoryReturnsOnCall == nil {
+		fake.deleteUserHistoryReturnsOnCall = make(map[int]struct {
+			result1 int64
+			result2 error
+		})
+	}
+	fake.deleteUserHistoryReturnsOnCall[i] = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Repository) FindAddressTransactions(arg1 context.Context, arg2 repository.AddressFilter) ([]repository.Transaction, error) {
+	fake.findAddressTransactionsMutex.Lock()
+	ret, specificReturn := fake.findAddressTransactionsReturnsOnCall[len(fake.findAddressTransactionsArgsForCall)]
+	fake.findAddressTransactionsArgsForCall = append(fake.findAddressTransactionsArgsForCall, struct {
+		arg1 context.Context
+		arg2 repository.AddressFilter
+	}{arg1, arg2})
+	stub := fake.FindAddressTransactionsStub
+	fakeReturns := fake.findAddressTransactionsReturns
+	fake.recordInvocation("FindAddressTransactions", []interface{}{arg1, arg2})
+	fake.findAddressTransactionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Repository) FindAddressTransactionsCallCount() int {
+	fake.findAddressTransactionsMutex.RLock()
+	defer fake.findAddressTransactionsMutex.RUnlock()
+	return len(fake.findAddressTransactionsArgsForCall)
+}
+
+func (fake *Repository) FindAddressTransactionsCalls(stub func(context.Context, repository.AddressFilter) ([]repository.Transaction, error)) {
+	fake.findAddressTransactionsMutex.Lock()
+	defer fake.findAddressTransactionsMutex.Unlock()
+	fake.FindAddressTransactionsStub = stub
+}
+
+func (fake *Repository) FindAddressTransactionsArgsForCall(i int) (context.Context, repository.AddressFilter) {
+	fake.findAddressTransactionsMutex.RLock()
+	defer fake.findAddressTransactionsMutex.RUnlock()
+	argsForCall := fake.findAddressTransactionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Repository) FindAddressTransactionsReturns(result1 []repository.Transaction, result2 error) {
+	fake.findAddressTransactionsMutex.Lock()
+	defer fake.findAddressTransactionsMutex.Unlock()
+	fake.FindAddressTransactionsStub = nil
+	fake.findAddressTransactionsReturns = struct {
+		result1 []repository.Transaction
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Repository) FindAddressTransactionsReturnsOnCall(i int, result1 []repository.Transaction, result2 error) {
+	fake.findAddressTransactionsMutex.Lock()
+	defer fake.findAddressTransactionsMutex.Unlock()
+	fake.FindAddressTransactionsStub = nil
+	if fake.findAddressTransactionsReturnsOnCall == nil {
+		fake.findAddressTransactionsReturnsOnCall = make(map[int]struct {
+			result1 []repository.Transaction
+			result2 error
+		})
+	}
+	fake.findAddressTransactionsReturnsOnCall[i] = struct {
+		result1 []repository.Transaction
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Repository) FindLogs(arg1 context.Context, arg2 repository.LogFilter) ([]repository.TransactionLog, error) {
+	fake.findLogsMutex.Lock()
+	ret, specificReturn := fake.findLogsReturnsOnCall[len(fake.findLogsArgsForCall)]
+	fake.findLogsArgsForCall = append(fake.findLogsArgsForCall, struct {
+		arg1 context.Context
+		arg2 repository.LogFilter
+	}{arg1, arg2})
+	stub := fake.FindLogsStub
+	fakeReturns := fake.findLogsReturns
+	fake.recordInvocation("FindLogs", []interface{}{arg1, arg2})
+	fake.findLogsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Repository) FindLogsCallCount() int {
+	fake.findLogsMutex.RLock()
+	defer fake.findLogsMutex.RUnlock()
+	return len(fake.findLogsArgsForCall)
+}
+
+func (fake *Repository) FindLogsCalls(stub func(context.Context, repository.LogFilter) ([]repository.TransactionLog, error)) {
+	fake.findLogsMutex.Lock()
+	defer fake.findLogsMutex.Unlock()
+	fake.FindLogsStub = stub
+}
+
+func (fake *Repository) FindLogsArgsForCall(i int) (context.Context, repository.LogFilter) {
+	fake.findLogsMutex.RLock()
+	defer fake.findLogsMutex.RUnlock()
+	argsForCall := fake.findLogsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Repository) FindLogsReturns(result1 []repository.TransactionLog, result2 error) {
+	fake.findLogsMutex.Lock()
+	defer fake.findLogsMutex.Unlock()
+	fake.FindLogsStub = nil
+	fake.findLogsReturns = struct {
+		result1 []repository.TransactionLog
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Repository) FindLogsReturnsOnCall(i int, result1 []repository.TransactionLog, result2 error) {
+	fake.findLogsMutex.Lock()
+	defer fake.findLogsMutex.Unlock()
+	fake.FindLogsStub = nil
+	if fake.findLogsReturnsOnCall == nil {
+		fake.findLogsReturnsOnCall = make(map[int]struct {
+			result1 []repository.TransactionLog
+			result2 error
+		})
+	}
+	fake.findLogsReturnsOnCall[i] = struct {
+		result1 []repository.TransactionLog
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Repository) FindTokenTransfers(arg1 context.Context, arg2 repository.TransferFilter) ([]repository.TokenTransfer, error) {
+	fake.findTokenTransfersMutex.Lock()
+	ret, specificReturn := fake.findTokenTransfersReturnsOnCall[len(fake.findTokenTransfersArgsForCall)]
+	fake.findTokenTransfersArgsForCall = append(fake.findTokenTransfersArgsForCall, struct {
+		arg1 context.Context
+		arg2 repository.TransferFilter
+	}{arg1, arg2})
+	stub := fake.FindTokenTransfersStub
+	fakeReturns := fake.findTokenTransfersReturns
+	fake.recordInvocation("FindTokenTransfers", []interface{}{arg1, arg2})
+	fake.findTokenTransfersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Repository) FindTokenTransfersCallCount() int {
+	fake.findTokenTransfersMutex.RLock()
+	defer fake.findTokenTransfersMutex.RUnlock()
+	return len(fake.findTokenTransfersArgsForCall)
+}
+
+func (fake *Repository) FindTokenTransfersCalls(stub func(context.Context, repository.TransferFilter) ([]repository.TokenTransfer, error)) {
+	fake.findTokenTransfersMutex.Lock()
+	defer fake.findTokenTransfersMutex.Unlock()
+	fake.FindTokenTransfersStub = stub
+}
+
+func (fake *Repository) FindTokenTransfersArgsForCall(i int) (context.Context, repository.TransferFilter) {
+	fake.findTokenTransfersMutex.RLock()
+	defer fake.findTokenTransfersMutex.RUnlock()
+	argsForCall := fake.findTokenTransfersArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Repository) FindTokenTransfersReturns(result1 []repository.TokenTransfer, result2 error) {
+	fake.findTokenTransfersMutex.Lock()
+	defer fake.findTokenTransfersMutex.Unlock()
+	fake.FindTokenTransfersStub = nil
+	fake.findTokenTransfersReturns = struct {
+		result1 []repository.TokenTransfer
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Repository) FindTokenTransfersReturnsOnCall(i int, result1 []repository.TokenTransfer, result2 error) {
+	fake.findTokenTransfersMutex.Lock()
+	defer fake.findTokenTransfersMutex.Unlock()
+	fake.FindTokenTransfersStub = nil
+	if fake.findTokenTransfersReturnsOnCall == nil {
+		fake.findTokenTransfersReturnsOnCall = make(map[int]struct {
+			result1 []repository.TokenTransfer
+			result2 error
+		})
+	}
+	fake.findTokenTransfersReturnsOnCall[i] = struct {
+		result1 []repository.TokenTransfer
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Repository) FindTransactions(arg1 context.Context, arg2 repository.TransactionFilter) ([]repository.Transaction, error) {
+	fake.findTransactionsMutex.Lock()
+	ret, specificReturn := fake.findTransactionsReturnsOnCall[len(fake.findTransactionsArgsForCall)]
+	fake.findTransactionsArgsForCall = append(fake.findTransactionsArgsForCall, struct {
+		arg1 context.Context
+		arg2 repository.TransactionFilter
+	}{arg1, arg2})
+	stub := fake.FindTransactionsStub
+	fakeReturns := fake.findTransactionsReturns
+	fake.recordInvocation("FindTransactions", []interface{}{arg1, arg2})
+	fake.findTransactionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Repository) FindTransactionsCallCount() int {
+	fake.findTransactionsMutex.RLock()
+	defer fake.findTransactionsMutex.RUnlock()
+	return len(fake.findTransactionsArgsForCall)
+}
+
+func (fake *Repository) FindTransactionsCalls(stub func(context.Context, repository.TransactionFilter) ([]repository.Transaction, error)) {
+	fake.findTransactionsMutex.Lock()
+	defer fake.findTransactionsMutex.Unlock()
+	fake.FindTransactionsStub = stub
+}
+
+func (fake *Repository) FindTransactionsArgsForCall(i int) (context.Context, repository.TransactionFilter) {
+	fake.findTransactionsMutex.RLock()
+	defer fake.findTransactionsMutex.RUnlock()
+	argsForCall := fake.findTransactionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Repository) FindTransactionsReturns(result1 []repository.Transaction, result2 error) {
+	fake.findTransactionsMutex.Lock()
+	defer fake.findTransactionsMutex.Unlock()
+	fake.FindTransactionsStub = nil
+	fake.findTransactionsReturns = struct {
+		result1 []repository.Transaction
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Repository) FindTransactionsReturnsOnCall(i int, result1 []repository.Transaction, result2 error) {
+	fake.findTransactionsMutex.Lock()
+	defer fake.findTransactionsMutex.Unlock()
+	fake.FindTransactionsStub = nil
+	if fake.findTransactionsReturnsOnCall == nil {
+		fake.findTransactionsReturnsOnCall = make(map[int]struct {
+			result1 []repository.Transaction
+			result2 error
+		})
+	}
+	fake.findTransactionsReturnsOnCall[i] = struct {
+		result1 []repository.Transaction
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Repository) GetBlockByHash(arg1 context.Context, arg2 string) (repository.Block, error) {
+	fake.getBlockByHashMutex.Lock()
+	ret, specificReturn := fake.getBlockByHashReturnsOnCall[len(fake.getBlockByHashArgsForCall)]
+	fake.getBlockByHashArgsForCall = append(fake.getBlockByHashArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetBlockByHashStub
+	fakeReturns := fake.getBlockByHashReturns
+	fake.recordInvocation("GetBlockByHash", []interface{}{arg1, arg2})
+	fake.getBlockByHashMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Repository) GetBlockByHashCallCount() int {
+	fake.getBlockByHashMutex.RLock()
+	defer fake.getBlockByHashMutex.RUnlock()
+	return len(fake.getBlockByHashArgsForCall)
+}
+
+func (fake *Repository) GetBlockByHashCalls(stub func(context.Context, string) (repository.Block, error)) {
+	fake.getBlockByHashMutex.Lock()
+	defer fake.getBlockByHashMutex.Unlock()
+	fake.GetBlockByHashStub = stub
+}
+
+func (fake *Repository) GetBlockByHashArgsForCall(i int) (context.Context, string) {
+	fake.getBlockByHashMutex.RLock()
+	defer fake.getBlockByHashMutex.RUnlock()
+	argsForCall := fake.getBlockByHashArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Repository) GetBlockByHashReturns(result1 repository.Block, result2 error) {
+	fake.getBlockByHashMutex.Lock()
+	defer fake.getBlockByHashMutex.Unlock()
+	fake.GetBlockByHashStub = nil
+	fake.getBlockByHashReturns = struct {
+		result1 repository.Block
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Repository) GetBlockByHashReturnsOnCall(i int, result1 repository.Block, result2 error) {
+	fake.getBlockByHashMutex.Lock()
+	defer fake.getBlockByHashMutex.Unlock()
+	fake.GetBlockByHashStub = nil
+	if fake.getBlockByHashReturnsOnCall == nil {
+		fake.getBlockByHashReturnsOnCall = make(map[int]struct {
+			result1 repository.Block
+			result2 error
+		})
+	}
+	fake.getBlockByHashReturnsOnCall[i] = struct {
+		result1 repository.Block
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Repository) GetBlockByNumber(arg1 context.Context, arg2 uint64) (repository.Block, error) {
+	fake.getBlockByNumberMutex.Lock()
+	ret, specificReturn := fake.getBlockByNumberReturnsOnCall[len(fake.getBlockByNumberArgsForCall)]
+	fake.getBlockByNumberArgsForCall = append(fake.getBlockByNumberArgsForCall, struct {
+		arg1 context.Context
+		arg2 uint64
+	}{arg1, arg2})
+	stub := fake.GetBlockByNumberStub
+	fakeReturns := fake.getBlockByNumberReturns
+	fake.recordInvocation("GetBlockByNumber", []interface{}{arg1, arg2})
+	fake.getBlockByNumberMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Repository) GetBlockByNumberCallCount() int {
+	fake.getBlockByNumberMutex.RLock()
+	defer fake.getBlockByNumberMutex.RUnlock()
+	return len(fake.getBlockByNumberArgsForCall)
+}
+
+func (fake *Repository) GetBlockByNumberCalls(stub func(context.Context, uint64) (repository.Block, error)) {
+	fake.getBlockByNumberMutex.Lock()
+	defer fake.getBlockByNumberMutex.Unlock()
+	fake.GetBlockByNumberStub = stub
+}
+
+func (fake *Repository) GetBlockByNumberArgsForCall(i int) (context.Context, uint64) {
+	fake.getBlockByNumberMutex.RLock()
+	defer fake.getBlockByNumberMutex.RUnlock()
+	argsForCall := fake.getBlockByNumberArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Repository) GetBlockByNumberReturns(result1 repository.Block, result2 error) {
+	fake.getBlockByNumberMutex.Lock()
+	defer fake.getBlockByNumberMutex.Unlock()
+	fake.GetBlockByNumberStub = nil
+	fake.getBlockByNumberReturns = struct {
+		result1 repository.Block
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Repository) GetBlockByNumberReturnsOnCall(i int, result1 repository.Block, result2 error) {
+	fake.getBlockByNumberMutex.Lock()
+	defer fake.getBlockByNumberMutex.Unlock()
+	fake.GetBlockByNumberStub = nil
+	if fake.getBlockByNumberReturnsOnCall == nil {
+		fake.getBlockByNumberReturnsOnCall = make(map[int]struct {
+			result1 repository.Block
+			result2 error
+		})
+	}
+	fake.getBlockByNumberReturnsOnCall[i] = struct {
+		result1 repository.Block
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Repository) GetCallTraces(arg1 context.Context, arg2 string) ([]repository.CallTrace, error) {
+	fake.getCallTracesMutex.Lock()
+	ret, specificReturn := fake.getCallTracesReturnsOnCall[len(fake.getCallTracesArgsForCall)]
+	fake.getCallTracesArgsForCall = append(fake.getCallTracesArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetCallTracesStub
+	fakeReturns := fake.getCallTracesReturns
+	fake.recordInvocation("GetCallTraces", []interface{}{arg1, arg2})
+	fake.getCallTracesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Repository) GetCallTracesCallCount() int {
+	fake.getCallTracesMutex.RLock()
+	defer fake.getCallTracesMutex.RUnlock()
+	return len(fake.getCallTracesArgsForCall)
+}
+
+func (fake *Repository) GetCallTracesCalls(stub func(context.Context, string) ([]repository.CallTrace, error)) {
+	fake.getCallTracesMutex.Lock()
+	defer fake.getCallTracesMutex.Unlock()
+	fake.GetCallTracesStub = stub
+}
+
+func (fake *Repository) GetCallTracesArgsForCall(i int) (context.Context, string) {
+	fake.getCallTracesMutex.RLock()
+	defer fake.getCallTracesMutex.RUnlock()
+	argsForCall := fake.getCallTracesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Repository) GetCallTracesReturns(result1 []repository.CallTrace, result2 error) {
+	fake.getCallTracesMutex.Lock()
+	defer fake.getCallTracesMutex.Unlock()
+	fake.GetCallTracesStub = nil
+	fake.getCallTracesReturns = struct {
+		result1 []repository.CallTrace
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Repository) GetCallTracesReturnsOnCall(i int, result1 []repository.CallTrace, result2 error) {
+	fake.getCallTracesMutex.Lock()
+	defer fake.getCallTracesMutex.Unlock()
+	fake.GetCallTracesStub = nil
+	if fake.getCallTracesReturnsOnCall == nil {
+		fake.getCallTracesReturnsOnCall = make(map[int]struct {
+			result1 []repository.CallTrace
+			result2 error
+		})
+	}
+	fake.getCallTracesReturnsOnCall[i] = struct {
+		result1 []repository.CallTrace
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Repository) GetContractABIs(arg1 context.Context) ([]repository.ContractABI, error) {
+	fake.getContractABIsMutex.Lock()
+	ret, specificReturn := fake.getContractABIsReturnsOnCall[len(fake.getContractABIsArgsForCall)]
+	fake.getContractABIsArgsForCall = append(fake.getContractABIsArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.GetContractABIsStub
+	fakeReturns := fake.getContractABIsReturns
+	fake.recordInvocation("GetContractABIs", []interface{}{arg1})
+	fake.getContractABIsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Repository) GetContractABIsCallCount() int {
+	fake.getContractABIsMutex.RLock()
+	defer fake.getContractABIsMutex.RUnlock()
+	return len(fake.getContractABIsArgsForCall)
+}
+
+func (fake *Repository) GetContractABIsCalls(stub func(context.Context) ([]repository.ContractABI, error)) {
+	fake.getContractABIsMutex.Lock()
+	defer fake.getContractABIsMutex.Unlock()
+	fake.GetContractABIsStub = stub
+}
+
+func (fake *Repository) GetContractABIsArgsForCall(i int) context.Context {
+	fake.getContractABIsMutex.RLock()
+	defer fake.getContractABIsMutex.RUnlock()
+	argsForCall := fake.getContractABIsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Repository) GetContractABIsReturns(result1 []repository.ContractABI, result2 error) {
+	fake.getContractABIsMutex.Lock()
+	defer fake.getContractABIsMutex.Unlock()
+	fake.GetContractABIsStub = nil
+	fake.getContractABIsReturns = struct {
+		result1 []repository.ContractABI
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Repository) GetContractABIsReturnsOnCall(i int, result1 []repository.ContractABI, result2 error) {
+	fake.getContractABIsMutex.Lock()
+	defer fake.getContractABIsMutex.Unlock()
+	fake.GetContractABIsStub = nil
+	if fake.getContractABIsReturnsOnCall == nil {
+		fake.getContractABIsReturnsOnCall = make(map[int]struct {
+			result1 []repository.ContractABI
+			result2 error
+		})
+	}
+	fake.getContractABIsReturnsOnCall[i] = struct {
+		result1 []repository.ContractABI
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Repository) GetIncompleteTransactions(arg1 context.Context, arg2 string, arg3 int) ([]repository.Transaction, error) {
+	fake.getIncompleteTransactionsMutex.Lock()
+	ret, specificReturn := fake.getIncompleteTransactionsReturnsOnCall[len(fake.getIncompleteTransactionsArgsForCall)]
+	fake.getIncompleteTransactionsArgsForCall = append(fake.getIncompleteTransactionsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.GetIncompleteTransactionsStub
+	fakeReturns := fake.getIncompleteTransactionsReturns
+	fake.recordInvocation("GetIncompleteTransactions", []interface{}{arg1, arg2, arg3})
+	fake.getIncompleteTransactionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Repository) GetIncompleteTransactionsCallCount() int {
+	fake.getIncompleteTransactionsMutex.RLock()
+	defer fake.getIncompleteTransactionsMutex.RUnlock()
+	return len(fake.getIncompleteTransactionsArgsForCall)
+}
+
+func (fake *Repository) GetIncompleteTransactionsCalls(stub func(context.Context, string, int) ([]repository.Transaction, error)) {
+	fake.getIncompleteTransactionsMutex.Lock()
+	defer fake.getIncompleteTransactionsMutex.Unlock()
+	fake.GetIncompleteTransactionsStub = stub
+}
+
+func (fake *Repository) GetIncompleteTransactionsArgsForCall(i int) (context.Context, string, int) {
+	fake.getIncompleteTransactionsMutex.RLock()
+	defer fake.getIncompleteTransactionsMutex.RUnlock()
+	argsForCall := fake.getIncompleteTransactionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Repository) GetIncompleteTransactionsReturns(result1 []repository.Transaction, result2 error) {
+	fake.getIncompleteTransactionsMutex.Lock()
+	defer fake.getIncompleteTransactionsMutex.Unlock()
+	fake.GetIncompleteTransactionsStub = nil
+	fake.getIncompleteTransactionsReturns = struct {
+		result1 []repository.Transaction
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Repository) GetIncompleteTransactionsReturnsOnCall(i int, result1 []repository.Transaction, result2 error) {
+	fake.getIncompleteTransactionsMutex.Lock()
+	defer fake.getIncompleteTransactionsMutex.Unlock()
+	fake.GetIncompleteTransactionsStub = nil
+	if fake.getIncompleteTransactionsReturnsOnCall == nil {
+		fake.getIncompleteTransactionsReturnsOnCall = make(map[int]struct {
+			result1 []repository.Transaction
+			result2 error
+		})
+	}
+	fake.getIncompleteTransactionsReturnsOnCall[i] = struct {
+		result1 []repository.Transaction
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Repository) GetTentativeTransactions(arg1 context.Context) ([]repository.Transaction, error) {
+	fake.getTentativeTransactionsMutex.Lock()
+	ret, specificReturn := fake.getTentativeTransactionsReturnsOnCall[len(fake.getTentativeTransactionsArgsForCall)]
+	fake.getTentativeTransactionsArgsForCall = append(fake.getTentativeTransactionsArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.GetTentativeTransactionsStub
+	fakeReturns := fake.getTentativeTransactionsReturns
+	fake.recordInvocation("GetTentativeTransactions", []interface{}{arg1})
+	fake.getTentativeTransactionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Repository) GetTentativeTransactionsCallCount() int {
+	fake.getTentativeTransactionsMutex.RLock()
+	defer fake.getTentativeTransactionsMutex.RUnlock()
+	return len(fake.getTentativeTransactionsArgsForCall)
+}
+
+func (fake *Repository) GetTentativeTransactionsCalls(stub func(context.Context) ([]repository.Transaction, error)) {
+	fake.getTentativeTransactionsMutex.Lock()
+	defer fake.getTentativeTransactionsMutex.Unlock()
+	fake.GetTentativeTransactionsStub = stub
+}
+
+func (fake *Repository) GetTentativeTransactionsArgsForCall(i int) context.Context {
+	fake.getTentativeTransactionsMutex.RLock()
+	defer fake.getTentativeTransactionsMutex.RUnlock()
+	argsForCall := fake.getTentativeTransactionsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Repository) GetTentativeTransactionsReturns(result1 []repository.Transaction, result2 error) {
+	fake.getTentativeTransactionsMutex.Lock()
+	defer fake.getTentativeTransactionsMutex.Unlock()
+	fake.GetTentativeTransactionsStub = nil
+	fake.getTentativeTransactionsReturns = struct {
+		result1 []repository.Transaction
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Repository) GetTentativeTransactionsReturnsOnCall(i int, result1 []repository.Transaction, result2 error) {
+	fake.getTentativeTransactionsMutex.Lock()
+	defer fake.getTentativeTransactionsMutex.Unlock()
+	fake.GetTentativeTransactionsStub = nil
+	if fake.getTentativeTransactionsReturnsOnCall == nil {
+		fake.getTentativeTransactionsReturnsOnCall = make(map[int]struct {
+			result1 []repository.Transaction
+			result2 error
+		})
+	}
+	fake.getTentativeTransactionsReturnsOnCall[i] = struct {
+		result1 []repository.Transaction
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Repository) GetTokenTransfers(arg1 context.Context, arg2 []string) ([]repository.TokenTransfer, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.getTokenTransfersMutex.Lock()
+	ret, specificReturn := fake.getTokenTransfersReturnsOnCall[len(fake.getTokenTransfersArgsForCall)]
+	fake.getTokenTransfersArgsForCall = append(fake.getTokenTransfersArgsForCall, struct {
+		arg1 context.Context
+		arg2 []string
+	}{arg1, arg2Copy})
+	stub := fake.GetTokenTransfersStub
+	fakeReturns := fake.getTokenTransfersReturns
+	fake.recordInvocation("GetTokenTransfers", []interface{}{arg1, arg2Copy})
+	fake.getTokenTransfersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Repository) GetTokenTransfersCallCount() int {
+	fake.getTokenTransfersMutex.RLock()
+	defer fake.getTokenTransfersMutex.RUnlock()
+	return len(fake.getTokenTransfersArgsForCall)
+}
+
+func (fake *Repository) GetTokenTransfersCalls(stub func(context.Context, []string) ([]repository.TokenTransfer, error)) {
+	fake.getTokenTransfersMutex.Lock()
+	defer fake.getTokenTransfersMutex.Unlock()
+	fake.GetTokenTransfersStub = stub
+}
+
+func (fake *Repository) GetTokenTransfersArgsForCall(i int) (context.Context, []string) {
+	fake.getTokenTransfersMutex.RLock()
+	defer fake.getTokenTransfersMutex.RUnlock()
+	argsForCall := fake.getTokenTransfersArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Repository) GetTokenTransfersReturns(result1 []repository.TokenTransfer, result2 error) {
+	fake.getTokenTransfersMutex.Lock()
+	defer fake.getTokenTransfersMutex.Unlock()
+	fake.GetTokenTransfersStub = nil
+	fake.getTokenTransfersReturns = struct {
+		result1 []repository.TokenTransfer
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Repository) GetTokenTransfersReturnsOnCall(i int, result1 []repository.TokenTransfer, result2 error) {
+	fake.getTokenTransfersMutex.Lock()
+	defer fake.getTokenTransfersMutex.Unlock()
+	fake.GetTokenTransfersStub = nil
+	if fake.getTokenTransfersReturnsOnCall == nil {
+		fake.getTokenTransfersReturnsOnCall = make(map[int]struct {
+			result1 []repository.TokenTransfer
+			result2 error
+		})
+	}
+	fake.getTokenTransfersReturnsOnCall[i] = struct {
+		result1 []repository.TokenTransfer
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Repository) GetTransactionLogs(arg1 context.Context, arg2 []string) ([]repository.TransactionLog, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.getTransactionLogsMutex.Lock()
+	ret, specificReturn := fake.getTransactionLogsReturnsOnCall[len(fake.getTransactionLogsArgsForCall)]
+	fake.getTransactionLogsArgsForCall = append(fake.getTransactionLogsArgsForCall, struct {
+		arg1 context.Context
+		arg2 []string
+	}{arg1, arg2Copy})
+	stub := fake.GetTransactionLogsStub
+	fakeReturns := fake.getTransactionLogsReturns
+	fake.recordInvocation("GetTransactionLogs", []interface{}{arg1, arg2Copy})
+	fake.getTransactionLogsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Repository) GetTransactionLogsCallCount() int {
+	fake.getTransactionLogsMutex.RLock()
+	defer fake.getTransactionLogsMutex.RUnlock()
+	return len(fake.getTransactionLogsArgsForCall)
+}
+
+func (fake *Repository) GetTransactionLogsCalls(stub func(context.Context, []string) ([]repository.TransactionLog, error)) {
+	fake.getTransactionLogsMutex.Lock()
+	defer fake.getTransactionLogsMutex.Unlock()
+	fake.GetTransactionLogsStub = stub
+}
+
+func (fake *Repository) GetTransactionLogsArgsForCall(i int) (context.Context, []string) {
+	fake.getTransactionLogsMutex.RLock()
+	defer fake.getTransactionLogsMutex.RUnlock()
+	argsForCall := fake.getTransactionLogsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Repository) GetTransactionLogsReturns(result1 []repository.TransactionLog, result2 error) {
+	fake.getTransactionLogsMutex.Lock()
+	defer fake.getTransactionLogsMutex.Unlock()
+	fake.GetTransactionLogsStub = nil
+	fake.getTransactionLogsReturns = struct {
+		result1 []repository.TransactionLog
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Repository) GetTransactionLogsReturnsOnCall(i int, result1 []repository.TransactionLog, result2 error) {
+	fake.getTransactionLogsMutex.Lock()
+	defer fake.getTransactionLogsMutex.Unlock()
+	fake.GetTransactionLogsStub = nil
+	if fake.getTransactionLogsReturnsOnCall == nil {
+		fake.getTransactionLogsReturnsOnCall = make(map[int]struct {
+			result1 []repository.TransactionLog
+			result2 error
+		})
+	}
+	fake.getTransactionLogsReturnsOnCall[i] = struct {
+		result1 []repository.TransactionLog
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Repository) GetTransactionsByHash(arg1 context.Context, arg2 []string) ([]repository.Transaction, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.getTransactionsByHashMutex.Lock()
+	ret, specificReturn := fake.getTransactionsByHashReturnsOnCall[len(fake.getTransactionsByHashArgsForCall)]
+	fake.getTransactionsByHashArgsForCall = append(fake.getTransactionsByHashArgsForCall, struct {
+		arg1 context.Context
+		arg2 []string
+	}{arg1, arg2Copy})
+	stub := fake.GetTransactionsByHashStub
+	fakeReturns := fake.getTransactionsByHashReturns
+	fake.recordInvocation("GetTransactionsByHash", []interface{}{arg1, arg2Copy})
+	fake.getTransactionsByHashMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Repository) GetTransactionsByHashCallCount() int {
+	fake.getTransactionsByHashMutex.RLock()
+	defer fake.getTransactionsByHashMutex.RUnlock()
+	return len(fake.getTransactionsByHashArgsForCall)
+}
+
+func (fake *Repository) GetTransactionsByHashCalls(stub func(context.Context, []string) ([]repository.Transaction, error)) {
+	fake.getTransactionsByHashMutex.Lock()
+	defer fake.getTransactionsByHashMutex.Unlock()
+	fake.GetTransactionsByHashStub = stub
+}
+
+func (fake *Repository) GetTransactionsByHashArgsForCall(i int) (context.Context, []string) {
+	fake.getTransactionsByHashMutex.RLock()
+	defer fake.getTransactionsByHashMutex.RUnlock()
+	argsForCall := fake.getTransactionsByHashArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Repository) GetTransactionsByHashReturns(result1 []repository.Transaction, result2 error) {
+	fake.getTransactionsByHashMutex.Lock()
+	defer fake.getTransactionsByHashMutex.Unlock()
+	fake.GetTransactionsByHashStub = nil
+	fake.getTransactionsByHashReturns = struct {
+		result1 []repository.Transaction
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Repository) GetTransactionsByHashReturnsOnCall(i int, result1 []repository.Transaction, result2 error) {
+	fake.getTransactionsByHashMutex.Lock()
+	defer fake.getTransactionsByHashMutex.Unlock()
+	fake.GetTransactionsByHashStub = nil
+	if fake.getTransactionsByHashReturnsOnCall == nil {
+		fake.getTransactionsByHashReturnsOnCall = make(map[int]struct {
+			result1 []repository.Transaction
+			result2 error
+		})
+	}
+	fake.getTransactionsByHashReturnsOnCall[i] = struct {
+		result1 []repository.Transaction
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Repository) GetUserFromDB(arg1 context.Context, arg2 string) (repository.User, error) {
+	fake.getUserFromDBMutex.Lock()
+	ret, specificReturn := fake.getUserFromDBReturnsOnCall[len(fake.getUserFromDBArgsForCall)]
+	fake.getUserFromDBArgsForCall = append(fake.getUserFromDBArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetUserFromDBStub
+	fakeReturns := fake.getUserFromDBReturns
+	fake.recordInvocation("GetUserFromDB", []interface{}{arg1, arg2})
+	fake.getUserFromDBMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Repository) GetUserFromDBCallCount() int {
+	fake.getUserFromDBMutex.RLock()
+	defer fake.getUserFromDBMutex.RUnlock()
+	return len(fake.getUserFromDBArgsForCall)
+}
+
+func (fake *Repository) GetUserFromDBCalls(stub func(context.Context, string) (repository.User, error)) {
+	fake.getUserFromDBMutex.Lock()
+	defer fake.getUserFromDBMutex.Unlock()
+	fake.GetUserFromDBStub = stub
+}
+
+func (fake *Repository) GetUserFromDBArgsForCall(i int) (context.Context, string) {
+	fake.getUserFromDBMutex.RLock()
+	defer fake.getUserFromDBMutex.RUnlock()
+	argsForCall := fake.getUserFromDBArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Repository) GetUserFromDBReturns(result1 repository.User, result2 error) {
+	fake.getUserFromDBMutex.Lock()
+	defer fake.getUserFromDBMutex.Unlock()
+	fake.GetUserFromDBStub = nil
+	fake.getUserFromDBReturns = struct {
+		result1 repository.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Repository) GetUserFromDBReturnsOnCall(i int, result1 repository.User, result2 error) {
+	fake.getUserFromDBMutex.Lock()
+	defer fake.getUserFromDBMutex.Unlock()
+	fake.GetUserFromDBStub = nil
+	if fake.getUserFromDBReturnsOnCall == nil {
+		fake.getUserFromDBReturnsOnCall = make(map[int]struct {
+			result1 repository.User
+			result2 error
+		})
+	}
+	fake.getUserFromDBReturnsOnCall[i] = struct {
+		result1 repository.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Repository) GetUserHistory(arg1 context.Context, arg2 repository.HistoryFilter) ([]repository.UserTransaction, error) {
+	fake.getUserHistoryMutex.Lock()
+	ret, specificReturn := fake.getUserHistoryReturnsOnCall[len(fake.getUserHistoryArgsForCall)]
+	fake.getUserHistoryArgsForCall = append(fake.getUserHistoryArgsForCall, struct {
+		arg1 context.Context
+		arg2 repository.HistoryFilter
+	}{arg1, arg2})
+	stub := fake.GetUserHistoryStub
+	fakeReturns := fake.getUserHistoryReturns
+	fake.recordInvocation("GetUserHistory", []interface{}{arg1, arg2})
+	fake.getUserHistoryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Repository) GetUserHistoryCallCount() int {
+	fake.getUserHistoryMutex.RLock()
+	defer fake.getUserHistoryMutex.RUnlock()
+	return len(fake.getUserHistoryArgsForCall)
+}
+
+func (fake *Repository) GetUserHistoryCalls(stub func(context.Context, repository.HistoryFilter) ([]repository.UserTransaction, error)) {
+	fake.getUserHistoryMutex.Lock()
+	defer fake.getUserHistoryMutex.Unlock()
+	fake.GetUserHistoryStub = stub
+}
+
+func (fake *Repository) GetUserHistoryArgsForCall(i int) (context.Context, repository.HistoryFilter) {
+	fake.getUserHistoryMutex.RLock()
+	defer fake.getUserHistoryMutex.RUnlock()
+	argsForCall := fake.getUserHistoryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Repository) GetUserHistoryReturns(result1 []repository.UserTransaction, result2 error) {
+	fake.getUserHistoryMutex.Lock()
+	defer fake.getUserHistoryMutex.Unlock()
+	fake.GetUserHistoryStub = nil
+	fake.getUserHistoryReturns = struct {
+		result1 []repository.UserTransaction
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Repository) GetUserHistoryReturnsOnCall(i int, result1 []repository.UserTransaction, result2 error) {
+	fake.getUserHistoryMutex.Lock()
+	defer fake.getUserHistoryMutex.Unlock()
+	fake.GetUserHistoryStub = nil
+	if fake.getUserHistoryReturnsOnCall == nil {
+		fake.getUserHistoryReturnsOnCall = make(map[int]struct {
+			result1 []repository.UserTransaction
+			result2 error
+		})
+	}
+	fake.getUserHistoryReturnsOnCall[i] = struct {
+		result1 []repository.UserTransaction
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Repository) ReplaceTokenTransfers(arg1 context.Context, arg2 string, arg3 []repository.TokenTransfer) error {
+	var arg3Copy []repository.TokenTransfer
+	if arg3 != nil {
+		arg3Copy = make([]repository.TokenTransfer, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.replaceTokenTransfersMutex.Lock()
+	ret, specificReturn := fake.replaceTokenTransfersReturnsOnCall[len(fake.replaceTokenTransfersArgsForCall)]
+	fake.replaceTokenTransfersArgsForCall = append(fake.replaceTokenTransfersArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []repository.TokenTransfer
+	}{arg1, arg2, arg3Copy})
+	stub := fake.ReplaceTokenTransfersStub
+	fakeReturns := fake.replaceTokenTransfersReturns
+	fake.recordInvocation("ReplaceTokenTransfers", []interface{}{arg1, arg2, arg3Copy})
+	fake.replaceTokenTransfersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Repository) ReplaceTokenTransfersCallCount() int {
+	fake.replaceTokenTransfersMutex.RLock()
+	defer fake.replaceTokenTransfersMutex.RUnlock()
+	return len(fake.replaceTokenTransfersArgsForCall)
+}
+
+func (fake *Repository) ReplaceTokenTransfersCalls(stub func(context.Context, string, []repository.TokenTransfer) error) {
+	fake.replaceTokenTransfersMutex.Lock()
+	defer fake.replaceTokenTransfersMutex.Unlock()
+	fake.ReplaceTokenTransfersStub = stub
+}
+
+func (fake *Repository) ReplaceTokenTransfersArgsForCall(i int) (context.Context, string, []repository.TokenTransfer) {
+	fake.replaceTokenTransfersMutex.RLock()
+	defer fake.replaceTokenTransfersMutex.RUnlock()
+	argsForCall := fake.replaceTokenTransfersArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Repository) ReplaceTokenTransfersReturns(result1 error) {
+	fake.replaceTokenTransfersMutex.Lock()
+	defer fake.replaceTokenTransfersMutex.Unlock()
+	fake.ReplaceTokenTransfersStub = nil
+	fake.replaceTokenTransfersReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Repository) ReplaceTokenTransfersReturnsOnCall(i int, result1 error) {
+	fake.replaceTokenTransfersMutex.Lock()
+	defer fake.replaceTokenTransfersMutex.Unlock()
+	fake.ReplaceTokenTransfersStub = nil
+	if fake.replaceTokenTransfersReturnsOnCall == nil {
+		fake.replaceTokenTransfersReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.replaceTokenTransfersReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Repository) ReplaceTransaction(arg1 context.Context, arg2 repository.Transaction, arg3 []repository.TransactionLog, arg4 []repository.TokenTransfer) error {
+	var arg3Copy []repository.TransactionLog
+	if arg3 != nil {
+		arg3Copy = make([]repository.TransactionLog, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	var arg4Copy []repository.TokenTransfer
+	if arg4 != nil {
+		arg4Copy = make([]repository.TokenTransfer, len(arg4))
+		copy(arg4Copy, arg4)
+	}
+	fake.replaceTransactionMutex.Lock()
+	ret, specificReturn := fake.replaceTransactionReturnsOnCall[len(fake.replaceTransactionArgsForCall)]
+	fake.replaceTransactionArgsForCall = append(fake.replaceTransactionArgsForCall, struct {
+		arg1 context.Context
+		arg2 repository.Transaction
+		arg3 []repository.TransactionLog
+		arg4 []repository.TokenTransfer
+	}{arg1, arg2, arg3Copy, arg4Copy})
+	stub := fake.ReplaceTransactionStub
+	fakeReturns := fake.replaceTransactionReturns
+	fake.recordInvocation("ReplaceTransaction", []interface{}{arg1, arg2, arg3Copy, arg4Copy})
+	fake.replaceTransactionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Repository) ReplaceTransactionCallCount() int {
+	fake.replaceTransactionMutex.RLock()
+	defer fake.replaceTransactionMutex.RUnlock()
+	return len(fake.replaceTransactionArgsForCall)
+}
+
+func (fake *Repository) ReplaceTransactionCalls(stub func(context.Context, repository.Transaction, []repository.TransactionLog, []repository.TokenTransfer) error) {
+	fake.replaceTransactionMutex.Lock()
+	defer fake.replaceTransactionMutex.Unlock()
+	fake.ReplaceTransactionStub = stub
+}
+
+func (fake *Repository) ReplaceTransactionArgsForCall(i int) (context.Context, repository.Transaction, []repository.TransactionLog, []repository.TokenTransfer) {
+	fake.replaceTransactionMutex.RLock()
+	defer fake.replaceTransactionMutex.RUnlock()
+	argsForCall := fake.replaceTransactionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *Repository) ReplaceTransactionReturns(result1 error) {
+	fake.replaceTransactionMutex.Lock()
+	defer fake.replaceTransactionMutex.Unlock()
+	fake.ReplaceTransactionStub = nil
+	fake.replaceTransactionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Repository) ReplaceTransactionReturnsOnCall(i int, result1 error) {
+	fake.replaceTransactionMutex.Lock()
+	defer fake.replaceTransactionMutex.Unlock()
+	fake.ReplaceTransactionStub = nil
+	if fake.replaceTransactionReturnsOnCall == nil {
+		fake.replaceTransactionReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.replaceTransactionReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Repository) ReplaceTransactionLogs(arg1 context.Context, arg2 string, arg3 []repository.TransactionLog) error {
+	var arg3Copy []repository.TransactionLog
+	if arg3 != nil {
+		arg3Copy = make([]repository.TransactionLog, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.replaceTransactionLogsMutex.Lock()
+	ret, specificReturn := fake.replaceTransactionLogsReturnsOnCall[len(fake.replaceTransactionLogsArgsForCall)]
+	fake.replaceTransactionLogsArgsForCall = append(fake.replaceTransactionLogsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []repository.TransactionLog
+	}{arg1, arg2, arg3Copy})
+	stub := fake.ReplaceTransactionLogsStub
+	fakeReturns := fake.replaceTransactionLogsReturns
+	fake.recordInvocation("ReplaceTransactionLogs", []interface{}{arg1, arg2, arg3Copy})
+	fake.replaceTransactionLogsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Repository) ReplaceTransactionLogsCallCount() int {
+	fake.replaceTransactionLogsMutex.RLock()
+	defer fake.replaceTransactionLogsMutex.RUnlock()
+	return len(fake.replaceTransactionLogsArgsForCall)
+}
+
+func (fake *Repository) ReplaceTransactionLogsCalls(stub func(context.Context, string, []repository.TransactionLog) error) {
+	fake.replaceTransactionLogsMutex.Lock()
+	defer fake.replaceTransactionLogsMutex.Unlock()
+	fake.ReplaceTransactionLogsStub = stub
+}
+
+func (fake *Repository) ReplaceTransactionLogsArgsForCall(i int) (context.Context, string, []repository.TransactionLog) {
+	fake.replaceTransactionLogsMutex.RLock()
+	defer fake.replaceTransactionLogsMutex.RUnlock()
+	argsForCall := fake.replaceTransactionLogsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Repository) ReplaceTransactionLogsReturns(result1 error) {
+	fake.replaceTransactionLogsMutex.Lock()
+	defer fake.replaceTransactionLogsMutex.Unlock()
+	fake.ReplaceTransactionLogsStub = nil
+	fake.replaceTransactionLogsReturns = struct {
 		result1 error
+	}{result1}
+}
+
+func (fake *Repository) ReplaceTransactionLogsReturnsOnCall(i int, result1 error) {
+	fake.replaceTransactionLogsMutex.Lock()
+	defer fake.replaceTransactionLogsMutex.Unlock()
+	fake.ReplaceTransactionLogsStub = nil
+	if fake.replaceTransactionLogsReturnsOnCall == nil {
+		fake.replaceTransactionLogsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
 	}
-	saveUserHistoryReturnsOnCall map[int]struct {
+	fake.replaceTransactionLogsReturnsOnCall[i] = struct {
 		result1 error
-	}
-	invocations      map[string][][]interface{}
-	invocationsMutex sync.RWMutex
+	}{result1}
 }
 
-func (fake *Repository) GetAllTransactions(arg1 context.Context) ([]repository.Transaction, error) {
-	fake.getAllTransactionsMutex.Lock()
-	ret, specificReturn := fake.getAllTransactionsReturnsOnCall[len(fake.getAllTransactionsArgsForCall)]
-	fake.getAllTransactionsArgsForCall = append(fake.getAllTransactionsArgsForCall, struct {
+func (fake *Repository) SaveBlock(arg1 context.Context, arg2 repository.Block) error {
+	fake.saveBlockMutex.Lock()
+	ret, specificReturn := fake.saveBlockReturnsOnCall[len(fake.saveBlockArgsForCall)]
+	fake.saveBlockArgsForCall = append(fake.saveBlockArgsForCall, struct {
 		arg1 context.Context
-	}{arg1})
-	stub := fake.GetAllTransactionsStub
-	fakeReturns := fake.getAllTransactionsReturns
-	fake.recordInvocation("GetAllTransactions", []interface{}{arg1})
-	fake.getAllTransactionsMutex.Unlock()
+		arg2 repository.Block
+	}{arg1, arg2})
+	stub := fake.SaveBlockStub
+	fakeReturns := fake.saveBlockReturns
+	fake.recordInvocation("SaveBlock", []interface{}{arg1, arg2})
+	fake.saveBlockMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
-		return ret.result1, ret.result2
+		return ret.result1
 	}
-	return fakeReturns.result1, fakeReturns.result2
+	return fakeReturns.result1
 }
 
-func (fake *Repository) GetAllTransactionsCallCount() int {
-	fake.getAllTransactionsMutex.RLock()
-	defer fake.getAllTransactionsMutex.RUnlock()
-	return len(fake.getAllTransactionsArgsForCall)
+func (fake *Repository) SaveBlockCallCount() int {
+	fake.saveBlockMutex.RLock()
+	defer fake.saveBlockMutex.RUnlock()
+	return len(fake.saveBlockArgsForCall)
 }
 
-func (fake *Repository) GetAllTransactionsCalls(stub func(context.Context) ([]repository.Transaction, error)) {
-	fake.getAllTransactionsMutex.Lock()
-	defer fake.getAllTransactionsMutex.Unlock()
-	fake.GetAllTransactionsStub = stub
+func (fake *Repository) SaveBlockCalls(stub func(context.Context, repository.Block) error) {
+	fake.saveBlockMutex.Lock()
+	defer fake.saveBlockMutex.Unlock()
+	fake.SaveBlockStub = stub
 }
 
-func (fake *Repository) GetAllTransactionsArgsForCall(i int) context.Context {
-	fake.getAllTransactionsMutex.RLock()
-	defer fake.getAllTransactionsMutex.RUnlock()
-	argsForCall := fake.getAllTransactionsArgsForCall[i]
-	return argsForCall.arg1
+func (fake *Repository) SaveBlockArgsForCall(i int) (context.Context, repository.Block) {
+	fake.saveBlockMutex.RLock()
+	defer fake.saveBlockMutex.RUnlock()
+	argsForCall := fake.saveBlockArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *Repository) GetAllTransactionsReturns(result1 []repository.Transaction, result2 error) {
-	fake.getAllTransactionsMutex.Lock()
-	defer fake.getAllTransactionsMutex.Unlock()
-	fake.GetAllTransactionsStub = nil
-	fake.getAllTransactionsReturns = struct {
-		result1 []repository.Transaction
-		result2 error
-	}{result1, result2}
+func (fake *Repository) SaveBlockReturns(result1 error) {
+	fake.saveBlockMutex.Lock()
+	defer fake.saveBlockMutex.Unlock()
+	fake.SaveBlockStub = nil
+	fake.saveBlockReturns = struct {
+		result1 error
+	}{result1}
 }
 
-func (fake *Repository) GetAllTransactionsReturnsOnCall(i int, result1 []repository.Transaction, result2 error) {
-	fake.getAllTransactionsMutex.Lock()
-	defer fake.getAllTransactionsMutex.Unlock()
-	fake.GetAllTransactionsStub = nil
-	if fake.getAllTransactionsReturnsOnCall == nil {
-		fake.getAllTransactionsReturnsOnCall = make(map[int]struct {
-			result1 []repository.Transaction
-			result2 error
+func (fake *Repository) SaveBlockReturnsOnCall(i int, result1 error) {
+	fake.saveBlockMutex.Lock()
+	defer fake.saveBlockMutex.Unlock()
+	fake.SaveBlockStub = nil
+	if fake.saveBlockReturnsOnCall == nil {
+		fake.saveBlockReturnsOnCall = make(map[int]struct {
+			result1 error
 		})
 	}
-	fake.getAllTransactionsReturnsOnCall[i] = struct {
-		result1 []repository.Transaction
-		result2 error
-	}{result1, result2}
+	fake.saveBlockReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
 }
 
-func (fake *Repository) GetTransactionsByHash(arg1 context.Context, arg2 []string) ([]repository.Transaction, error) {
-	var arg2Copy []string
+func (fake *Repository) SaveCallTraces(arg1 context.Context, arg2 []repository.CallTrace) error {
+	var arg2Copy []repository.CallTrace
 	if arg2 != nil {
-		arg2Copy = make([]string, len(arg2))
+		arg2Copy = make([]repository.CallTrace, len(arg2))
 		copy(arg2Copy, arg2)
 	}
-	fake.getTransactionsByHashMutex.Lock()
-	ret, specificReturn := fake.getTransactionsByHashReturnsOnCall[len(fake.getTransactionsByHashArgsForCall)]
-	fake.getTransactionsByHashArgsForCall = append(fake.getTransactionsByHashArgsForCall, struct {
+	fake.saveCallTracesMutex.Lock()
+	ret, specificReturn := fake.saveCallTracesReturnsOnCall[len(fake.saveCallTracesArgsForCall)]
+	fake.saveCallTracesArgsForCall = append(fake.saveCallTracesArgsForCall, struct {
 		arg1 context.Context
-		arg2 []string
+		arg2 []repository.CallTrace
 	}{arg1, arg2Copy})
-	stub := fake.GetTransactionsByHashStub
-	fakeReturns := fake.getTransactionsByHashReturns
-	fake.recordInvocation("GetTransactionsByHash", []interface{}{arg1, arg2Copy})
-	fake.getTransactionsByHashMutex.Unlock()
+	stub := fake.SaveCallTracesStub
+	fakeReturns := fake.saveCallTracesReturns
+	fake.recordInvocation("SaveCallTraces", []interface{}{arg1, arg2Copy})
+	fake.saveCallTracesMutex.Unlock()
 	if stub != nil {
 		return stub(arg1, arg2)
 	}
 	if specificReturn {
-		return ret.result1, ret.result2
+		return ret.result1
 	}
-	return fakeReturns.result1, fakeReturns.result2
+	return fakeReturns.result1
 }
 
-func (fake *Repository) GetTransactionsByHashCallCount() int {
-	fake.getTransactionsByHashMutex.RLock()
-	defer fake.getTransactionsByHashMutex.RUnlock()
-	return len(fake.getTransactionsByHashArgsForCall)
+func (fake *Repository) SaveCallTracesCallCount() int {
+	fake.saveCallTracesMutex.RLock()
+	defer fake.saveCallTracesMutex.RUnlock()
+	return len(fake.saveCallTracesArgsForCall)
 }
 
-func (fake *Repository) GetTransactionsByHashCalls(stub func(context.Context, []string) ([]repository.Transaction, error)) {
-	fake.getTransactionsByHashMutex.Lock()
-	defer fake.getTransactionsByHashMutex.Unlock()
-	fake.GetTransactionsByHashStub = stub
+func (fake *Repository) SaveCallTracesCalls(stub func(context.Context, []repository.CallTrace) error) {
+	fake.saveCallTracesMutex.Lock()
+	defer fake.saveCallTracesMutex.Unlock()
+	fake.SaveCallTracesStub = stub
 }
 
-func (fake *Repository) GetTransactionsByHashArgsForCall(i int) (context.Context, []string) {
-	fake.getTransactionsByHashMutex.RLock()
-	defer fake.getTransactionsByHashMutex.RUnlock()
-	argsForCall := fake.getTransactionsByHashArgsForCall[i]
+func (fake *Repository) SaveCallTracesArgsForCall(i int) (context.Context, []repository.CallTrace) {
+	fake.saveCallTracesMutex.RLock()
+	defer fake.saveCallTracesMutex.RUnlock()
+	argsForCall := fake.saveCallTracesArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *Repository) GetTransactionsByHashReturns(result1 []repository.Transaction, result2 error) {
-	fake.getTransactionsByHashMutex.Lock()
-	defer fake.getTransactionsByHashMutex.Unlock()
-	fake.GetTransactionsByHashStub = nil
-	fake.getTransactionsByHashReturns = struct {
-		result1 []repository.Transaction
-		result2 error
-	}{result1, result2}
+func (fake *Repository) SaveCallTracesReturns(result1 error) {
+	fake.saveCallTracesMutex.Lock()
+	defer fake.saveCallTracesMutex.Unlock()
+	fake.SaveCallTracesStub = nil
+	fake.saveCallTracesReturns = struct {
+		result1 error
+	}{result1}
 }
 
-func (fake *Repository) GetTransactionsByHashReturnsOnCall(i int, result1 []repository.Transaction, result2 error) {
-	fake.getTransactionsByHashMutex.Lock()
-	defer fake.getTransactionsByHashMutex.Unlock()
-	fake.GetTransactionsByHashStub = nil
-	if fake.getTransactionsByHashReturnsOnCall == nil {
-		fake.getTransactionsByHashReturnsOnCall = make(map[int]struct {
-			result1 []repository.Transaction
-			result2 error
+func (fake *Repository) SaveCallTracesReturnsOnCall(i int, result1 error) {
+	fake.saveCallTracesMutex.Lock()
+	defer fake.saveCallTracesMutex.Unlock()
+	fake.SaveCallTracesStub = nil
+	if fake.saveCallTracesReturnsOnCall == nil {
+		fake.saveCallTracesReturnsOnCall = make(map[int]struct {
+			result1 error
 		})
 	}
-	fake.getTransactionsByHashReturnsOnCall[i] = struct {
-		result1 []repository.Transaction
-		result2 error
-	}{result1, result2}
+	fake.saveCallTracesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
 }
 
-func (fake *Repository) GetUserFromDB(arg1 context.Context, arg2 string) (repository.User, error) {
-	fake.getUserFromDBMutex.Lock()
-	ret, specificReturn := fake.getUserFromDBReturnsOnCall[len(fake.getUserFromDBArgsForCall)]
-	fake.getUserFromDBArgsForCall = append(fake.getUserFromDBArgsForCall, struct {
+func (fake *Repository) SaveContractABI(arg1 context.Context, arg2 repository.ContractABI) error {
+	fake.saveContractABIMutex.Lock()
+	ret, specificReturn := fake.saveContractABIReturnsOnCall[len(fake.saveContractABIArgsForCall)]
+	fake.saveContractABIArgsForCall = append(fake.saveContractABIArgsForCall, struct {
 		arg1 context.Context
-		arg2 string
+		arg2 repository.ContractABI
 	}{arg1, arg2})
-	stub := fake.GetUserFromDBStub
-	fakeReturns := fake.getUserFromDBReturns
-	fake.recordInvocation("GetUserFromDB", []interface{}{arg1, arg2})
-	fake.getUserFromDBMutex.Unlock()
+	stub := fake.SaveContractABIStub
+	fakeReturns := fake.saveContractABIReturns
+	fake.recordInvocation("SaveContractABI", []interface{}{arg1, arg2})
+	fake.saveContractABIMutex.Unlock()
 	if stub != nil {
 		return stub(arg1, arg2)
 	}
 	if specificReturn {
-		return ret.result1, ret.result2
+		return ret.result1
 	}
-	return fakeReturns.result1, fakeReturns.result2
+	return fakeReturns.result1
 }
 
-func (fake *Repository) GetUserFromDBCallCount() int {
-	fake.getUserFromDBMutex.RLock()
-	defer fake.getUserFromDBMutex.RUnlock()
-	return len(fake.getUserFromDBArgsForCall)
+func (fake *Repository) SaveContractABICallCount() int {
+	fake.saveContractABIMutex.RLock()
+	defer fake.saveContractABIMutex.RUnlock()
+	return len(fake.saveContractABIArgsForCall)
 }
 
-func (fake *Repository) GetUserFromDBCalls(stub func(context.Context, string) (repository.User, error)) {
-	fake.getUserFromDBMutex.Lock()
-	defer fake.getUserFromDBMutex.Unlock()
-	fake.GetUserFromDBStub = stub
+func (fake *Repository) SaveContractABICalls(stub func(context.Context, repository.ContractABI) error) {
+	fake.saveContractABIMutex.Lock()
+	defer fake.saveContractABIMutex.Unlock()
+	fake.SaveContractABIStub = stub
 }
 
-func (fake *Repository) GetUserFromDBArgsForCall(i int) (context.Context, string) {
-	fake.getUserFromDBMutex.RLock()
-	defer fake.getUserFromDBMutex.RUnlock()
-	argsForCall := fake.getUserFromDBArgsForCall[i]
+func (fake *Repository) SaveContractABIArgsForCall(i int) (context.Context, repository.ContractABI) {
+	fake.saveContractABIMutex.RLock()
+	defer fake.saveContractABIMutex.RUnlock()
+	argsForCall := fake.saveContractABIArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *Repository) GetUserFromDBReturns(result1 repository.User, result2 error) {
-	fake.getUserFromDBMutex.Lock()
-	defer fake.getUserFromDBMutex.Unlock()
-	fake.GetUserFromDBStub = nil
-	fake.getUserFromDBReturns = struct {
-		result1 repository.User
-		result2 error
-	}{result1, result2}
+func (fake *Repository) SaveContractABIReturns(result1 error) {
+	fake.saveContractABIMutex.Lock()
+	defer fake.saveContractABIMutex.Unlock()
+	fake.SaveContractABIStub = nil
+	fake.saveContractABIReturns = struct {
+		result1 error
+	}{result1}
 }
 
-func (fake *Repository) GetUserFromDBReturnsOnCall(i int, result1 repository.User, result2 error) {
-	fake.getUserFromDBMutex.Lock()
-	defer fake.getUserFromDBMutex.Unlock()
-	fake.GetUserFromDBStub = nil
-	if fake.getUserFromDBReturnsOnCall == nil {
-		fake.getUserFromDBReturnsOnCall = make(map[int]struct {
-			result1 repository.User
-			result2 error
+func (fake *Repository) SaveContractABIReturnsOnCall(i int, result1 error) {
+	fake.saveContractABIMutex.Lock()
+	defer fake.saveContractABIMutex.Unlock()
+	fake.SaveContractABIStub = nil
+	if fake.saveContractABIReturnsOnCall == nil {
+		fake.saveContractABIReturnsOnCall = make(map[int]struct {
+			result1 error
 		})
 	}
-	fake.getUserFromDBReturnsOnCall[i] = struct {
-		result1 repository.User
-		result2 error
-	}{result1, result2}
+	fake.saveContractABIReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
 }
 
-func (fake *Repository) GetUserHistory(arg1 context.Context, arg2 string) ([]string, error) {
-	fake.getUserHistoryMutex.Lock()
-	ret, specificReturn := fake.getUserHistoryReturnsOnCall[len(fake.getUserHistoryArgsForCall)]
-	fake.getUserHistoryArgsForCall = append(fake.getUserHistoryArgsForCall, struct {
+func (fake *Repository) SaveTokenTransfers(arg1 context.Context, arg2 []repository.TokenTransfer) error {
+	var arg2Copy []repository.TokenTransfer
+	if arg2 != nil {
+		arg2Copy = make([]repository.TokenTransfer, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.saveTokenTransfersMutex.Lock()
+	ret, specificReturn := fake.saveTokenTransfersReturnsOnCall[len(fake.saveTokenTransfersArgsForCall)]
+	fake.saveTokenTransfersArgsForCall = append(fake.saveTokenTransfersArgsForCall, struct {
 		arg1 context.Context
-		arg2 string
-	}{arg1, arg2})
-	stub := fake.GetUserHistoryStub
-	fakeReturns := fake.getUserHistoryReturns
-	fake.recordInvocation("GetUserHistory", []interface{}{arg1, arg2})
-	fake.getUserHistoryMutex.Unlock()
+		arg2 []repository.TokenTransfer
+	}{arg1, arg2Copy})
+	stub := fake.SaveTokenTransfersStub
+	fakeReturns := fake.saveTokenTransfersReturns
+	fake.recordInvocation("SaveTokenTransfers", []interface{}{arg1, arg2Copy})
+	fake.saveTokenTransfersMutex.Unlock()
 	if stub != nil {
 		return stub(arg1, arg2)
 	}
 	if specificReturn {
-		return ret.result1, ret.result2
+		return ret.result1
 	}
-	return fakeReturns.result1, fakeReturns.result2
+	return fakeReturns.result1
 }
 
-func (fake *Repository) GetUserHistoryCallCount() int {
-	fake.getUserHistoryMutex.RLock()
-	defer fake.getUserHistoryMutex.RUnlock()
-	return len(fake.getUserHistoryArgsForCall)
+func (fake *Repository) SaveTokenTransfersCallCount() int {
+	fake.saveTokenTransfersMutex.RLock()
+	defer fake.saveTokenTransfersMutex.RUnlock()
+	return len(fake.saveTokenTransfersArgsForCall)
 }
 
-func (fake *Repository) GetUserHistoryCalls(stub func(context.Context, string) ([]string, error)) {
-	fake.getUserHistoryMutex.Lock()
-	defer fake.getUserHistoryMutex.Unlock()
-	fake.GetUserHistoryStub = stub
+func (fake *Repository) SaveTokenTransfersCalls(stub func(context.Context, []repository.TokenTransfer) error) {
+	fake.saveTokenTransfersMutex.Lock()
+	defer fake.saveTokenTransfersMutex.Unlock()
+	fake.SaveTokenTransfersStub = stub
 }
 
-func (fake *Repository) GetUserHistoryArgsForCall(i int) (context.Context, string) {
-	fake.getUserHistoryMutex.RLock()
-	defer fake.getUserHistoryMutex.RUnlock()
-	argsForCall := fake.getUserHistoryArgsForCall[i]
+func (fake *Repository) SaveTokenTransfersArgsForCall(i int) (context.Context, []repository.TokenTransfer) {
+	fake.saveTokenTransfersMutex.RLock()
+	defer fake.saveTokenTransfersMutex.RUnlock()
+	argsForCall := fake.saveTokenTransfersArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *Repository) GetUserHistoryReturns(result1 []string, result2 error) {
-	fake.getUserHistoryMutex.Lock()
-	defer fake.getUserHistoryMutex.Unlock()
-	fake.GetUserHistoryStub = nil
-	fake.getUserHistoryReturns = struct {
-		result1 []string
-		result2 error
-	}{result1, result2}
+func (fake *Repository) SaveTokenTransfersReturns(result1 error) {
+	fake.saveTokenTransfersMutex.Lock()
+	defer fake.saveTokenTransfersMutex.Unlock()
+	fake.SaveTokenTransfersStub = nil
+	fake.saveTokenTransfersReturns = struct {
+		result1 error
+	}{result1}
 }
 
-func (fake *Repository) GetUserHistoryReturnsOnCall(i int, result1 []string, result2 error) {
-	fake.getUserHistoryMutex.Lock()
-	defer fake.getUserHistoryMutex.Unlock()
-	fake.GetUserHistoryStub = nil
-	if fake.getUserHistoryReturnsOnCall == nil {
-		fake.getUserHistoryReturnsOnCall = make(map[int]struct {
-			result1 []string
-			result2 error
+func (fake *Repository) SaveTokenTransfersReturnsOnCall(i int, result1 error) {
+	fake.saveTokenTransfersMutex.Lock()
+	defer fake.saveTokenTransfersMutex.Unlock()
+	fake.SaveTokenTransfersStub = nil
+	if fake.saveTokenTransfersReturnsOnCall == nil {
+		fake.saveTokenTransfersReturnsOnCall = make(map[int]struct {
+			result1 error
 		})
 	}
-	fake.getUserHistoryReturnsOnCall[i] = struct {
-		result1 []string
-		result2 error
-	}{result1, result2}
+	fake.saveTokenTransfersReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Repository) SaveTransactionLogs(arg1 context.Context, arg2 []repository.TransactionLog) error {
+	var arg2Copy []repository.TransactionLog
+	if arg2 != nil {
+		arg2Copy = make([]repository.TransactionLog, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.saveTransactionLogsMutex.Lock()
+	ret, specificReturn := fake.saveTransactionLogsReturnsOnCall[len(fake.saveTransactionLogsArgsForCall)]
+	fake.saveTransactionLogsArgsForCall = append(fake.saveTransactionLogsArgsForCall, struct {
+		arg1 context.Context
+		arg2 []repository.TransactionLog
+	}{arg1, arg2Copy})
+	stub := fake.SaveTransactionLogsStub
+	fakeReturns := fake.saveTransactionLogsReturns
+	fake.recordInvocation("SaveTransactionLogs", []interface{}{arg1, arg2Copy})
+	fake.saveTransactionLogsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Repository) SaveTransactionLogsCallCount() int {
+	fake.saveTransactionLogsMutex.RLock()
+	defer fake.saveTransactionLogsMutex.RUnlock()
+	return len(fake.saveTransactionLogsArgsForCall)
+}
+
+func (fake *Repository) SaveTransactionLogsCalls(stub func(context.Context, []repository.TransactionLog) error) {
+	fake.saveTransactionLogsMutex.Lock()
+	defer fake.saveTransactionLogsMutex.Unlock()
+	fake.SaveTransactionLogsStub = stub
+}
+
+func (fake *Repository) SaveTransactionLogsArgsForCall(i int) (context.Context, []repository.TransactionLog) {
+	fake.saveTransactionLogsMutex.RLock()
+	defer fake.saveTransactionLogsMutex.RUnlock()
+	argsForCall := fake.saveTransactionLogsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Repository) SaveTransactionLogsReturns(result1 error) {
+	fake.saveTransactionLogsMutex.Lock()
+	defer fake.saveTransactionLogsMutex.Unlock()
+	fake.SaveTransactionLogsStub = nil
+	fake.saveTransactionLogsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Repository) SaveTransactionLogsReturnsOnCall(i int, result1 error) {
+	fake.saveTransactionLogsMutex.Lock()
+	defer fake.saveTransactionLogsMutex.Unlock()
+	fake.SaveTransactionLogsStub = nil
+	if fake.saveTransactionLogsReturnsOnCall == nil {
+		fake.saveTransactionLogsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveTransactionLogsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
 }
 
 func (fake *Repository) SaveTransactions(arg1 context.Context, arg2 []repository.Transaction) error {
@@ -492,21 +2262,129 @@ func (fake *Repository) SaveUserHistoryReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *Repository) UpdateTransaction(arg1 context.Context, arg2 repository.Transaction) error {
+	fake.updateTransactionMutex.Lock()
+	ret, specificReturn := fake.updateTransactionReturnsOnCall[len(fake.updateTransactionArgsForCall)]
+	fake.updateTransactionArgsForCall = append(fake.updateTransactionArgsForCall, struct {
+		arg1 context.Context
+		arg2 repository.Transaction
+	}{arg1, arg2})
+	stub := fake.UpdateTransactionStub
+	fakeReturns := fake.updateTransactionReturns
+	fake.recordInvocation("UpdateTransaction", []interface{}{arg1, arg2})
+	fake.updateTransactionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Repository) UpdateTransactionCallCount() int {
+	fake.updateTransactionMutex.RLock()
+	defer fake.updateTransactionMutex.RUnlock()
+	return len(fake.updateTransactionArgsForCall)
+}
+
+func (fake *Repository) UpdateTransactionCalls(stub func(context.Context, repository.Transaction) error) {
+	fake.updateTransactionMutex.Lock()
+	defer fake.updateTransactionMutex.Unlock()
+	fake.UpdateTransactionStub = stub
+}
+
+func (fake *Repository) UpdateTransactionArgsForCall(i int) (context.Context, repository.Transaction) {
+	fake.updateTransactionMutex.RLock()
+	defer fake.updateTransactionMutex.RUnlock()
+	argsForCall := fake.updateTransactionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Repository) UpdateTransactionReturns(result1 error) {
+	fake.updateTransactionMutex.Lock()
+	defer fake.updateTransactionMutex.Unlock()
+	fake.UpdateTransactionStub = nil
+	fake.updateTransactionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Repository) UpdateTransactionReturnsOnCall(i int, result1 error) {
+	fake.updateTransactionMutex.Lock()
+	defer fake.updateTransactionMutex.Unlock()
+	fake.UpdateTransactionStub = nil
+	if fake.updateTransactionReturnsOnCall == nil {
+		fake.updateTransactionReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateTransactionReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *Repository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
-	fake.getAllTransactionsMutex.RLock()
-	defer fake.getAllTransactionsMutex.RUnlock()
+	fake.clearUserHistoryMutex.RLock()
+	defer fake.clearUserHistoryMutex.RUnlock()
+	fake.deleteTransactionsMutex.RLock()
+	defer fake.deleteTransactionsMutex.RUnlock()
+	fake.deleteUserHistoryMutex.RLock()
+	defer fake.deleteUserHistoryMutex.RUnlock()
+	fake.findAddressTransactionsMutex.RLock()
+	defer fake.findAddressTransactionsMutex.RUnlock()
+	fake.findLogsMutex.RLock()
+	defer fake.findLogsMutex.RUnlock()
+	fake.findTokenTransfersMutex.RLock()
+	defer fake.findTokenTransfersMutex.RUnlock()
+	fake.findTransactionsMutex.RLock()
+	defer fake.findTransactionsMutex.RUnlock()
+	fake.getBlockByHashMutex.RLock()
+	defer fake.getBlockByHashMutex.RUnlock()
+	fake.getBlockByNumberMutex.RLock()
+	defer fake.getBlockByNumberMutex.RUnlock()
+	fake.getCallTracesMutex.RLock()
+	defer fake.getCallTracesMutex.RUnlock()
+	fake.getContractABIsMutex.RLock()
+	defer fake.getContractABIsMutex.RUnlock()
+	fake.getIncompleteTransactionsMutex.RLock()
+	defer fake.getIncompleteTransactionsMutex.RUnlock()
+	fake.getTentativeTransactionsMutex.RLock()
+	defer fake.getTentativeTransactionsMutex.RUnlock()
+	fake.getTokenTransfersMutex.RLock()
+	defer fake.getTokenTransfersMutex.RUnlock()
+	fake.getTransactionLogsMutex.RLock()
+	defer fake.getTransactionLogsMutex.RUnlock()
 	fake.getTransactionsByHashMutex.RLock()
 	defer fake.getTransactionsByHashMutex.RUnlock()
 	fake.getUserFromDBMutex.RLock()
 	defer fake.getUserFromDBMutex.RUnlock()
 	fake.getUserHistoryMutex.RLock()
 	defer fake.getUserHistoryMutex.RUnlock()
+	fake.replaceTokenTransfersMutex.RLock()
+	defer fake.replaceTokenTransfersMutex.RUnlock()
+	fake.replaceTransactionMutex.RLock()
+	defer fake.replaceTransactionMutex.RUnlock()
+	fake.replaceTransactionLogsMutex.RLock()
+	defer fake.replaceTransactionLogsMutex.RUnlock()
+	fake.saveBlockMutex.RLock()
+	defer fake.saveBlockMutex.RUnlock()
+	fake.saveCallTracesMutex.RLock()
+	defer fake.saveCallTracesMutex.RUnlock()
+	fake.saveContractABIMutex.RLock()
+	defer fake.saveContractABIMutex.RUnlock()
+	fake.saveTokenTransfersMutex.RLock()
+	defer fake.saveTokenTransfersMutex.RUnlock()
+	fake.saveTransactionLogsMutex.RLock()
+	defer fake.saveTransactionLogsMutex.RUnlock()
 	fake.saveTransactionsMutex.RLock()
 	defer fake.saveTransactionsMutex.RUnlock()
 	fake.saveUserHistoryMutex.RLock()
 	defer fake.saveUserHistoryMutex.RUnlock()
+	fake.updateTransactionMutex.RLock()
+	defer fake.updateTransactionMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,113 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"fethcher/internal/core"
+	"sync"
+)
+
+type WriteQueue struct {
+	EnqueueStub        func(string, func(ctx context.Context) error) error
+	enqueueMutex       sync.RWMutex
+	enqueueArgsForCall []struct {
+		arg1 string
+		arg2 func(ctx context.Context) error
+	}
+	enqueueReturns struct {
+		result1 error
+	}
+	enqueueReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *WriteQueue) Enqueue(arg1 string, arg2 func(ctx context.Context) error) error {
+	fake.enqueueMutex.Lock()
+	ret, specificReturn := fake.enqueueReturnsOnCall[len(fake.enqueueArgsForCall)]
+	fake.enqueueArgsForCall = append(fake.enqueueArgsForCall, struct {
+		arg1 string
+		arg2 func(ctx context.Context) error
+	}{arg1, arg2})
+	stub := fake.EnqueueStub
+	fakeReturns := fake.enqueueReturns
+	fake.recordInvocation("Enqueue", []interface{}{arg1, arg2})
+	fake.enqueueMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *WriteQueue) EnqueueCallCount() int {
+	fake.enqueueMutex.RLock()
+	defer fake.enqueueMutex.RUnlock()
+	return len(fake.enqueueArgsForCall)
+}
+
+func (fake *WriteQueue) EnqueueCalls(stub func(string, func(ctx context.Context) error) error) {
+	fake.enqueueMutex.Lock()
+	defer fake.enqueueMutex.Unlock()
+	fake.EnqueueStub = stub
+}
+
+func (fake *WriteQueue) EnqueueArgsForCall(i int) (string, func(ctx context.Context) error) {
+	fake.enqueueMutex.RLock()
+	defer fake.enqueueMutex.RUnlock()
+	argsForCall := fake.enqueueArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *WriteQueue) EnqueueReturns(result1 error) {
+	fake.enqueueMutex.Lock()
+	defer fake.enqueueMutex.Unlock()
+	fake.EnqueueStub = nil
+	fake.enqueueReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *WriteQueue) EnqueueReturnsOnCall(i int, result1 error) {
+	fake.enqueueMutex.Lock()
+	defer fake.enqueueMutex.Unlock()
+	fake.EnqueueStub = nil
+	if fake.enqueueReturnsOnCall == nil {
+		fake.enqueueReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.enqueueReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *WriteQueue) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.enqueueMutex.RLock()
+	defer fake.enqueueMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *WriteQueue) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ core.WriteQueue = new(WriteQueue)
@@ -0,0 +1,78 @@
+package core
+
+import (
+	"context"
+	"fmt"
+)
+
+// IndexBlock caches every transaction of the canonical block with the given number that is not cached yet, together with its
+// logs and token transfers, in a single bulk write. The block itself is cached once it reached the confirmation depth. It
+// returns how many transactions were cached and fails without caching anything when one of them cannot be fetched, so that the
+// block can be retried as a whole.
+func (f *Fethcher) IndexBlock(ctx context.Context, number uint64) (int, error) {
+	block, err := f.ethService.FetchBlockByNumber(ctx, number)
+	if err != nil {
+		return 0, fmt.Errorf("fetch block %d: %w", number, err)
+	}
+
+	missing := block.TransactionHashes
+	if len(missing) > 0 {
+		cached, err := f.repo.GetTransactionsByHash(ctx, block.TransactionHashes)
+		if err != nil {
+			return 0, fmt.Errorf("get cached transactions of block %d: %w", number, err)
+		}
+
+		cachedSet := make(map[string]struct{}, len(cached))
+		for _, tx := range cached {
+			cachedSet[tx.TransactionHash] = struct{}{}
+		}
+
+		missing = make([]string, 0, len(block.TransactionHashes))
+		for _, hash := range block.TransactionHashes {
+			if _, ok := cachedSet[hash]; !ok {
+				missing = append(missing, hash)
+			}
+		}
+	}
+
+	if len(missing) > 0 {
+		records := make([]TransactionRecord, 0, len(missing))
+		for _, result := range f.getTransactionsFromNode(ctx, missing) {
+			if result.Transaction == nil {
+				return 0, fmt.Errorf("fetch transaction %s of block %d: %s: %s", result.TransactionHash, number, result.Status, result.Reason)
+			}
+			records = append(records, *result.Transaction)
+		}
+
+		if err := f.saveTransactionsToDB(ctx, records); err != nil {
+			return 0, fmt.Errorf("save transactions of block %d: %w", number, err)
+		}
+	}
+
+	if block.Confirmations >= f.confirmationDepth {
+		if err := f.repo.SaveBlock(ctx, recordToBlock(blockToRecord(block))); err != nil {
+			return 0, fmt.Errorf("save block %d: %w", number, err)
+		}
+	}
+
+	return len(missing), nil
+}
+
+// FinalizedBlockNumber returns the number of the latest block that reached the confirmation depth, or 0 while the chain is
+// shorter than the confirmation depth.
+func (f *Fethcher) FinalizedBlockNumber(ctx context.Context) (uint64, error) {
+	head, err := f.ethService.FetchHeadNumber(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("fetch chain head: %w", err)
+	}
+
+	switch {
+	case f.confirmationDepth == 0:
+		return head, nil
+	case head+1 < f.confirmationDepth:
+		return 0, nil
+	default:
+		// the head itself has one confirmation
+		return head + 1 - f.confirmationDepth, nil
+	}
+}
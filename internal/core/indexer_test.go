@@ -0,0 +1,148 @@
+package core_test
+
+import (
+	"context"
+	"errors"
+	"fethcher/internal/core"
+	"fethcher/internal/core/fake"
+	"fethcher/internal/ethereum"
+	"fethcher/internal/repository"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+)
+
+var _ = Describe("Indexer", func() {
+	var (
+		fakeRepo *fake.Repository
+		fakeEth  *fake.EthereumService
+		ctx      context.Context
+		fetcher  *core.Fethcher
+		fakeErr  error
+	)
+
+	BeforeEach(func() {
+		fakeRepo = new(fake.Repository)
+		fakeEth = new(fake.EthereumService)
+		fakeEth.ReplayTransactionReturns(&ethereum.Revert{Reproduced: true, Message: "execution reverted", Data: "0x"}, nil)
+		ctx = context.Background()
+		fakeErr = errors.New("fake error")
+
+		fetcher = core.NewFethcher(zap.NewNop().Sugar(), fakeRepo, new(fake.JWTIssuer), fakeEth, new(fake.ABIRegistry), new(fake.WriteQueue), 12)
+	})
+
+	Describe("IndexBlock", func() {
+		var (
+			indexed int
+			err     error
+		)
+
+		BeforeEach(func() {
+			fakeEth.FetchBlockByNumberReturns(&ethereum.Block{
+				Number:            100,
+				Hash:              "0xb1",
+				TransactionHashes: []string{"0x1", "0x2", "0x3"},
+				Confirmations:     12,
+			}, nil)
+			fakeRepo.GetTransactionsByHashReturns([]repository.Transaction{{TransactionHash: "0x2"}}, nil)
+			fakeEth.FetchTransactionsReturns([]*ethereum.TxResult{
+				{Hash: "0x1", Transaction: &ethereum.Transaction{TransactionHash: "0x1", BlockNumber: 100}},
+				{Hash: "0x3", Transaction: &ethereum.Transaction{TransactionHash: "0x3", BlockNumber: 100}},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			indexed, err = fetcher.IndexBlock(ctx, 100)
+		})
+
+		It("caches the transactions that are not cached yet in one write", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(indexed).To(Equal(2))
+			_, hashes := fakeEth.FetchTransactionsArgsForCall(0)
+			Expect(hashes).To(Equal([]string{"0x1", "0x3"}))
+			Expect(fakeRepo.SaveTransactionsCallCount()).To(Equal(1))
+			_, saved := fakeRepo.SaveTransactionsArgsForCall(0)
+			Expect(saved).To(HaveLen(2))
+		})
+
+		It("caches the final block", func() {
+			Expect(fakeRepo.SaveBlockCallCount()).To(Equal(1))
+			_, block := fakeRepo.SaveBlockArgsForCall(0)
+			Expect(block.Hash).To(Equal("0xb1"))
+		})
+
+		When("every transaction is cached already", func() {
+			BeforeEach(func() {
+				fakeRepo.GetTransactionsByHashReturns([]repository.Transaction{
+					{TransactionHash: "0x1"}, {TransactionHash: "0x2"}, {TransactionHash: "0x3"},
+				}, nil)
+			})
+
+			It("does not ask the node for them", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(indexed).To(Equal(0))
+				Expect(fakeEth.FetchTransactionsCallCount()).To(Equal(0))
+				Expect(fakeRepo.SaveTransactionsCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the block is not final yet", func() {
+			BeforeEach(func() {
+				fakeEth.FetchBlockByNumberReturns(&ethereum.Block{Number: 100, Confirmations: 3}, nil)
+			})
+
+			It("does not cache the block", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeRepo.SaveBlockCallCount()).To(Equal(0))
+			})
+		})
+
+		When("a transaction cannot be fetched", func() {
+			BeforeEach(func() {
+				fakeEth.FetchTransactionsReturns([]*ethereum.TxResult{
+					{Hash: "0x1", Transaction: &ethereum.Transaction{TransactionHash: "0x1"}},
+					{Hash: "0x3", Error: fakeErr},
+				}, nil)
+			})
+
+			It("fails without caching anything", func() {
+				Expect(err).To(MatchError(ContainSubstring("0x3")))
+				Expect(fakeRepo.SaveTransactionsCallCount()).To(Equal(0))
+				Expect(fakeRepo.SaveBlockCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the block cannot be fetched", func() {
+			BeforeEach(func() {
+				fakeEth.FetchBlockByNumberReturns(nil, ethereum.ErrBlockNotFound)
+			})
+
+			It("returns the error", func() {
+				Expect(err).To(MatchError(ethereum.ErrBlockNotFound))
+			})
+		})
+	})
+
+	Describe("FinalizedBlockNumber", func() {
+		It("returns the latest block with enough confirmations", func() {
+			fakeEth.FetchHeadNumberReturns(100, nil)
+			finalized, err := fetcher.FinalizedBlockNumber(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(finalized).To(Equal(uint64(89)))
+		})
+
+		It("returns 0 while the chain is shorter than the confirmation depth", func() {
+			fakeEth.FetchHeadNumberReturns(5, nil)
+			finalized, err := fetcher.FinalizedBlockNumber(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(finalized).To(BeZero())
+		})
+
+		It("returns the error of the node", func() {
+			fakeEth.FetchHeadNumberReturns(0, fakeErr)
+			_, err := fetcher.FinalizedBlockNumber(ctx)
+			Expect(err).To(MatchError(fakeErr))
+		})
+	})
+})
@@ -1,16 +1,334 @@
 package core
 
+import (
+	"fethcher/internal/decoder"
+	"time"
+)
+
+// TransactionRecord is a transaction together with its receipt. Confirmations is the count at the time the transaction was
+// fetched from the node: the reconciler refreshes it while the transaction is tentative, but it is frozen once the transaction
+// is final, so a cached transaction reports the count it was cached with.
 type TransactionRecord struct {
-	TransactionHash   string  `gorm:"size:66;uniqueIndex;not null"`
-	TransactionStatus uint64  `gorm:"not null"`
-	BlockHash         string  `gorm:"size:66;not null"`
-	BlockNumber       uint64  `gorm:"not null;index"`
-	From              string  `gorm:"size:42;not null"`
-	To                *string `gorm:"size:42"`
-	ContractAddress   *string `gorm:"size:42"`
-	LogsCount         int     `gorm:"not null;default:0"`
-	Input             string  `gorm:"type:text;not null"`
-	Value             string  `gorm:"size:100;not null"`
+	TransactionHash   string `gorm:"size:66;uniqueIndex;not null"`
+	TransactionStatus uint64 `gorm:"not null"`
+	BlockHash         string `gorm:"size:66;not null"`
+	BlockNumber       uint64 `gorm:"not null;index"`
+	BlockTimestamp    *uint64
+	From              string           `gorm:"size:42;not null"`
+	To                *string          `gorm:"size:42"`
+	ContractAddress   *string          `gorm:"size:42"`
+	LogsCount         int              `gorm:"not null;default:0"`
+	Input             string           `gorm:"type:text;not null"`
+	Value             string           `gorm:"size:100;not null"`
+	Verified          bool             `gorm:"not null;default:false"`
+	Confirmations     uint64           `gorm:"not null;default:0"`
+	Tentative         bool             `gorm:"not null;default:false;index"`
+	Logs              []LogRecord      `gorm:"-" json:",omitempty"`
+	Transfers         []TransferRecord `gorm:"-" json:",omitempty"`
+	DecodedInput      *decoder.Call    `gorm:"-" json:",omitempty"`
+
+	// The type, gas and fee fields are nil for transactions cached before they were captured, until they are backfilled.
+	Type              *uint8
+	Nonce             *uint64
+	GasLimit          *uint64
+	GasUsed           *uint64
+	EffectiveGasPrice *string
+
+	// Type-specific sections, only set for the transaction types that have them.
+	Legacy            *LegacyFees     `gorm:"-" json:",omitempty"`
+	DynamicFee        *DynamicFees    `gorm:"-" json:",omitempty"`
+	AccessList        []AccessTuple   `gorm:"-" json:",omitempty"`
+	Blob              *BlobFields     `gorm:"-" json:",omitempty"`
+	AuthorizationList []Authorization `gorm:"-" json:",omitempty"`
+
+	// L2 sections, only set on the chains that have them.
+	L1Fees  *L1Fees        `gorm:"-" json:",omitempty"`
+	Deposit *DepositFields `gorm:"-" json:",omitempty"`
+
+	// Cost is derived from the gas and fee fields and nil while they are unknown.
+	Cost *CostBreakdown `gorm:"-" json:",omitempty"`
+
+	// Failure is only set for failed transactions that were replayed when they were fetched.
+	Failure *Failure `gorm:"-" json:",omitempty"`
+}
+
+// BlockRecord is a block header together with the hashes of the transactions included in the block.
+type BlockRecord struct {
+	Number            uint64
+	Hash              string
+	ParentHash        string
+	Timestamp         uint64
+	Miner             string
+	BaseFee           *string
+	GasUsed           uint64
+	GasLimit          uint64
+	StateRoot         string
+	TransactionsRoot  string
+	ReceiptsRoot      string
+	TransactionHashes []string
+}
+
+// LegacyFees are the fees of legacy and EIP-2930 access list transactions.
+type LegacyFees struct {
+	GasPrice string
+}
+
+// DynamicFees are the EIP-1559 fees of dynamic fee, blob and set code transactions.
+type DynamicFees struct {
+	MaxFeePerGas         string
+	MaxPriorityFeePerGas string
+}
+
+// AccessTuple is an address and the storage slots of it that an EIP-2930 access list pre-warms.
+type AccessTuple struct {
+	Address     string
+	StorageKeys []string
+}
+
+// BlobFields are the EIP-4844 fields of blob transactions.
+type BlobFields struct {
+	BlobVersionedHashes []string
+	MaxFeePerBlobGas    string
+	BlobGasUsed         uint64
+	BlobGasPrice        string
+}
+
+// L1Fees are what L2 chains charge for posting a transaction to L1. OP-stack chains charge L1Fee on top of the execution fee,
+// computed from L1GasUsed, L1GasPrice and L1BlobBaseFee, while on Arbitrum chains GasUsedForL1 is part of the gas used and paid at
+// the effective gas price.
+type L1Fees struct {
+	L1Fee         *string `json:",omitempty"`
+	L1GasUsed     *uint64 `json:",omitempty"`
+	L1GasPrice    *string `json:",omitempty"`
+	L1BlobBaseFee *string `json:",omitempty"`
+	GasUsedForL1  *uint64 `json:",omitempty"`
+}
+
+// DepositFields tie an L2 system transaction, which is not signed by its sender, to the L1 transaction that requested it:
+// SourceHash and Mint for OP-stack deposits and RequestID for Arbitrum transactions.
+type DepositFields struct {
+	SourceHash *string `json:",omitempty"`
+	Mint       *string `json:",omitempty"`
+	RequestID  *string `json:",omitempty"`
+}
+
+// CostBreakdown is what a transaction cost its sender, in wei. ExecutionFee is the gas used for execution at the effective gas
+// price, L1Fee the cost of posting the transaction to L1 on L2 chains and BlobFee the cost of the blobs of blob transactions.
+// TotalFee is their sum.
+type CostBreakdown struct {
+	ExecutionFee string
+	L1Fee        string
+	BlobFee      string
+	TotalFee     string
+}
+
+// Failure explains why a transaction failed, as found by replaying it on the state of its parent block. Reproduced is false when
+// the replay succeeded, which happens when the transaction failed because of a transaction before it in its block. Otherwise
+// Message is the error the node reported, Data the revert data and Revert its decoding, which is nil when the transaction failed
+// without revert data, e.g. by running out of gas. Reason sums the failure up.
+type Failure struct {
+	Reproduced bool
+	Reason     string
+	Message    string          `json:",omitempty"`
+	Data       string          `json:",omitempty"`
+	Revert     *decoder.Revert `json:",omitempty"`
+}
+
+// Authorization is an EIP-7702 authorization of a set code transaction. Authority is nil when the signature is invalid.
+type Authorization struct {
+	ChainID   string
+	Address   string
+	Nonce     uint64
+	YParity   uint8
+	R         string
+	S         string
+	Authority *string `json:",omitempty"`
+}
+
+// LogRecord is an event emitted by a transaction.
+type LogRecord struct {
+	TransactionHash string
+	BlockNumber     uint64
+	LogIndex        uint
+	Address         string
+	Topics          []string
+	Data            string
+	Decoded         *decoder.Event `json:",omitempty"`
+}
+
+// TransferRecord is an ERC-20, ERC-721 or ERC-1155 token movement made by a transaction. TokenID is only set for ERC-721 and
+// ERC-1155 transfers.
+type TransferRecord struct {
+	TransactionHash string
+	BlockNumber     uint64
+	LogIndex        uint
+	BatchIndex      uint
+	Token           string
+	From            string
+	To              string
+	Amount          string
+	TokenID         *string `json:",omitempty"`
+	Standard        string
+}
+
+// TraceRecord is the call tree of a transaction, flattened depth first, together with the internal transfers of ether it made.
+// InternalValue is the total amount of wei that the internal transfers moved.
+type TraceRecord struct {
+	TransactionHash   string
+	Calls             []CallRecord
+	InternalTransfers []InternalTransfer
+	InternalValue     string
+}
+
+// CallRecord is a call made while executing a transaction. TraceAddress is the path of call indexes that leads to it from the
+// top-level call, and Reverted is set when the call or one of its callers failed, which undid its value transfer.
+type CallRecord struct {
+	TraceAddress []int
+	Type         string
+	From         string
+	To           string
+	Value        string
+	Gas          uint64
+	GasUsed      uint64
+	Input        string
+	Output       string
+	Error        string `json:",omitempty"`
+	Reverted     bool
+}
+
+// InternalTransfer is an amount of ether moved by a call that a contract made, as opposed to the top-level call of the
+// transaction.
+type InternalTransfer struct {
+	TraceAddress []int
+	From         string
+	To           string
+	Value        string
+}
+
+// IncludeOptions selects the optional sections that are returned together with every transaction.
+type IncludeOptions struct {
+	Logs      bool
+	Transfers bool
+}
+
+// LogFilter selects logs by the address of the contract that emitted them and/or their first topic.
+type LogFilter struct {
+	Address string
+	Topic0  string
+	Limit   int
+}
+
+// TransferFilter selects token transfers by token contract and/or holder, which matches both the sender and the recipient.
+type TransferFilter struct {
+	Token  string
+	Holder string
+	Limit  int
+}
+
+// Directions of the transactions of an address.
+const (
+	DirectionAll = "all"
+	DirectionIn  = "in"
+	DirectionOut = "out"
+)
+
+// TransactionFilter selects cached transactions; nil and empty fields match every transaction. Status is the receipt status, 1
+// for success and 0 for failure, ContractCreation selects contract creations when true and every other transaction when false,
+// MinValue is a decimal amount of wei and the block range bounds are inclusive. Transactions are returned newest first unless
+// Ascending is set, and Cursor is the NextCursor of the previous page.
+type TransactionFilter struct {
+	Status           *uint64
+	FromBlock        *uint64
+	ToBlock          *uint64
+	From             string
+	To               string
+	ContractCreation *bool
+	MinValue         string
+	Ascending        bool
+	Cursor           string
+	Limit            int
+}
+
+// AddressFilter selects the cached transactions that involve Address. Direction is DirectionOut for the transactions sent from
+// the address, DirectionIn for the ones sent to it or that created it and DirectionAll, or empty, for both. The block range
+// bounds are inclusive and Cursor is the NextCursor of the previous page.
+type AddressFilter struct {
+	Address   string
+	Direction string
+	FromBlock *uint64
+	ToBlock   *uint64
+	Cursor    string
+	Limit     int
+}
+
+// TransactionPage is a page of transactions. NextCursor is empty on the last page.
+type TransactionPage struct {
+	Transactions []TransactionRecord `json:"transactions"`
+	NextCursor   string              `json:"nextCursor,omitempty"`
+}
+
+// HistoryFilter selects the query history of a user, most recently queried first. The query time bounds are inclusive and
+// Cursor is the NextCursor of the previous page.
+type HistoryFilter struct {
+	QueriedFrom *time.Time
+	QueriedTo   *time.Time
+	Cursor      string
+	Limit       int
+}
+
+// HistoryEntry is a transaction of the query history of a user. QueriedAt is when the user last looked it up and QueryCount how
+// many times they did. Transaction is nil when the transaction is no longer cached.
+type HistoryEntry struct {
+	TransactionHash string             `json:"transactionHash"`
+	QueriedAt       time.Time          `json:"queriedAt"`
+	QueryCount      uint               `json:"queryCount"`
+	Transaction     *TransactionRecord `json:"transaction,omitempty"`
+}
+
+// HistoryPage is a page of the query history of a user. NextCursor is empty on the last page.
+type HistoryPage struct {
+	Entries    []HistoryEntry `json:"history"`
+	NextCursor string         `json:"nextCursor,omitempty"`
+}
+
+// Lookup statuses reported for every requested transaction hash. StatusDropped and StatusReplaced are reported for a
+// transaction that was seen pending and then left the mempool without being mined. Telling them apart is a heuristic:
+// StatusReplaced only means that the nonce of the sender at the latest block moved past the nonce of the transaction, which
+// is taken as another transaction with the same nonce having been mined, without looking that transaction up.
+const (
+	StatusCached   = "cached"
+	StatusFetched  = "fetched"
+	StatusNotFound = "not_found"
+	StatusPending  = "pending"
+	StatusDropped  = "dropped"
+	StatusReplaced = "replaced"
+	StatusError    = "error"
+)
+
+// TransactionResult is the outcome of looking up a single transaction hash. Transaction is only set when the status is
+// StatusCached or StatusFetched, Pending only when it is StatusPending and the node returned the transaction, and Reason only
+// when the transaction could not be returned.
+type TransactionResult struct {
+	TransactionHash string             `json:"transactionHash"`
+	Status          string             `json:"status"`
+	Reason          string             `json:"reason,omitempty"`
+	Transaction     *TransactionRecord `json:"transaction,omitempty"`
+	Pending         *PendingRecord     `json:"pending,omitempty"`
+}
+
+// PendingRecord is a transaction that is waiting in the mempool of the node. It only has the fields of the signed transaction,
+// the block and receipt fields are only known once it is mined.
+type PendingRecord struct {
+	TransactionHash string
+	From            string
+	To              *string `json:",omitempty"`
+	Input           string
+	Value           string
+	Type            uint8
+	Nonce           uint64
+	GasLimit        uint64
+	Legacy          *LegacyFees  `json:",omitempty"`
+	DynamicFee      *DynamicFees `json:",omitempty"`
 }
 
 type AuthMessage struct {
@@ -0,0 +1,209 @@
+package core
+
+import (
+	"context"
+	"fethcher/internal/ethereum"
+	"fmt"
+	"time"
+)
+
+const (
+	// maxPendingWatches bounds the number of pending transactions watched at the same time, so that looking up many hashes that
+	// never get mined cannot grow the watch list without limit.
+	maxPendingWatches = 10000
+	// settledRetention is how long the outcome of a transaction that left the mempool without being mined is reported.
+	settledRetention = time.Hour
+)
+
+// pendingWatch is a pending transaction that was looked up and is watched until it is mined or leaves the mempool. Status is
+// empty while the transaction is pending and set to StatusDropped or StatusReplaced at settledAt once it left the mempool.
+type pendingWatch struct {
+	record    PendingRecord
+	status    string
+	settledAt time.Time
+}
+
+// watchPending starts watching the pending transaction, unless it is already watched. A transaction that was settled as dropped
+// or replaced is watched again, as it was broadcast again.
+func (f *Fethcher) watchPending(record PendingRecord) {
+	f.watchesMu.Lock()
+	defer f.watchesMu.Unlock()
+
+	if watch, ok := f.watches[record.TransactionHash]; ok && watch.status == "" {
+		return
+	}
+	if len(f.watches) >= maxPendingWatches {
+		f.logs.Errorw("too many pending transactions watched", "transaction", record.TransactionHash, "max", maxPendingWatches)
+		return
+	}
+	f.watches[record.TransactionHash] = &pendingWatch{record: record}
+}
+
+// unwatchPending stops watching the transactions, which were mined.
+func (f *Fethcher) unwatchPending(transactionHashes ...string) {
+	f.watchesMu.Lock()
+	defer f.watchesMu.Unlock()
+
+	for _, transactionHash := range transactionHashes {
+		delete(f.watches, transactionHash)
+	}
+}
+
+// settledPending returns the status of the watched transaction when it left the mempool without being mined.
+func (f *Fethcher) settledPending(transactionHash string) (string, bool) {
+	f.watchesMu.Lock()
+	defer f.watchesMu.Unlock()
+
+	watch, ok := f.watches[transactionHash]
+	if !ok || watch.status == "" {
+		return "", false
+	}
+	return watch.status, true
+}
+
+// pendingWatches returns the transactions that are still pending and forgets the settled ones once settledRetention passed.
+func (f *Fethcher) pendingWatches() []PendingRecord {
+	f.watchesMu.Lock()
+	defer f.watchesMu.Unlock()
+
+	pending := make([]PendingRecord, 0, len(f.watches))
+	for transactionHash, watch := range f.watches {
+		switch {
+		case watch.status == "":
+			pending = append(pending, watch.record)
+		case time.Since(watch.settledAt) > settledRetention:
+			delete(f.watches, transactionHash)
+		}
+	}
+	return pending
+}
+
+// settlePending records that the watched transaction left the mempool with the given status.
+func (f *Fethcher) settlePending(transactionHash string, status string) {
+	f.watchesMu.Lock()
+	defer f.watchesMu.Unlock()
+
+	if watch, ok := f.watches[transactionHash]; ok {
+		watch.status = status
+		watch.settledAt = time.Now()
+	}
+}
+
+// trackPending watches the pending transaction of a node lookup and reports the transactions that the node no longer knows
+// with the status they were settled with by CheckPendingTransactions.
+func (f *Fethcher) trackPending(result TransactionResult) TransactionResult {
+	switch {
+	case result.Pending != nil:
+		f.watchPending(*result.Pending)
+	case result.Status == StatusNotFound:
+		if status, ok := f.settledPending(result.TransactionHash); ok {
+			result.Status = status
+			result.Reason = settledReason(status)
+		}
+	}
+	return result
+}
+
+// CheckPendingTransactions rechecks every watched pending transaction against the node. Transactions that were mined are queued
+// to be cached and no longer watched. Transactions that the node no longer knows are settled as replaced when the nonce of
+// their sender moved past theirs and as dropped otherwise, see StatusReplaced.
+func (f *Fethcher) CheckPendingTransactions(ctx context.Context) error {
+	pending := f.pendingWatches()
+	if len(pending) == 0 {
+		return nil
+	}
+
+	hashes := make([]string, 0, len(pending))
+	for _, record := range pending {
+		hashes = append(hashes, record.TransactionHash)
+	}
+
+	mined := make([]TransactionRecord, 0)
+	minedHashes := make([]string, 0)
+	nonces := make(map[string]uint64)
+	for i, result := range f.getTransactionsFromNode(ctx, hashes) {
+		record := pending[i]
+		switch result.Status {
+		case StatusFetched:
+			mined = append(mined, *result.Transaction)
+			minedHashes = append(minedHashes, result.TransactionHash)
+		case StatusNotFound:
+			nonce, ok := nonces[record.From]
+			if !ok {
+				var err error
+				nonce, err = f.ethService.FetchNonce(ctx, record.From)
+				if err != nil {
+					f.logs.Errorw("failed to fetch nonce of pending transaction sender", "error", err, "transaction", record.TransactionHash)
+					continue
+				}
+				nonces[record.From] = nonce
+			}
+
+			status := StatusDropped
+			if nonce > record.Nonce {
+				status = StatusReplaced
+			}
+			f.settlePending(record.TransactionHash, status)
+			f.logs.Infow("pending transaction left the mempool", "transaction", record.TransactionHash, "status", status)
+		case StatusError:
+			f.logs.Errorw("failed to recheck pending transaction", "reason", result.Reason, "transaction", result.TransactionHash)
+		}
+	}
+
+	if len(mined) > 0 {
+		err := f.writes.Enqueue("cache transactions", func(ctx context.Context) error {
+			return f.saveTransactionsToDB(ctx, mined)
+		})
+		if err != nil {
+			return fmt.Errorf("queue mined transactions: %w", err)
+		}
+		f.unwatchPending(minedHashes...)
+	}
+
+	f.logs.Infow("pending transactions checked", "checked", len(pending), "mined", minedHashes)
+	return nil
+}
+
+// RunPendingWatcher checks the watched pending transactions every interval until the context is cancelled.
+func (f *Fethcher) RunPendingWatcher(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := f.CheckPendingTransactions(ctx); err != nil {
+				f.logs.Errorw("failed to check pending transactions", "error", err)
+			}
+		}
+	}
+}
+
+func settledReason(status string) string {
+	if status == StatusReplaced {
+		return "transaction was replaced by another transaction of its sender with the same nonce"
+	}
+	return "transaction was dropped from the mempool"
+}
+
+func pendingToRecord(tx *ethereum.PendingTransaction) *PendingRecord {
+	record := &PendingRecord{
+		TransactionHash: tx.TransactionHash,
+		From:            tx.From,
+		To:              tx.To,
+		Input:           tx.Input,
+		Value:           tx.Value,
+		Type:            tx.Type,
+		Nonce:           tx.Nonce,
+		GasLimit:        tx.GasLimit,
+	}
+	if tx.GasPrice != nil {
+		record.Legacy = &LegacyFees{GasPrice: *tx.GasPrice}
+	}
+	if tx.MaxFeePerGas != nil && tx.MaxPriorityFeePerGas != nil {
+		record.DynamicFee = &DynamicFees{MaxFeePerGas: *tx.MaxFeePerGas, MaxPriorityFeePerGas: *tx.MaxPriorityFeePerGas}
+	}
+	return record
+}
@@ -0,0 +1,217 @@
+package core_test
+
+import (
+	"context"
+	"errors"
+	"fethcher/internal/core"
+	"fethcher/internal/core/fake"
+	"fethcher/internal/ethereum"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+)
+
+var _ = Describe("Pending transactions", func() {
+	var (
+		fakeRepo   *fake.Repository
+		fakeEth    *fake.EthereumService
+		fakeWrites *fake.WriteQueue
+		ctx        context.Context
+		fetcher    *core.Fethcher
+		pending    *ethereum.PendingTransaction
+		results    []core.TransactionResult
+		err        error
+	)
+
+	notFound := []*ethereum.TxResult{{Hash: "0x3", Error: ethereum.ErrTransactionNotFound}}
+
+	BeforeEach(func() {
+		fakeRepo = new(fake.Repository)
+		fakeEth = new(fake.EthereumService)
+		fakeEth.ReplayTransactionReturns(&ethereum.Revert{Reproduced: true, Message: "execution reverted", Data: "0x"}, nil)
+		fakeWrites = new(fake.WriteQueue)
+		ctx = context.Background()
+
+		fetcher = core.NewFethcher(zap.NewNop().Sugar(), fakeRepo, new(fake.JWTIssuer), fakeEth, new(fake.ABIRegistry), fakeWrites, 12)
+
+		to := "0x2"
+		maxFee := "30"
+		tip := "2"
+		pending = &ethereum.PendingTransaction{
+			TransactionHash:      "0x3",
+			From:                 "0x1",
+			To:                   &to,
+			Input:                "0x",
+			Value:                "100",
+			Type:                 2,
+			Nonce:                7,
+			GasLimit:             21000,
+			MaxFeePerGas:         &maxFee,
+			MaxPriorityFeePerGas: &tip,
+		}
+		fakeEth.FetchTransactionsReturnsOnCall(0, []*ethereum.TxResult{
+			{Hash: "0x3", Pending: pending, Error: ethereum.ErrTransactionPending},
+		}, nil)
+	})
+
+	JustBeforeEach(func() {
+		results, err = fetcher.GetTransactions(ctx, []string{"0x3"}, core.IncludeOptions{})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("returns the pending transaction without caching it", func() {
+		to := "0x2"
+		Expect(results).To(Equal([]core.TransactionResult{{
+			TransactionHash: "0x3",
+			Status:          core.StatusPending,
+			Reason:          ethereum.ErrTransactionPending.Error(),
+			Pending: &core.PendingRecord{
+				TransactionHash: "0x3",
+				From:            "0x1",
+				To:              &to,
+				Input:           "0x",
+				Value:           "100",
+				Type:            2,
+				Nonce:           7,
+				GasLimit:        21000,
+				DynamicFee:      &core.DynamicFees{MaxFeePerGas: "30", MaxPriorityFeePerGas: "2"},
+			},
+		}}))
+		Expect(fakeWrites.EnqueueCallCount()).To(BeZero())
+	})
+
+	Describe("CheckPendingTransactions", func() {
+		JustBeforeEach(func() {
+			err = fetcher.CheckPendingTransactions(ctx)
+		})
+
+		When("the transaction was mined", func() {
+			BeforeEach(func() {
+				fakeEth.FetchTransactionsReturnsOnCall(1, []*ethereum.TxResult{
+					{Hash: "0x3", Transaction: &ethereum.Transaction{TransactionHash: "0x3", TransactionStatus: 1, BlockHash: "0xaaa", BlockNumber: 100, Confirmations: 20}},
+				}, nil)
+			})
+
+			It("queues it to be cached and stops watching it", func() {
+				Expect(err).NotTo(HaveOccurred())
+				_, hashes := fakeEth.FetchTransactionsArgsForCall(1)
+				Expect(hashes).To(Equal([]string{"0x3"}))
+
+				Expect(fakeWrites.EnqueueCallCount()).To(Equal(1))
+				name, write := fakeWrites.EnqueueArgsForCall(0)
+				Expect(name).To(Equal("cache transactions"))
+				Expect(fakeRepo.SaveTransactionsCallCount()).To(BeZero())
+
+				Expect(write(ctx)).To(Succeed())
+				Expect(fakeRepo.SaveTransactionsCallCount()).To(Equal(1))
+				_, saved := fakeRepo.SaveTransactionsArgsForCall(0)
+				Expect(saved).To(HaveLen(1))
+				Expect(saved[0].TransactionHash).To(Equal("0x3"))
+				Expect(saved[0].BlockHash).To(Equal("0xaaa"))
+
+				Expect(fetcher.CheckPendingTransactions(ctx)).To(Succeed())
+				Expect(fakeEth.FetchTransactionsCallCount()).To(Equal(2))
+			})
+
+			When("the mined transaction cannot be queued", func() {
+				BeforeEach(func() {
+					fakeWrites.EnqueueReturns(errors.New("fake error"))
+				})
+
+				It("keeps watching it", func() {
+					Expect(err).To(MatchError(ContainSubstring("fake error")))
+
+					_ = fetcher.CheckPendingTransactions(ctx)
+					Expect(fakeEth.FetchTransactionsCallCount()).To(Equal(3))
+				})
+			})
+		})
+
+		When("the transaction is still pending", func() {
+			BeforeEach(func() {
+				fakeEth.FetchTransactionsReturnsOnCall(1, []*ethereum.TxResult{
+					{Hash: "0x3", Pending: pending, Error: ethereum.ErrTransactionPending},
+				}, nil)
+			})
+
+			It("keeps watching it", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeRepo.SaveTransactionsCallCount()).To(BeZero())
+				Expect(fakeEth.FetchNonceCallCount()).To(BeZero())
+			})
+		})
+
+		When("the transaction left the mempool after its sender got the same nonce mined", func() {
+			BeforeEach(func() {
+				fakeEth.FetchTransactionsReturnsOnCall(1, notFound, nil)
+				fakeEth.FetchTransactionsReturnsOnCall(2, notFound, nil)
+				fakeEth.FetchNonceReturns(8, nil)
+			})
+
+			It("reports it as replaced", func() {
+				Expect(err).NotTo(HaveOccurred())
+				_, sender := fakeEth.FetchNonceArgsForCall(0)
+				Expect(sender).To(Equal("0x1"))
+
+				results, err = fetcher.GetTransactions(ctx, []string{"0x3"}, core.IncludeOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(results).To(HaveLen(1))
+				Expect(results[0].Status).To(Equal(core.StatusReplaced))
+				Expect(results[0].Reason).To(ContainSubstring("same nonce"))
+			})
+
+			It("does not check it again", func() {
+				Expect(fetcher.CheckPendingTransactions(ctx)).To(Succeed())
+				Expect(fakeEth.FetchTransactionsCallCount()).To(Equal(2))
+			})
+		})
+
+		When("the transaction left the mempool before its nonce was used", func() {
+			BeforeEach(func() {
+				fakeEth.FetchTransactionsReturnsOnCall(1, notFound, nil)
+				fakeEth.FetchTransactionsReturnsOnCall(2, notFound, nil)
+				fakeEth.FetchNonceReturns(7, nil)
+			})
+
+			It("reports it as dropped", func() {
+				Expect(err).NotTo(HaveOccurred())
+
+				results, err = fetcher.GetTransactions(ctx, []string{"0x3"}, core.IncludeOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(results).To(HaveLen(1))
+				Expect(results[0].Status).To(Equal(core.StatusDropped))
+			})
+		})
+
+		When("the nonce of the sender cannot be fetched", func() {
+			BeforeEach(func() {
+				fakeEth.FetchTransactionsReturnsOnCall(1, notFound, nil)
+				fakeEth.FetchTransactionsReturnsOnCall(2, notFound, nil)
+				fakeEth.FetchNonceReturns(0, errors.New("fake error"))
+			})
+
+			It("keeps watching it", func() {
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fetcher.CheckPendingTransactions(ctx)).To(Succeed())
+				Expect(fakeEth.FetchTransactionsCallCount()).To(Equal(3))
+			})
+		})
+	})
+
+	When("the transaction is mined by the time it is looked up again", func() {
+		It("stops watching it", func() {
+			fakeEth.FetchTransactionsReturnsOnCall(1, []*ethereum.TxResult{
+				{Hash: "0x3", Transaction: &ethereum.Transaction{TransactionHash: "0x3", TransactionStatus: 1, BlockHash: "0xaaa", BlockNumber: 100}},
+			}, nil)
+
+			results, err = fetcher.GetTransactions(ctx, []string{"0x3"}, core.IncludeOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results[0].Status).To(Equal(core.StatusFetched))
+
+			Expect(fetcher.CheckPendingTransactions(ctx)).To(Succeed())
+			Expect(fakeEth.FetchTransactionsCallCount()).To(Equal(2))
+		})
+	})
+})
@@ -2,6 +2,7 @@ package core
 
 import (
 	"context"
+	"fethcher/internal/decoder"
 	"fethcher/internal/ethereum"
 	"fethcher/internal/repository"
 	tokenIssuer "fethcher/pkg/jwt"
@@ -16,9 +17,32 @@ type Repository interface {
 	GetUserFromDB(ctx context.Context, username string) (repository.User, error)
 	GetTransactionsByHash(ctx context.Context, txHashes []string) ([]repository.Transaction, error)
 	SaveTransactions(ctx context.Context, transactions []repository.Transaction) error
-	GetUserHistory(ctx context.Context, userID string) ([]string, error)
+	GetUserHistory(ctx context.Context, filter repository.HistoryFilter) ([]repository.UserTransaction, error)
 	SaveUserHistory(ctx context.Context, userID string, transactions []string) error
-	GetAllTransactions(ctx context.Context) ([]repository.Transaction, error)
+	DeleteUserHistory(ctx context.Context, userID string, txHashes []string) (int64, error)
+	ClearUserHistory(ctx context.Context, userID string) (int64, error)
+	FindTransactions(ctx context.Context, filter repository.TransactionFilter) ([]repository.Transaction, error)
+	FindAddressTransactions(ctx context.Context, filter repository.AddressFilter) ([]repository.Transaction, error)
+	GetTentativeTransactions(ctx context.Context) ([]repository.Transaction, error)
+	GetIncompleteTransactions(ctx context.Context, after string, limit int) ([]repository.Transaction, error)
+	UpdateTransaction(ctx context.Context, transaction repository.Transaction) error
+	ReplaceTransaction(ctx context.Context, transaction repository.Transaction, logs []repository.TransactionLog, transfers []repository.TokenTransfer) error
+	DeleteTransactions(ctx context.Context, txHashes []string) error
+	SaveTransactionLogs(ctx context.Context, logs []repository.TransactionLog) error
+	ReplaceTransactionLogs(ctx context.Context, txHash string, logs []repository.TransactionLog) error
+	GetTransactionLogs(ctx context.Context, txHashes []string) ([]repository.TransactionLog, error)
+	FindLogs(ctx context.Context, filter repository.LogFilter) ([]repository.TransactionLog, error)
+	SaveTokenTransfers(ctx context.Context, transfers []repository.TokenTransfer) error
+	ReplaceTokenTransfers(ctx context.Context, txHash string, transfers []repository.TokenTransfer) error
+	GetTokenTransfers(ctx context.Context, txHashes []string) ([]repository.TokenTransfer, error)
+	FindTokenTransfers(ctx context.Context, filter repository.TransferFilter) ([]repository.TokenTransfer, error)
+	SaveCallTraces(ctx context.Context, traces []repository.CallTrace) error
+	GetCallTraces(ctx context.Context, txHash string) ([]repository.CallTrace, error)
+	SaveBlock(ctx context.Context, block repository.Block) error
+	GetBlockByHash(ctx context.Context, hash string) (repository.Block, error)
+	GetBlockByNumber(ctx context.Context, number uint64) (repository.Block, error)
+	SaveContractABI(ctx context.Context, contractABI repository.ContractABI) error
+	GetContractABIs(ctx context.Context) ([]repository.ContractABI, error)
 }
 
 //counterfeiter:generate -o fake -fake-name JWTIssuer . JWTIssuer
@@ -30,5 +54,24 @@ type JWTIssuer interface {
 
 //counterfeiter:generate -o fake -fake-name EthereumService . EthereumService
 type EthereumService interface {
-	FetchTransactions(ctx context.Context, hashes []string) ([]*ethereum.Transaction, error)
+	FetchTransactions(ctx context.Context, hashes []string) ([]*ethereum.TxResult, error)
+	FetchBlockByHash(ctx context.Context, hash string) (*ethereum.Block, error)
+	FetchBlockByNumber(ctx context.Context, number uint64) (*ethereum.Block, error)
+	FetchHeadNumber(ctx context.Context) (uint64, error)
+	FetchCallTrace(ctx context.Context, hash string) ([]ethereum.CallFrame, error)
+	ReplayTransaction(ctx context.Context, tx *ethereum.Transaction) (*ethereum.Revert, error)
+	FetchNonce(ctx context.Context, address string) (uint64, error)
+}
+
+//counterfeiter:generate -o fake -fake-name WriteQueue . WriteQueue
+type WriteQueue interface {
+	Enqueue(name string, write func(ctx context.Context) error) error
+}
+
+//counterfeiter:generate -o fake -fake-name ABIRegistry . ABIRegistry
+type ABIRegistry interface {
+	Register(address string, abiJSON string) error
+	DecodeInput(to *string, input string) *decoder.Call
+	DecodeLog(address string, topics []string, data string) *decoder.Event
+	DecodeRevert(to *string, data string) *decoder.Revert
 }
@@ -0,0 +1,83 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ReconcileTentativeTransactions rechecks every tentative transaction against the canonical chain. Transactions that were reorged
+// into another block are updated with their new block, together with their logs and token transfers, and the ones that reached
+// the confirmation depth are marked as final. Transactions the node no longer knows about or that went back to the mempool are
+// evicted from the cache once they were missing on two reconciliations in a row, so that a single node of a failover pool that
+// lags behind the others cannot evict them.
+func (f *Fethcher) ReconcileTentativeTransactions(ctx context.Context) error {
+	tentative, err := f.repo.GetTentativeTransactions(ctx)
+	if err != nil {
+		return fmt.Errorf("get tentative transactions: %w", err)
+	}
+
+	hashes := make([]string, 0, len(tentative))
+	blockHashes := make(map[string]string, len(tentative))
+	for _, cached := range tentative {
+		hashes = append(hashes, cached.TransactionHash)
+		blockHashes[cached.TransactionHash] = cached.BlockHash
+	}
+
+	evicted := make([]string, 0)
+	missing := make(map[string]struct{})
+	if len(hashes) > 0 {
+		for _, result := range f.getTransactionsFromNode(ctx, hashes) {
+			switch result.Status {
+			case StatusNotFound, StatusPending:
+				if _, ok := f.missing[result.TransactionHash]; ok {
+					evicted = append(evicted, result.TransactionHash)
+				} else {
+					missing[result.TransactionHash] = struct{}{}
+				}
+				continue
+			case StatusError:
+				f.logs.Errorw("failed to recheck tentative transaction", "reason", result.Reason, "transaction", result.TransactionHash)
+				continue
+			}
+
+			record := *result.Transaction
+			if oldBlockHash := blockHashes[result.TransactionHash]; record.BlockHash != oldBlockHash {
+				f.logs.Infow("tentative transaction was reorged",
+					"transaction", result.TransactionHash,
+					"old_block_hash", oldBlockHash,
+					"new_block_hash", record.BlockHash)
+			}
+
+			err := f.repo.ReplaceTransaction(ctx, recordToTransaction(record), recordToLogs(record), recordToTransfers(record))
+			if err != nil {
+				f.logs.Errorw("failed to update tentative transaction", "error", err, "transaction", result.TransactionHash)
+			}
+		}
+	}
+	f.missing = missing
+
+	if err := f.repo.DeleteTransactions(ctx, evicted); err != nil {
+		return fmt.Errorf("evict reorged transactions: %w", err)
+	}
+
+	f.logs.Infow("tentative transactions reconciled", "checked", len(tentative), "evicted", evicted)
+	return nil
+}
+
+// RunReconciler reconciles the tentative transactions every interval until the context is cancelled.
+func (f *Fethcher) RunReconciler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := f.ReconcileTentativeTransactions(ctx); err != nil {
+				f.logs.Errorw("failed to reconcile tentative transactions", "error", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package core_test
+
+import (
+	"context"
+	"errors"
+	"fethcher/internal/core"
+	"fethcher/internal/core/fake"
+	"fethcher/internal/ethereum"
+	"fethcher/internal/repository"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+)
+
+var _ = Describe("Reconciler", func() {
+	var (
+		fakeRepo *fake.Repository
+		fakeJWT  *fake.JWTIssuer
+		fakeEth  *fake.EthereumService
+		ctx      context.Context
+		fetcher  *core.Fethcher
+		fakeErr  error
+		err      error
+	)
+
+	BeforeEach(func() {
+		fakeRepo = new(fake.Repository)
+		fakeJWT = new(fake.JWTIssuer)
+		fakeEth = new(fake.EthereumService)
+		fakeEth.ReplayTransactionReturns(&ethereum.Revert{Reproduced: true, Message: "execution reverted", Data: "0x"}, nil)
+		ctx = context.Background()
+		fakeErr = errors.New("fake error")
+
+		fetcher = core.NewFethcher(zap.NewNop().Sugar(), fakeRepo, fakeJWT, fakeEth, new(fake.ABIRegistry), new(fake.WriteQueue), 12)
+
+		fakeRepo.GetTentativeTransactionsReturns([]repository.Transaction{
+			{TransactionHash: "0x1", BlockHash: "0xaaa", BlockNumber: 100, Tentative: true},
+		}, nil)
+	})
+
+	JustBeforeEach(func() {
+		err = fetcher.ReconcileTentativeTransactions(ctx)
+	})
+
+	When("the transaction was reorged into another block", func() {
+		BeforeEach(func() {
+			fakeEth.FetchTransactionsReturns([]*ethereum.TxResult{
+				{Hash: "0x1", Transaction: &ethereum.Transaction{TransactionHash: "0x1", BlockHash: "0xbbb", BlockNumber: 101, Confirmations: 2}},
+			}, nil)
+		})
+
+		It("replaces the cached transaction, logs and token transfers at once and keeps it tentative", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeEth.FetchTransactionsCallCount()).To(Equal(1))
+			_, argHashes := fakeEth.FetchTransactionsArgsForCall(0)
+			Expect(argHashes).To(Equal([]string{"0x1"}))
+
+			Expect(fakeRepo.ReplaceTransactionCallCount()).To(Equal(1))
+			_, updated, _, _ := fakeRepo.ReplaceTransactionArgsForCall(0)
+			Expect(updated.TransactionHash).To(Equal("0x1"))
+			Expect(updated.BlockHash).To(Equal("0xbbb"))
+			Expect(updated.BlockNumber).To(Equal(uint64(101)))
+			Expect(updated.Tentative).To(BeTrue())
+
+			Expect(fakeRepo.UpdateTransactionCallCount()).To(Equal(0))
+			Expect(fakeRepo.ReplaceTransactionLogsCallCount()).To(Equal(0))
+			Expect(fakeRepo.ReplaceTokenTransfersCallCount()).To(Equal(0))
+
+			_, evicted := fakeRepo.DeleteTransactionsArgsForCall(0)
+			Expect(evicted).To(BeEmpty())
+		})
+	})
+
+	When("the transaction reached the confirmation depth", func() {
+		BeforeEach(func() {
+			fakeEth.FetchTransactionsReturns([]*ethereum.TxResult{
+				{Hash: "0x1", Transaction: &ethereum.Transaction{TransactionHash: "0x1", BlockHash: "0xaaa", BlockNumber: 100, Confirmations: 12}},
+			}, nil)
+		})
+
+		It("marks it as final", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeRepo.ReplaceTransactionCallCount()).To(Equal(1))
+			_, updated, _, _ := fakeRepo.ReplaceTransactionArgsForCall(0)
+			Expect(updated.Tentative).To(BeFalse())
+			Expect(updated.Confirmations).To(Equal(uint64(12)))
+		})
+	})
+
+	When("replacing the transaction fails", func() {
+		BeforeEach(func() {
+			fakeEth.FetchTransactionsReturns([]*ethereum.TxResult{
+				{Hash: "0x1", Transaction: &ethereum.Transaction{TransactionHash: "0x1", BlockHash: "0xbbb", BlockNumber: 101, Confirmations: 2}},
+			}, nil)
+			fakeRepo.ReplaceTransactionReturns(fakeErr)
+		})
+
+		It("leaves the transaction to the next reconciliation", func() {
+			Expect(err).NotTo(HaveOccurred())
+			_, evicted := fakeRepo.DeleteTransactionsArgsForCall(0)
+			Expect(evicted).To(BeEmpty())
+		})
+	})
+
+	When("the node no longer knows the transaction", func() {
+		BeforeEach(func() {
+			fakeEth.FetchTransactionsReturns([]*ethereum.TxResult{
+				{Hash: "0x1", Error: ethereum.ErrTransactionNotFound},
+			}, nil)
+		})
+
+		It("keeps it until the next reconciliation", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeRepo.ReplaceTransactionCallCount()).To(Equal(0))
+			_, evicted := fakeRepo.DeleteTransactionsArgsForCall(0)
+			Expect(evicted).To(BeEmpty())
+		})
+
+		When("it is still unknown on the next reconciliation", func() {
+			JustBeforeEach(func() {
+				err = fetcher.ReconcileTentativeTransactions(ctx)
+			})
+
+			It("evicts it from the cache", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeRepo.ReplaceTransactionCallCount()).To(Equal(0))
+				Expect(fakeRepo.DeleteTransactionsCallCount()).To(Equal(2))
+				_, evicted := fakeRepo.DeleteTransactionsArgsForCall(1)
+				Expect(evicted).To(Equal([]string{"0x1"}))
+			})
+		})
+
+		When("another node knows it on the next reconciliation", func() {
+			JustBeforeEach(func() {
+				fakeEth.FetchTransactionsReturns([]*ethereum.TxResult{
+					{Hash: "0x1", Transaction: &ethereum.Transaction{TransactionHash: "0x1", BlockHash: "0xaaa", BlockNumber: 100, Confirmations: 2}},
+				}, nil)
+				Expect(fetcher.ReconcileTentativeTransactions(ctx)).To(Succeed())
+
+				fakeEth.FetchTransactionsReturns([]*ethereum.TxResult{
+					{Hash: "0x1", Error: ethereum.ErrTransactionNotFound},
+				}, nil)
+				err = fetcher.ReconcileTentativeTransactions(ctx)
+			})
+
+			It("starts over before evicting it", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeRepo.DeleteTransactionsCallCount()).To(Equal(3))
+				for i := range 3 {
+					_, evicted := fakeRepo.DeleteTransactionsArgsForCall(i)
+					Expect(evicted).To(BeEmpty())
+				}
+			})
+		})
+	})
+
+	When("the transaction went back to the mempool on two reconciliations in a row", func() {
+		BeforeEach(func() {
+			fakeEth.FetchTransactionsReturns([]*ethereum.TxResult{
+				{Hash: "0x1", Error: ethereum.ErrTransactionPending},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			err = fetcher.ReconcileTentativeTransactions(ctx)
+		})
+
+		It("evicts it from the cache", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeRepo.ReplaceTransactionCallCount()).To(Equal(0))
+			_, evicted := fakeRepo.DeleteTransactionsArgsForCall(1)
+			Expect(evicted).To(Equal([]string{"0x1"}))
+		})
+	})
+
+	When("fetching the transaction fails", func() {
+		BeforeEach(func() {
+			fakeEth.FetchTransactionsReturns([]*ethereum.TxResult{
+				{Hash: "0x1", Error: fakeErr},
+			}, nil)
+		})
+
+		It("leaves the cached transaction untouched", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeRepo.ReplaceTransactionCallCount()).To(Equal(0))
+			_, evicted := fakeRepo.DeleteTransactionsArgsForCall(0)
+			Expect(evicted).To(BeEmpty())
+		})
+	})
+
+	When("the node fails", func() {
+		BeforeEach(func() {
+			fakeEth.FetchTransactionsReturns(nil, fakeErr)
+		})
+
+		It("leaves the cached transaction untouched", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeRepo.ReplaceTransactionCallCount()).To(Equal(0))
+			_, evicted := fakeRepo.DeleteTransactionsArgsForCall(0)
+			Expect(evicted).To(BeEmpty())
+		})
+	})
+
+	When("loading tentative transactions fails", func() {
+		BeforeEach(func() {
+			fakeRepo.GetTentativeTransactionsReturns(nil, fakeErr)
+		})
+
+		It("returns the error", func() {
+			Expect(err).To(MatchError(fakeErr))
+			Expect(fakeEth.FetchTransactionsCallCount()).To(Equal(0))
+		})
+	})
+})
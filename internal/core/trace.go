@@ -0,0 +1,152 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fethcher/internal/ethereum"
+	"fethcher/internal/repository"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// GetCallTrace retrieves the call tree of the transaction with the given hash. Cached traces are returned from the database,
+// other transactions are traced by the Ethereum node. A trace is only cached when its transaction is cached and no longer
+// tentative, so that it is never kept for a transaction that may still be reorged into another block. It returns
+// ErrTracingUnsupported when the node does not serve call traces and ErrTransactionNotFound when it does not know the
+// transaction.
+func (f *Fethcher) GetCallTrace(ctx context.Context, transactionHash string) (TraceRecord, error) {
+	transactionHash = common.HexToHash(transactionHash).Hex()
+
+	cached, err := f.repo.GetCallTraces(ctx, transactionHash)
+	if err != nil {
+		return TraceRecord{}, fmt.Errorf("get call traces from db: %w", err)
+	}
+	if len(cached) > 0 {
+		calls := make([]CallRecord, 0, len(cached))
+		for _, trace := range cached {
+			calls = append(calls, repoTraceToCall(trace))
+		}
+		return traceRecord(transactionHash, calls), nil
+	}
+
+	frames, err := f.ethService.FetchCallTrace(ctx, transactionHash)
+	if err != nil {
+		switch {
+		case errors.Is(err, ethereum.ErrTracingUnsupported):
+			return TraceRecord{}, ErrTracingUnsupported
+		case errors.Is(err, ethereum.ErrTransactionNotFound):
+			return TraceRecord{}, ErrTransactionNotFound
+		}
+		return TraceRecord{}, fmt.Errorf("fetch call trace from node: %w", err)
+	}
+
+	calls := make([]CallRecord, 0, len(frames))
+	for _, frame := range frames {
+		calls = append(calls, frameToCall(frame))
+	}
+
+	transactions, err := f.repo.GetTransactionsByHash(ctx, []string{transactionHash})
+	if err != nil {
+		f.logs.Errorw("failed to get traced transaction from db", "error", err, "transaction", transactionHash)
+	} else if len(transactions) == 1 && !transactions[0].Tentative {
+		err := f.writes.Enqueue("cache call traces", func(ctx context.Context) error {
+			return f.repo.SaveCallTraces(ctx, callsToTraces(transactionHash, calls))
+		})
+		if err != nil {
+			f.logs.Errorw("failed to queue call traces for caching", "error", err, "transaction", transactionHash)
+		}
+	}
+
+	return traceRecord(transactionHash, calls), nil
+}
+
+// traceRecord summarizes the internal transfers of the calls: the calls made by contracts that moved ether and were not
+// reverted. Delegate and static calls never move ether of their own.
+func traceRecord(transactionHash string, calls []CallRecord) TraceRecord {
+	record := TraceRecord{
+		TransactionHash:   transactionHash,
+		Calls:             calls,
+		InternalTransfers: []InternalTransfer{},
+	}
+
+	total := new(big.Int)
+	for _, call := range calls {
+		if len(call.TraceAddress) == 0 || call.Reverted || call.Type == "DELEGATECALL" || call.Type == "STATICCALL" {
+			continue
+		}
+		value, ok := new(big.Int).SetString(call.Value, 10)
+		if !ok || value.Sign() == 0 {
+			continue
+		}
+
+		record.InternalTransfers = append(record.InternalTransfers, InternalTransfer{
+			TraceAddress: call.TraceAddress,
+			From:         call.From,
+			To:           call.To,
+			Value:        call.Value,
+		})
+		total.Add(total, value)
+	}
+	record.InternalValue = total.String()
+	return record
+}
+
+func frameToCall(frame ethereum.CallFrame) CallRecord {
+	return CallRecord{
+		TraceAddress: frame.TraceAddress,
+		Type:         frame.Type,
+		From:         frame.From,
+		To:           frame.To,
+		Value:        frame.Value,
+		Gas:          frame.Gas,
+		GasUsed:      frame.GasUsed,
+		Input:        frame.Input,
+		Output:       frame.Output,
+		Error:        frame.Error,
+		Reverted:     frame.Reverted,
+	}
+}
+
+func callsToTraces(transactionHash string, calls []CallRecord) []repository.CallTrace {
+	traces := make([]repository.CallTrace, 0, len(calls))
+	for i, call := range calls {
+		traces = append(traces, repository.CallTrace{
+			TransactionHash: transactionHash,
+			TraceIndex:      uint(i),
+			TraceAddress:    call.TraceAddress,
+			CallType:        call.Type,
+			FromAddress:     call.From,
+			ToAddress:       call.To,
+			Value:           call.Value,
+			Gas:             call.Gas,
+			GasUsed:         call.GasUsed,
+			Input:           call.Input,
+			Output:          call.Output,
+			Error:           call.Error,
+			Reverted:        call.Reverted,
+		})
+	}
+	return traces
+}
+
+func repoTraceToCall(trace repository.CallTrace) CallRecord {
+	traceAddress := trace.TraceAddress
+	if traceAddress == nil {
+		traceAddress = []int{}
+	}
+	return CallRecord{
+		TraceAddress: traceAddress,
+		Type:         trace.CallType,
+		From:         trace.FromAddress,
+		To:           trace.ToAddress,
+		Value:        trace.Value,
+		Gas:          trace.Gas,
+		GasUsed:      trace.GasUsed,
+		Input:        trace.Input,
+		Output:       trace.Output,
+		Error:        trace.Error,
+		Reverted:     trace.Reverted,
+	}
+}
@@ -0,0 +1,219 @@
+package core
+
+import (
+	"fethcher/internal/ethereum"
+	"fethcher/internal/repository"
+	"math/big"
+)
+
+// setNodeTypeFields sets the type, gas and fee fields and the type-specific sections of the record from a transaction fetched
+// from the node.
+func setNodeTypeFields(record *TransactionRecord, tx *ethereum.Transaction) {
+	record.Type = &tx.Type
+	record.Nonce = &tx.Nonce
+	record.GasLimit = &tx.GasLimit
+	record.GasUsed = &tx.GasUsed
+	record.EffectiveGasPrice = &tx.EffectiveGasPrice
+
+	if tx.GasPrice != nil {
+		record.Legacy = &LegacyFees{GasPrice: *tx.GasPrice}
+	}
+	if tx.MaxFeePerGas != nil && tx.MaxPriorityFeePerGas != nil {
+		record.DynamicFee = &DynamicFees{MaxFeePerGas: *tx.MaxFeePerGas, MaxPriorityFeePerGas: *tx.MaxPriorityFeePerGas}
+	}
+	if tx.MaxFeePerBlobGas != nil {
+		record.Blob = &BlobFields{
+			BlobVersionedHashes: tx.BlobVersionedHashes,
+			MaxFeePerBlobGas:    *tx.MaxFeePerBlobGas,
+			BlobGasUsed:         deref(tx.BlobGasUsed),
+			BlobGasPrice:        deref(tx.BlobGasPrice),
+		}
+	}
+
+	if tx.AccessList != nil {
+		record.AccessList = make([]AccessTuple, 0, len(tx.AccessList))
+		for _, tuple := range tx.AccessList {
+			record.AccessList = append(record.AccessList, AccessTuple(tuple))
+		}
+	}
+	if tx.AuthorizationList != nil {
+		record.AuthorizationList = make([]Authorization, 0, len(tx.AuthorizationList))
+		for _, auth := range tx.AuthorizationList {
+			record.AuthorizationList = append(record.AuthorizationList, Authorization(auth))
+		}
+	}
+
+	record.L1Fees = l1Fees(tx.L1Fee, tx.L1GasUsed, tx.L1GasPrice, tx.L1BlobBaseFee, tx.GasUsedForL1)
+	record.Deposit = depositFields(tx.SourceHash, tx.Mint, tx.RequestID)
+	record.Cost = costBreakdown(*record)
+}
+
+// setRepoTypeFields sets the type, gas and fee fields and the type-specific sections of the record from a cached transaction.
+func setRepoTypeFields(record *TransactionRecord, tx repository.Transaction) {
+	record.Type = tx.TxType
+	record.Nonce = tx.Nonce
+	record.GasLimit = tx.GasLimit
+	record.GasUsed = tx.GasUsed
+	record.EffectiveGasPrice = tx.EffectiveGasPrice
+
+	if tx.GasPrice != nil {
+		record.Legacy = &LegacyFees{GasPrice: *tx.GasPrice}
+	}
+	if tx.MaxFeePerGas != nil && tx.MaxPriorityFeePerGas != nil {
+		record.DynamicFee = &DynamicFees{MaxFeePerGas: *tx.MaxFeePerGas, MaxPriorityFeePerGas: *tx.MaxPriorityFeePerGas}
+	}
+	if tx.MaxFeePerBlobGas != nil {
+		record.Blob = &BlobFields{
+			BlobVersionedHashes: tx.BlobVersionedHashes,
+			MaxFeePerBlobGas:    *tx.MaxFeePerBlobGas,
+			BlobGasUsed:         deref(tx.BlobGasUsed),
+			BlobGasPrice:        deref(tx.BlobGasPrice),
+		}
+	}
+
+	if tx.AccessList != nil {
+		record.AccessList = make([]AccessTuple, 0, len(tx.AccessList))
+		for _, tuple := range tx.AccessList {
+			record.AccessList = append(record.AccessList, AccessTuple(tuple))
+		}
+	}
+	if tx.AuthorizationList != nil {
+		record.AuthorizationList = make([]Authorization, 0, len(tx.AuthorizationList))
+		for _, auth := range tx.AuthorizationList {
+			record.AuthorizationList = append(record.AuthorizationList, Authorization(auth))
+		}
+	}
+
+	record.L1Fees = l1Fees(tx.L1Fee, tx.L1GasUsed, tx.L1GasPrice, tx.L1BlobBaseFee, tx.GasUsedForL1)
+	record.Deposit = depositFields(tx.SourceHash, tx.Mint, tx.RequestID)
+	record.Cost = costBreakdown(*record)
+}
+
+// setTransactionTypeFields flattens the type, gas and fee fields and the type-specific sections of the record into the cached
+// transaction.
+func setTransactionTypeFields(tx *repository.Transaction, record TransactionRecord) {
+	tx.TxType = record.Type
+	tx.Nonce = record.Nonce
+	tx.GasLimit = record.GasLimit
+	tx.GasUsed = record.GasUsed
+	tx.EffectiveGasPrice = record.EffectiveGasPrice
+
+	if record.Legacy != nil {
+		tx.GasPrice = &record.Legacy.GasPrice
+	}
+	if record.DynamicFee != nil {
+		tx.MaxFeePerGas = &record.DynamicFee.MaxFeePerGas
+		tx.MaxPriorityFeePerGas = &record.DynamicFee.MaxPriorityFeePerGas
+	}
+	if record.Blob != nil {
+		tx.BlobVersionedHashes = record.Blob.BlobVersionedHashes
+		tx.MaxFeePerBlobGas = &record.Blob.MaxFeePerBlobGas
+		tx.BlobGasUsed = &record.Blob.BlobGasUsed
+		tx.BlobGasPrice = &record.Blob.BlobGasPrice
+	}
+
+	if record.AccessList != nil {
+		tx.AccessList = make([]repository.AccessTuple, 0, len(record.AccessList))
+		for _, tuple := range record.AccessList {
+			tx.AccessList = append(tx.AccessList, repository.AccessTuple(tuple))
+		}
+	}
+	if record.AuthorizationList != nil {
+		tx.AuthorizationList = make([]repository.Authorization, 0, len(record.AuthorizationList))
+		for _, auth := range record.AuthorizationList {
+			tx.AuthorizationList = append(tx.AuthorizationList, repository.Authorization(auth))
+		}
+	}
+
+	if record.L1Fees != nil {
+		tx.L1Fee = record.L1Fees.L1Fee
+		tx.L1GasUsed = record.L1Fees.L1GasUsed
+		tx.L1GasPrice = record.L1Fees.L1GasPrice
+		tx.L1BlobBaseFee = record.L1Fees.L1BlobBaseFee
+		tx.GasUsedForL1 = record.L1Fees.GasUsedForL1
+	}
+	if record.Deposit != nil {
+		tx.SourceHash = record.Deposit.SourceHash
+		tx.Mint = record.Deposit.Mint
+		tx.RequestID = record.Deposit.RequestID
+	}
+}
+
+// l1Fees returns the L1 fee section of a transaction, or nil when it has none of the fields.
+func l1Fees(l1Fee *string, l1GasUsed *uint64, l1GasPrice *string, l1BlobBaseFee *string, gasUsedForL1 *uint64) *L1Fees {
+	if l1Fee == nil && l1GasUsed == nil && l1GasPrice == nil && l1BlobBaseFee == nil && gasUsedForL1 == nil {
+		return nil
+	}
+	return &L1Fees{
+		L1Fee:         l1Fee,
+		L1GasUsed:     l1GasUsed,
+		L1GasPrice:    l1GasPrice,
+		L1BlobBaseFee: l1BlobBaseFee,
+		GasUsedForL1:  gasUsedForL1,
+	}
+}
+
+// depositFields returns the deposit section of a transaction, or nil when it has none of the fields.
+func depositFields(sourceHash *string, mint *string, requestID *string) *DepositFields {
+	if sourceHash == nil && mint == nil && requestID == nil {
+		return nil
+	}
+	return &DepositFields{
+		SourceHash: sourceHash,
+		Mint:       mint,
+		RequestID:  requestID,
+	}
+}
+
+// costBreakdown splits what the transaction cost into the fee for its execution, for posting it to L1 and for its blobs. On
+// Arbitrum chains the gas used for L1 is taken out of the execution fee, since it is part of the gas used. It returns nil while
+// the gas used or the effective gas price are unknown.
+func costBreakdown(record TransactionRecord) *CostBreakdown {
+	if record.GasUsed == nil || record.EffectiveGasPrice == nil {
+		return nil
+	}
+	gasPrice, ok := new(big.Int).SetString(*record.EffectiveGasPrice, 10)
+	if !ok {
+		return nil
+	}
+
+	executionGas := *record.GasUsed
+	l1Fee := new(big.Int)
+	if record.L1Fees != nil {
+		if record.L1Fees.GasUsedForL1 != nil && *record.L1Fees.GasUsedForL1 <= executionGas {
+			executionGas -= *record.L1Fees.GasUsedForL1
+			l1Fee.Mul(new(big.Int).SetUint64(*record.L1Fees.GasUsedForL1), gasPrice)
+		}
+		if record.L1Fees.L1Fee != nil {
+			if fee, ok := new(big.Int).SetString(*record.L1Fees.L1Fee, 10); ok {
+				l1Fee.Add(l1Fee, fee)
+			}
+		}
+	}
+
+	blobFee := new(big.Int)
+	if record.Blob != nil {
+		if blobGasPrice, ok := new(big.Int).SetString(record.Blob.BlobGasPrice, 10); ok {
+			blobFee.Mul(new(big.Int).SetUint64(record.Blob.BlobGasUsed), blobGasPrice)
+		}
+	}
+
+	executionFee := new(big.Int).Mul(new(big.Int).SetUint64(executionGas), gasPrice)
+	total := new(big.Int).Add(executionFee, l1Fee)
+	total.Add(total, blobFee)
+
+	return &CostBreakdown{
+		ExecutionFee: executionFee.String(),
+		L1Fee:        l1Fee.String(),
+		BlobFee:      blobFee.String(),
+		TotalFee:     total.String(),
+	}
+}
+
+func deref[T any](value *T) T {
+	var zero T
+	if value == nil {
+		return zero
+	}
+	return *value
+}
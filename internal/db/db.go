@@ -5,15 +5,72 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"time"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"gorm.io/gorm/logger"
 )
 
 var ErrNotFound = errors.New("record not found")
 
+// Condition restricts a query to the records whose Column compares to Value using Operator, e.g. "=", ">=" or "IN". The
+// "IS NULL" and "IS NOT NULL" operators ignore Value. A []any Value is compared as a row, so that a Column of "(a, b)" can be
+// compared to it as a whole.
+type Condition struct {
+	Column   string
+	Operator string
+	Value    any
+}
+
+// clause renders the condition as a WHERE clause together with its arguments. The IS NULL and IS NOT NULL operators take no
+// value.
+func (c Condition) clause() (string, []any) {
+	switch strings.ToUpper(c.Operator) {
+	case "IN":
+		return fmt.Sprintf("%s IN (?)", c.Column), []any{c.Value}
+	case "IS NULL", "IS NOT NULL":
+		return fmt.Sprintf("%s %s", c.Column, strings.ToUpper(c.Operator)), nil
+	default:
+		return fmt.Sprintf("%s %s ?", c.Column, c.Operator), []any{c.Value}
+	}
+}
+
+// Query describes which records Find retrieves. All Where conditions must hold and, when AnyOf is set, at least one of its
+// conditions as well. OrderBy is passed as the ORDER BY clause and a Limit of zero means no limit.
+type Query struct {
+	Where   []Condition
+	AnyOf   []Condition
+	OrderBy string
+	Limit   int
+}
+
+// Keyset orders records by Columns, which must identify a record uniquely, so that they can be paged through without an offset.
+// After holds the values of the Columns of the last record of the previous page and is empty for the first page.
+type Keyset struct {
+	Columns    []string
+	After      []any
+	Descending bool
+}
+
+// Conflict tells Upsert how to resolve the records that collide with stored ones on Columns, which must be covered by a unique
+// index. Colliding records are skipped unless UpdateAll is set, in which case they overwrite the stored ones; when UpdateIf is
+// set as well, only the stored records for which it holds are overwritten. UpdateIf can refer to the new values as
+// excluded.<column>.
+type Conflict struct {
+	Columns   []string
+	UpdateAll bool
+	UpdateIf  string
+}
+
+// Increment is an UpdateWhere value that adds to the current value of a column instead of overwriting it.
+type Increment int
+
+// txKey is the context key of the database transaction started by Transaction.
+type txKey struct{}
+
 // PostgresDB is a struct that provides methods to interact with a PostgreSQL database using GORM.
 type PostgresDB struct {
 	DB *gorm.DB
@@ -31,28 +88,67 @@ func NewPostgresDB(dsn string) (*PostgresDB, error) {
 	}, nil
 }
 
-// MigrateTable migrates the provided tables to the database schema.
-func (f *PostgresDB) MigrateTable(tbl ...any) error {
-	err := f.DB.AutoMigrate(tbl...)
-	if err != nil {
-		return fmt.Errorf("failed to migrate table: %w", err)
+// InsertToTable inserts the provided records into the specified table.
+func (f *PostgresDB) InsertToTable(ctx context.Context, records any) error {
+	if err := f.conn(ctx).Create(records).Error; err != nil {
+		return fmt.Errorf("insert to table: %w", err)
+	}
+	return nil
+}
+
+// Upsert inserts the provided records into the specified table, resolving the records that collide with stored ones as
+// described by conflict.
+func (f *PostgresDB) Upsert(ctx context.Context, records any, conflict Conflict) error {
+	if len(conflict.Columns) == 0 {
+		return errors.New("upsert to table: no conflict columns")
+	}
+
+	onConflict := clause.OnConflict{
+		DoNothing: !conflict.UpdateAll,
+		UpdateAll: conflict.UpdateAll,
+	}
+	for _, column := range conflict.Columns {
+		onConflict.Columns = append(onConflict.Columns, clause.Column{Name: column})
+	}
+	if conflict.UpdateAll && conflict.UpdateIf != "" {
+		onConflict.Where = clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: conflict.UpdateIf}}}
 	}
 
+	if err := f.conn(ctx).Clauses(onConflict).Create(records).Error; err != nil {
+		return fmt.Errorf("upsert to table: %w", err)
+	}
 	return nil
 }
 
-// InsertToTable inserts the provided records into the specified table.
-func (f *PostgresDB) InsertToTable(ctx context.Context, records any) error {
-	if err := f.DB.Create(records).Error; err != nil {
-		return fmt.Errorf("insert to table: %w", err)
+// Transaction runs fn in a database transaction that is committed when fn returns nil and rolled back otherwise. Every call
+// made with the context passed to fn is part of the transaction, and a Transaction started with it joins the transaction
+// instead of starting a new one.
+func (f *PostgresDB) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+
+	err := f.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+	if err != nil {
+		return fmt.Errorf("run transaction: %w", err)
 	}
 	return nil
 }
 
+// conn returns the database transaction ctx runs in or, outside of a transaction, the database bound to ctx.
+func (f *PostgresDB) conn(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx
+	}
+	return f.DB.WithContext(ctx)
+}
+
 // GetOneBy retrieves a single record from the specified table where the given column matches the provided value.
 func (f *PostgresDB) GetOneBy(ctx context.Context, column string, value any, entity any) error {
 	query := fmt.Sprintf("%s = ?", column)
-	err := f.DB.Where(query, value).First(&entity).Error
+	err := f.conn(ctx).Where(query, value).First(&entity).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return ErrNotFound
@@ -64,16 +160,156 @@ func (f *PostgresDB) GetOneBy(ctx context.Context, column string, value any, ent
 
 // GetAllBy retrieves all records from the specified table where the given column matches the provided value.
 func (f *PostgresDB) GetAllBy(ctx context.Context, column string, value any, entity any) error {
-	tx := f.DB.Where(fmt.Sprintf("%s IN (?)", column), value).Find(entity)
+	tx := f.conn(ctx).Where(fmt.Sprintf("%s IN (?)", column), value).Find(entity)
 	if tx.Error != nil {
 		return fmt.Errorf("getting records by %q: %w", column, tx.Error)
 	}
 	return nil
 }
 
+// UpdateBy overwrites every column of the records where the given column matches the provided value with the fields of record.
+func (f *PostgresDB) UpdateBy(ctx context.Context, column string, value any, record any) error {
+	query := fmt.Sprintf("%s = ?", column)
+	tx := f.conn(ctx).Model(record).Where(query, value).Select("*").Updates(record)
+	if tx.Error != nil {
+		return fmt.Errorf("updating records by %q: %w", column, tx.Error)
+	}
+	if tx.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// OverwriteWhere overwrites every column of the records that match all conditions with the fields of record.
+func (f *PostgresDB) OverwriteWhere(ctx context.Context, conditions []Condition, record any) error {
+	if len(conditions) == 0 {
+		return errors.New("overwriting records: no conditions")
+	}
+
+	tx := filter(f.conn(ctx).Model(record), Query{Where: conditions}).Select("*").Updates(record)
+	if tx.Error != nil {
+		return fmt.Errorf("overwriting records: %w", tx.Error)
+	}
+	if tx.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// UpdateWhere sets the given columns of the records of the entity's table that match all conditions and returns how many records
+// were updated.
+func (f *PostgresDB) UpdateWhere(ctx context.Context, conditions []Condition, values map[string]any, entity any) (int64, error) {
+	if len(conditions) == 0 {
+		return 0, errors.New("updating records: no conditions")
+	}
+
+	updates := make(map[string]any, len(values))
+	for column, value := range values {
+		if increment, ok := value.(Increment); ok {
+			value = gorm.Expr(fmt.Sprintf("%s + ?", column), int(increment))
+		}
+		updates[column] = value
+	}
+
+	tx := filter(f.conn(ctx).Model(entity), Query{Where: conditions}).Updates(updates)
+	if tx.Error != nil {
+		return 0, fmt.Errorf("updating records: %w", tx.Error)
+	}
+	return tx.RowsAffected, nil
+}
+
+// DeleteWhere deletes the records of the entity's table that match all conditions and returns how many records were deleted.
+func (f *PostgresDB) DeleteWhere(ctx context.Context, conditions []Condition, entity any) (int64, error) {
+	if len(conditions) == 0 {
+		return 0, errors.New("deleting records: no conditions")
+	}
+
+	tx := filter(f.conn(ctx), Query{Where: conditions}).Delete(entity)
+	if tx.Error != nil {
+		return 0, fmt.Errorf("deleting records: %w", tx.Error)
+	}
+	return tx.RowsAffected, nil
+}
+
+// DeleteBy deletes all records from the specified table where the given column matches the provided value.
+func (f *PostgresDB) DeleteBy(ctx context.Context, column string, value any, entity any) error {
+	tx := f.conn(ctx).Where(fmt.Sprintf("%s IN (?)", column), value).Delete(entity)
+	if tx.Error != nil {
+		return fmt.Errorf("deleting records by %q: %w", column, tx.Error)
+	}
+	return nil
+}
+
+// Find retrieves the records that match the given query and stores them in the provided entity object.
+func (f *PostgresDB) Find(ctx context.Context, query Query, entity any) error {
+	tx := filter(f.conn(ctx), query)
+	if query.OrderBy != "" {
+		tx = tx.Order(query.OrderBy)
+	}
+	if query.Limit > 0 {
+		tx = tx.Limit(query.Limit)
+	}
+
+	if err := tx.Find(entity).Error; err != nil {
+		return fmt.Errorf("finding records: %w", err)
+	}
+	return nil
+}
+
+// FindPage retrieves a page of the records that match the given query, ordered by the keyset, and stores them in the provided
+// entity object. The OrderBy of the query is ignored.
+func (f *PostgresDB) FindPage(ctx context.Context, query Query, keyset Keyset, entity any) error {
+	if len(keyset.Columns) == 0 {
+		return errors.New("finding page: keyset has no columns")
+	}
+	if len(keyset.After) > 0 && len(keyset.After) != len(keyset.Columns) {
+		return fmt.Errorf("finding page: keyset has %d columns but %d values", len(keyset.Columns), len(keyset.After))
+	}
+
+	direction, operator := "ASC", ">"
+	if keyset.Descending {
+		direction, operator = "DESC", "<"
+	}
+
+	tx := filter(f.conn(ctx), query)
+	if len(keyset.After) > 0 {
+		tx = tx.Where(fmt.Sprintf("(%s) %s ?", strings.Join(keyset.Columns, ", "), operator), keyset.After)
+	}
+	for _, column := range keyset.Columns {
+		tx = tx.Order(fmt.Sprintf("%s %s", column, direction))
+	}
+	if query.Limit > 0 {
+		tx = tx.Limit(query.Limit)
+	}
+
+	if err := tx.Find(entity).Error; err != nil {
+		return fmt.Errorf("finding page: %w", err)
+	}
+	return nil
+}
+
+// filter restricts tx to the records that match the conditions of the query.
+func filter(tx *gorm.DB, query Query) *gorm.DB {
+	for _, cond := range query.Where {
+		clause, values := cond.clause()
+		tx = tx.Where(clause, values...)
+	}
+	if len(query.AnyOf) > 0 {
+		clauses := make([]string, 0, len(query.AnyOf))
+		values := make([]any, 0, len(query.AnyOf))
+		for _, cond := range query.AnyOf {
+			clause, condValues := cond.clause()
+			clauses = append(clauses, clause)
+			values = append(values, condValues...)
+		}
+		tx = tx.Where(strings.Join(clauses, " OR "), values...)
+	}
+	return tx
+}
+
 // GetAll retrieves all records from the specified table and stores them in the provided entity object
 func (f *PostgresDB) GetAll(ctx context.Context, entity any) error {
-	tx := f.DB.Find(entity)
+	tx := f.conn(ctx).Find(entity)
 	if tx.Error != nil {
 		return fmt.Errorf("getting all records: %w", tx.Error)
 	}
@@ -96,7 +332,7 @@ func (f *PostgresDB) SeedTable(ctx context.Context, records any) error {
 	var count int64
 
 	elemType := slice.Index(0).Interface()
-	if err := f.DB.Model(elemType).Count(&count).Error; err != nil {
+	if err := f.conn(ctx).Model(elemType).Count(&count).Error; err != nil {
 		return fmt.Errorf("get model count: %w", err)
 	}
 
@@ -104,7 +340,7 @@ func (f *PostgresDB) SeedTable(ctx context.Context, records any) error {
 		return nil
 	}
 
-	if err := f.DB.Create(records).Error; err != nil {
+	if err := f.conn(ctx).Create(records).Error; err != nil {
 		return fmt.Errorf("insert to table: %w", err)
 	}
 
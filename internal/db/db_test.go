@@ -3,6 +3,7 @@ package db_test
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fethcher/internal/db"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -18,6 +19,11 @@ type Test struct {
 	Username string
 }
 
+type Counter struct {
+	Name string
+	Hits int
+}
+
 var _ = Describe("Database", func() {
 	var (
 		mock   sqlmock.Sqlmock
@@ -49,25 +55,6 @@ var _ = Describe("Database", func() {
 		Expect(mockDb.Close()).To(Succeed())
 	})
 
-	Describe("MigrateTable", func() {
-		var err error
-
-		BeforeEach(func() {
-			mock.ExpectQuery(`SELECT.*FROM information_schema\.tables.*`).
-				WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(0))
-
-			mock.ExpectExec(`^CREATE TABLE \"tests\".*$`).
-				WillReturnResult(sqlmock.NewResult(0, 1))
-		})
-		JustBeforeEach(func() {
-			err = testDB.MigrateTable(&Test{})
-		})
-		It("should migrate the table successfully", func() {
-			Expect(err).NotTo(HaveOccurred())
-			Expect(mock.ExpectationsWereMet()).To(Succeed())
-		})
-	})
-
 	Describe("InsertToTable", func() {
 		var err error
 		BeforeEach(func() {
@@ -93,6 +80,118 @@ var _ = Describe("Database", func() {
 		})
 	})
 
+	Describe("Upsert", func() {
+		var (
+			err      error
+			conflict db.Conflict
+		)
+
+		JustBeforeEach(func() {
+			err = testDB.Upsert(context.Background(), &[]Counter{{Name: "Alice", Hits: 1}}, conflict)
+		})
+
+		When("colliding records are skipped", func() {
+			BeforeEach(func() {
+				conflict = db.Conflict{Columns: []string{"name"}}
+
+				mock.ExpectBegin()
+				mock.ExpectExec(`^INSERT INTO "counters" \("name","hits"\) VALUES \(\$1,\$2\) ON CONFLICT \("name"\) DO NOTHING$`).
+					WithArgs("Alice", 1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			})
+
+			It("should insert the records without updating the stored ones", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mock.ExpectationsWereMet()).To(Succeed())
+			})
+		})
+
+		When("colliding records overwrite the stored ones", func() {
+			BeforeEach(func() {
+				conflict = db.Conflict{Columns: []string{"name"}, UpdateAll: true, UpdateIf: "counters.hits < excluded.hits"}
+
+				mock.ExpectBegin()
+				mock.ExpectExec(`^INSERT INTO "counters" \("name","hits"\) VALUES \(\$1,\$2\) ON CONFLICT \("name"\) DO UPDATE SET .*"hits"="excluded"."hits".* WHERE counters.hits < excluded.hits$`).
+					WithArgs("Alice", 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			})
+
+			It("should update the stored records the condition holds for", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mock.ExpectationsWereMet()).To(Succeed())
+			})
+		})
+
+		When("no conflict columns are given", func() {
+			BeforeEach(func() {
+				conflict = db.Conflict{}
+			})
+
+			It("should return an error", func() {
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("Transaction", func() {
+		var (
+			err    error
+			failFn error
+		)
+
+		BeforeEach(func() {
+			failFn = nil
+		})
+
+		JustBeforeEach(func() {
+			err = testDB.Transaction(context.Background(), func(ctx context.Context) error {
+				if err := testDB.InsertToTable(ctx, &[]Counter{{Name: "Alice", Hits: 1}}); err != nil {
+					return err
+				}
+				// a nested transaction joins the outer one
+				return testDB.Transaction(ctx, func(ctx context.Context) error {
+					if _, err := testDB.UpdateWhere(ctx, []db.Condition{{Column: "name", Operator: "=", Value: "Alice"}},
+						map[string]any{"hits": db.Increment(1)}, &Counter{}); err != nil {
+						return err
+					}
+					return failFn
+				})
+			})
+		})
+
+		When("every write succeeds", func() {
+			BeforeEach(func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`^INSERT INTO "counters"`).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`^UPDATE "counters"`).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			})
+
+			It("should commit the writes together", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mock.ExpectationsWereMet()).To(Succeed())
+			})
+		})
+
+		When("the function fails", func() {
+			BeforeEach(func() {
+				failFn = errors.New("fn error")
+
+				mock.ExpectBegin()
+				mock.ExpectExec(`^INSERT INTO "counters"`).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`^UPDATE "counters"`).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectRollback()
+			})
+
+			It("should roll back every write and return the error", func() {
+				Expect(err).To(MatchError(failFn))
+				Expect(mock.ExpectationsWereMet()).To(Succeed())
+			})
+		})
+	})
+
 	Describe("GetOneBy", func() {
 		When("a record is found", func() {
 			BeforeEach(func() {
@@ -165,4 +264,347 @@ var _ = Describe("Database", func() {
 		})
 	})
 
+	Describe("UpdateBy", func() {
+		var err error
+
+		When("the record exists", func() {
+			BeforeEach(func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`^UPDATE "tests" SET "username"=\$1 WHERE id = \$2$`).
+					WithArgs("Carol", 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			})
+
+			JustBeforeEach(func() {
+				err = testDB.UpdateBy(context.Background(), "id", 1, &Test{Username: "Carol"})
+			})
+
+			It("should update the record", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mock.ExpectationsWereMet()).To(Succeed())
+			})
+		})
+
+		When("no record matches", func() {
+			BeforeEach(func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`^UPDATE "tests" SET "username"=\$1 WHERE id = \$2$`).
+					WithArgs("Ghost", 7).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			})
+
+			JustBeforeEach(func() {
+				err = testDB.UpdateBy(context.Background(), "id", 7, &Test{Username: "Ghost"})
+			})
+
+			It("should return ErrNotFound", func() {
+				Expect(err).To(Equal(db.ErrNotFound))
+				Expect(mock.ExpectationsWereMet()).To(Succeed())
+			})
+		})
+	})
+
+	Describe("OverwriteWhere", func() {
+		var err error
+
+		When("the record exists", func() {
+			BeforeEach(func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`^UPDATE "tests" SET "username"=\$1 WHERE id = \$2 AND username = \$3$`).
+					WithArgs("Carol", 1, "Alice").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			})
+
+			JustBeforeEach(func() {
+				err = testDB.OverwriteWhere(context.Background(), []db.Condition{
+					{Column: "id", Operator: "=", Value: 1},
+					{Column: "username", Operator: "=", Value: "Alice"},
+				}, &Test{Username: "Carol"})
+			})
+
+			It("should overwrite the record", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mock.ExpectationsWereMet()).To(Succeed())
+			})
+		})
+
+		When("no record matches", func() {
+			BeforeEach(func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`^UPDATE "tests" SET "username"=\$1 WHERE id = \$2$`).
+					WithArgs("Ghost", 7).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			})
+
+			JustBeforeEach(func() {
+				err = testDB.OverwriteWhere(context.Background(), []db.Condition{{Column: "id", Operator: "=", Value: 7}}, &Test{Username: "Ghost"})
+			})
+
+			It("should return ErrNotFound", func() {
+				Expect(err).To(Equal(db.ErrNotFound))
+				Expect(mock.ExpectationsWereMet()).To(Succeed())
+			})
+		})
+
+		When("no conditions are given", func() {
+			It("should refuse to overwrite every record", func() {
+				err = testDB.OverwriteWhere(context.Background(), nil, &Test{Username: "Ghost"})
+				Expect(err).To(MatchError(ContainSubstring("no conditions")))
+			})
+		})
+	})
+
+	Describe("DeleteBy", func() {
+		var err error
+
+		BeforeEach(func() {
+			mock.ExpectBegin()
+			mock.ExpectExec(`^DELETE FROM "tests" WHERE username IN \(\$1,\$2\)$`).
+				WithArgs("Alice", "Bob").
+				WillReturnResult(sqlmock.NewResult(0, 2))
+			mock.ExpectCommit()
+		})
+
+		JustBeforeEach(func() {
+			err = testDB.DeleteBy(context.Background(), "username", []string{"Alice", "Bob"}, &Test{})
+		})
+
+		It("should delete the matching records", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+		})
+	})
+
+	Describe("UpdateWhere", func() {
+		var (
+			err     error
+			updated int64
+		)
+
+		When("conditions are given", func() {
+			BeforeEach(func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`^UPDATE "counters" SET "hits"=hits \+ \$1,"name"=\$2 WHERE name IN \(\$3,\$4\)$`).
+					WithArgs(2, "Carol", "Alice", "Bob").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			})
+
+			JustBeforeEach(func() {
+				updated, err = testDB.UpdateWhere(context.Background(),
+					[]db.Condition{{Column: "name", Operator: "IN", Value: []string{"Alice", "Bob"}}},
+					map[string]any{"name": "Carol", "hits": db.Increment(2)},
+					&Counter{})
+			})
+
+			It("should update the matching records", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(updated).To(Equal(int64(2)))
+				Expect(mock.ExpectationsWereMet()).To(Succeed())
+			})
+		})
+
+		When("no conditions are given", func() {
+			JustBeforeEach(func() {
+				updated, err = testDB.UpdateWhere(context.Background(), nil, map[string]any{"name": "Carol"}, &Counter{})
+			})
+
+			It("should return an error", func() {
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("DeleteWhere", func() {
+		var (
+			err     error
+			deleted int64
+		)
+
+		When("conditions are given", func() {
+			BeforeEach(func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`^DELETE FROM "tests" WHERE username = \$1 AND id IN \(\$2,\$3\)$`).
+					WithArgs("Alice", 1, 2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			})
+
+			JustBeforeEach(func() {
+				deleted, err = testDB.DeleteWhere(context.Background(), []db.Condition{
+					{Column: "username", Operator: "=", Value: "Alice"},
+					{Column: "id", Operator: "IN", Value: []int{1, 2}},
+				}, &Test{})
+			})
+
+			It("should delete the matching records", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(deleted).To(Equal(int64(1)))
+				Expect(mock.ExpectationsWereMet()).To(Succeed())
+			})
+		})
+
+		When("no conditions are given", func() {
+			JustBeforeEach(func() {
+				deleted, err = testDB.DeleteWhere(context.Background(), nil, &Test{})
+			})
+
+			It("should return an error", func() {
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("Find", func() {
+		var (
+			err     error
+			records []Test
+			query   db.Query
+		)
+
+		BeforeEach(func() {
+			query = db.Query{
+				Where: []db.Condition{
+					{Column: "username", Operator: "IN", Value: []string{"Alice", "Bob"}},
+					{Column: "id", Operator: ">", Value: 1},
+				},
+				OrderBy: "id DESC",
+				Limit:   10,
+			}
+		})
+
+		JustBeforeEach(func() {
+			err = testDB.Find(context.Background(), query, &records)
+		})
+
+		When("all conditions must hold", func() {
+			BeforeEach(func() {
+				mock.ExpectQuery(`^SELECT \* FROM "tests" WHERE username IN \(\$1,\$2\) AND id > \$3 ORDER BY id DESC LIMIT \$4$`).
+					WithArgs("Alice", "Bob", 1, 10).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(2, "Bob"))
+			})
+
+			It("should return the matching records", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(records).To(Equal([]Test{{ID: 2, Username: "Bob"}}))
+				Expect(mock.ExpectationsWereMet()).To(Succeed())
+			})
+		})
+
+		When("a condition compares with NULL", func() {
+			BeforeEach(func() {
+				query = db.Query{Where: []db.Condition{{Column: "username", Operator: "IS NULL"}}}
+
+				mock.ExpectQuery(`^SELECT \* FROM "tests" WHERE username IS NULL$`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(3, nil))
+			})
+
+			It("should render the NULL literal", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(records).To(HaveLen(1))
+				Expect(mock.ExpectationsWereMet()).To(Succeed())
+			})
+		})
+
+		When("a condition compares a row", func() {
+			BeforeEach(func() {
+				query = db.Query{Where: []db.Condition{{Column: "(id, username)", Operator: "<", Value: []any{5, "Carol"}}}}
+
+				mock.ExpectQuery(`^SELECT \* FROM "tests" WHERE \(id, username\) < \(\$1,\$2\)$`).
+					WithArgs(5, "Carol").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(2, "Bob"))
+			})
+
+			It("should render the row value", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(records).To(HaveLen(1))
+				Expect(mock.ExpectationsWereMet()).To(Succeed())
+			})
+		})
+
+		When("any of the alternative conditions may hold", func() {
+			BeforeEach(func() {
+				query.AnyOf = []db.Condition{
+					{Column: "username", Operator: "=", Value: "Carol"},
+					{Column: "id", Operator: "<", Value: 5},
+				}
+
+				mock.ExpectQuery(`^SELECT \* FROM "tests" WHERE username IN \(\$1,\$2\) AND id > \$3 AND \(username = \$4 OR id < \$5\) ORDER BY id DESC LIMIT \$6$`).
+					WithArgs("Alice", "Bob", 1, "Carol", 5, 10).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(2, "Bob"))
+			})
+
+			It("should group the alternatives", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(records).To(Equal([]Test{{ID: 2, Username: "Bob"}}))
+				Expect(mock.ExpectationsWereMet()).To(Succeed())
+			})
+		})
+	})
+
+	Describe("FindPage", func() {
+		var (
+			err     error
+			records []Test
+			query   db.Query
+			keyset  db.Keyset
+		)
+
+		BeforeEach(func() {
+			query = db.Query{
+				Where:   []db.Condition{{Column: "username", Operator: "<>", Value: "Alice"}},
+				OrderBy: "ignored",
+				Limit:   2,
+			}
+			keyset = db.Keyset{Columns: []string{"id", "username"}}
+		})
+
+		JustBeforeEach(func() {
+			err = testDB.FindPage(context.Background(), query, keyset, &records)
+		})
+
+		When("the first page is requested", func() {
+			BeforeEach(func() {
+				mock.ExpectQuery(`^SELECT \* FROM "tests" WHERE username <> \$1 ORDER BY id ASC,username ASC LIMIT \$2$`).
+					WithArgs("Alice", 2).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(2, "Bob"))
+			})
+
+			It("should order by the keyset", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(records).To(Equal([]Test{{ID: 2, Username: "Bob"}}))
+				Expect(mock.ExpectationsWereMet()).To(Succeed())
+			})
+		})
+
+		When("a later page is requested in descending order", func() {
+			BeforeEach(func() {
+				keyset.After = []any{5, "Carol"}
+				keyset.Descending = true
+
+				mock.ExpectQuery(`^SELECT \* FROM "tests" WHERE username <> \$1 AND \(id, username\) < \(\$2,\$3\) ORDER BY id DESC,username DESC LIMIT \$4$`).
+					WithArgs("Alice", 5, "Carol", 2).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(2, "Bob"))
+			})
+
+			It("should continue after the last record of the previous page", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mock.ExpectationsWereMet()).To(Succeed())
+			})
+		})
+
+		When("the keyset values do not match its columns", func() {
+			BeforeEach(func() {
+				keyset.After = []any{5}
+			})
+
+			It("should return an error", func() {
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
 })
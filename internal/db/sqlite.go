@@ -0,0 +1,47 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// sqlitePragmas make concurrent writers wait for each other instead of failing and let readers run alongside the writer.
+const sqlitePragmas = "_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)"
+
+// SQLiteDB is a Storage backed by a pure Go SQLite database, so that the service and its tests run without a PostgreSQL server.
+// It shares the queries of PostgresDB, which only use SQL that both databases understand, and differs in how it connects.
+type SQLiteDB struct {
+	*PostgresDB
+}
+
+// NewSQLiteDB is a constructor function that opens the SQLite database at path, creating it when it does not exist. A path of
+// ":memory:" opens a database that only lives as long as the process.
+func NewSQLiteDB(path string) (*SQLiteDB, error) {
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+
+	db, err := gorm.Open(sqlite.Open(path+separator+sqlitePragmas), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Info),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite database: %w", err)
+	}
+
+	// SQLite runs one writer at a time and an in-memory database only exists on the connection that created it, so a single
+	// connection is shared by all queries.
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("get sqlite connection pool: %w", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+
+	return &SQLiteDB{
+		PostgresDB: &PostgresDB{DB: db},
+	}, nil
+}
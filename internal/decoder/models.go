@@ -0,0 +1,64 @@
+package decoder
+
+// Where a decoded call or event got its definition from.
+const (
+	// SourceABI means the definition comes from the ABI uploaded for the contract.
+	SourceABI = "abi"
+	// SourceSignature means the definition comes from the function signature database. Selectors can collide, so the decoding
+	// is a best guess.
+	SourceSignature = "signature"
+	// SourceSelector means no definition is known and only the selector or topic is returned.
+	SourceSelector = "selector"
+	// SourceBuiltin means the definition is one of the errors built into Solidity.
+	SourceBuiltin = "builtin"
+)
+
+// The kinds of revert data.
+const (
+	// RevertError is the Error(string) of a failed require or of a revert with a message.
+	RevertError = "error"
+	// RevertPanic is the Panic(uint256) of a failed assert or another runtime error, such as an arithmetic overflow.
+	RevertPanic = "panic"
+	// RevertCustom is a custom error of the contract.
+	RevertCustom = "custom"
+)
+
+// Call is the decoded input of a transaction.
+type Call struct {
+	Selector  string `json:"selector"`
+	Method    string `json:"method,omitempty"`
+	Signature string `json:"signature,omitempty"`
+	Args      []Arg  `json:"args,omitempty"`
+	Source    string `json:"source"`
+}
+
+// Event is a decoded event log.
+type Event struct {
+	Topic     string `json:"topic"`
+	Name      string `json:"name,omitempty"`
+	Signature string `json:"signature,omitempty"`
+	Args      []Arg  `json:"args,omitempty"`
+	Source    string `json:"source"`
+}
+
+// Revert is the decoded revert data of a failed call. Reason is the message of an error or the description of a panic, Code the
+// code of a panic. Custom errors carry their name, signature and arguments when they are known, and only their selector otherwise.
+type Revert struct {
+	Kind      string  `json:"kind"`
+	Selector  string  `json:"selector"`
+	Reason    string  `json:"reason,omitempty"`
+	Code      *uint64 `json:"code,omitempty"`
+	Name      string  `json:"name,omitempty"`
+	Signature string  `json:"signature,omitempty"`
+	Args      []Arg   `json:"args,omitempty"`
+	Source    string  `json:"source"`
+}
+
+// Arg is a single decoded argument. Numbers that do not fit into 64 bits are returned as decimal strings and byte values as hex.
+// Indexed event arguments of dynamic types hold the keccak256 hash of the value, as that is all a topic contains.
+type Arg struct {
+	Name    string `json:"name,omitempty"`
+	Type    string `json:"type"`
+	Indexed bool   `json:"indexed,omitempty"`
+	Value   any    `json:"value"`
+}
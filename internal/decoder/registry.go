@@ -0,0 +1,295 @@
+// Package decoder decodes transaction inputs and event logs using uploaded contract ABIs and a database of known signatures.
+package decoder
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var ErrInvalidABI = errors.New("invalid abi")
+
+// Registry holds the ABIs of known contracts together with a database of function and event signatures and decodes transaction
+// inputs and logs with them. Contract ABIs take precedence over the signature database. It is safe for concurrent use.
+type Registry struct {
+	mu        sync.RWMutex
+	contracts map[common.Address]*abi.ABI
+	methods   map[[4]byte][]abi.Method
+	events    map[common.Hash][]signatureEvent
+}
+
+// signatureEvent is an event known only by its signature, so which of its arguments are indexed is unknown.
+type signatureEvent struct {
+	name      string
+	signature string
+	inputs    []abi.ArgumentMarshaling
+}
+
+// NewRegistry is a constructor function for the Registry type.
+func NewRegistry() *Registry {
+	return &Registry{
+		contracts: make(map[common.Address]*abi.ABI),
+		methods:   make(map[[4]byte][]abi.Method),
+		events:    make(map[common.Hash][]signatureEvent),
+	}
+}
+
+// Register parses the JSON ABI and uses it to decode the inputs of transactions sent to address and the logs it emits. A
+// previously registered ABI of the same contract is replaced.
+func (r *Registry) Register(address string, abiJSON string) error {
+	if !common.IsHexAddress(address) {
+		return fmt.Errorf("%w: %q is not an address", ErrInvalidABI, address)
+	}
+
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidABI, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.contracts[common.HexToAddress(address)] = &parsed
+	return nil
+}
+
+// DecodeInput decodes the calldata of a transaction sent to the given address. It returns nil for contract creations and plain
+// transfers. When the method is unknown, only its selector is returned.
+func (r *Registry) DecodeInput(to *string, input string) *Call {
+	if to == nil {
+		return nil
+	}
+
+	data, err := hexutil.Decode(input)
+	if err != nil || len(data) < 4 {
+		return nil
+	}
+
+	call := &Call{
+		Selector: hexutil.Encode(data[:4]),
+		Source:   SourceSelector,
+	}
+
+	r.mu.RLock()
+	contract := r.contracts[common.HexToAddress(*to)]
+	candidates := r.methods[[4]byte(data[:4])]
+	r.mu.RUnlock()
+
+	if contract != nil {
+		if method, err := contract.MethodById(data[:4]); err == nil {
+			if args, err := unpackArgs(method.Inputs, data[4:]); err == nil {
+				call.Method, call.Signature, call.Args, call.Source = method.RawName, method.Sig, args, SourceABI
+				return call
+			}
+		}
+	}
+
+	for _, method := range candidates {
+		if args, err := unpackArgs(method.Inputs, data[4:]); err == nil {
+			call.Method, call.Signature, call.Args, call.Source = method.RawName, method.Sig, args, SourceSignature
+			return call
+		}
+	}
+
+	return call
+}
+
+// DecodeLog decodes an event emitted by the contract at address. It returns nil for anonymous logs without topics. When the
+// event is unknown, only its topic is returned.
+func (r *Registry) DecodeLog(address string, topics []string, data string) *Event {
+	if len(topics) == 0 {
+		return nil
+	}
+
+	hashes := make([]common.Hash, 0, len(topics))
+	for _, topic := range topics {
+		hashes = append(hashes, common.HexToHash(topic))
+	}
+
+	payload, err := hexutil.Decode(data)
+	if err != nil {
+		payload = nil
+	}
+
+	event := &Event{
+		Topic:  hashes[0].Hex(),
+		Source: SourceSelector,
+	}
+
+	r.mu.RLock()
+	contract := r.contracts[common.HexToAddress(address)]
+	candidates := r.events[hashes[0]]
+	r.mu.RUnlock()
+
+	if contract != nil {
+		if ev, err := contract.EventByID(hashes[0]); err == nil {
+			if args, err := unpackEvent(ev.Inputs, hashes[1:], payload); err == nil {
+				event.Name, event.Signature, event.Args, event.Source = ev.RawName, ev.Sig, args, SourceABI
+				return event
+			}
+		}
+	}
+
+	for _, candidate := range candidates {
+		inputs, err := candidate.arguments(len(hashes) - 1)
+		if err != nil {
+			continue
+		}
+		if args, err := unpackEvent(inputs, hashes[1:], payload); err == nil {
+			event.Name, event.Signature, event.Args, event.Source = candidate.name, candidate.signature, args, SourceSignature
+			return event
+		}
+	}
+
+	return event
+}
+
+// arguments builds the arguments of the event assuming that the first indexedCount of them are indexed, which is the only
+// layout that can be derived from a signature and the number of topics of a log.
+func (e signatureEvent) arguments(indexedCount int) (abi.Arguments, error) {
+	if indexedCount > len(e.inputs) {
+		return nil, fmt.Errorf("event %s has %d arguments but %d indexed topics", e.signature, len(e.inputs), indexedCount)
+	}
+
+	args, err := toArguments(e.inputs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range indexedCount {
+		args[i].Indexed = true
+	}
+	return args, nil
+}
+
+func unpackArgs(inputs abi.Arguments, data []byte) ([]Arg, error) {
+	values, err := inputs.Unpack(data)
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]Arg, 0, len(inputs))
+	for i, input := range inputs {
+		args = append(args, Arg{
+			Name:  input.Name,
+			Type:  input.Type.String(),
+			Value: formatValue(reflect.ValueOf(values[i])),
+		})
+	}
+	return args, nil
+}
+
+func unpackEvent(inputs abi.Arguments, topics []common.Hash, data []byte) ([]Arg, error) {
+	var indexed abi.Arguments
+	for _, input := range inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+	if len(indexed) != len(topics) {
+		return nil, fmt.Errorf("expected %d indexed topics, got %d", len(indexed), len(topics))
+	}
+
+	values, err := inputs.NonIndexed().Unpack(data)
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]Arg, 0, len(inputs))
+	var topicIdx, dataIdx int
+	for _, input := range inputs {
+		arg := Arg{
+			Name:    input.Name,
+			Type:    input.Type.String(),
+			Indexed: input.Indexed,
+		}
+
+		if input.Indexed {
+			topicValue := make(map[string]any, 1)
+			if err := abi.ParseTopicsIntoMap(topicValue, abi.Arguments{input}, topics[topicIdx:topicIdx+1]); err != nil {
+				return nil, err
+			}
+			arg.Value = formatValue(reflect.ValueOf(topicValue[input.Name]))
+			topicIdx++
+		} else {
+			arg.Value = formatValue(reflect.ValueOf(values[dataIdx]))
+			dataIdx++
+		}
+
+		args = append(args, arg)
+	}
+	return args, nil
+}
+
+func toArguments(inputs []abi.ArgumentMarshaling) (abi.Arguments, error) {
+	args := make(abi.Arguments, 0, len(inputs))
+	for _, input := range inputs {
+		typ, err := abi.NewType(input.Type, input.InternalType, input.Components)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, abi.Argument{Type: typ})
+	}
+	return args, nil
+}
+
+var (
+	bigIntType  = reflect.TypeOf((*big.Int)(nil))
+	addressType = reflect.TypeOf(common.Address{})
+	hashType    = reflect.TypeOf(common.Hash{})
+)
+
+// formatValue converts an unpacked ABI value into a value that encodes to readable JSON.
+func formatValue(v reflect.Value) any {
+	if !v.IsValid() {
+		return nil
+	}
+
+	switch v.Type() {
+	case bigIntType:
+		if v.IsNil() {
+			return nil
+		}
+		return v.Interface().(*big.Int).String()
+	case addressType:
+		return v.Interface().(common.Address).Hex()
+	case hashType:
+		return v.Interface().(common.Hash).Hex()
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(b), v)
+			return hexutil.Encode(b)
+		}
+		items := make([]any, 0, v.Len())
+		for i := range v.Len() {
+			items = append(items, formatValue(v.Index(i)))
+		}
+		return items
+	case reflect.Struct:
+		fields := make(map[string]any, v.NumField())
+		for i := range v.NumField() {
+			name := v.Type().Field(i).Tag.Get("json")
+			if name == "" {
+				name = v.Type().Field(i).Name
+			}
+			fields[name] = formatValue(v.Field(i))
+		}
+		return fields
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return formatValue(v.Elem())
+	default:
+		return v.Interface()
+	}
+}
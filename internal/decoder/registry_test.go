@@ -0,0 +1,320 @@
+package decoder_test
+
+import (
+	"fethcher/internal/decoder"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const vaultABI = `[
+	{"type":"error","name":"InsufficientBalance","inputs":[{"name":"available","type":"uint256"},{"name":"required","type":"uint256"}]}
+]`
+
+const erc20ABI = `[
+	{"type":"function","name":"transfer","stateMutability":"nonpayable","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"event","name":"Transfer","anonymous":false,"inputs":[{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"value","type":"uint256","indexed":false}]}
+]`
+
+var _ = Describe("Registry", func() {
+	var (
+		registry  *decoder.Registry
+		token     string
+		recipient common.Address
+		calldata  string
+	)
+
+	BeforeEach(func() {
+		registry = decoder.NewRegistry()
+		token = "0x00000000000000000000000000000000c0ffee00"
+		recipient = common.HexToAddress("0x00000000000000000000000000000000000000b0")
+
+		parsed, err := abi.JSON(strings.NewReader(erc20ABI))
+		Expect(err).NotTo(HaveOccurred())
+		packed, err := parsed.Pack("transfer", recipient, big.NewInt(1000))
+		Expect(err).NotTo(HaveOccurred())
+		calldata = hexutil.Encode(packed)
+	})
+
+	Describe("Register", func() {
+		It("rejects malformed ABIs", func() {
+			Expect(registry.Register(token, `{"not":"an abi"`)).To(MatchError(decoder.ErrInvalidABI))
+		})
+
+		It("rejects invalid addresses", func() {
+			Expect(registry.Register("0x123", erc20ABI)).To(MatchError(decoder.ErrInvalidABI))
+		})
+	})
+
+	Describe("DecodeInput", func() {
+		var call *decoder.Call
+
+		JustBeforeEach(func() {
+			call = registry.DecodeInput(&token, calldata)
+		})
+
+		When("the contract ABI is registered", func() {
+			BeforeEach(func() {
+				Expect(registry.Register(token, erc20ABI)).To(Succeed())
+			})
+
+			It("decodes the method and its arguments", func() {
+				Expect(call).To(Equal(&decoder.Call{
+					Selector:  "0xa9059cbb",
+					Method:    "transfer",
+					Signature: "transfer(address,uint256)",
+					Args: []decoder.Arg{
+						{Name: "to", Type: "address", Value: recipient.Hex()},
+						{Name: "amount", Type: "uint256", Value: "1000"},
+					},
+					Source: decoder.SourceABI,
+				}))
+			})
+		})
+
+		When("only the signature is known", func() {
+			BeforeEach(func() {
+				loaded, err := registry.LoadSignatures(strings.NewReader("# erc20\n\ntransfer(address,uint256)\ntransfer(address,uint256)\n"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(loaded).To(Equal(1))
+			})
+
+			It("decodes the arguments without names", func() {
+				Expect(call.Source).To(Equal(decoder.SourceSignature))
+				Expect(call.Method).To(Equal("transfer"))
+				Expect(call.Args).To(Equal([]decoder.Arg{
+					{Type: "address", Value: recipient.Hex()},
+					{Type: "uint256", Value: "1000"},
+				}))
+			})
+		})
+
+		When("the method is unknown", func() {
+			It("falls back to the selector", func() {
+				Expect(call).To(Equal(&decoder.Call{Selector: "0xa9059cbb", Source: decoder.SourceSelector}))
+			})
+		})
+
+		When("the input does not match the known signature", func() {
+			BeforeEach(func() {
+				_, err := registry.LoadSignatures(strings.NewReader("transfer(address,uint256)"))
+				Expect(err).NotTo(HaveOccurred())
+				calldata = calldata[:20]
+			})
+
+			It("falls back to the selector", func() {
+				Expect(call.Source).To(Equal(decoder.SourceSelector))
+				Expect(call.Args).To(BeEmpty())
+			})
+		})
+
+		When("the transaction carries no calldata", func() {
+			BeforeEach(func() {
+				calldata = "0x"
+			})
+
+			It("returns nothing", func() {
+				Expect(call).To(BeNil())
+			})
+		})
+	})
+
+	Describe("DecodeLog", func() {
+		var (
+			event  *decoder.Event
+			topics []string
+			data   string
+		)
+
+		BeforeEach(func() {
+			topics = []string{
+				crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)")).Hex(),
+				common.BytesToHash(common.HexToAddress("0xa0").Bytes()).Hex(),
+				common.BytesToHash(recipient.Bytes()).Hex(),
+			}
+			data = hexutil.Encode(common.BigToHash(big.NewInt(5)).Bytes())
+		})
+
+		JustBeforeEach(func() {
+			event = registry.DecodeLog(token, topics, data)
+		})
+
+		When("the contract ABI is registered", func() {
+			BeforeEach(func() {
+				Expect(registry.Register(token, erc20ABI)).To(Succeed())
+			})
+
+			It("decodes the indexed and non-indexed arguments", func() {
+				Expect(event.Source).To(Equal(decoder.SourceABI))
+				Expect(event.Name).To(Equal("Transfer"))
+				Expect(event.Signature).To(Equal("Transfer(address,address,uint256)"))
+				Expect(event.Args).To(Equal([]decoder.Arg{
+					{Name: "from", Type: "address", Indexed: true, Value: common.HexToAddress("0xa0").Hex()},
+					{Name: "to", Type: "address", Indexed: true, Value: recipient.Hex()},
+					{Name: "value", Type: "uint256", Value: "5"},
+				}))
+			})
+		})
+
+		When("only the signature is known", func() {
+			BeforeEach(func() {
+				_, err := registry.LoadSignatures(strings.NewReader("event Transfer(address,address,uint256)"))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("treats the leading arguments as indexed", func() {
+				Expect(event.Source).To(Equal(decoder.SourceSignature))
+				Expect(event.Args).To(HaveLen(3))
+				Expect(event.Args[1]).To(Equal(decoder.Arg{Type: "address", Indexed: true, Value: recipient.Hex()}))
+				Expect(event.Args[2]).To(Equal(decoder.Arg{Type: "uint256", Value: "5"}))
+			})
+		})
+
+		When("the event is unknown", func() {
+			It("falls back to the topic", func() {
+				Expect(event).To(Equal(&decoder.Event{Topic: topics[0], Source: decoder.SourceSelector}))
+			})
+		})
+
+		When("the log is anonymous", func() {
+			BeforeEach(func() {
+				topics = nil
+			})
+
+			It("returns nothing", func() {
+				Expect(event).To(BeNil())
+			})
+		})
+	})
+
+	Describe("DecodeRevert", func() {
+		var (
+			data   string
+			revert *decoder.Revert
+		)
+
+		pack := func(signature string, args ...any) string {
+			selector, err := abi.ParseSelector(signature)
+			Expect(err).NotTo(HaveOccurred())
+			inputs := make(abi.Arguments, 0, len(selector.Inputs))
+			for _, input := range selector.Inputs {
+				typ, err := abi.NewType(input.Type, "", nil)
+				Expect(err).NotTo(HaveOccurred())
+				inputs = append(inputs, abi.Argument{Type: typ})
+			}
+			packed, err := inputs.Pack(args...)
+			Expect(err).NotTo(HaveOccurred())
+			return hexutil.Encode(append(crypto.Keccak256([]byte(signature))[:4], packed...))
+		}
+
+		JustBeforeEach(func() {
+			revert = registry.DecodeRevert(&token, data)
+		})
+
+		When("the call reverted with a message", func() {
+			BeforeEach(func() {
+				data = pack("Error(string)", "ERC20: transfer amount exceeds balance")
+			})
+
+			It("decodes the message", func() {
+				Expect(revert).To(Equal(&decoder.Revert{
+					Kind:      decoder.RevertError,
+					Selector:  "0x08c379a0",
+					Reason:    "ERC20: transfer amount exceeds balance",
+					Name:      "Error",
+					Signature: "Error(string)",
+					Source:    decoder.SourceBuiltin,
+				}))
+			})
+		})
+
+		When("the call panicked", func() {
+			BeforeEach(func() {
+				data = pack("Panic(uint256)", big.NewInt(0x11))
+			})
+
+			It("decodes and describes the panic code", func() {
+				Expect(revert.Kind).To(Equal(decoder.RevertPanic))
+				Expect(revert.Code).To(HaveValue(Equal(uint64(0x11))))
+				Expect(revert.Reason).To(Equal("arithmetic underflow or overflow"))
+			})
+		})
+
+		When("the call reverted with a custom error of a registered contract", func() {
+			BeforeEach(func() {
+				Expect(registry.Register("0x000000000000000000000000000000000000ba17", vaultABI)).To(Succeed())
+				data = pack("InsufficientBalance(uint256,uint256)", big.NewInt(5), big.NewInt(10))
+			})
+
+			It("decodes the error with the ABI even though another contract was called", func() {
+				Expect(revert).To(Equal(&decoder.Revert{
+					Kind:      decoder.RevertCustom,
+					Selector:  revert.Selector,
+					Name:      "InsufficientBalance",
+					Signature: "InsufficientBalance(uint256,uint256)",
+					Args: []decoder.Arg{
+						{Name: "available", Type: "uint256", Value: "5"},
+						{Name: "required", Type: "uint256", Value: "10"},
+					},
+					Source: decoder.SourceABI,
+				}))
+			})
+		})
+
+		When("only the signature of the custom error is known", func() {
+			BeforeEach(func() {
+				_, err := registry.LoadSignatures(strings.NewReader("InsufficientBalance(uint256,uint256)"))
+				Expect(err).NotTo(HaveOccurred())
+				data = pack("InsufficientBalance(uint256,uint256)", big.NewInt(5), big.NewInt(10))
+			})
+
+			It("decodes the arguments without names", func() {
+				Expect(revert.Source).To(Equal(decoder.SourceSignature))
+				Expect(revert.Name).To(Equal("InsufficientBalance"))
+				Expect(revert.Args).To(HaveLen(2))
+			})
+		})
+
+		When("the custom error is unknown", func() {
+			BeforeEach(func() {
+				data = pack("Unauthorized()")
+			})
+
+			It("falls back to the selector", func() {
+				Expect(revert).To(Equal(&decoder.Revert{
+					Kind:     decoder.RevertCustom,
+					Selector: data,
+					Source:   decoder.SourceSelector,
+				}))
+			})
+		})
+
+		When("the call reverted without data", func() {
+			BeforeEach(func() {
+				data = "0x"
+			})
+
+			It("returns nothing", func() {
+				Expect(revert).To(BeNil())
+			})
+		})
+	})
+
+	Describe("LoadSignatures", func() {
+		It("reports the line of a malformed signature", func() {
+			_, err := registry.LoadSignatures(strings.NewReader("transfer(address,uint256)\ntransfer(address,\n"))
+			Expect(err).To(MatchError(ContainSubstring("line 2")))
+		})
+
+		It("fails for a missing file", func() {
+			_, err := registry.LoadSignaturesFile("/does/not/exist")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,132 @@
+package decoder
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// The selectors of the errors built into Solidity, Error(string) and Panic(uint256).
+var (
+	errorSelector = [4]byte{0x08, 0xc3, 0x79, 0xa0}
+	panicSelector = [4]byte{0x4e, 0x48, 0x7b, 0x71}
+)
+
+// panicReasons describes the codes of Panic(uint256) that the Solidity compiler emits.
+var panicReasons = map[uint64]string{
+	0x00: "generic compiler inserted panic",
+	0x01: "assertion failed",
+	0x11: "arithmetic underflow or overflow",
+	0x12: "division or modulo by zero",
+	0x21: "conversion to an invalid enum value",
+	0x22: "access to an incorrectly encoded storage byte array",
+	0x31: "pop on an empty array",
+	0x32: "array index out of bounds",
+	0x41: "out of memory",
+	0x51: "call to a zero-initialized internal function",
+}
+
+var (
+	stringType, _  = abi.NewType("string", "", nil)
+	uint256Type, _ = abi.NewType("uint256", "", nil)
+)
+
+// DecodeRevert decodes the revert data of a failed call to the given address. Custom errors are looked up in the ABI of the
+// contract first, then in every other registered ABI, since errors bubble up from the contracts it calls, and in the signature
+// database last. It returns nil when the call reverted without data. When a custom error is unknown, only its selector is
+// returned.
+func (r *Registry) DecodeRevert(to *string, data string) *Revert {
+	payload, err := hexutil.Decode(data)
+	if err != nil || len(payload) < 4 {
+		return nil
+	}
+
+	selector := [4]byte(payload[:4])
+	revert := &Revert{
+		Kind:     RevertCustom,
+		Selector: hexutil.Encode(payload[:4]),
+		Source:   SourceSelector,
+	}
+
+	switch selector {
+	case errorSelector:
+		values, err := abi.Arguments{{Type: stringType}}.Unpack(payload[4:])
+		if err == nil {
+			revert.Kind, revert.Reason, revert.Source = RevertError, values[0].(string), SourceBuiltin
+			revert.Name, revert.Signature = "Error", "Error(string)"
+			return revert
+		}
+	case panicSelector:
+		values, err := abi.Arguments{{Type: uint256Type}}.Unpack(payload[4:])
+		if err == nil {
+			code := values[0].(*big.Int)
+			revert.Kind, revert.Source = RevertPanic, SourceBuiltin
+			revert.Name, revert.Signature = "Panic", "Panic(uint256)"
+			revert.Reason = fmt.Sprintf("unknown panic code 0x%x", code)
+			if code.IsUint64() {
+				number := code.Uint64()
+				revert.Code = &number
+				if reason, ok := panicReasons[number]; ok {
+					revert.Reason = reason
+				}
+			}
+			return revert
+		}
+	}
+
+	r.mu.RLock()
+	contracts := r.errorContracts(to)
+	candidates := r.methods[selector]
+	r.mu.RUnlock()
+
+	for _, contract := range contracts {
+		if abiError, err := contract.ErrorByID(selector); err == nil {
+			if args, err := unpackArgs(abiError.Inputs, payload[4:]); err == nil {
+				revert.Name, revert.Signature, revert.Args, revert.Source = abiError.Name, abiError.Sig, args, SourceABI
+				return revert
+			}
+		}
+	}
+
+	for _, method := range candidates {
+		if args, err := unpackArgs(method.Inputs, payload[4:]); err == nil {
+			revert.Name, revert.Signature, revert.Args, revert.Source = method.RawName, method.Sig, args, SourceSignature
+			return revert
+		}
+	}
+
+	return revert
+}
+
+// errorContracts returns the registered ABIs that custom errors are looked up in: the one of the contract at to first and the
+// others ordered by address. The caller must hold the read lock.
+func (r *Registry) errorContracts(to *string) []*abi.ABI {
+	var first common.Address
+	if to != nil {
+		first = common.HexToAddress(*to)
+	}
+
+	addresses := make([]common.Address, 0, len(r.contracts))
+	for address := range r.contracts {
+		if to == nil || address != first {
+			addresses = append(addresses, address)
+		}
+	}
+	slices.SortFunc(addresses, func(a, b common.Address) int {
+		return bytes.Compare(a[:], b[:])
+	})
+
+	contracts := make([]*abi.ABI, 0, len(r.contracts))
+	if contract, ok := r.contracts[first]; ok && to != nil {
+		contracts = append(contracts, contract)
+	}
+	for _, address := range addresses {
+		contracts = append(contracts, r.contracts[address])
+	}
+	return contracts
+}
@@ -0,0 +1,114 @@
+package decoder
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// LoadSignaturesFile loads the function and event signatures in the file at path, see LoadSignatures.
+func (r *Registry) LoadSignaturesFile(path string) (int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, fmt.Errorf("open signatures file: %w", err)
+	}
+	defer file.Close()
+
+	return r.LoadSignatures(file)
+}
+
+// LoadSignatures adds the signatures read from reader to the signature database and returns how many were added. Every line
+// holds a single canonical signature, e.g. "transfer(address,uint256)" for a function or
+// "event Transfer(address,address,uint256)" for an event. Empty lines and lines starting with # are skipped, and so are
+// signatures that are already known. A malformed line stops the loading with an error that names it.
+func (r *Registry) LoadSignatures(reader io.Reader) (int, error) {
+	scanner := bufio.NewScanner(reader)
+
+	loaded := 0
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		var (
+			added bool
+			err   error
+		)
+		if signature, ok := strings.CutPrefix(line, "event "); ok {
+			added, err = r.addEventSignature(strings.TrimSpace(signature))
+		} else {
+			added, err = r.addMethodSignature(strings.TrimSpace(strings.TrimPrefix(line, "function ")))
+		}
+		if err != nil {
+			return loaded, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		if added {
+			loaded++
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return loaded, fmt.Errorf("read signatures: %w", err)
+	}
+	return loaded, nil
+}
+
+func (r *Registry) addMethodSignature(signature string) (bool, error) {
+	selector, err := abi.ParseSelector(signature)
+	if err != nil {
+		return false, err
+	}
+
+	args, err := toArguments(selector.Inputs)
+	if err != nil {
+		return false, fmt.Errorf("parse %q: %w", signature, err)
+	}
+
+	method := abi.NewMethod(selector.Name, selector.Name, abi.Function, "", false, false, args, nil)
+	id := [4]byte(method.ID)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, known := range r.methods[id] {
+		if known.Sig == method.Sig {
+			return false, nil
+		}
+	}
+	r.methods[id] = append(r.methods[id], method)
+	return true, nil
+}
+
+func (r *Registry) addEventSignature(signature string) (bool, error) {
+	selector, err := abi.ParseSelector(signature)
+	if err != nil {
+		return false, err
+	}
+
+	args, err := toArguments(selector.Inputs)
+	if err != nil {
+		return false, fmt.Errorf("parse %q: %w", signature, err)
+	}
+
+	event := abi.NewEvent(selector.Name, selector.Name, false, args)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, known := range r.events[event.ID] {
+		if known.signature == event.Sig {
+			return false, nil
+		}
+	}
+	r.events[event.ID] = append(r.events[event.ID], signatureEvent{
+		name:      selector.Name,
+		signature: event.Sig,
+		inputs:    selector.Inputs,
+	})
+	return true, nil
+}
@@ -0,0 +1,39 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// NodeClient is an EthClient backed by a single JSON-RPC endpoint. On top of the typed ethclient API it exposes the raw RPC
+// client for batch requests.
+type NodeClient struct {
+	*ethclient.Client
+	rpcClient *rpc.Client
+}
+
+// DialNode connects to the Ethereum node at the given URL.
+func DialNode(ctx context.Context, rawURL string) (*NodeClient, error) {
+	rpcClient, err := rpc.DialContext(ctx, rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("dial rpc: %w", err)
+	}
+
+	return &NodeClient{
+		Client:    ethclient.NewClient(rpcClient),
+		rpcClient: rpcClient,
+	}, nil
+}
+
+// CallContext sends a single JSON-RPC request, for the methods that the typed API does not cover.
+func (c *NodeClient) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	return c.rpcClient.CallContext(ctx, result, method, args...)
+}
+
+// BatchCallContext sends all given requests as a single JSON-RPC batch.
+func (c *NodeClient) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	return c.rpcClient.BatchCallContext(ctx, b)
+}
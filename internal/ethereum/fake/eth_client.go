@@ -9,66 +9,329 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
 type EthClient struct {
-	NetworkIDStub        func(context.Context) (*big.Int, error)
-	networkIDMutex       sync.RWMutex
-	networkIDArgsForCall []struct {
+	BatchCallContextStub        func(context.Context, []rpc.BatchElem) error
+	batchCallContextMutex       sync.RWMutex
+	batchCallContextArgsForCall []struct {
 		arg1 context.Context
+		arg2 []rpc.BatchElem
 	}
-	networkIDReturns struct {
+	batchCallContextReturns struct {
+		result1 error
+	}
+	batchCallContextReturnsOnCall map[int]struct {
+		result1 error
+	}
+	BlockByHashStub        func(context.Context, common.Hash) (*types.Block, error)
+	blockByHashMutex       sync.RWMutex
+	blockByHashArgsForCall []struct {
+		arg1 context.Context
+		arg2 common.Hash
+	}
+	blockByHashReturns struct {
+		result1 *types.Block
+		result2 error
+	}
+	blockByHashReturnsOnCall map[int]struct {
+		result1 *types.Block
+		result2 error
+	}
+	BlockByNumberStub        func(context.Context, *big.Int) (*types.Block, error)
+	blockByNumberMutex       sync.RWMutex
+	blockByNumberArgsForCall []struct {
+		arg1 context.Context
+		arg2 *big.Int
+	}
+	blockByNumberReturns struct {
+		result1 *types.Block
+		result2 error
+	}
+	blockByNumberReturnsOnCall map[int]struct {
+		result1 *types.Block
+		result2 error
+	}
+	BlockNumberStub        func(context.Context) (uint64, error)
+	blockNumberMutex       sync.RWMutex
+	blockNumberArgsForCall []struct {
+		arg1 context.Context
+	}
+	blockNumberReturns struct {
+		result1 uint64
+		result2 error
+	}
+	blockNumberReturnsOnCall map[int]struct {
+		result1 uint64
+		result2 error
+	}
+	BlockReceiptsStub        func(context.Context, rpc.BlockNumberOrHash) ([]*types.Receipt, error)
+	blockReceiptsMutex       sync.RWMutex
+	blockReceiptsArgsForCall []struct {
+		arg1 context.Context
+		arg2 rpc.BlockNumberOrHash
+	}
+	blockReceiptsReturns struct {
+		result1 []*types.Receipt
+		result2 error
+	}
+	blockReceiptsReturnsOnCall map[int]struct {
+		result1 []*types.Receipt
+		result2 error
+	}
+	CallContextStub        func(context.Context, any, string, ...any) error
+	callContextMutex       sync.RWMutex
+	callContextArgsForCall []struct {
+		arg1 context.Context
+		arg2 any
+		arg3 string
+		arg4 []any
+	}
+	callContextReturns struct {
+		result1 error
+	}
+	callContextReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ChainIDStub        func(context.Context) (*big.Int, error)
+	chainIDMutex       sync.RWMutex
+	chainIDArgsForCall []struct {
+		arg1 context.Context
+	}
+	chainIDReturns struct {
 		result1 *big.Int
 		result2 error
 	}
-	networkIDReturnsOnCall map[int]struct {
+	chainIDReturnsOnCall map[int]struct {
 		result1 *big.Int
 		result2 error
 	}
-	TransactionByHashStub        func(context.Context, common.Hash) (*types.Transaction, bool, error)
-	transactionByHashMutex       sync.RWMutex
-	transactionByHashArgsForCall []struct {
+	HeaderByHashStub        func(context.Context, common.Hash) (*types.Header, error)
+	headerByHashMutex       sync.RWMutex
+	headerByHashArgsForCall []struct {
 		arg1 context.Context
 		arg2 common.Hash
 	}
-	transactionByHashReturns struct {
-		result1 *types.Transaction
-		result2 bool
-		result3 error
+	headerByHashReturns struct {
+		result1 *types.Header
+		result2 error
+	}
+	headerByHashReturnsOnCall map[int]struct {
+		result1 *types.Header
+		result2 error
 	}
-	transactionByHashReturnsOnCall map[int]struct {
-		result1 *types.Transaction
-		result2 bool
-		result3 error
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *EthClient) BatchCallContext(arg1 context.Context, arg2 []rpc.BatchElem) error {
+	var arg2Copy []rpc.BatchElem
+	if arg2 != nil {
+		arg2Copy = make([]rpc.BatchElem, len(arg2))
+		copy(arg2Copy, arg2)
 	}
-	TransactionReceiptStub        func(context.Context, common.Hash) (*types.Receipt, error)
-	transactionReceiptMutex       sync.RWMutex
-	transactionReceiptArgsForCall []struct {
+	fake.batchCallContextMutex.Lock()
+	ret, specificReturn := fake.batchCallContextReturnsOnCall[len(fake.batchCallContextArgsForCall)]
+	fake.batchCallContextArgsForCall = append(fake.batchCallContextArgsForCall, struct {
+		arg1 context.Context
+		arg2 []rpc.BatchElem
+	}{arg1, arg2Copy})
+	stub := fake.BatchCallContextStub
+	fakeReturns := fake.batchCallContextReturns
+	fake.recordInvocation("BatchCallContext", []interface{}{arg1, arg2Copy})
+	fake.batchCallContextMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *EthClient) BatchCallContextCallCount() int {
+	fake.batchCallContextMutex.RLock()
+	defer fake.batchCallContextMutex.RUnlock()
+	return len(fake.batchCallContextArgsForCall)
+}
+
+func (fake *EthClient) BatchCallContextCalls(stub func(context.Context, []rpc.BatchElem) error) {
+	fake.batchCallContextMutex.Lock()
+	defer fake.batchCallContextMutex.Unlock()
+	fake.BatchCallContextStub = stub
+}
+
+func (fake *EthClient) BatchCallContextArgsForCall(i int) (context.Context, []rpc.BatchElem) {
+	fake.batchCallContextMutex.RLock()
+	defer fake.batchCallContextMutex.RUnlock()
+	argsForCall := fake.batchCallContextArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *EthClient) BatchCallContextReturns(result1 error) {
+	fake.batchCallContextMutex.Lock()
+	defer fake.batchCallContextMutex.Unlock()
+	fake.BatchCallContextStub = nil
+	fake.batchCallContextReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *EthClient) BatchCallContextReturnsOnCall(i int, result1 error) {
+	fake.batchCallContextMutex.Lock()
+	defer fake.batchCallContextMutex.Unlock()
+	fake.BatchCallContextStub = nil
+	if fake.batchCallContextReturnsOnCall == nil {
+		fake.batchCallContextReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.batchCallContextReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *EthClient) BlockByHash(arg1 context.Context, arg2 common.Hash) (*types.Block, error) {
+	fake.blockByHashMutex.Lock()
+	ret, specificReturn := fake.blockByHashReturnsOnCall[len(fake.blockByHashArgsForCall)]
+	fake.blockByHashArgsForCall = append(fake.blockByHashArgsForCall, struct {
 		arg1 context.Context
 		arg2 common.Hash
+	}{arg1, arg2})
+	stub := fake.BlockByHashStub
+	fakeReturns := fake.blockByHashReturns
+	fake.recordInvocation("BlockByHash", []interface{}{arg1, arg2})
+	fake.blockByHashMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
 	}
-	transactionReceiptReturns struct {
-		result1 *types.Receipt
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *EthClient) BlockByHashCallCount() int {
+	fake.blockByHashMutex.RLock()
+	defer fake.blockByHashMutex.RUnlock()
+	return len(fake.blockByHashArgsForCall)
+}
+
+func (fake *EthClient) BlockByHashCalls(stub func(context.Context, common.Hash) (*types.Block, error)) {
+	fake.blockByHashMutex.Lock()
+	defer fake.blockByHashMutex.Unlock()
+	fake.BlockByHashStub = stub
+}
+
+func (fake *EthClient) BlockByHashArgsForCall(i int) (context.Context, common.Hash) {
+	fake.blockByHashMutex.RLock()
+	defer fake.blockByHashMutex.RUnlock()
+	argsForCall := fake.blockByHashArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *EthClient) BlockByHashReturns(result1 *types.Block, result2 error) {
+	fake.blockByHashMutex.Lock()
+	defer fake.blockByHashMutex.Unlock()
+	fake.BlockByHashStub = nil
+	fake.blockByHashReturns = struct {
+		result1 *types.Block
 		result2 error
+	}{result1, result2}
+}
+
+func (fake *EthClient) BlockByHashReturnsOnCall(i int, result1 *types.Block, result2 error) {
+	fake.blockByHashMutex.Lock()
+	defer fake.blockByHashMutex.Unlock()
+	fake.BlockByHashStub = nil
+	if fake.blockByHashReturnsOnCall == nil {
+		fake.blockByHashReturnsOnCall = make(map[int]struct {
+			result1 *types.Block
+			result2 error
+		})
 	}
-	transactionReceiptReturnsOnCall map[int]struct {
-		result1 *types.Receipt
+	fake.blockByHashReturnsOnCall[i] = struct {
+		result1 *types.Block
 		result2 error
+	}{result1, result2}
+}
+
+func (fake *EthClient) BlockByNumber(arg1 context.Context, arg2 *big.Int) (*types.Block, error) {
+	fake.blockByNumberMutex.Lock()
+	ret, specificReturn := fake.blockByNumberReturnsOnCall[len(fake.blockByNumberArgsForCall)]
+	fake.blockByNumberArgsForCall = append(fake.blockByNumberArgsForCall, struct {
+		arg1 context.Context
+		arg2 *big.Int
+	}{arg1, arg2})
+	stub := fake.BlockByNumberStub
+	fakeReturns := fake.blockByNumberReturns
+	fake.recordInvocation("BlockByNumber", []interface{}{arg1, arg2})
+	fake.blockByNumberMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
 	}
-	invocations      map[string][][]interface{}
-	invocationsMutex sync.RWMutex
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *EthClient) BlockByNumberCallCount() int {
+	fake.blockByNumberMutex.RLock()
+	defer fake.blockByNumberMutex.RUnlock()
+	return len(fake.blockByNumberArgsForCall)
+}
+
+func (fake *EthClient) BlockByNumberCalls(stub func(context.Context, *big.Int) (*types.Block, error)) {
+	fake.blockByNumberMutex.Lock()
+	defer fake.blockByNumberMutex.Unlock()
+	fake.BlockByNumberStub = stub
+}
+
+func (fake *EthClient) BlockByNumberArgsForCall(i int) (context.Context, *big.Int) {
+	fake.blockByNumberMutex.RLock()
+	defer fake.blockByNumberMutex.RUnlock()
+	argsForCall := fake.blockByNumberArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *EthClient) BlockByNumberReturns(result1 *types.Block, result2 error) {
+	fake.blockByNumberMutex.Lock()
+	defer fake.blockByNumberMutex.Unlock()
+	fake.BlockByNumberStub = nil
+	fake.blockByNumberReturns = struct {
+		result1 *types.Block
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EthClient) BlockByNumberReturnsOnCall(i int, result1 *types.Block, result2 error) {
+	fake.blockByNumberMutex.Lock()
+	defer fake.blockByNumberMutex.Unlock()
+	fake.BlockByNumberStub = nil
+	if fake.blockByNumberReturnsOnCall == nil {
+		fake.blockByNumberReturnsOnCall = make(map[int]struct {
+			result1 *types.Block
+			result2 error
+		})
+	}
+	fake.blockByNumberReturnsOnCall[i] = struct {
+		result1 *types.Block
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *EthClient) NetworkID(arg1 context.Context) (*big.Int, error) {
-	fake.networkIDMutex.Lock()
-	ret, specificReturn := fake.networkIDReturnsOnCall[len(fake.networkIDArgsForCall)]
-	fake.networkIDArgsForCall = append(fake.networkIDArgsForCall, struct {
+func (fake *EthClient) BlockNumber(arg1 context.Context) (uint64, error) {
+	fake.blockNumberMutex.Lock()
+	ret, specificReturn := fake.blockNumberReturnsOnCall[len(fake.blockNumberArgsForCall)]
+	fake.blockNumberArgsForCall = append(fake.blockNumberArgsForCall, struct {
 		arg1 context.Context
 	}{arg1})
-	stub := fake.NetworkIDStub
-	fakeReturns := fake.networkIDReturns
-	fake.recordInvocation("NetworkID", []interface{}{arg1})
-	fake.networkIDMutex.Unlock()
+	stub := fake.BlockNumberStub
+	fakeReturns := fake.blockNumberReturns
+	fake.recordInvocation("BlockNumber", []interface{}{arg1})
+	fake.blockNumberMutex.Unlock()
 	if stub != nil {
 		return stub(arg1)
 	}
@@ -78,130 +341,255 @@ func (fake *EthClient) NetworkID(arg1 context.Context) (*big.Int, error) {
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *EthClient) NetworkIDCallCount() int {
-	fake.networkIDMutex.RLock()
-	defer fake.networkIDMutex.RUnlock()
-	return len(fake.networkIDArgsForCall)
+func (fake *EthClient) BlockNumberCallCount() int {
+	fake.blockNumberMutex.RLock()
+	defer fake.blockNumberMutex.RUnlock()
+	return len(fake.blockNumberArgsForCall)
 }
 
-func (fake *EthClient) NetworkIDCalls(stub func(context.Context) (*big.Int, error)) {
-	fake.networkIDMutex.Lock()
-	defer fake.networkIDMutex.Unlock()
-	fake.NetworkIDStub = stub
+func (fake *EthClient) BlockNumberCalls(stub func(context.Context) (uint64, error)) {
+	fake.blockNumberMutex.Lock()
+	defer fake.blockNumberMutex.Unlock()
+	fake.BlockNumberStub = stub
 }
 
-func (fake *EthClient) NetworkIDArgsForCall(i int) context.Context {
-	fake.networkIDMutex.RLock()
-	defer fake.networkIDMutex.RUnlock()
-	argsForCall := fake.networkIDArgsForCall[i]
+func (fake *EthClient) BlockNumberArgsForCall(i int) context.Context {
+	fake.blockNumberMutex.RLock()
+	defer fake.blockNumberMutex.RUnlock()
+	argsForCall := fake.blockNumberArgsForCall[i]
 	return argsForCall.arg1
 }
 
-func (fake *EthClient) NetworkIDReturns(result1 *big.Int, result2 error) {
-	fake.networkIDMutex.Lock()
-	defer fake.networkIDMutex.Unlock()
-	fake.NetworkIDStub = nil
-	fake.networkIDReturns = struct {
-		result1 *big.Int
+func (fake *EthClient) BlockNumberReturns(result1 uint64, result2 error) {
+	fake.blockNumberMutex.Lock()
+	defer fake.blockNumberMutex.Unlock()
+	fake.BlockNumberStub = nil
+	fake.blockNumberReturns = struct {
+		result1 uint64
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *EthClient) NetworkIDReturnsOnCall(i int, result1 *big.Int, result2 error) {
-	fake.networkIDMutex.Lock()
-	defer fake.networkIDMutex.Unlock()
-	fake.NetworkIDStub = nil
-	if fake.networkIDReturnsOnCall == nil {
-		fake.networkIDReturnsOnCall = make(map[int]struct {
-			result1 *big.Int
+func (fake *EthClient) BlockNumberReturnsOnCall(i int, result1 uint64, result2 error) {
+	fake.blockNumberMutex.Lock()
+	defer fake.blockNumberMutex.Unlock()
+	fake.BlockNumberStub = nil
+	if fake.blockNumberReturnsOnCall == nil {
+		fake.blockNumberReturnsOnCall = make(map[int]struct {
+			result1 uint64
 			result2 error
 		})
 	}
-	fake.networkIDReturnsOnCall[i] = struct {
-		result1 *big.Int
+	fake.blockNumberReturnsOnCall[i] = struct {
+		result1 uint64
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *EthClient) TransactionByHash(arg1 context.Context, arg2 common.Hash) (*types.Transaction, bool, error) {
-	fake.transactionByHashMutex.Lock()
-	ret, specificReturn := fake.transactionByHashReturnsOnCall[len(fake.transactionByHashArgsForCall)]
-	fake.transactionByHashArgsForCall = append(fake.transactionByHashArgsForCall, struct {
+func (fake *EthClient) BlockReceipts(arg1 context.Context, arg2 rpc.BlockNumberOrHash) ([]*types.Receipt, error) {
+	fake.blockReceiptsMutex.Lock()
+	ret, specificReturn := fake.blockReceiptsReturnsOnCall[len(fake.blockReceiptsArgsForCall)]
+	fake.blockReceiptsArgsForCall = append(fake.blockReceiptsArgsForCall, struct {
 		arg1 context.Context
-		arg2 common.Hash
+		arg2 rpc.BlockNumberOrHash
 	}{arg1, arg2})
-	stub := fake.TransactionByHashStub
-	fakeReturns := fake.transactionByHashReturns
-	fake.recordInvocation("TransactionByHash", []interface{}{arg1, arg2})
-	fake.transactionByHashMutex.Unlock()
+	stub := fake.BlockReceiptsStub
+	fakeReturns := fake.blockReceiptsReturns
+	fake.recordInvocation("BlockReceipts", []interface{}{arg1, arg2})
+	fake.blockReceiptsMutex.Unlock()
 	if stub != nil {
 		return stub(arg1, arg2)
 	}
 	if specificReturn {
-		return ret.result1, ret.result2, ret.result3
+		return ret.result1, ret.result2
 	}
-	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *EthClient) TransactionByHashCallCount() int {
-	fake.transactionByHashMutex.RLock()
-	defer fake.transactionByHashMutex.RUnlock()
-	return len(fake.transactionByHashArgsForCall)
+func (fake *EthClient) BlockReceiptsCallCount() int {
+	fake.blockReceiptsMutex.RLock()
+	defer fake.blockReceiptsMutex.RUnlock()
+	return len(fake.blockReceiptsArgsForCall)
 }
 
-func (fake *EthClient) TransactionByHashCalls(stub func(context.Context, common.Hash) (*types.Transaction, bool, error)) {
-	fake.transactionByHashMutex.Lock()
-	defer fake.transactionByHashMutex.Unlock()
-	fake.TransactionByHashStub = stub
+func (fake *EthClient) BlockReceiptsCalls(stub func(context.Context, rpc.BlockNumberOrHash) ([]*types.Receipt, error)) {
+	fake.blockReceiptsMutex.Lock()
+	defer fake.blockReceiptsMutex.Unlock()
+	fake.BlockReceiptsStub = stub
 }
 
-func (fake *EthClient) TransactionByHashArgsForCall(i int) (context.Context, common.Hash) {
-	fake.transactionByHashMutex.RLock()
-	defer fake.transactionByHashMutex.RUnlock()
-	argsForCall := fake.transactionByHashArgsForCall[i]
+func (fake *EthClient) BlockReceiptsArgsForCall(i int) (context.Context, rpc.BlockNumberOrHash) {
+	fake.blockReceiptsMutex.RLock()
+	defer fake.blockReceiptsMutex.RUnlock()
+	argsForCall := fake.blockReceiptsArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *EthClient) TransactionByHashReturns(result1 *types.Transaction, result2 bool, result3 error) {
-	fake.transactionByHashMutex.Lock()
-	defer fake.transactionByHashMutex.Unlock()
-	fake.TransactionByHashStub = nil
-	fake.transactionByHashReturns = struct {
-		result1 *types.Transaction
-		result2 bool
-		result3 error
-	}{result1, result2, result3}
-}
-
-func (fake *EthClient) TransactionByHashReturnsOnCall(i int, result1 *types.Transaction, result2 bool, result3 error) {
-	fake.transactionByHashMutex.Lock()
-	defer fake.transactionByHashMutex.Unlock()
-	fake.TransactionByHashStub = nil
-	if fake.transactionByHashReturnsOnCall == nil {
-		fake.transactionByHashReturnsOnCall = make(map[int]struct {
-			result1 *types.Transaction
-			result2 bool
-			result3 error
+func (fake *EthClient) BlockReceiptsReturns(result1 []*types.Receipt, result2 error) {
+	fake.blockReceiptsMutex.Lock()
+	defer fake.blockReceiptsMutex.Unlock()
+	fake.BlockReceiptsStub = nil
+	fake.blockReceiptsReturns = struct {
+		result1 []*types.Receipt
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EthClient) BlockReceiptsReturnsOnCall(i int, result1 []*types.Receipt, result2 error) {
+	fake.blockReceiptsMutex.Lock()
+	defer fake.blockReceiptsMutex.Unlock()
+	fake.BlockReceiptsStub = nil
+	if fake.blockReceiptsReturnsOnCall == nil {
+		fake.blockReceiptsReturnsOnCall = make(map[int]struct {
+			result1 []*types.Receipt
+			result2 error
+		})
+	}
+	fake.blockReceiptsReturnsOnCall[i] = struct {
+		result1 []*types.Receipt
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EthClient) CallContext(arg1 context.Context, arg2 any, arg3 string, arg4 ...any) error {
+	fake.callContextMutex.Lock()
+	ret, specificReturn := fake.callContextReturnsOnCall[len(fake.callContextArgsForCall)]
+	fake.callContextArgsForCall = append(fake.callContextArgsForCall, struct {
+		arg1 context.Context
+		arg2 any
+		arg3 string
+		arg4 []any
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.CallContextStub
+	fakeReturns := fake.callContextReturns
+	fake.recordInvocation("CallContext", []interface{}{arg1, arg2, arg3, arg4})
+	fake.callContextMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4...)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *EthClient) CallContextCallCount() int {
+	fake.callContextMutex.RLock()
+	defer fake.callContextMutex.RUnlock()
+	return len(fake.callContextArgsForCall)
+}
+
+func (fake *EthClient) CallContextCalls(stub func(context.Context, any, string, ...any) error) {
+	fake.callContextMutex.Lock()
+	defer fake.callContextMutex.Unlock()
+	fake.CallContextStub = stub
+}
+
+func (fake *EthClient) CallContextArgsForCall(i int) (context.Context, any, string, []any) {
+	fake.callContextMutex.RLock()
+	defer fake.callContextMutex.RUnlock()
+	argsForCall := fake.callContextArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *EthClient) CallContextReturns(result1 error) {
+	fake.callContextMutex.Lock()
+	defer fake.callContextMutex.Unlock()
+	fake.CallContextStub = nil
+	fake.callContextReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *EthClient) CallContextReturnsOnCall(i int, result1 error) {
+	fake.callContextMutex.Lock()
+	defer fake.callContextMutex.Unlock()
+	fake.CallContextStub = nil
+	if fake.callContextReturnsOnCall == nil {
+		fake.callContextReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.callContextReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *EthClient) ChainID(arg1 context.Context) (*big.Int, error) {
+	fake.chainIDMutex.Lock()
+	ret, specificReturn := fake.chainIDReturnsOnCall[len(fake.chainIDArgsForCall)]
+	fake.chainIDArgsForCall = append(fake.chainIDArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.ChainIDStub
+	fakeReturns := fake.chainIDReturns
+	fake.recordInvocation("ChainID", []interface{}{arg1})
+	fake.chainIDMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *EthClient) ChainIDCallCount() int {
+	fake.chainIDMutex.RLock()
+	defer fake.chainIDMutex.RUnlock()
+	return len(fake.chainIDArgsForCall)
+}
+
+func (fake *EthClient) ChainIDCalls(stub func(context.Context) (*big.Int, error)) {
+	fake.chainIDMutex.Lock()
+	defer fake.chainIDMutex.Unlock()
+	fake.ChainIDStub = stub
+}
+
+func (fake *EthClient) ChainIDArgsForCall(i int) context.Context {
+	fake.chainIDMutex.RLock()
+	defer fake.chainIDMutex.RUnlock()
+	argsForCall := fake.chainIDArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *EthClient) ChainIDReturns(result1 *big.Int, result2 error) {
+	fake.chainIDMutex.Lock()
+	defer fake.chainIDMutex.Unlock()
+	fake.ChainIDStub = nil
+	fake.chainIDReturns = struct {
+		result1 *big.Int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EthClient) ChainIDReturnsOnCall(i int, result1 *big.Int, result2 error) {
+	fake.chainIDMutex.Lock()
+	defer fake.chainIDMutex.Unlock()
+	fake.ChainIDStub = nil
+	if fake.chainIDReturnsOnCall == nil {
+		fake.chainIDReturnsOnCall = make(map[int]struct {
+			result1 *big.Int
+			result2 error
 		})
 	}
-	fake.transactionByHashReturnsOnCall[i] = struct {
-		result1 *types.Transaction
-		result2 bool
-		result3 error
-	}{result1, result2, result3}
+	fake.chainIDReturnsOnCall[i] = struct {
+		result1 *big.Int
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *EthClient) TransactionReceipt(arg1 context.Context, arg2 common.Hash) (*types.Receipt, error) {
-	fake.transactionReceiptMutex.Lock()
-	ret, specificReturn := fake.transactionReceiptReturnsOnCall[len(fake.transactionReceiptArgsForCall)]
-	fake.transactionReceiptArgsForCall = append(fake.transactionReceiptArgsForCall, struct {
+func (fake *EthClient) HeaderByHash(arg1 context.Context, arg2 common.Hash) (*types.Header, error) {
+	fake.headerByHashMutex.Lock()
+	ret, specificReturn := fake.headerByHashReturnsOnCall[len(fake.headerByHashArgsForCall)]
+	fake.headerByHashArgsForCall = append(fake.headerByHashArgsForCall, struct {
 		arg1 context.Context
 		arg2 common.Hash
 	}{arg1, arg2})
-	stub := fake.TransactionReceiptStub
-	fakeReturns := fake.transactionReceiptReturns
-	fake.recordInvocation("TransactionReceipt", []interface{}{arg1, arg2})
-	fake.transactionReceiptMutex.Unlock()
+	stub := fake.HeaderByHashStub
+	fakeReturns := fake.headerByHashReturns
+	fake.recordInvocation("HeaderByHash", []interface{}{arg1, arg2})
+	fake.headerByHashMutex.Unlock()
 	if stub != nil {
 		return stub(arg1, arg2)
 	}
@@ -211,47 +599,47 @@ func (fake *EthClient) TransactionReceipt(arg1 context.Context, arg2 common.Hash
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *EthClient) TransactionReceiptCallCount() int {
-	fake.transactionReceiptMutex.RLock()
-	defer fake.transactionReceiptMutex.RUnlock()
-	return len(fake.transactionReceiptArgsForCall)
+func (fake *EthClient) HeaderByHashCallCount() int {
+	fake.headerByHashMutex.RLock()
+	defer fake.headerByHashMutex.RUnlock()
+	return len(fake.headerByHashArgsForCall)
 }
 
-func (fake *EthClient) TransactionReceiptCalls(stub func(context.Context, common.Hash) (*types.Receipt, error)) {
-	fake.transactionReceiptMutex.Lock()
-	defer fake.transactionReceiptMutex.Unlock()
-	fake.TransactionReceiptStub = stub
+func (fake *EthClient) HeaderByHashCalls(stub func(context.Context, common.Hash) (*types.Header, error)) {
+	fake.headerByHashMutex.Lock()
+	defer fake.headerByHashMutex.Unlock()
+	fake.HeaderByHashStub = stub
 }
 
-func (fake *EthClient) TransactionReceiptArgsForCall(i int) (context.Context, common.Hash) {
-	fake.transactionReceiptMutex.RLock()
-	defer fake.transactionReceiptMutex.RUnlock()
-	argsForCall := fake.transactionReceiptArgsForCall[i]
+func (fake *EthClient) HeaderByHashArgsForCall(i int) (context.Context, common.Hash) {
+	fake.headerByHashMutex.RLock()
+	defer fake.headerByHashMutex.RUnlock()
+	argsForCall := fake.headerByHashArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *EthClient) TransactionReceiptReturns(result1 *types.Receipt, result2 error) {
-	fake.transactionReceiptMutex.Lock()
-	defer fake.transactionReceiptMutex.Unlock()
-	fake.TransactionReceiptStub = nil
-	fake.transactionReceiptReturns = struct {
-		result1 *types.Receipt
+func (fake *EthClient) HeaderByHashReturns(result1 *types.Header, result2 error) {
+	fake.headerByHashMutex.Lock()
+	defer fake.headerByHashMutex.Unlock()
+	fake.HeaderByHashStub = nil
+	fake.headerByHashReturns = struct {
+		result1 *types.Header
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *EthClient) TransactionReceiptReturnsOnCall(i int, result1 *types.Receipt, result2 error) {
-	fake.transactionReceiptMutex.Lock()
-	defer fake.transactionReceiptMutex.Unlock()
-	fake.TransactionReceiptStub = nil
-	if fake.transactionReceiptReturnsOnCall == nil {
-		fake.transactionReceiptReturnsOnCall = make(map[int]struct {
-			result1 *types.Receipt
+func (fake *EthClient) HeaderByHashReturnsOnCall(i int, result1 *types.Header, result2 error) {
+	fake.headerByHashMutex.Lock()
+	defer fake.headerByHashMutex.Unlock()
+	fake.HeaderByHashStub = nil
+	if fake.headerByHashReturnsOnCall == nil {
+		fake.headerByHashReturnsOnCall = make(map[int]struct {
+			result1 *types.Header
 			result2 error
 		})
 	}
-	fake.transactionReceiptReturnsOnCall[i] = struct {
-		result1 *types.Receipt
+	fake.headerByHashReturnsOnCall[i] = struct {
+		result1 *types.Header
 		result2 error
 	}{result1, result2}
 }
@@ -259,12 +647,22 @@ func (fake *EthClient) TransactionReceiptReturnsOnCall(i int, result1 *types.Rec
 func (fake *EthClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
-	fake.networkIDMutex.RLock()
-	defer fake.networkIDMutex.RUnlock()
-	fake.transactionByHashMutex.RLock()
-	defer fake.transactionByHashMutex.RUnlock()
-	fake.transactionReceiptMutex.RLock()
-	defer fake.transactionReceiptMutex.RUnlock()
+	fake.batchCallContextMutex.RLock()
+	defer fake.batchCallContextMutex.RUnlock()
+	fake.blockByHashMutex.RLock()
+	defer fake.blockByHashMutex.RUnlock()
+	fake.blockByNumberMutex.RLock()
+	defer fake.blockByNumberMutex.RUnlock()
+	fake.blockNumberMutex.RLock()
+	defer fake.blockNumberMutex.RUnlock()
+	fake.blockReceiptsMutex.RLock()
+	defer fake.blockReceiptsMutex.RUnlock()
+	fake.callContextMutex.RLock()
+	defer fake.callContextMutex.RUnlock()
+	fake.chainIDMutex.RLock()
+	defer fake.chainIDMutex.RUnlock()
+	fake.headerByHashMutex.RLock()
+	defer fake.headerByHashMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,175 @@
+package ethereum_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// batchServer answers JSON-RPC batches the way a node would, from in-memory transactions and receipts or from recorded node
+// responses. Transactions without a receipt are reported as pending.
+type batchServer struct {
+	txs      map[common.Hash]*types.Transaction
+	receipts map[common.Hash]*types.Receipt
+	recorded map[common.Hash]fixture
+	errs     map[common.Hash]error
+	aliases  map[common.Hash]common.Hash
+}
+
+// fixture is a transaction and its receipt as returned by eth_getTransactionByHash and eth_getTransactionReceipt.
+type fixture struct {
+	Transaction json.RawMessage `json:"transaction"`
+	Receipt     json.RawMessage `json:"receipt"`
+}
+
+func newBatchServer() *batchServer {
+	return &batchServer{
+		txs:      map[common.Hash]*types.Transaction{},
+		receipts: map[common.Hash]*types.Receipt{},
+		recorded: map[common.Hash]fixture{},
+		errs:     map[common.Hash]error{},
+		aliases:  map[common.Hash]common.Hash{},
+	}
+}
+
+// record serves the node responses recorded in testdata/<name>.json and returns the hash of their transaction.
+func (s *batchServer) record(name string) (common.Hash, error) {
+	raw, err := os.ReadFile(filepath.Join("testdata", name+".json"))
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	var recorded fixture
+	if err := json.Unmarshal(raw, &recorded); err != nil {
+		return common.Hash{}, err
+	}
+
+	var tx struct {
+		Hash common.Hash `json:"hash"`
+	}
+	if err := json.Unmarshal(recorded.Transaction, &tx); err != nil {
+		return common.Hash{}, err
+	}
+
+	s.recorded[tx.Hash] = recorded
+	return tx.Hash, nil
+}
+
+func (s *batchServer) add(tx *types.Transaction, receipt *types.Receipt) *batchServer {
+	s.txs[tx.Hash()] = tx
+	if receipt != nil {
+		if receipt.Logs == nil {
+			receipt.Logs = []*types.Log{}
+		}
+		receipt.TxHash = tx.Hash()
+		s.receipts[tx.Hash()] = receipt
+	}
+	return s
+}
+
+func (s *batchServer) fail(hash common.Hash, err error) *batchServer {
+	s.errs[hash] = err
+	return s
+}
+
+// alias answers requests for the hash with the transaction and receipt of another hash, the way a lying node would.
+func (s *batchServer) alias(hash common.Hash, served common.Hash) *batchServer {
+	s.aliases[hash] = served
+	return s
+}
+
+func (s *batchServer) serve(_ context.Context, elems []rpc.BatchElem) error {
+	for i := range elems {
+		hash := elems[i].Args[0].(common.Hash)
+		if err, ok := s.errs[hash]; ok {
+			elems[i].Error = err
+			continue
+		}
+
+		raw, err := s.result(elems[i].Method, hash)
+		if err != nil {
+			return err
+		}
+		elems[i].Error = json.Unmarshal(raw, elems[i].Result)
+	}
+	return nil
+}
+
+func (s *batchServer) result(method string, hash common.Hash) ([]byte, error) {
+	if served, ok := s.aliases[hash]; ok {
+		hash = served
+	}
+
+	if recorded, ok := s.recorded[hash]; ok {
+		switch method {
+		case "eth_getTransactionByHash":
+			return recorded.Transaction, nil
+		case "eth_getTransactionReceipt":
+			return recorded.Receipt, nil
+		}
+	}
+
+	switch method {
+	case "eth_getTransactionByHash":
+		tx, ok := s.txs[hash]
+		if !ok {
+			return []byte("null"), nil
+		}
+		return rpcTxJSON(tx, s.receipts[hash])
+	case "eth_getTransactionReceipt":
+		receipt, ok := s.receipts[hash]
+		if !ok {
+			return []byte("null"), nil
+		}
+		return json.Marshal(receipt)
+	default:
+		return nil, fmt.Errorf("unexpected method %q", method)
+	}
+}
+
+// rpcTxJSON encodes the transaction the way eth_getTransactionByHash does, including the block it was mined in.
+func rpcTxJSON(tx *types.Transaction, receipt *types.Receipt) ([]byte, error) {
+	encoded, err := json.Marshal(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]any{}
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		return nil, err
+	}
+
+	if receipt != nil {
+		fields["blockHash"] = receipt.BlockHash
+		fields["blockNumber"] = (*hexutil.Big)(receipt.BlockNumber)
+		fields["transactionIndex"] = hexutil.Uint64(receipt.TransactionIndex)
+	}
+
+	return json.Marshal(fields)
+}
+
+// rpcError is a JSON-RPC error response, as returned by the rpc client.
+type rpcError struct {
+	code    int
+	message string
+	data    any
+}
+
+func (e rpcError) Error() string {
+	return e.message
+}
+
+func (e rpcError) ErrorCode() int {
+	return e.code
+}
+
+func (e rpcError) ErrorData() any {
+	return e.data
+}
@@ -0,0 +1,151 @@
+package ethereum
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Family is the kind of chain a node serves. It tells which transaction types carry no signature to recover the sender from and
+// which L1 fee fields the receipts carry.
+type Family string
+
+const (
+	FamilyEthereum Family = "ethereum"
+	FamilyOPStack  Family = "op-stack"
+	FamilyArbitrum Family = "arbitrum"
+)
+
+// The L2 transaction types that are derived from L1 or created by the chain itself instead of being signed by their sender.
+const (
+	OPDepositTxType               = 0x7e
+	ArbitrumDepositTxType         = 0x64
+	ArbitrumUnsignedTxType        = 0x65
+	ArbitrumContractTxType        = 0x66
+	ArbitrumRetryTxType           = 0x68
+	ArbitrumSubmitRetryableTxType = 0x69
+	ArbitrumInternalTxType        = 0x6a
+	// ArbitrumLegacyTxType wraps the transactions of the chain before the Nitro upgrade, whose signatures are not recoverable with
+	// the Ethereum signers.
+	ArbitrumLegacyTxType = 0x78
+)
+
+// familyChains maps the chain IDs of well-known L2 networks to their family. Every other chain is of FamilyEthereum unless
+// configured otherwise.
+var familyChains = map[uint64]Family{
+	10:       FamilyOPStack,
+	8453:     FamilyOPStack,
+	84532:    FamilyOPStack,
+	11155420: FamilyOPStack,
+	7777777:  FamilyOPStack,
+	34443:    FamilyOPStack,
+	42161:    FamilyArbitrum,
+	42170:    FamilyArbitrum,
+	421614:   FamilyArbitrum,
+}
+
+// FamilyOf returns the family of the chain with the given ID.
+func FamilyOf(chainID uint64) Family {
+	if family, ok := familyChains[chainID]; ok {
+		return family
+	}
+	return FamilyEthereum
+}
+
+// ParseFamily parses the name of a family. An empty name yields the empty family, which is detected from the chain ID.
+func ParseFamily(name string) (Family, error) {
+	switch family := Family(name); family {
+	case "", FamilyEthereum, FamilyOPStack, FamilyArbitrum:
+		return family, nil
+	default:
+		return "", fmt.Errorf("parse family: unknown family %q", name)
+	}
+}
+
+// isSystemTxType tells whether transactions of the given type are L2 system transactions of the family.
+func (f Family) isSystemTxType(txType uint64) bool {
+	switch f {
+	case FamilyOPStack:
+		return txType == OPDepositTxType
+	case FamilyArbitrum:
+		switch txType {
+		case ArbitrumDepositTxType, ArbitrumUnsignedTxType, ArbitrumContractTxType, ArbitrumRetryTxType,
+			ArbitrumSubmitRetryableTxType, ArbitrumInternalTxType, ArbitrumLegacyTxType:
+			return true
+		}
+	}
+	return false
+}
+
+// systemTransaction is an L2 system transaction as returned by eth_getTransactionByHash. go-ethereum cannot decode these types,
+// and their sender is taken from the node instead of being recovered from a signature.
+type systemTransaction struct {
+	Type     hexutil.Uint64  `json:"type"`
+	Hash     common.Hash     `json:"hash"`
+	Nonce    hexutil.Uint64  `json:"nonce"`
+	Gas      hexutil.Uint64  `json:"gas"`
+	GasPrice *hexutil.Big    `json:"gasPrice"`
+	To       *common.Address `json:"to"`
+	Value    *hexutil.Big    `json:"value"`
+	Input    hexutil.Bytes   `json:"input"`
+
+	// SourceHash and Mint are set for OP-stack deposits, RequestID for the Arbitrum transactions that were requested on L1.
+	SourceHash *common.Hash `json:"sourceHash"`
+	Mint       *hexutil.Big `json:"mint"`
+	RequestID  *common.Hash `json:"requestId"`
+}
+
+// l1FeeFields are the fields that L2 nodes add to receipts for the cost of posting the transaction to L1. OP-stack nodes report
+// the L1 fee, which is charged on top of the L2 execution fee, together with the data gas and prices it was computed from.
+// Arbitrum nodes report the part of the gas used that paid for L1, at the same price as the rest.
+type l1FeeFields struct {
+	L1Fee         *hexutil.Big    `json:"l1Fee"`
+	L1GasUsed     *hexutil.Uint64 `json:"l1GasUsed"`
+	L1GasPrice    *hexutil.Big    `json:"l1GasPrice"`
+	L1BlobBaseFee *hexutil.Big    `json:"l1BlobBaseFee"`
+	GasUsedForL1  *hexutil.Uint64 `json:"gasUsedForL1"`
+}
+
+// setL1FeeFields sets the L1 fee fields of the transaction that the family reports.
+func setL1FeeFields(transaction *Transaction, fields l1FeeFields, family Family) {
+	switch family {
+	case FamilyOPStack:
+		transaction.L1Fee = bigToPtr(fields.L1Fee)
+		transaction.L1GasUsed = (*uint64)(fields.L1GasUsed)
+		transaction.L1GasPrice = bigToPtr(fields.L1GasPrice)
+		transaction.L1BlobBaseFee = bigToPtr(fields.L1BlobBaseFee)
+	case FamilyArbitrum:
+		transaction.GasUsedForL1 = (*uint64)(fields.GasUsedForL1)
+	}
+}
+
+// setSystemTypeFields sets the type, gas and fee fields of an L2 system transaction together with the fields that tie it to L1.
+func setSystemTypeFields(transaction *Transaction, tx *systemTransaction, receipt *rpcReceipt) {
+	transaction.Type = uint8(tx.Type)
+	transaction.Nonce = uint64(tx.Nonce)
+	transaction.GasLimit = uint64(tx.Gas)
+	transaction.GasUsed = receipt.receipt.GasUsed
+	transaction.EffectiveGasPrice = "0"
+	if tx.GasPrice != nil {
+		transaction.EffectiveGasPrice = tx.GasPrice.ToInt().String()
+	}
+	if receipt.receipt.EffectiveGasPrice != nil {
+		transaction.EffectiveGasPrice = receipt.receipt.EffectiveGasPrice.String()
+	}
+
+	if tx.SourceHash != nil {
+		transaction.SourceHash = toPtr(tx.SourceHash.Hex())
+	}
+	transaction.Mint = bigToPtr(tx.Mint)
+	if tx.RequestID != nil {
+		transaction.RequestID = toPtr(tx.RequestID.Hex())
+	}
+}
+
+func bigToPtr(value *hexutil.Big) *string {
+	if value == nil {
+		return nil
+	}
+	return toPtr(value.ToInt().String())
+}
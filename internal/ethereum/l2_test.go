@@ -0,0 +1,159 @@
+package ethereum_test
+
+import (
+	"context"
+	"fethcher/internal/ethereum"
+	"fethcher/internal/ethereum/fake"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("L2 chains", func() {
+	var (
+		fakeClient *fake.EthClient
+		node       *batchServer
+		ctx        context.Context
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeClient = new(fake.EthClient)
+		fakeClient.BlockNumberReturns(0x107b1a48, nil)
+		node = newBatchServer()
+		fakeClient.BatchCallContextStub = node.serve
+	})
+
+	fetch := func(service *ethereum.EthService, fixture string) *ethereum.TxResult {
+		hash, err := node.record(fixture)
+		Expect(err).NotTo(HaveOccurred())
+
+		results, err := service.FetchTransactions(ctx, []string{hash.Hex()})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(1))
+		return results[0]
+	}
+
+	Describe("OP-stack", func() {
+		var service *ethereum.EthService
+
+		BeforeEach(func() {
+			fakeClient.ChainIDReturns(big.NewInt(8453), nil)
+			service = ethereum.NewEthService(fakeClient, ethereum.FamilyOPStack, false, 100, 4)
+		})
+
+		It("should record the L1 fee fields of a signed transaction", func() {
+			result := fetch(service, "base_dynamic_fee")
+			Expect(result.Error).NotTo(HaveOccurred())
+
+			tx := result.Transaction
+			Expect(tx.From).To(Equal("0xFE3B557E8Fb62b89F4916B721be55cEb828dBd73"))
+			Expect(tx.Type).To(Equal(uint8(2)))
+			Expect(tx.GasUsed).To(Equal(uint64(46835)))
+			Expect(tx.EffectiveGasPrice).To(Equal("1001187"))
+			Expect(tx.L1Fee).To(HaveValue(Equal("5472000000000")))
+			Expect(tx.L1GasUsed).To(HaveValue(Equal(uint64(1600))))
+			Expect(tx.L1GasPrice).To(HaveValue(Equal("5000000000")))
+			Expect(tx.L1BlobBaseFee).To(HaveValue(Equal("1")))
+			Expect(tx.GasUsedForL1).To(BeNil())
+			Expect(tx.SourceHash).To(BeNil())
+			Expect(tx.TokenTransfers).To(HaveLen(1))
+		})
+
+		It("should take the sender of a deposit from the node", func() {
+			result := fetch(service, "base_deposit")
+			Expect(result.Error).NotTo(HaveOccurred())
+
+			tx := result.Transaction
+			Expect(tx.Type).To(Equal(uint8(ethereum.OPDepositTxType)))
+			Expect(tx.From).To(Equal(common.HexToAddress("0x977f82a600a1414e583f7f13623f1ac5d58b1c0b").Hex()))
+			Expect(tx.To).To(HaveValue(Equal(common.HexToAddress("0x977f82a600a1414e583f7f13623f1ac5d58b1c0b").Hex())))
+			Expect(tx.Value).To(Equal("10000000000000000"))
+			Expect(tx.Mint).To(HaveValue(Equal("10000000000000000")))
+			Expect(tx.SourceHash).To(HaveValue(Equal("0x8f4a2e1b7c9d3f5a0e6b2c8d4f1a7e3b9c5d0f2a6e8b4c1d7f3a9e5b0c2d6f8a")))
+			Expect(tx.Nonce).To(Equal(uint64(0x1a2c4)))
+			Expect(tx.GasLimit).To(Equal(uint64(100000)))
+			Expect(tx.GasUsed).To(Equal(uint64(21000)))
+			Expect(tx.EffectiveGasPrice).To(Equal("0"))
+			Expect(tx.L1Fee).To(BeNil())
+			Expect(tx.Input).To(Equal("0x"))
+		})
+
+		It("should detect the family from the chain ID", func() {
+			service = ethereum.NewEthService(fakeClient, "", false, 100, 4)
+			result := fetch(service, "base_deposit")
+			Expect(result.Error).NotTo(HaveOccurred())
+			Expect(result.Transaction.Mint).NotTo(BeNil())
+		})
+
+		It("should not verify the receipts", func() {
+			service = ethereum.NewEthService(fakeClient, ethereum.FamilyOPStack, true, 100, 4)
+			result := fetch(service, "base_dynamic_fee")
+			Expect(result.Error).NotTo(HaveOccurred())
+			Expect(result.Transaction.Verified).To(BeFalse())
+			Expect(fakeClient.BlockByHashCallCount()).To(BeZero())
+		})
+	})
+
+	Describe("Arbitrum", func() {
+		var service *ethereum.EthService
+
+		BeforeEach(func() {
+			fakeClient.ChainIDReturns(big.NewInt(42161), nil)
+			service = ethereum.NewEthService(fakeClient, "", false, 100, 4)
+		})
+
+		It("should record the gas used for L1 of a signed transaction", func() {
+			result := fetch(service, "arbitrum_dynamic_fee")
+			Expect(result.Error).NotTo(HaveOccurred())
+
+			tx := result.Transaction
+			Expect(tx.From).To(Equal("0xFE3B557E8Fb62b89F4916B721be55cEb828dBd73"))
+			Expect(tx.GasUsed).To(Equal(uint64(107188)))
+			Expect(tx.GasUsedForL1).To(HaveValue(Equal(uint64(40000))))
+			Expect(tx.EffectiveGasPrice).To(Equal("10000000"))
+			Expect(tx.L1Fee).To(BeNil())
+			Expect(tx.Confirmations).To(Equal(uint64(1)))
+		})
+
+		It("should take the sender of an internal transaction from the node", func() {
+			result := fetch(service, "arbitrum_internal")
+			Expect(result.Error).NotTo(HaveOccurred())
+
+			tx := result.Transaction
+			Expect(tx.Type).To(Equal(uint8(ethereum.ArbitrumInternalTxType)))
+			Expect(tx.From).To(Equal(common.HexToAddress("0x00000000000000000000000000000000000a4b05").Hex()))
+			Expect(tx.GasUsed).To(BeZero())
+			Expect(tx.GasUsedForL1).To(HaveValue(BeZero()))
+			Expect(tx.Mint).To(BeNil())
+		})
+	})
+
+	When("the system transaction does not belong to the family", func() {
+		It("should fail the transaction", func() {
+			fakeClient.ChainIDReturns(big.NewInt(1), nil)
+			service := ethereum.NewEthService(fakeClient, "", false, 100, 4)
+
+			result := fetch(service, "base_deposit")
+			Expect(result.Transaction).To(BeNil())
+			Expect(result.Error).To(MatchError(ethereum.ErrUnsupportedTxType))
+		})
+	})
+
+	Describe("ParseFamily", func() {
+		It("should accept the known families and the empty one", func() {
+			for _, name := range []string{"", "ethereum", "op-stack", "arbitrum"} {
+				family, err := ethereum.ParseFamily(name)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(family).To(Equal(ethereum.Family(name)))
+			}
+		})
+
+		It("should reject an unknown family", func() {
+			_, err := ethereum.ParseFamily("zksync")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
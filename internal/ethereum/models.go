@@ -1,7 +1,19 @@
 package ethereum
 
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// TxResult is the outcome of fetching a single transaction. Exactly one of Transaction and Error is set. Pending is set
+// together with an ErrTransactionPending error when the node returned the transaction that is not mined yet.
 type TxResult struct {
+	Hash        string
 	Transaction *Transaction
+	Pending     *PendingTransaction
 	Error       error
 }
 
@@ -10,10 +22,144 @@ type Transaction struct {
 	TransactionStatus uint64
 	BlockHash         string
 	BlockNumber       uint64
+	BlockTimestamp    *uint64
 	From              string
 	To                *string
 	ContractAddress   *string
 	LogsCount         int
 	Input             string
 	Value             string
+	Verified          bool
+	Confirmations     uint64
+	Logs              []Log
+	TokenTransfers    []TokenTransfer
+
+	Type              uint8
+	Nonce             uint64
+	GasLimit          uint64
+	GasUsed           uint64
+	EffectiveGasPrice string
+
+	// GasPrice is only set for legacy and access list transactions, MaxFeePerGas and MaxPriorityFeePerGas for the later types.
+	GasPrice             *string
+	MaxFeePerGas         *string
+	MaxPriorityFeePerGas *string
+	// AccessList is set for every type but legacy transactions.
+	AccessList []AccessTuple
+	// The blob fields are only set for blob transactions.
+	BlobVersionedHashes []string
+	MaxFeePerBlobGas    *string
+	BlobGasUsed         *uint64
+	BlobGasPrice        *string
+	// AuthorizationList is only set for set code transactions.
+	AuthorizationList []Authorization
+
+	// The L1 fee fields are only set on L2 chains: L1Fee, L1GasUsed, L1GasPrice and, since the Ecotone upgrade, L1BlobBaseFee on
+	// OP-stack chains and GasUsedForL1, which is part of GasUsed, on Arbitrum chains.
+	L1Fee         *string
+	L1GasUsed     *uint64
+	L1GasPrice    *string
+	L1BlobBaseFee *string
+	GasUsedForL1  *uint64
+	// The deposit fields are only set for L2 system transactions: SourceHash and Mint for OP-stack deposits and RequestID for the
+	// Arbitrum transactions requested on L1.
+	SourceHash *string
+	Mint       *string
+	RequestID  *string
+}
+
+// Block is a block header together with the hashes of the transactions included in the block.
+type Block struct {
+	Number            uint64
+	Hash              string
+	ParentHash        string
+	Timestamp         uint64
+	Miner             string
+	BaseFee           *string
+	GasUsed           uint64
+	GasLimit          uint64
+	StateRoot         string
+	TransactionsRoot  string
+	ReceiptsRoot      string
+	TransactionHashes []string
+	Confirmations     uint64
+}
+
+// AccessTuple is an address and the storage slots of it that an EIP-2930 access list pre-warms.
+type AccessTuple struct {
+	Address     string
+	StorageKeys []string
+}
+
+// Authorization is an EIP-7702 authorization to set the code of Authority to the code at Address. Authority is recovered from
+// the signature and is nil when the signature is invalid.
+type Authorization struct {
+	ChainID   string
+	Address   string
+	Nonce     uint64
+	YParity   uint8
+	R         string
+	S         string
+	Authority *string
+}
+
+// Log is an event emitted by a transaction, as found in its receipt.
+type Log struct {
+	Address  string
+	Topics   []string
+	Data     string
+	LogIndex uint
+}
+
+// TokenTransfer is a token movement parsed from a standard transfer event. Amount is the number of tokens moved, which is always
+// one for ERC-721, and TokenID identifies the token for ERC-721 and ERC-1155. An ERC-1155 TransferBatch event yields one transfer
+// per token, told apart by BatchIndex.
+type TokenTransfer struct {
+	Token      string
+	From       string
+	To         string
+	Amount     string
+	TokenID    *string
+	Standard   string
+	LogIndex   uint
+	BatchIndex uint
+}
+
+// rpcTransaction is the result of eth_getTransactionByHash: the transaction itself plus the block it was included in. L2 system
+// transactions, which go-ethereum cannot decode, are held by system instead of tx.
+type rpcTransaction struct {
+	tx     *types.Transaction
+	system *systemTransaction
+	txExtraInfo
+}
+
+type txExtraInfo struct {
+	BlockNumber *string         `json:"blockNumber,omitempty"`
+	BlockHash   *common.Hash    `json:"blockHash,omitempty"`
+	From        *common.Address `json:"from,omitempty"`
+}
+
+func (tx *rpcTransaction) UnmarshalJSON(msg []byte) error {
+	err := json.Unmarshal(msg, &tx.tx)
+	if errors.Is(err, types.ErrTxTypeNotSupported) {
+		tx.tx = nil
+		err = json.Unmarshal(msg, &tx.system)
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(msg, &tx.txExtraInfo)
+}
+
+// rpcReceipt is the result of eth_getTransactionReceipt: the receipt itself plus the L1 fee fields of L2 chains.
+type rpcReceipt struct {
+	receipt *types.Receipt
+	l1FeeFields
+}
+
+func (r *rpcReceipt) UnmarshalJSON(msg []byte) error {
+	if err := json.Unmarshal(msg, &r.receipt); err != nil {
+		return err
+	}
+	return json.Unmarshal(msg, &r.l1FeeFields)
 }
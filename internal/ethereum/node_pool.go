@@ -0,0 +1,362 @@
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	goethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var (
+	ErrNoHealthyNodes   = errors.New("no healthy ethereum nodes")
+	ErrQuorumNotReached = errors.New("node quorum not reached")
+)
+
+// Node is a single Ethereum node endpoint that is part of a NodePool. Name identifies the node in errors and statuses and
+// should therefore not contain credentials such as API keys.
+type Node struct {
+	Name   string
+	Client EthClient
+}
+
+// NodeStatus describes the health of a node in the pool as seen by its circuit breaker.
+type NodeStatus struct {
+	Name                string    `json:"name"`
+	Healthy             bool      `json:"healthy"`
+	ConsecutiveFailures int       `json:"consecutiveFailures"`
+	LastError           string    `json:"lastError,omitempty"`
+	OpenUntil           time.Time `json:"openUntil"`
+}
+
+// NodePool is an EthClient that spreads calls over several Ethereum nodes. Calls go to the first healthy node in the order the
+// nodes were configured and fail over to the next one when a node errors. A node that fails failureThreshold times in a row is
+// taken out of rotation for the cooldown period. Once the cooldown elapses the node is tried again: a success closes its
+// circuit, while a single further failure takes it out of rotation for another cooldown period.
+//
+// When quorum is greater than one, batches are sent to every healthy node and transaction receipts are only accepted when at
+// least quorum nodes return identical receipts.
+type NodePool struct {
+	nodes            []*poolNode
+	quorum           int
+	failureThreshold int
+	cooldown         time.Duration
+}
+
+type poolNode struct {
+	name   string
+	client EthClient
+
+	mu                  sync.Mutex
+	consecutiveFailures int
+	lastErr             error
+	openUntil           time.Time
+}
+
+// NewNodePool is a constructor function for the NodePool type.
+func NewNodePool(nodes []Node, quorum int, failureThreshold int, cooldown time.Duration) (*NodePool, error) {
+	if len(nodes) == 0 {
+		return nil, errors.New("node pool requires at least one node")
+	}
+	if quorum > len(nodes) {
+		return nil, fmt.Errorf("quorum of %d cannot be reached with %d nodes", quorum, len(nodes))
+	}
+	if failureThreshold < 1 {
+		failureThreshold = 1
+	}
+
+	poolNodes := make([]*poolNode, 0, len(nodes))
+	for _, node := range nodes {
+		poolNodes = append(poolNodes, &poolNode{
+			name:   node.Name,
+			client: node.Client,
+		})
+	}
+
+	return &NodePool{
+		nodes:            poolNodes,
+		quorum:           quorum,
+		failureThreshold: failureThreshold,
+		cooldown:         cooldown,
+	}, nil
+}
+
+// Status reports the health of every node in the pool.
+func (p *NodePool) Status() []NodeStatus {
+	now := time.Now()
+	statuses := make([]NodeStatus, 0, len(p.nodes))
+	for _, node := range p.nodes {
+		node.mu.Lock()
+		status := NodeStatus{
+			Name:                node.name,
+			Healthy:             !now.Before(node.openUntil),
+			ConsecutiveFailures: node.consecutiveFailures,
+			OpenUntil:           node.openUntil,
+		}
+		if node.lastErr != nil {
+			status.LastError = node.lastErr.Error()
+		}
+		node.mu.Unlock()
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// BatchCallContext sends the batch to the first healthy node. In quorum mode the batch is sent to every healthy node instead.
+func (p *NodePool) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	if p.quorum > 1 {
+		return p.quorumBatch(ctx, b)
+	}
+
+	_, err := failover(ctx, p, func(client EthClient) (struct{}, error) {
+		return struct{}{}, client.BatchCallContext(ctx, b)
+	})
+	return err
+}
+
+// CallContext sends the request to the first healthy node that supports its method.
+func (p *NodePool) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	_, err := failover(ctx, p, func(client EthClient) (struct{}, error) {
+		return struct{}{}, client.CallContext(ctx, result, method, args...)
+	})
+	return err
+}
+
+// ChainID returns the chain ID reported by the first healthy node.
+func (p *NodePool) ChainID(ctx context.Context) (*big.Int, error) {
+	return failover(ctx, p, func(client EthClient) (*big.Int, error) {
+		return client.ChainID(ctx)
+	})
+}
+
+// BlockNumber returns the chain head reported by the first healthy node.
+func (p *NodePool) BlockNumber(ctx context.Context) (uint64, error) {
+	return failover(ctx, p, func(client EthClient) (uint64, error) {
+		return client.BlockNumber(ctx)
+	})
+}
+
+// BlockByHash returns the block with the given hash from the first healthy node.
+func (p *NodePool) BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
+	return failover(ctx, p, func(client EthClient) (*types.Block, error) {
+		return client.BlockByHash(ctx, hash)
+	})
+}
+
+// BlockByNumber returns the block with the given number from the first healthy node.
+func (p *NodePool) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	return failover(ctx, p, func(client EthClient) (*types.Block, error) {
+		return client.BlockByNumber(ctx, number)
+	})
+}
+
+// HeaderByHash returns the header of the block with the given hash from the first healthy node.
+func (p *NodePool) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	return failover(ctx, p, func(client EthClient) (*types.Header, error) {
+		return client.HeaderByHash(ctx, hash)
+	})
+}
+
+// BlockReceipts returns all receipts of the given block from the first healthy node.
+func (p *NodePool) BlockReceipts(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]*types.Receipt, error) {
+	return failover(ctx, p, func(client EthClient) ([]*types.Receipt, error) {
+		return client.BlockReceipts(ctx, blockNrOrHash)
+	})
+}
+
+// quorumBatch sends the batch to every healthy node. The result of every eth_getTransactionReceipt call is only accepted when at
+// least quorum nodes returned identical results (a null result included, which means the receipt is not known). Every other
+// call takes the result of the first node, in configuration order, that answered it.
+func (p *NodePool) quorumBatch(ctx context.Context, b []rpc.BatchElem) error {
+	nodes := p.available()
+	if len(nodes) < p.quorum {
+		return fmt.Errorf("%w: %d of %d nodes healthy, %d required", ErrQuorumNotReached, len(nodes), len(p.nodes), p.quorum)
+	}
+
+	answers := make([][]rpc.BatchElem, len(nodes))
+	errs := make([]error, len(nodes))
+
+	var waitGrp sync.WaitGroup
+	for i, node := range nodes {
+		waitGrp.Add(1)
+		go func(i int, node *poolNode) {
+			defer waitGrp.Done()
+			elems := make([]rpc.BatchElem, len(b))
+			for j := range b {
+				elems[j] = rpc.BatchElem{Method: b[j].Method, Args: b[j].Args, Result: new(json.RawMessage)}
+			}
+			err := node.client.BatchCallContext(ctx, elems)
+			node.record(err, p.failureThreshold, p.cooldown)
+			if err != nil {
+				errs[i] = fmt.Errorf("node %s: %w", node.name, err)
+			}
+			answers[i] = elems
+		}(i, node)
+	}
+	waitGrp.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	answered := 0
+	for _, err := range errs {
+		if err == nil {
+			answered++
+		}
+	}
+	if answered < p.quorum {
+		err := fmt.Errorf("%w: %d of %d nodes answered, %d required", ErrQuorumNotReached, answered, len(nodes), p.quorum)
+		return errors.Join(append([]error{err}, errs...)...)
+	}
+
+	for j := range b {
+		b[j].Error = p.pickResult(b[j], j, answers, errs)
+	}
+
+	return nil
+}
+
+// pickResult decodes the result that the nodes agreed on for the j-th call of the batch into elem.
+func (p *NodePool) pickResult(elem rpc.BatchElem, j int, answers [][]rpc.BatchElem, errs []error) error {
+	required := 1
+	if elem.Method == "eth_getTransactionReceipt" {
+		required = p.quorum
+	}
+
+	tally := make(map[string]int)
+	var elemErr error
+	for i, answer := range answers {
+		if errs[i] != nil {
+			continue
+		}
+		if answer[j].Error != nil {
+			elemErr = errors.Join(elemErr, answer[j].Error)
+			continue
+		}
+
+		raw := *answer[j].Result.(*json.RawMessage)
+		key := string(raw)
+		if elem.Method == "eth_getTransactionReceipt" {
+			key = receiptKey(raw)
+		}
+		tally[key]++
+		if tally[key] >= required {
+			if err := json.Unmarshal(raw, elem.Result); err != nil {
+				return fmt.Errorf("decode %s result: %w", elem.Method, err)
+			}
+			return nil
+		}
+	}
+
+	if len(tally) == 0 && elemErr != nil {
+		return elemErr
+	}
+
+	return fmt.Errorf("%w: %d nodes required to agree on %s", ErrQuorumNotReached, required, elem.Method)
+}
+
+// receiptKey returns what nodes must agree on for a receipt: its consensus encoding together with the block it is in and its
+// index there, so that nodes that format the same receipt differently, e.g. with or without the optional fields of newer
+// clients, are counted together. A receipt that cannot be decoded is keyed by its raw JSON.
+func receiptKey(raw json.RawMessage) string {
+	var receipt *types.Receipt
+	if err := json.Unmarshal(raw, &receipt); err != nil || receipt == nil {
+		return string(raw)
+	}
+
+	encoded, err := receipt.MarshalBinary()
+	if err != nil {
+		return string(raw)
+	}
+	return fmt.Sprintf("%x/%s/%v/%d", encoded, receipt.BlockHash.Hex(), receipt.BlockNumber, receipt.TransactionIndex)
+}
+
+// available returns the nodes whose circuit is closed, or whose cooldown has elapsed, in configuration order.
+func (p *NodePool) available() []*poolNode {
+	now := time.Now()
+	nodes := make([]*poolNode, 0, len(p.nodes))
+	for _, node := range p.nodes {
+		if node.available(now) {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// failover calls fn on the healthy nodes of the pool one after another until one of them answers. A node that does not support
+// the method called is skipped without counting against its health, as it still serves every other call.
+func failover[T any](ctx context.Context, p *NodePool, fn func(client EthClient) (T, error)) (T, error) {
+	var zero T
+
+	nodes := p.available()
+	if len(nodes) == 0 {
+		return zero, ErrNoHealthyNodes
+	}
+
+	var errs error
+	for _, node := range nodes {
+		res, err := fn(node.client)
+		if isMethodUnsupported(err) {
+			errs = errors.Join(errs, fmt.Errorf("node %s: %w", node.name, err))
+			continue
+		}
+
+		node.record(err, p.failureThreshold, p.cooldown)
+		if !isNodeFailure(err) {
+			return res, err
+		}
+
+		errs = errors.Join(errs, fmt.Errorf("node %s: %w", node.name, err))
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return zero, ctxErr
+		}
+	}
+
+	return zero, errs
+}
+
+// isNodeFailure reports whether err means that the node itself misbehaved, as opposed to a legitimate answer such as a
+// transaction not being found, a call reverting or the caller giving up.
+func isNodeFailure(err error) bool {
+	return err != nil &&
+		!errors.Is(err, goethereum.NotFound) &&
+		!isExecutionError(err) &&
+		!errors.Is(err, context.Canceled) &&
+		!errors.Is(err, context.DeadlineExceeded)
+}
+
+func (n *poolNode) available(now time.Time) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return !now.Before(n.openUntil)
+}
+
+// record updates the circuit breaker of the node with the outcome of a call.
+func (n *poolNode) record(err error, failureThreshold int, cooldown time.Duration) {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if !isNodeFailure(err) {
+		n.consecutiveFailures = 0
+		n.openUntil = time.Time{}
+		return
+	}
+
+	n.consecutiveFailures++
+	n.lastErr = err
+	if n.consecutiveFailures >= failureThreshold {
+		n.openUntil = time.Now().Add(cooldown)
+	}
+}
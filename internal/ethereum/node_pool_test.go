@@ -0,0 +1,357 @@
+package ethereum_test
+
+import (
+	"context"
+	"errors"
+	"fethcher/internal/ethereum"
+	"fethcher/internal/ethereum/fake"
+	"math/big"
+	"time"
+
+	goethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NodePool", func() {
+	var (
+		primary   *fake.EthClient
+		secondary *fake.EthClient
+		tertiary  *fake.EthClient
+		pool      *ethereum.NodePool
+		quorum    int
+		cooldown  time.Duration
+		ctx       context.Context
+		testErr   error
+		chainID   *big.Int
+		signedTx  *types.Transaction
+		receipt   *types.Receipt
+	)
+
+	BeforeEach(func() {
+		primary = new(fake.EthClient)
+		secondary = new(fake.EthClient)
+		tertiary = new(fake.EthClient)
+		quorum = 0
+		cooldown = time.Minute
+		ctx = context.Background()
+		testErr = errors.New("node down")
+
+		privateKey, err := crypto.GenerateKey()
+		Expect(err).NotTo(HaveOccurred())
+		chainID = big.NewInt(5)
+		signedTx, _ = types.SignTx(types.NewTransaction(0, common.Address{}, big.NewInt(0), 0, big.NewInt(0), nil), types.LatestSignerForChainID(chainID), privateKey)
+
+		receipt = &types.Receipt{
+			Status:      1,
+			BlockHash:   common.HexToHash("0xabc"),
+			BlockNumber: big.NewInt(100),
+			Logs:        []*types.Log{},
+		}
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		pool, err = ethereum.NewNodePool([]ethereum.Node{
+			{Name: "primary", Client: primary},
+			{Name: "secondary", Client: secondary},
+			{Name: "tertiary", Client: tertiary},
+		}, quorum, 2, cooldown)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("failover", func() {
+		When("the primary node is healthy", func() {
+			BeforeEach(func() {
+				primary.BlockNumberReturns(100, nil)
+			})
+
+			It("only calls the primary node", func() {
+				head, err := pool.BlockNumber(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(head).To(Equal(uint64(100)))
+				Expect(primary.BlockNumberCallCount()).To(Equal(1))
+				Expect(secondary.BlockNumberCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the primary node fails", func() {
+			BeforeEach(func() {
+				primary.BlockNumberReturns(0, testErr)
+				secondary.BlockNumberReturns(101, nil)
+			})
+
+			It("fails over to the next node", func() {
+				head, err := pool.BlockNumber(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(head).To(Equal(uint64(101)))
+				Expect(primary.BlockNumberCallCount()).To(Equal(1))
+				Expect(secondary.BlockNumberCallCount()).To(Equal(1))
+				Expect(tertiary.BlockNumberCallCount()).To(Equal(0))
+
+				status := pool.Status()
+				Expect(status[0].ConsecutiveFailures).To(Equal(1))
+				Expect(status[0].LastError).To(Equal(testErr.Error()))
+				Expect(status[0].Healthy).To(BeTrue())
+			})
+		})
+
+		When("the primary node keeps failing", func() {
+			BeforeEach(func() {
+				primary.BlockNumberReturns(0, testErr)
+				secondary.BlockNumberReturns(101, nil)
+			})
+
+			It("opens the circuit of the primary node", func() {
+				for range 4 {
+					_, err := pool.BlockNumber(ctx)
+					Expect(err).NotTo(HaveOccurred())
+				}
+
+				Expect(primary.BlockNumberCallCount()).To(Equal(2))
+				Expect(secondary.BlockNumberCallCount()).To(Equal(4))
+				Expect(pool.Status()[0].Healthy).To(BeFalse())
+			})
+		})
+
+		When("the cooldown of an open circuit elapses", func() {
+			BeforeEach(func() {
+				cooldown = 10 * time.Millisecond
+				primary.BlockNumberReturnsOnCall(0, 0, testErr)
+				primary.BlockNumberReturnsOnCall(1, 0, testErr)
+				primary.BlockNumberReturnsOnCall(2, 100, nil)
+				secondary.BlockNumberReturns(101, nil)
+			})
+
+			It("tries the node again and closes the circuit on success", func() {
+				for range 2 {
+					_, err := pool.BlockNumber(ctx)
+					Expect(err).NotTo(HaveOccurred())
+				}
+				Expect(pool.Status()[0].Healthy).To(BeFalse())
+
+				Eventually(func() bool { return pool.Status()[0].Healthy }).Should(BeTrue())
+
+				head, err := pool.BlockNumber(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(head).To(Equal(uint64(100)))
+				Expect(pool.Status()[0].ConsecutiveFailures).To(Equal(0))
+			})
+		})
+
+		When("the node does not know the block", func() {
+			BeforeEach(func() {
+				primary.BlockByHashReturns(nil, goethereum.NotFound)
+			})
+
+			It("returns not found without failing over", func() {
+				_, err := pool.BlockByHash(ctx, common.HexToHash("0xabc"))
+				Expect(err).To(MatchError(goethereum.NotFound))
+				Expect(secondary.BlockByHashCallCount()).To(Equal(0))
+				Expect(pool.Status()[0].ConsecutiveFailures).To(Equal(0))
+			})
+		})
+
+		When("the primary node does not serve the method", func() {
+			BeforeEach(func() {
+				primary.CallContextReturns(rpcError{code: -32601, message: "the method debug_traceTransaction does not exist/is not available"})
+			})
+
+			It("calls the next node without failing the primary one", func() {
+				Expect(pool.CallContext(ctx, nil, "debug_traceTransaction")).To(Succeed())
+				Expect(secondary.CallContextCallCount()).To(Equal(1))
+				Expect(pool.Status()[0].ConsecutiveFailures).To(Equal(0))
+			})
+		})
+
+		When("a call reverts on the primary node", func() {
+			BeforeEach(func() {
+				primary.CallContextReturns(rpcError{code: 3, message: "execution reverted", data: "0x"})
+			})
+
+			It("returns the revert without failing over", func() {
+				Expect(pool.CallContext(ctx, nil, "eth_call")).To(MatchError("execution reverted"))
+				Expect(secondary.CallContextCallCount()).To(BeZero())
+				Expect(pool.Status()[0].ConsecutiveFailures).To(Equal(0))
+			})
+		})
+
+		When("a batch fails on the primary node", func() {
+			BeforeEach(func() {
+				primary.BatchCallContextReturns(testErr)
+				secondary.BatchCallContextStub = newBatchServer().add(signedTx, receipt).serve
+			})
+
+			It("retries the batch on the next node", func() {
+				var res *types.Receipt
+				elems := []rpc.BatchElem{{Method: "eth_getTransactionReceipt", Args: []any{signedTx.Hash()}, Result: &res}}
+				Expect(pool.BatchCallContext(ctx, elems)).To(Succeed())
+				Expect(elems[0].Error).NotTo(HaveOccurred())
+				Expect(res.TxHash).To(Equal(signedTx.Hash()))
+				Expect(pool.Status()[0].ConsecutiveFailures).To(Equal(1))
+			})
+		})
+
+		When("every node fails", func() {
+			BeforeEach(func() {
+				primary.BlockNumberReturns(0, testErr)
+				secondary.BlockNumberReturns(0, testErr)
+				tertiary.BlockNumberReturns(0, testErr)
+			})
+
+			It("returns the errors of all nodes", func() {
+				_, err := pool.BlockNumber(ctx)
+				Expect(err).To(MatchError(testErr))
+				Expect(err.Error()).To(ContainSubstring("node secondary"))
+
+				_, err = pool.BlockNumber(ctx)
+				Expect(err).To(HaveOccurred())
+
+				_, err = pool.BlockNumber(ctx)
+				Expect(err).To(MatchError(ethereum.ErrNoHealthyNodes))
+			})
+		})
+	})
+
+	Describe("quorum", func() {
+		var (
+			res *types.Receipt
+			tx  *types.Transaction
+			err error
+		)
+
+		BeforeEach(func() {
+			quorum = 2
+			res = nil
+			tx = nil
+		})
+
+		JustBeforeEach(func() {
+			elems := []rpc.BatchElem{
+				{Method: "eth_getTransactionByHash", Args: []any{signedTx.Hash()}, Result: &tx},
+				{Method: "eth_getTransactionReceipt", Args: []any{signedTx.Hash()}, Result: &res},
+			}
+			err = pool.BatchCallContext(ctx, elems)
+			if err == nil {
+				err = errors.Join(elems[0].Error, elems[1].Error)
+			}
+		})
+
+		When("enough nodes return identical receipts", func() {
+			BeforeEach(func() {
+				forged := *receipt
+				forged.Status = 0
+
+				primary.BatchCallContextStub = newBatchServer().add(signedTx, &forged).serve
+				secondary.BatchCallContextStub = newBatchServer().add(signedTx, receipt).serve
+				tertiary.BatchCallContextStub = newBatchServer().add(signedTx, receipt).serve
+			})
+
+			It("returns the agreed receipt", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res.Status).To(Equal(uint64(1)))
+				Expect(tx.Hash()).To(Equal(signedTx.Hash()))
+				Expect(primary.BatchCallContextCallCount()).To(Equal(1))
+				Expect(secondary.BatchCallContextCallCount()).To(Equal(1))
+				Expect(tertiary.BatchCallContextCallCount()).To(Equal(1))
+			})
+		})
+
+		When("nodes format the same receipt differently", func() {
+			BeforeEach(func() {
+				formatted := *receipt
+				formatted.EffectiveGasPrice = big.NewInt(7)
+				formatted.GasUsed = 21000
+
+				primary.BatchCallContextStub = newBatchServer().add(signedTx, receipt).serve
+				secondary.BatchCallContextStub = newBatchServer().add(signedTx, &formatted).serve
+				tertiary.BatchCallContextReturns(testErr)
+			})
+
+			It("counts them as agreeing", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res.Status).To(Equal(uint64(1)))
+				Expect(res.BlockHash).To(Equal(receipt.BlockHash))
+			})
+		})
+
+		When("the nodes disagree", func() {
+			BeforeEach(func() {
+				forged := *receipt
+				forged.BlockHash = common.HexToHash("0xdef")
+
+				primary.BatchCallContextStub = newBatchServer().add(signedTx, receipt).serve
+				secondary.BatchCallContextStub = newBatchServer().add(signedTx, &forged).serve
+				tertiary.BatchCallContextReturns(testErr)
+			})
+
+			It("rejects the receipt", func() {
+				Expect(err).To(MatchError(ethereum.ErrQuorumNotReached))
+				Expect(res).To(BeNil())
+			})
+		})
+
+		When("too few nodes answer", func() {
+			BeforeEach(func() {
+				primary.BatchCallContextStub = newBatchServer().add(signedTx, receipt).serve
+				secondary.BatchCallContextReturns(testErr)
+				tertiary.BatchCallContextReturns(testErr)
+			})
+
+			It("rejects the batch", func() {
+				Expect(err).To(MatchError(ethereum.ErrQuorumNotReached))
+				Expect(err).To(MatchError(testErr))
+			})
+		})
+
+		When("enough nodes do not know the transaction", func() {
+			BeforeEach(func() {
+				primary.BatchCallContextStub = newBatchServer().serve
+				secondary.BatchCallContextStub = newBatchServer().serve
+				tertiary.BatchCallContextStub = newBatchServer().add(signedTx, receipt).serve
+			})
+
+			It("returns no receipt", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res).To(BeNil())
+			})
+		})
+	})
+
+	Describe("as the client of an EthService", func() {
+		var (
+			results []*ethereum.TxResult
+			err     error
+		)
+
+		BeforeEach(func() {
+			quorum = 2
+
+			for _, client := range []*fake.EthClient{primary, secondary, tertiary} {
+				client.BlockNumberReturns(110, nil)
+				client.ChainIDReturns(chainID, nil)
+				client.BatchCallContextStub = newBatchServer().add(signedTx, receipt).serve
+			}
+			primary.BatchCallContextStub = nil
+			primary.BatchCallContextReturns(testErr)
+		})
+
+		JustBeforeEach(func() {
+			service := ethereum.NewEthService(pool, ethereum.FamilyEthereum, false, 100, 4)
+			results, err = service.FetchTransactions(ctx, []string{signedTx.Hash().Hex()})
+		})
+
+		It("fetches the transaction through the healthy nodes", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(HaveLen(1))
+			Expect(results[0].Error).NotTo(HaveOccurred())
+			Expect(results[0].Transaction.TransactionHash).To(Equal(signedTx.Hash().Hex()))
+			Expect(results[0].Transaction.Confirmations).To(Equal(uint64(11)))
+			Expect(secondary.BatchCallContextCallCount()).To(Equal(1))
+		})
+	})
+})
@@ -1,91 +1,352 @@
 package ethereum
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
 	"sync"
 
+	goethereum "github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+var (
+	ErrVerificationFailed  = errors.New("receipt verification failed")
+	ErrTransactionNotFound = errors.New("transaction not found")
+	ErrTransactionPending  = errors.New("transaction pending")
+	ErrBlockNotFound       = errors.New("block not found")
+	ErrUnsupportedTxType   = errors.New("unsupported transaction type")
 )
 
 // EthService defines the interface for interacting with Ethereum transactions.
 type EthService struct {
-	client EthClient
+	client         EthClient
+	family         Family
+	verifyReceipts bool
+	batchSize      int
+	workers        int
+
+	chainIDMu sync.Mutex
+	chainID   *big.Int
 }
 
-// NewEthService is a constructor function for the EthService type.
-func NewEthService(ethClient EthClient) *EthService {
+// NewEthService is a constructor function for the EthService type. The family of the chain decides how transactions and receipts
+// are decoded and is detected from the chain ID when empty. When verifyReceipts is set, every receipt of an Ethereum family chain
+// is proven against the receiptsRoot of its block (and the transaction against the transactionsRoot) before it is returned; the
+// blocks of L2 chains hold system transactions that cannot be re-encoded, so their receipts are returned unverified. Transactions
+// are requested in JSON-RPC batches of at most batchSize calls, with at most workers batches in flight at the same time.
+func NewEthService(ethClient EthClient, family Family, verifyReceipts bool, batchSize int, workers int) *EthService {
+	if batchSize < 2 {
+		batchSize = 2
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
 	return &EthService{
-		client: ethClient,
+		client:         ethClient,
+		family:         family,
+		verifyReceipts: verifyReceipts,
+		batchSize:      batchSize,
+		workers:        workers,
 	}
 }
 
-// FetchTransactions fetches multiple transactions by their hashes concurrently.
-func (s *EthService) FetchTransactions(ctx context.Context, hashes []string) ([]*Transaction, error) {
-	resultsChan := make(chan *TxResult)
+// FetchTransactions fetches multiple transactions by their hashes and returns one result per hash, in the order of hashes.
+// Transactions that could not be fetched carry their own error, wrapping ErrTransactionNotFound or ErrTransactionPending
+// where that applies; the returned error is only set when nothing could be fetched at all. Pending transactions are returned
+// without their receipt fields next to ErrTransactionPending.
+//
+// The transaction and its receipt are requested in the same JSON-RPC batch, so fetching n hashes takes one round trip for the
+// chain head plus one per batch of batchSize/2 hashes, followed by one header request per block the transactions were included
+// in for their timestamp. The number of confirmations of each transaction is computed against the chain head observed once at
+// the start of the call.
+func (s *EthService) FetchTransactions(ctx context.Context, hashes []string) ([]*TxResult, error) {
+	if len(hashes) == 0 {
+		return nil, nil
+	}
+
+	chainID, err := s.getChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get chain id: %w", err)
+	}
 
+	head, err := s.client.BlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get chain head: %w", err)
+	}
+
+	family := s.family
+	if family == "" {
+		family = FamilyOf(chainID.Uint64())
+	}
+
+	signer := types.LatestSignerForChainID(chainID)
+	hashesPerBatch := s.batchSize / 2
+	batchCount := (len(hashes) + hashesPerBatch - 1) / hashesPerBatch
+	results := make([]*TxResult, len(hashes))
+
+	proofs := newBlockProofs()
+	batches := make(chan int)
 	var waitGrp sync.WaitGroup
-	for ind, hashStr := range hashes {
+	for range min(s.workers, batchCount) {
 		waitGrp.Add(1)
-		go func(i int, hashStr string) {
+		go func() {
 			defer waitGrp.Done()
-			hash := common.HexToHash(hashStr)
-			res := s.getTransactionByHash(ctx, hash)
-			if res.Error != nil {
-				res.Error = fmt.Errorf("fetching transaction %q: %w", hashStr, res.Error)
+			for start := range batches {
+				end := min(start+hashesPerBatch, len(hashes))
+				s.fetchBatch(ctx, hashes[start:end], results[start:end], head, signer, family, proofs)
 			}
-			resultsChan <- res
-		}(ind, hashStr)
+		}()
 	}
 
-	go func() {
-		waitGrp.Wait()
-		close(resultsChan)
-	}()
+	for start := 0; start < len(hashes); start += hashesPerBatch {
+		batches <- start
+	}
+	close(batches)
+	waitGrp.Wait()
 
-	var results []*Transaction
+	for i, result := range results {
+		result.Hash = hashes[i]
+	}
 
-	var aggrErr error
-	for result := range resultsChan {
-		if result.Error != nil {
-			aggrErr = errors.Join(aggrErr, result.Error)
-			continue
-		}
+	s.setBlockTimestamps(ctx, results)
+
+	return results, nil
+}
 
-		results = append(results, result.Transaction)
+// FetchBlockByHash fetches the block with the given hash. It returns an error wrapping ErrBlockNotFound when the node does not
+// know the block.
+func (s *EthService) FetchBlockByHash(ctx context.Context, hash string) (*Block, error) {
+	block, err := s.client.BlockByHash(ctx, common.HexToHash(hash))
+	if err != nil {
+		if errors.Is(err, goethereum.NotFound) {
+			return nil, fmt.Errorf("%w: %w", ErrBlockNotFound, err)
+		}
+		return nil, fmt.Errorf("get block by hash: %w", err)
 	}
+	return s.buildBlock(ctx, block)
+}
 
-	return results, aggrErr
+// FetchBlockByNumber fetches the canonical block with the given number. It returns an error wrapping ErrBlockNotFound when the
+// chain has not reached the block yet.
+func (s *EthService) FetchBlockByNumber(ctx context.Context, number uint64) (*Block, error) {
+	block, err := s.client.BlockByNumber(ctx, new(big.Int).SetUint64(number))
+	if err != nil {
+		if errors.Is(err, goethereum.NotFound) {
+			return nil, fmt.Errorf("%w: %w", ErrBlockNotFound, err)
+		}
+		return nil, fmt.Errorf("get block by number: %w", err)
+	}
+	return s.buildBlock(ctx, block)
 }
 
-func (s *EthService) getTransactionByHash(ctx context.Context, hash common.Hash) *TxResult {
-	tx, _, err := s.client.TransactionByHash(ctx, hash)
+// FetchHeadNumber fetches the number of the latest block of the chain.
+func (s *EthService) FetchHeadNumber(ctx context.Context) (uint64, error) {
+	head, err := s.client.BlockNumber(ctx)
 	if err != nil {
-		return &TxResult{nil, err}
+		return 0, fmt.Errorf("get chain head: %w", err)
 	}
+	return head, nil
+}
 
-	receipt, err := s.client.TransactionReceipt(ctx, hash)
+func (s *EthService) buildBlock(ctx context.Context, block *types.Block) (*Block, error) {
+	head, err := s.client.BlockNumber(ctx)
 	if err != nil {
-		return &TxResult{nil, err}
+		return nil, fmt.Errorf("get chain head: %w", err)
+	}
+
+	var baseFee *string
+	if block.BaseFee() != nil {
+		baseFee = toPtr(block.BaseFee().String())
+	}
+
+	txHashes := make([]string, 0, len(block.Transactions()))
+	for _, tx := range block.Transactions() {
+		txHashes = append(txHashes, tx.Hash().Hex())
+	}
+
+	return &Block{
+		Number:            block.NumberU64(),
+		Hash:              block.Hash().Hex(),
+		ParentHash:        block.ParentHash().Hex(),
+		Timestamp:         block.Time(),
+		Miner:             block.Coinbase().Hex(),
+		BaseFee:           baseFee,
+		GasUsed:           block.GasUsed(),
+		GasLimit:          block.GasLimit(),
+		StateRoot:         block.Root().Hex(),
+		TransactionsRoot:  block.TxHash().Hex(),
+		ReceiptsRoot:      block.ReceiptHash().Hex(),
+		TransactionHashes: txHashes,
+		Confirmations:     confirmations(head, block.NumberU64()),
+	}, nil
+}
+
+// setBlockTimestamps requests the header of every block that the fetched transactions were included in, with at most workers
+// requests in flight, and sets the block timestamp of the transactions. The timestamp is informational, so it is left unset
+// when the header cannot be fetched.
+func (s *EthService) setBlockTimestamps(ctx context.Context, results []*TxResult) {
+	byBlock := make(map[common.Hash][]*Transaction)
+	for _, result := range results {
+		if result.Transaction != nil {
+			blockHash := common.HexToHash(result.Transaction.BlockHash)
+			byBlock[blockHash] = append(byBlock[blockHash], result.Transaction)
+		}
+	}
+
+	slots := make(chan struct{}, s.workers)
+	var waitGrp sync.WaitGroup
+	for blockHash, txs := range byBlock {
+		waitGrp.Add(1)
+		slots <- struct{}{}
+		go func() {
+			defer func() {
+				<-slots
+				waitGrp.Done()
+			}()
+
+			header, err := s.client.HeaderByHash(ctx, blockHash)
+			if err != nil || header == nil {
+				return
+			}
+			for _, tx := range txs {
+				timestamp := header.Time
+				tx.BlockTimestamp = &timestamp
+			}
+		}()
 	}
+	waitGrp.Wait()
+}
+
+// fetchBatch requests the transactions and receipts of the given hashes in a single JSON-RPC batch and stores the outcome for
+// every hash at the same index of results.
+func (s *EthService) fetchBatch(ctx context.Context, hashes []string, results []*TxResult, head uint64, signer types.Signer, family Family, proofs *blockProofs) {
+	txs := make([]*rpcTransaction, len(hashes))
+	receipts := make([]*rpcReceipt, len(hashes))
+
+	elems := make([]rpc.BatchElem, 0, 2*len(hashes))
+	for i, hashStr := range hashes {
+		hash := common.HexToHash(hashStr)
+		elems = append(elems,
+			rpc.BatchElem{Method: "eth_getTransactionByHash", Args: []any{hash}, Result: &txs[i]},
+			rpc.BatchElem{Method: "eth_getTransactionReceipt", Args: []any{hash}, Result: &receipts[i]},
+		)
+	}
+
+	if err := s.client.BatchCallContext(ctx, elems); err != nil {
+		for i := range results {
+			results[i] = &TxResult{Error: err}
+		}
+		return
+	}
+
+	for i := range hashes {
+		txElem, receiptElem := elems[2*i], elems[2*i+1]
+		switch {
+		case txElem.Error != nil:
+			results[i] = &TxResult{Error: txElem.Error}
+		case txs[i] == nil || (txs[i].tx == nil && txs[i].system == nil):
+			results[i] = &TxResult{Error: fmt.Errorf("%w: %w", ErrTransactionNotFound, goethereum.NotFound)}
+		case txs[i].BlockHash == nil && txs[i].tx != nil:
+			results[i] = &TxResult{Pending: buildPendingTransaction(txs[i].tx, signer), Error: ErrTransactionPending}
+		case txs[i].BlockHash == nil:
+			results[i] = &TxResult{Error: ErrTransactionPending}
+		case receiptElem.Error != nil:
+			results[i] = &TxResult{Error: receiptElem.Error}
+		case receipts[i] == nil || receipts[i].receipt == nil:
+			results[i] = &TxResult{Error: fmt.Errorf("%w: receipt: %w", ErrTransactionNotFound, goethereum.NotFound)}
+		case txs[i].system != nil:
+			results[i] = buildSystemTransaction(txs[i], receipts[i], head, family)
+		default:
+			results[i] = s.buildTransaction(ctx, common.HexToHash(hashes[i]), txs[i].tx, receipts[i], head, signer, family, proofs)
+		}
+	}
+}
 
-	chainID, err := s.client.NetworkID(ctx)
+// getChainID returns the chain ID of the node, which is only requested once per service lifetime.
+func (s *EthService) getChainID(ctx context.Context) (*big.Int, error) {
+	s.chainIDMu.Lock()
+	defer s.chainIDMu.Unlock()
+
+	if s.chainID != nil {
+		return s.chainID, nil
+	}
+
+	chainID, err := s.client.ChainID(ctx)
 	if err != nil {
-		return &TxResult{nil, err}
+		return nil, err
 	}
 
-	signer := types.LatestSignerForChainID(chainID)
+	s.chainID = chainID
+	return chainID, nil
+}
+
+// ChainID returns the ID of the chain the nodes of the service belong to.
+func (s *EthService) ChainID(ctx context.Context) (uint64, error) {
+	chainID, err := s.getChainID(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("get chain id: %w", err)
+	}
+	return chainID.Uint64(), nil
+}
 
+func (s *EthService) buildTransaction(ctx context.Context, hash common.Hash, tx *types.Transaction, receipt *rpcReceipt, head uint64, signer types.Signer, family Family, proofs *blockProofs) *TxResult {
 	from, err := types.Sender(signer, tx)
 	if err != nil {
-		return &TxResult{nil, err}
+		return &TxResult{Error: err}
+	}
+
+	var verified bool
+	if s.verifyReceipts && family == FamilyEthereum {
+		if err := s.verifyReceipt(ctx, proofs, hash, tx, from, receipt.receipt); err != nil {
+			return &TxResult{Error: err}
+		}
+		verified = true
+	}
+
+	transaction := newTransaction(tx.Hash(), from, tx.To(), tx.Data(), tx.Value(), receipt.receipt, head)
+	transaction.Verified = verified
+	setTypeFields(transaction, tx, receipt.receipt)
+	setL1FeeFields(transaction, receipt.l1FeeFields, family)
+
+	return &TxResult{Transaction: transaction}
+}
+
+// buildSystemTransaction builds an L2 system transaction, whose sender is the one reported by the node since the transaction is
+// not signed by it. System transaction types of other families are rejected, as they cannot be told apart from a malformed
+// transaction.
+func buildSystemTransaction(tx *rpcTransaction, receipt *rpcReceipt, head uint64, family Family) *TxResult {
+	if !family.isSystemTxType(uint64(tx.system.Type)) {
+		return &TxResult{Error: fmt.Errorf("%w: 0x%x on %s chain", ErrUnsupportedTxType, uint64(tx.system.Type), family)}
+	}
+	if tx.From == nil {
+		return &TxResult{Error: fmt.Errorf("%w: 0x%x without sender", ErrUnsupportedTxType, uint64(tx.system.Type))}
 	}
 
-	var to string
-	if tx.To() != nil {
-		to = tx.To().Hex()
+	value := new(big.Int)
+	if tx.system.Value != nil {
+		value = tx.system.Value.ToInt()
+	}
+
+	transaction := newTransaction(tx.system.Hash, *tx.From, tx.system.To, tx.system.Input, value, receipt.receipt, head)
+	setSystemTypeFields(transaction, tx.system, receipt)
+	setL1FeeFields(transaction, receipt.l1FeeFields, family)
+
+	return &TxResult{Transaction: transaction}
+}
+
+// newTransaction builds the transaction from its fields and the fields of its receipt that do not depend on its type.
+func newTransaction(hash common.Hash, from common.Address, to *common.Address, data []byte, value *big.Int, receipt *types.Receipt, head uint64) *Transaction {
+	var toAddr string
+	if to != nil {
+		toAddr = to.Hex()
 	}
 
 	var contractAddress *string
@@ -95,21 +356,218 @@ func (s *EthService) getTransactionByHash(ctx context.Context, hash common.Hash)
 		contractAddress = &addr
 	}
 
-	return &TxResult{
-		Transaction: &Transaction{
-			TransactionHash:   tx.Hash().Hex(),
-			TransactionStatus: receipt.Status,
-			BlockHash:         receipt.BlockHash.Hex(),
-			BlockNumber:       receipt.BlockNumber.Uint64(),
-			From:              from.Hex(),
-			To:                toPtr(to),
-			ContractAddress:   contractAddress,
-			LogsCount:         len(receipt.Logs),
-			Input:             fmt.Sprintf("0x%x", tx.Data()),
-			Value:             tx.Value().String(),
-		},
-		Error: nil,
+	logs := make([]Log, 0, len(receipt.Logs))
+	for _, l := range receipt.Logs {
+		topics := make([]string, 0, len(l.Topics))
+		for _, topic := range l.Topics {
+			topics = append(topics, topic.Hex())
+		}
+		logs = append(logs, Log{
+			Address:  l.Address.Hex(),
+			Topics:   topics,
+			Data:     fmt.Sprintf("0x%x", l.Data),
+			LogIndex: l.Index,
+		})
+	}
+
+	return &Transaction{
+		TransactionHash:   hash.Hex(),
+		TransactionStatus: receipt.Status,
+		BlockHash:         receipt.BlockHash.Hex(),
+		BlockNumber:       receipt.BlockNumber.Uint64(),
+		From:              from.Hex(),
+		To:                toPtr(toAddr),
+		ContractAddress:   contractAddress,
+		LogsCount:         len(receipt.Logs),
+		Input:             fmt.Sprintf("0x%x", data),
+		Value:             value.String(),
+		Confirmations:     confirmations(head, receipt.BlockNumber.Uint64()),
+		Logs:              logs,
+		TokenTransfers:    tokenTransfers(receipt.Logs),
+	}
+}
+
+// setTypeFields sets the gas and fee fields of the transaction and the fields that only exist for some transaction types.
+func setTypeFields(transaction *Transaction, tx *types.Transaction, receipt *types.Receipt) {
+	transaction.Type = tx.Type()
+	transaction.Nonce = tx.Nonce()
+	transaction.GasLimit = tx.Gas()
+	transaction.GasUsed = receipt.GasUsed
+	transaction.EffectiveGasPrice = tx.GasPrice().String()
+	if receipt.EffectiveGasPrice != nil {
+		transaction.EffectiveGasPrice = receipt.EffectiveGasPrice.String()
+	}
+
+	switch tx.Type() {
+	case types.LegacyTxType, types.AccessListTxType:
+		transaction.GasPrice = toPtr(tx.GasPrice().String())
+	default:
+		transaction.MaxFeePerGas = toPtr(tx.GasFeeCap().String())
+		transaction.MaxPriorityFeePerGas = toPtr(tx.GasTipCap().String())
+	}
+
+	if tx.Type() != types.LegacyTxType {
+		transaction.AccessList = make([]AccessTuple, 0, len(tx.AccessList()))
+		for _, tuple := range tx.AccessList() {
+			keys := make([]string, 0, len(tuple.StorageKeys))
+			for _, key := range tuple.StorageKeys {
+				keys = append(keys, key.Hex())
+			}
+			transaction.AccessList = append(transaction.AccessList, AccessTuple{Address: tuple.Address.Hex(), StorageKeys: keys})
+		}
+	}
+
+	if tx.Type() == types.BlobTxType {
+		transaction.BlobVersionedHashes = make([]string, 0, len(tx.BlobHashes()))
+		for _, hash := range tx.BlobHashes() {
+			transaction.BlobVersionedHashes = append(transaction.BlobVersionedHashes, hash.Hex())
+		}
+		transaction.MaxFeePerBlobGas = toPtr(tx.BlobGasFeeCap().String())
+		transaction.BlobGasUsed = &receipt.BlobGasUsed
+		if receipt.BlobGasPrice != nil {
+			transaction.BlobGasPrice = toPtr(receipt.BlobGasPrice.String())
+		}
+	}
+
+	if tx.Type() == types.SetCodeTxType {
+		transaction.AuthorizationList = make([]Authorization, 0, len(tx.SetCodeAuthorizations()))
+		for _, auth := range tx.SetCodeAuthorizations() {
+			authorization := Authorization{
+				ChainID: auth.ChainID.Dec(),
+				Address: auth.Address.Hex(),
+				Nonce:   auth.Nonce,
+				YParity: auth.V,
+				R:       auth.R.Hex(),
+				S:       auth.S.Hex(),
+			}
+			if authority, err := auth.Authority(); err == nil {
+				authorization.Authority = toPtr(authority.Hex())
+			}
+			transaction.AuthorizationList = append(transaction.AuthorizationList, authorization)
+		}
+	}
+}
+
+// blockProofs holds the blocks proven while verifying the receipts of a FetchTransactions call, so that a block is downloaded
+// and proven once however many of the fetched transactions it includes.
+type blockProofs struct {
+	mu     sync.Mutex
+	blocks map[common.Hash]*blockProof
+}
+
+// blockProof is a block whose transactions and receipts were proven against the roots committed to in its header, or the error
+// that proving it failed with.
+type blockProof struct {
+	once     sync.Once
+	block    *types.Block
+	receipts []*types.Receipt
+	err      error
+}
+
+func newBlockProofs() *blockProofs {
+	return &blockProofs{blocks: make(map[common.Hash]*blockProof)}
+}
+
+// get returns the proof of the block with the given hash, proving the block on the first call for it.
+func (p *blockProofs) get(ctx context.Context, s *EthService, blockHash common.Hash) *blockProof {
+	p.mu.Lock()
+	proof, ok := p.blocks[blockHash]
+	if !ok {
+		proof = &blockProof{}
+		p.blocks[blockHash] = proof
+	}
+	p.mu.Unlock()
+
+	proof.once.Do(func() {
+		proof.block, proof.receipts, proof.err = s.proveBlock(ctx, blockHash)
+	})
+	return proof
+}
+
+// proveBlock downloads the block with the given hash together with all of its receipts, rebuilds the transactions and receipts
+// tries and checks them against the roots committed to in the block header.
+func (s *EthService) proveBlock(ctx context.Context, blockHash common.Hash) (*types.Block, []*types.Receipt, error) {
+	block, err := s.client.BlockByHash(ctx, blockHash)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get block by hash: %w", err)
+	}
+
+	if block.Hash() != blockHash {
+		return nil, nil, fmt.Errorf("%w: header hash %s does not match block hash %s", ErrVerificationFailed, block.Hash().Hex(), blockHash.Hex())
+	}
+
+	if txRoot := types.DeriveSha(block.Transactions(), trie.NewStackTrie(nil)); txRoot != block.TxHash() {
+		return nil, nil, fmt.Errorf("%w: transactions root %s does not match header %s", ErrVerificationFailed, txRoot.Hex(), block.TxHash().Hex())
+	}
+
+	receipts, err := s.client.BlockReceipts(ctx, rpc.BlockNumberOrHashWithHash(blockHash, false))
+	if err != nil {
+		return nil, nil, fmt.Errorf("get block receipts: %w", err)
+	}
+
+	if receiptsRoot := types.DeriveSha(types.Receipts(receipts), trie.NewStackTrie(nil)); receiptsRoot != block.ReceiptHash() {
+		return nil, nil, fmt.Errorf("%w: receipts root %s does not match header %s", ErrVerificationFailed, receiptsRoot.Hex(), block.ReceiptHash().Hex())
+	}
+
+	return block, receipts, nil
+}
+
+// verifyReceipt proves the block that the receipt points to, once per FetchTransactions call. The transaction must be the one
+// that was requested by hash and the one proven at the index of the receipt in the block, the receipt must be in the block at
+// its number and must be identical to the one proven at its index. The fields of the receipt that are not part of its
+// consensus encoding, the gas used and the contract address, are then replaced by the ones derived from the proven data.
+func (s *EthService) verifyReceipt(ctx context.Context, proofs *blockProofs, hash common.Hash, tx *types.Transaction, from common.Address, receipt *types.Receipt) error {
+	if tx.Hash() != hash {
+		return fmt.Errorf("%w: node returned transaction %s for %s", ErrVerificationFailed, tx.Hash().Hex(), hash.Hex())
+	}
+
+	proof := proofs.get(ctx, s, receipt.BlockHash)
+	if proof.err != nil {
+		return proof.err
+	}
+	transactions, receipts := proof.block.Transactions(), proof.receipts
+
+	if receipt.BlockNumber == nil || receipt.BlockNumber.Uint64() != proof.block.NumberU64() {
+		return fmt.Errorf("%w: receipt of %s claims block number %v, block %s is number %d", ErrVerificationFailed, tx.Hash().Hex(), receipt.BlockNumber, receipt.BlockHash.Hex(), proof.block.NumberU64())
+	}
+
+	index := int(receipt.TransactionIndex)
+	if index >= len(transactions) || index >= len(receipts) || transactions[index].Hash() != tx.Hash() {
+		return fmt.Errorf("%w: transaction %s not found at index %d of block %s", ErrVerificationFailed, tx.Hash().Hex(), index, receipt.BlockHash.Hex())
+	}
+
+	proven, err := receipts[index].MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("encode block receipt: %w", err)
+	}
+
+	served, err := receipt.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("encode transaction receipt: %w", err)
+	}
+
+	if !bytes.Equal(proven, served) {
+		return fmt.Errorf("%w: receipt of %s differs from the one committed in block %s", ErrVerificationFailed, tx.Hash().Hex(), receipt.BlockHash.Hex())
+	}
+
+	receipt.GasUsed = receipts[index].CumulativeGasUsed
+	if index > 0 {
+		receipt.GasUsed -= receipts[index-1].CumulativeGasUsed
+	}
+	receipt.ContractAddress = common.Address{}
+	if tx.To() == nil {
+		receipt.ContractAddress = crypto.CreateAddress(from, tx.Nonce())
+	}
+
+	return nil
+}
+
+// confirmations returns the number of blocks, including its own, that have been built on top of the given block.
+func confirmations(head, blockNumber uint64) uint64 {
+	if head < blockNumber {
+		return 0
 	}
+	return head - blockNumber + 1
 }
 
 func toPtr(s string) *string {
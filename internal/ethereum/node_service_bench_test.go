@@ -0,0 +1,70 @@
+package ethereum_test
+
+import (
+	"context"
+	"fethcher/internal/ethereum"
+	"fethcher/internal/ethereum/fake"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// BenchmarkFetchTransactions reports the number of node round trips needed to fetch 100 transactions for several batch sizes.
+// A batch size of 2 sends one transaction and its receipt per request, which is what fetching without batching costs.
+func BenchmarkFetchTransactions(b *testing.B) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	chainID := big.NewInt(5)
+	signer := types.LatestSignerForChainID(chainID)
+	server := newBatchServer()
+
+	hashes := make([]string, 0, 100)
+	for i := range 100 {
+		tx, err := types.SignTx(types.NewTransaction(uint64(i), common.Address{}, big.NewInt(1), 21000, big.NewInt(1), nil), signer, privateKey)
+		if err != nil {
+			b.Fatal(err)
+		}
+		server.add(tx, &types.Receipt{
+			Status:      1,
+			BlockHash:   common.HexToHash("0xabc"),
+			BlockNumber: big.NewInt(100),
+		})
+		hashes = append(hashes, tx.Hash().Hex())
+	}
+
+	for _, batchSize := range []int{2, 20, 100, 200} {
+		b.Run(fmt.Sprintf("batch=%d", batchSize), func(b *testing.B) {
+			client := new(fake.EthClient)
+			client.ChainIDReturns(chainID, nil)
+			client.BlockNumberReturns(110, nil)
+			client.BatchCallContextStub = server.serve
+
+			service := ethereum.NewEthService(client, ethereum.FamilyEthereum, false, batchSize, 4)
+			ctx := context.Background()
+
+			b.ResetTimer()
+			for range b.N {
+				results, err := service.FetchTransactions(ctx, hashes)
+				if err != nil {
+					b.Fatal(err)
+				}
+				for _, result := range results {
+					if result.Error != nil {
+						b.Fatal(result.Error)
+					}
+				}
+			}
+			b.StopTimer()
+
+			roundTrips := client.BatchCallContextCallCount() + client.ChainIDCallCount() + client.BlockNumberCallCount() + client.HeaderByHashCallCount()
+			b.ReportMetric(float64(roundTrips)/float64(b.N), "roundtrips/op")
+		})
+	}
+}
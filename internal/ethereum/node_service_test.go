@@ -5,13 +5,18 @@ import (
 	"errors"
 	"fethcher/internal/ethereum"
 	"fethcher/internal/ethereum/fake"
-	"fmt"
 	"math/big"
+	"sync/atomic"
 	"time"
 
+	goethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/holiman/uint256"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -28,19 +33,20 @@ var _ = Describe("EthService", func() {
 		fakeClient = new(fake.EthClient)
 		testErr = errors.New("test error")
 		ctx = context.Background()
-		service = ethereum.NewEthService(fakeClient)
+		service = ethereum.NewEthService(fakeClient, ethereum.FamilyEthereum, false, 100, 4)
 	})
 
 	Describe("FetchTransactions", func() {
 		var (
 			hashes    []string
-			results   []*ethereum.Transaction
+			results   []*ethereum.TxResult
 			err       error
 			signedTx1 *types.Transaction
 			signedTx2 *types.Transaction
 			chainID   *big.Int
 			tx1       *types.Transaction
 			tx2       *types.Transaction
+			node      *batchServer
 		)
 
 		BeforeEach(func() {
@@ -61,18 +67,20 @@ var _ = Describe("EthService", func() {
 				signedTx2.Hash().Hex(),
 			}
 
-			fakeClient.NetworkIDReturns(chainID, nil)
+			fakeClient.ChainIDReturns(chainID, nil)
 
-			fakeClient.TransactionReceiptReturnsOnCall(0, &types.Receipt{
-				Status:      1,
-				BlockHash:   common.HexToHash("0xabc"),
-				BlockNumber: big.NewInt(100),
-			}, nil)
-			fakeClient.TransactionReceiptReturnsOnCall(1, &types.Receipt{
-				Status:      1,
-				BlockHash:   common.HexToHash("0xdef"),
-				BlockNumber: big.NewInt(101),
-			}, nil)
+			node = newBatchServer().
+				add(signedTx1, &types.Receipt{
+					Status:      1,
+					BlockHash:   common.HexToHash("0xabc"),
+					BlockNumber: big.NewInt(100),
+				}).
+				add(signedTx2, &types.Receipt{
+					Status:      1,
+					BlockHash:   common.HexToHash("0xdef"),
+					BlockNumber: big.NewInt(101),
+				})
+			fakeClient.BatchCallContextStub = node.serve
 		})
 
 		JustBeforeEach(func() {
@@ -80,46 +88,337 @@ var _ = Describe("EthService", func() {
 		})
 
 		When("all transactions are fetched successfully", func() {
+			It("should return all transactions in request order", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(results).To(HaveLen(2))
+				Expect(results[0].Hash).To(Equal(hashes[0]))
+				Expect(results[0].Error).NotTo(HaveOccurred())
+				Expect(results[0].Transaction.TransactionHash).To(Equal(signedTx1.Hash().Hex()))
+				Expect(results[1].Hash).To(Equal(hashes[1]))
+				Expect(results[1].Transaction.TransactionHash).To(Equal(signedTx2.Hash().Hex()))
+				Expect(results[1].Transaction.BlockHash).To(Equal(common.HexToHash("0xdef").Hex()))
+				Expect(results[1].Transaction.Value).To(Equal("1"))
+			})
+
+			It("should request transactions and receipts in a single batch", func() {
+				Expect(fakeClient.BatchCallContextCallCount()).To(Equal(1))
+
+				_, elems := fakeClient.BatchCallContextArgsForCall(0)
+				Expect(elems).To(HaveLen(4))
+				Expect(elems[0].Method).To(Equal("eth_getTransactionByHash"))
+				Expect(elems[0].Args).To(Equal([]any{signedTx1.Hash()}))
+				Expect(elems[1].Method).To(Equal("eth_getTransactionReceipt"))
+				Expect(elems[1].Args).To(Equal([]any{signedTx1.Hash()}))
+				Expect(elems[2].Method).To(Equal("eth_getTransactionByHash"))
+				Expect(elems[2].Args).To(Equal([]any{signedTx2.Hash()}))
+			})
+		})
+
+		When("the receipt contains logs", func() {
 			BeforeEach(func() {
-				fakeClient.TransactionByHashReturnsOnCall(0, signedTx1, false, nil)
-				fakeClient.TransactionByHashReturnsOnCall(1, signedTx2, false, nil)
+				node.add(signedTx2, &types.Receipt{
+					Status:      1,
+					BlockHash:   common.HexToHash("0xdef"),
+					BlockNumber: big.NewInt(101),
+					Logs: []*types.Log{{
+						Address: common.HexToAddress("0xc0ffee"),
+						Topics:  []common.Hash{common.HexToHash("0x01"), common.HexToHash("0x02")},
+						Data:    []byte{0xca, 0xfe},
+						TxHash:  signedTx2.Hash(),
+						Index:   7,
+					}},
+				})
 			})
 
-			It("should return all transactions", func() {
+			It("should return the logs of the transaction", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(results[0].Transaction.Logs).To(BeEmpty())
+				Expect(results[1].Transaction.LogsCount).To(Equal(1))
+				Expect(results[1].Transaction.Logs).To(Equal([]ethereum.Log{{
+					Address:  common.HexToAddress("0xc0ffee").Hex(),
+					Topics:   []string{common.HexToHash("0x01").Hex(), common.HexToHash("0x02").Hex()},
+					Data:     "0xcafe",
+					LogIndex: 7,
+				}}))
+			})
+		})
+
+		When("the receipt contains token transfer events", func() {
+			var token, alice, bob common.Address
+
+			BeforeEach(func() {
+				token = common.HexToAddress("0x70ce")
+				alice = common.HexToAddress("0xa11ce")
+				bob = common.HexToAddress("0xb0b")
+
+				transfer := crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+				transferSingle := crypto.Keccak256Hash([]byte("TransferSingle(address,address,address,uint256,uint256)"))
+				transferBatch := crypto.Keccak256Hash([]byte("TransferBatch(address,address,address,uint256[],uint256[])"))
+
+				uint256Type, _ := abi.NewType("uint256", "", nil)
+				uint256ArrayType, _ := abi.NewType("uint256[]", "", nil)
+				singleData, err := abi.Arguments{{Type: uint256Type}, {Type: uint256Type}}.Pack(big.NewInt(9), big.NewInt(3))
+				Expect(err).NotTo(HaveOccurred())
+				batchData, err := abi.Arguments{{Type: uint256ArrayType}, {Type: uint256ArrayType}}.
+					Pack([]*big.Int{big.NewInt(1), big.NewInt(2)}, []*big.Int{big.NewInt(10), big.NewInt(20)})
+				Expect(err).NotTo(HaveOccurred())
+
+				from, to, operator := common.BytesToHash(alice.Bytes()), common.BytesToHash(bob.Bytes()), common.HexToHash("0x0b")
+				node.add(signedTx2, &types.Receipt{
+					Status:      1,
+					BlockHash:   common.HexToHash("0xdef"),
+					BlockNumber: big.NewInt(101),
+					Logs: []*types.Log{
+						{Address: token, Topics: []common.Hash{transfer, from, to}, Data: common.LeftPadBytes([]byte{0x64}, 32), Index: 0},
+						{Address: token, Topics: []common.Hash{transfer, from, to, common.HexToHash("0x2a")}, Index: 1},
+						{Address: token, Topics: []common.Hash{transfer, from}, Data: []byte{0x01}, Index: 2},
+						{Address: token, Topics: []common.Hash{transferSingle, operator, from, to}, Data: singleData, Index: 3},
+						{Address: token, Topics: []common.Hash{transferBatch, operator, from, to}, Data: batchData, Index: 4},
+					},
+				})
+			})
+
+			It("should return the token transfers of the transaction", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(results[0].Transaction.TokenTransfers).To(BeEmpty())
+
+				tokenID := func(id string) *string { return &id }
+				Expect(results[1].Transaction.TokenTransfers).To(Equal([]ethereum.TokenTransfer{
+					{Token: token.Hex(), From: alice.Hex(), To: bob.Hex(), Amount: "100", Standard: ethereum.StandardERC20, LogIndex: 0},
+					{Token: token.Hex(), From: alice.Hex(), To: bob.Hex(), Amount: "1", TokenID: tokenID("42"), Standard: ethereum.StandardERC721, LogIndex: 1},
+					{Token: token.Hex(), From: alice.Hex(), To: bob.Hex(), Amount: "3", TokenID: tokenID("9"), Standard: ethereum.StandardERC1155, LogIndex: 3},
+					{Token: token.Hex(), From: alice.Hex(), To: bob.Hex(), Amount: "10", TokenID: tokenID("1"), Standard: ethereum.StandardERC1155, LogIndex: 4},
+					{Token: token.Hex(), From: alice.Hex(), To: bob.Hex(), Amount: "20", TokenID: tokenID("2"), Standard: ethereum.StandardERC1155, LogIndex: 4, BatchIndex: 1},
+				}))
+			})
+		})
+
+		When("typed transactions are fetched", func() {
+			var dynamicFeeTx, blobTx, setCodeTx *types.Transaction
+
+			BeforeEach(func() {
+				key, err := crypto.GenerateKey()
+				Expect(err).NotTo(HaveOccurred())
+				signer := types.LatestSignerForChainID(chainID)
+				to := common.HexToAddress("0xc0ffee")
+				accessList := types.AccessList{{Address: to, StorageKeys: []common.Hash{common.HexToHash("0x01")}}}
+
+				dynamicFeeTx = types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+					ChainID: chainID, Nonce: 7, GasTipCap: big.NewInt(2), GasFeeCap: big.NewInt(30), Gas: 21000, To: &to, AccessList: accessList,
+				})
+				blobTx = types.MustSignNewTx(key, signer, &types.BlobTx{
+					ChainID: uint256.MustFromBig(chainID), Nonce: 8, GasTipCap: uint256.NewInt(2), GasFeeCap: uint256.NewInt(30), Gas: 21000,
+					To: to, BlobFeeCap: uint256.NewInt(5), BlobHashes: []common.Hash{common.HexToHash("0x0101")},
+				})
+				auth, err := types.SignSetCode(key, types.SetCodeAuthorization{ChainID: *uint256.MustFromBig(chainID), Address: to, Nonce: 10})
+				Expect(err).NotTo(HaveOccurred())
+				setCodeTx = types.MustSignNewTx(key, signer, &types.SetCodeTx{
+					ChainID: uint256.MustFromBig(chainID), Nonce: 9, GasTipCap: uint256.NewInt(2), GasFeeCap: uint256.NewInt(30), Gas: 50000,
+					To: to, AuthList: []types.SetCodeAuthorization{auth},
+				})
+
+				for _, tx := range []*types.Transaction{dynamicFeeTx, blobTx, setCodeTx} {
+					node.add(tx, &types.Receipt{
+						Type:              tx.Type(),
+						Status:            1,
+						BlockHash:         common.HexToHash("0xabc"),
+						BlockNumber:       big.NewInt(100),
+						GasUsed:           21000,
+						EffectiveGasPrice: big.NewInt(12),
+						BlobGasUsed:       131072,
+						BlobGasPrice:      big.NewInt(3),
+					})
+				}
+				hashes = []string{signedTx1.Hash().Hex(), dynamicFeeTx.Hash().Hex(), blobTx.Hash().Hex(), setCodeTx.Hash().Hex()}
+			})
+
+			It("should return the fields of every transaction type", func() {
+				Expect(err).NotTo(HaveOccurred())
+
+				legacy := results[0].Transaction
+				Expect(legacy.Type).To(Equal(uint8(types.LegacyTxType)))
+				Expect(*legacy.GasPrice).To(Equal("0"))
+				Expect(legacy.MaxFeePerGas).To(BeNil())
+				Expect(legacy.AccessList).To(BeNil())
+
+				dynamicFee := results[1].Transaction
+				Expect(dynamicFee.Type).To(Equal(uint8(types.DynamicFeeTxType)))
+				Expect(dynamicFee.Nonce).To(Equal(uint64(7)))
+				Expect(dynamicFee.GasLimit).To(Equal(uint64(21000)))
+				Expect(dynamicFee.GasUsed).To(Equal(uint64(21000)))
+				Expect(dynamicFee.EffectiveGasPrice).To(Equal("12"))
+				Expect(dynamicFee.GasPrice).To(BeNil())
+				Expect(*dynamicFee.MaxFeePerGas).To(Equal("30"))
+				Expect(*dynamicFee.MaxPriorityFeePerGas).To(Equal("2"))
+				Expect(dynamicFee.AccessList).To(Equal([]ethereum.AccessTuple{{
+					Address:     common.HexToAddress("0xc0ffee").Hex(),
+					StorageKeys: []string{common.HexToHash("0x01").Hex()},
+				}}))
+				Expect(dynamicFee.BlobGasUsed).To(BeNil())
+
+				blob := results[2].Transaction
+				Expect(blob.Type).To(Equal(uint8(types.BlobTxType)))
+				Expect(blob.BlobVersionedHashes).To(Equal([]string{common.HexToHash("0x0101").Hex()}))
+				Expect(*blob.MaxFeePerBlobGas).To(Equal("5"))
+				Expect(*blob.BlobGasUsed).To(Equal(uint64(131072)))
+				Expect(*blob.BlobGasPrice).To(Equal("3"))
+				Expect(blob.AuthorizationList).To(BeNil())
+
+				setCode := results[3].Transaction
+				Expect(setCode.Type).To(Equal(uint8(types.SetCodeTxType)))
+				Expect(setCode.AuthorizationList).To(HaveLen(1))
+				Expect(setCode.AuthorizationList[0].Address).To(Equal(common.HexToAddress("0xc0ffee").Hex()))
+				Expect(setCode.AuthorizationList[0].Nonce).To(Equal(uint64(10)))
+				Expect(setCode.AuthorizationList[0].ChainID).To(Equal("5"))
+				Expect(*setCode.AuthorizationList[0].Authority).To(Equal(setCode.From))
+			})
+		})
+
+		When("the block headers are known", func() {
+			BeforeEach(func() {
+				fakeClient.HeaderByHashStub = func(_ context.Context, hash common.Hash) (*types.Header, error) {
+					if hash == common.HexToHash("0xdef") {
+						return nil, testErr
+					}
+					return &types.Header{Time: 1700000000}, nil
+				}
+			})
+
+			It("should set the block timestamp where the header could be fetched", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeClient.HeaderByHashCallCount()).To(Equal(2))
+				Expect(*results[0].Transaction.BlockTimestamp).To(Equal(uint64(1700000000)))
+				Expect(results[1].Error).NotTo(HaveOccurred())
+				Expect(results[1].Transaction.BlockTimestamp).To(BeNil())
+			})
+		})
+
+		When("the chain head is known", func() {
+			BeforeEach(func() {
+				fakeClient.BlockNumberReturns(110, nil)
+			})
+
+			It("should count the confirmations of every transaction", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(results).To(HaveLen(2))
-				Expect(results[0].TransactionHash).To(Or(Equal(signedTx1.Hash().Hex()), Equal(signedTx2.Hash().Hex())))
-				Expect(results[1].TransactionHash).To(Or(Equal(signedTx1.Hash().Hex()), Equal(signedTx2.Hash().Hex())))
+				Expect(fakeClient.BlockNumberCallCount()).To(Equal(1))
+				Expect(results[0].Transaction.Confirmations).To(Equal(uint64(11)))
+				Expect(results[1].Transaction.Confirmations).To(Equal(uint64(10)))
+			})
+		})
 
-				Expect(fakeClient.TransactionByHashCallCount()).To(Equal(2))
+		When("getting the chain head fails", func() {
+			BeforeEach(func() {
+				fakeClient.BlockNumberReturns(0, testErr)
+			})
+
+			It("should return an error without fetching transactions", func() {
+				Expect(err).To(MatchError(testErr))
+				Expect(results).To(BeNil())
+				Expect(fakeClient.BatchCallContextCallCount()).To(Equal(0))
+			})
+		})
+
+		When("getting the chain id fails", func() {
+			BeforeEach(func() {
+				fakeClient.ChainIDReturns(nil, testErr)
+			})
+
+			It("should return an error without fetching transactions", func() {
+				Expect(err).To(MatchError(testErr))
+				Expect(fakeClient.BatchCallContextCallCount()).To(Equal(0))
+			})
+		})
+
+		When("transactions are fetched more than once", func() {
+			It("should only request the chain id once", func() {
+				Expect(err).NotTo(HaveOccurred())
+				_, err = service.FetchTransactions(ctx, hashes)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeClient.ChainIDCallCount()).To(Equal(1))
+				Expect(fakeClient.BlockNumberCallCount()).To(Equal(2))
+			})
+		})
+
+		When("the node does not know a transaction", func() {
+			BeforeEach(func() {
+				hashes = append(hashes, common.HexToHash("0x404").Hex())
+			})
+
+			It("should return a transaction not found error for it", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(results).To(HaveLen(3))
+				Expect(results[0].Error).NotTo(HaveOccurred())
+				Expect(results[2].Hash).To(Equal(hashes[2]))
+				Expect(results[2].Transaction).To(BeNil())
+				Expect(results[2].Error).To(MatchError(ethereum.ErrTransactionNotFound))
+			})
+		})
 
-				_, argHash1 := fakeClient.TransactionByHashArgsForCall(0)
-				Expect(argHash1.Hex()).To(Or(Equal(signedTx1.Hash().Hex()), Equal(signedTx2.Hash().Hex())))
+		When("a transaction has not been mined yet", func() {
+			var sender common.Address
 
-				_, argHash2 := fakeClient.TransactionByHashArgsForCall(1)
-				Expect(argHash2.Hex()).To(Or(Equal(signedTx1.Hash().Hex()), Equal(signedTx2.Hash().Hex())))
+			BeforeEach(func() {
+				privateKey, keyErr := crypto.GenerateKey()
+				Expect(keyErr).NotTo(HaveOccurred())
+				sender = crypto.PubkeyToAddress(privateKey.PublicKey)
+				pendingTx, _ := types.SignTx(types.NewTransaction(2, common.Address{}, big.NewInt(2), 2, big.NewInt(2), nil), types.LatestSignerForChainID(chainID), privateKey)
 
-				Expect(fakeClient.TransactionReceiptCallCount()).To(Equal(2))
+				node.add(pendingTx, nil)
+				hashes = append(hashes, pendingTx.Hash().Hex())
+			})
 
-				_, argRecHash1 := fakeClient.TransactionReceiptArgsForCall(0)
-				Expect(argRecHash1).To(Or(Equal(signedTx1.Hash()), Equal(signedTx2.Hash())))
+			It("should return a transaction pending error for it", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(results).To(HaveLen(3))
+				Expect(results[2].Transaction).To(BeNil())
+				Expect(results[2].Error).To(MatchError(ethereum.ErrTransactionPending))
+			})
 
-				_, argRecHash2 := fakeClient.TransactionReceiptArgsForCall(1)
-				Expect(argRecHash2).To(Or(Equal(signedTx1.Hash()), Equal(signedTx2.Hash())))
+			It("should return the pending transaction without receipt fields", func() {
+				Expect(err).NotTo(HaveOccurred())
+				to := common.Address{}.Hex()
+				gasPrice := "2"
+				Expect(results[2].Pending).To(Equal(&ethereum.PendingTransaction{
+					TransactionHash: hashes[2],
+					From:            sender.Hex(),
+					To:              &to,
+					Input:           "0x",
+					Value:           "2",
+					Type:            types.LegacyTxType,
+					Nonce:           2,
+					GasLimit:        2,
+					GasPrice:        &gasPrice,
+				}))
 			})
 		})
 
 		When("some transactions fail to fetch", func() {
 			BeforeEach(func() {
-				fakeClient.TransactionByHashReturnsOnCall(0, nil, false, testErr)
-				fakeClient.TransactionByHashReturnsOnCall(1, signedTx2, false, nil)
+				node.fail(signedTx1.Hash(), testErr)
 			})
 
-			It("should return partial results with error", func() {
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring(fmt.Sprintf("fetching transaction %q: %s", hashes[0], testErr.Error())))
-				Expect(results).To(HaveLen(1))
-				Expect(results[0].TransactionHash).To(Equal(signedTx2.Hash().Hex()))
+			It("should return the error of the failed transaction only", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(results).To(HaveLen(2))
+				Expect(results[0].Error).To(MatchError(testErr))
+				Expect(results[0].Transaction).To(BeNil())
+				Expect(results[1].Error).NotTo(HaveOccurred())
+				Expect(results[1].Transaction.TransactionHash).To(Equal(signedTx2.Hash().Hex()))
+			})
+		})
+
+		When("the batch request fails", func() {
+			BeforeEach(func() {
+				fakeClient.BatchCallContextReturns(testErr)
+				fakeClient.BatchCallContextStub = nil
+			})
+
+			It("should return an error for every transaction", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(results).To(HaveLen(2))
+				Expect(results[0].Error).To(MatchError(testErr))
+				Expect(results[1].Error).To(MatchError(testErr))
 			})
 		})
 
@@ -129,20 +428,347 @@ var _ = Describe("EthService", func() {
 				ctx, cancel = context.WithCancel(ctx)
 				cancel()
 
-				fakeClient.TransactionByHashStub = func(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+				fakeClient.BatchCallContextStub = func(ctx context.Context, elems []rpc.BatchElem) error {
 					select {
 					case <-ctx.Done():
-						return nil, false, ctx.Err()
+						return ctx.Err()
 					case <-time.After(100 * time.Millisecond):
-						return tx1, false, nil
+						return node.serve(ctx, elems)
 					}
 				}
 			})
 
 			It("should return context cancelled error", func() {
-				Expect(err).To(MatchError(context.Canceled))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(results[0].Error).To(MatchError(context.Canceled))
+			})
+		})
+
+		When("there are more hashes than fit in one batch", func() {
+			var (
+				inFlight    atomic.Int32
+				maxInFlight atomic.Int32
+			)
+
+			BeforeEach(func() {
+				service = ethereum.NewEthService(fakeClient, ethereum.FamilyEthereum, false, 2, 2)
+				for i := range 4 {
+					hashes = append(hashes, common.BigToHash(big.NewInt(int64(1000+i))).Hex())
+				}
+
+				inFlight.Store(0)
+				maxInFlight.Store(0)
+				fakeClient.BatchCallContextStub = func(ctx context.Context, elems []rpc.BatchElem) error {
+					current := inFlight.Add(1)
+					defer inFlight.Add(-1)
+					for {
+						peak := maxInFlight.Load()
+						if current <= peak || maxInFlight.CompareAndSwap(peak, current) {
+							break
+						}
+					}
+					time.Sleep(10 * time.Millisecond)
+					return node.serve(ctx, elems)
+				}
+			})
+
+			It("should split the hashes into batches and bound the concurrency", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(results).To(HaveLen(6))
+				Expect(results[1].Error).NotTo(HaveOccurred())
+				Expect(results[5].Error).To(MatchError(ethereum.ErrTransactionNotFound))
+				Expect(fakeClient.BatchCallContextCallCount()).To(Equal(6))
+				for i := range 6 {
+					_, elems := fakeClient.BatchCallContextArgsForCall(i)
+					Expect(elems).To(HaveLen(2))
+				}
+				Expect(maxInFlight.Load()).To(BeNumerically("<=", 2))
 			})
 		})
 	})
 
+	Describe("FetchHeadNumber", func() {
+		It("should return the number of the latest block", func() {
+			fakeClient.BlockNumberReturns(110, nil)
+			head, err := service.FetchHeadNumber(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(head).To(Equal(uint64(110)))
+		})
+
+		It("should return the error of the node", func() {
+			fakeClient.BlockNumberReturns(0, errors.New("node down"))
+			_, err := service.FetchHeadNumber(ctx)
+			Expect(err).To(MatchError(ContainSubstring("node down")))
+		})
+	})
+
+	Describe("FetchBlock", func() {
+		var (
+			block  *types.Block
+			result *ethereum.Block
+			err    error
+		)
+
+		BeforeEach(func() {
+			tx := types.NewTransaction(0, common.Address{}, big.NewInt(0), 21000, big.NewInt(1), nil)
+			block = types.NewBlock(&types.Header{
+				Number:     big.NewInt(100),
+				ParentHash: common.HexToHash("0xaaa"),
+				Time:       1700000000,
+				Coinbase:   common.HexToAddress("0xc0ffee"),
+				BaseFee:    big.NewInt(7),
+				GasUsed:    21000,
+				GasLimit:   30000000,
+				Root:       common.HexToHash("0xbbb"),
+			}, &types.Body{Transactions: []*types.Transaction{tx}}, nil, trie.NewStackTrie(nil))
+
+			fakeClient.BlockByHashReturns(block, nil)
+			fakeClient.BlockByNumberReturns(block, nil)
+			fakeClient.BlockNumberReturns(110, nil)
+		})
+
+		When("the block is fetched by hash", func() {
+			JustBeforeEach(func() {
+				result, err = service.FetchBlockByHash(ctx, block.Hash().Hex())
+			})
+
+			It("should return the header fields and the transaction hashes", func() {
+				Expect(err).NotTo(HaveOccurred())
+				_, hash := fakeClient.BlockByHashArgsForCall(0)
+				Expect(hash).To(Equal(block.Hash()))
+
+				baseFee := "7"
+				Expect(result).To(Equal(&ethereum.Block{
+					Number:            100,
+					Hash:              block.Hash().Hex(),
+					ParentHash:        common.HexToHash("0xaaa").Hex(),
+					Timestamp:         1700000000,
+					Miner:             common.HexToAddress("0xc0ffee").Hex(),
+					BaseFee:           &baseFee,
+					GasUsed:           21000,
+					GasLimit:          30000000,
+					StateRoot:         common.HexToHash("0xbbb").Hex(),
+					TransactionsRoot:  block.TxHash().Hex(),
+					ReceiptsRoot:      types.EmptyReceiptsHash.Hex(),
+					TransactionHashes: []string{block.Transactions()[0].Hash().Hex()},
+					Confirmations:     11,
+				}))
+			})
+		})
+
+		When("the block is fetched by number", func() {
+			JustBeforeEach(func() {
+				result, err = service.FetchBlockByNumber(ctx, 100)
+			})
+
+			It("should request the block by number", func() {
+				Expect(err).NotTo(HaveOccurred())
+				_, number := fakeClient.BlockByNumberArgsForCall(0)
+				Expect(number).To(Equal(big.NewInt(100)))
+				Expect(result.Hash).To(Equal(block.Hash().Hex()))
+			})
+
+			When("the node does not know the block", func() {
+				BeforeEach(func() {
+					fakeClient.BlockByNumberReturns(nil, goethereum.NotFound)
+				})
+
+				It("should return a block not found error", func() {
+					Expect(err).To(MatchError(ethereum.ErrBlockNotFound))
+				})
+			})
+
+			When("getting the block fails", func() {
+				BeforeEach(func() {
+					fakeClient.BlockByNumberReturns(nil, testErr)
+				})
+
+				It("should return the error", func() {
+					Expect(err).To(MatchError(testErr))
+					Expect(err).NotTo(MatchError(ethereum.ErrBlockNotFound))
+				})
+			})
+		})
+	})
+
+	Describe("FetchTransactions with receipt verification", func() {
+		var (
+			results  []*ethereum.TxResult
+			err      error
+			signedTx *types.Transaction
+			block    *types.Block
+			receipts []*types.Receipt
+			served   *types.Receipt
+			otherTx  *types.Transaction
+			node     *batchServer
+			hashes   []string
+		)
+
+		BeforeEach(func() {
+			service = ethereum.NewEthService(fakeClient, ethereum.FamilyEthereum, true, 100, 4)
+
+			privateKey, err := crypto.GenerateKey()
+			Expect(err).NotTo(HaveOccurred())
+
+			chainID := big.NewInt(5)
+			signer := types.LatestSignerForChainID(chainID)
+
+			otherTx, _ = types.SignTx(types.NewTransaction(0, common.Address{}, big.NewInt(0), 21000, big.NewInt(1), nil), signer, privateKey)
+			signedTx, _ = types.SignTx(types.NewTransaction(1, common.Address{}, big.NewInt(1), 21000, big.NewInt(1), nil), signer, privateKey)
+
+			receipts = []*types.Receipt{
+				{Type: types.LegacyTxType, Status: 1, CumulativeGasUsed: 21000, Logs: []*types.Log{}, TxHash: otherTx.Hash()},
+				{Type: types.LegacyTxType, Status: 1, CumulativeGasUsed: 42000, Logs: []*types.Log{}, TxHash: signedTx.Hash()},
+			}
+			for _, r := range receipts {
+				r.Bloom = types.CreateBloom(r)
+			}
+
+			block = types.NewBlock(
+				&types.Header{Number: big.NewInt(100)},
+				&types.Body{Transactions: []*types.Transaction{otherTx, signedTx}},
+				receipts,
+				trie.NewStackTrie(nil))
+
+			served = &types.Receipt{
+				Type:              types.LegacyTxType,
+				Status:            1,
+				CumulativeGasUsed: 42000,
+				Bloom:             receipts[1].Bloom,
+				Logs:              []*types.Log{},
+				BlockHash:         block.Hash(),
+				BlockNumber:       big.NewInt(100),
+				TransactionIndex:  1,
+			}
+
+			fakeClient.ChainIDReturns(chainID, nil)
+			fakeClient.BlockByHashReturns(block, nil)
+			fakeClient.BlockReceiptsReturns(receipts, nil)
+
+			node = newBatchServer().add(signedTx, served)
+			hashes = []string{signedTx.Hash().Hex()}
+		})
+
+		JustBeforeEach(func() {
+			fakeClient.BatchCallContextStub = node.serve
+			results, err = service.FetchTransactions(ctx, hashes)
+		})
+
+		When("the receipt matches the block receipts root", func() {
+			It("should return a verified transaction", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(results).To(HaveLen(1))
+				Expect(results[0].Error).NotTo(HaveOccurred())
+				Expect(results[0].Transaction.Verified).To(BeTrue())
+
+				Expect(fakeClient.BlockByHashCallCount()).To(Equal(1))
+				_, argHash := fakeClient.BlockByHashArgsForCall(0)
+				Expect(argHash).To(Equal(block.Hash()))
+				Expect(fakeClient.BlockReceiptsCallCount()).To(Equal(1))
+			})
+		})
+
+		When("several transactions of the same block are fetched", func() {
+			BeforeEach(func() {
+				node.add(otherTx, &types.Receipt{
+					Type:              types.LegacyTxType,
+					Status:            1,
+					CumulativeGasUsed: 21000,
+					Bloom:             receipts[0].Bloom,
+					Logs:              []*types.Log{},
+					BlockHash:         block.Hash(),
+					BlockNumber:       big.NewInt(100),
+					TransactionIndex:  0,
+				})
+				hashes = append(hashes, otherTx.Hash().Hex())
+			})
+
+			It("should prove the block once", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(results).To(HaveLen(2))
+				for _, result := range results {
+					Expect(result.Error).NotTo(HaveOccurred())
+					Expect(result.Transaction.Verified).To(BeTrue())
+				}
+
+				Expect(fakeClient.BlockByHashCallCount()).To(Equal(1))
+				Expect(fakeClient.BlockReceiptsCallCount()).To(Equal(1))
+			})
+		})
+
+		When("the node serves a tampered receipt", func() {
+			BeforeEach(func() {
+				served.Status = 0
+			})
+
+			It("should reject the transaction", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(results[0].Error).To(MatchError(ethereum.ErrVerificationFailed))
+				Expect(results[0].Transaction).To(BeNil())
+			})
+		})
+
+		When("the node serves non-consensus receipt fields that differ from the proven block", func() {
+			BeforeEach(func() {
+				served.GasUsed = 1
+				served.ContractAddress = common.HexToAddress("0x1")
+			})
+
+			It("should take them from the proven block", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(results[0].Error).NotTo(HaveOccurred())
+				Expect(results[0].Transaction.GasUsed).To(Equal(uint64(21000)))
+				Expect(results[0].Transaction.ContractAddress).To(BeNil())
+			})
+		})
+
+		When("the node serves another transaction of the block under the requested hash", func() {
+			BeforeEach(func() {
+				requested := common.HexToHash("0x1234")
+				node.alias(requested, signedTx.Hash())
+				hashes = []string{requested.Hex()}
+			})
+
+			It("should reject the transaction", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(results[0].Error).To(MatchError(ethereum.ErrVerificationFailed))
+				Expect(results[0].Transaction).To(BeNil())
+			})
+		})
+
+		When("the receipt claims another block number than the proven block", func() {
+			BeforeEach(func() {
+				served.BlockNumber = big.NewInt(99)
+			})
+
+			It("should reject the transaction", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(results[0].Error).To(MatchError(ethereum.ErrVerificationFailed))
+				Expect(results[0].Error.Error()).To(ContainSubstring("block number"))
+			})
+		})
+
+		When("the block receipts do not match the receipts root", func() {
+			BeforeEach(func() {
+				receipts[0].CumulativeGasUsed = 1
+			})
+
+			It("should reject the transaction", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(results[0].Error).To(MatchError(ethereum.ErrVerificationFailed))
+				Expect(results[0].Error.Error()).To(ContainSubstring("receipts root"))
+			})
+		})
+
+		When("fetching the block fails", func() {
+			BeforeEach(func() {
+				fakeClient.BlockByHashReturns(nil, testErr)
+			})
+
+			It("should return the error", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(results[0].Error).To(MatchError(testErr))
+			})
+		})
+	})
 })
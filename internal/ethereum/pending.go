@@ -0,0 +1,70 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// PendingTransaction is a transaction that the node holds in its mempool and that is not included in a block yet. It only has
+// the fields of the signed transaction, as there is no receipt to take the others from.
+type PendingTransaction struct {
+	TransactionHash string
+	From            string
+	To              *string
+	Input           string
+	Value           string
+	Type            uint8
+	Nonce           uint64
+	GasLimit        uint64
+
+	// GasPrice is only set for legacy and access list transactions, MaxFeePerGas and MaxPriorityFeePerGas for the later types.
+	GasPrice             *string
+	MaxFeePerGas         *string
+	MaxPriorityFeePerGas *string
+}
+
+// FetchNonce returns the number of transactions sent by the address that are included in the latest block, which is the nonce
+// of the next transaction the address can get mined.
+func (s *EthService) FetchNonce(ctx context.Context, address string) (uint64, error) {
+	var nonce hexutil.Uint64
+	if err := s.client.CallContext(ctx, &nonce, "eth_getTransactionCount", common.HexToAddress(address), "latest"); err != nil {
+		return 0, fmt.Errorf("get transaction count: %w", err)
+	}
+	return uint64(nonce), nil
+}
+
+// buildPendingTransaction builds the pending transaction from the signed transaction alone. It returns nil when the sender
+// cannot be recovered from the signature.
+func buildPendingTransaction(tx *types.Transaction, signer types.Signer) *PendingTransaction {
+	from, err := types.Sender(signer, tx)
+	if err != nil {
+		return nil
+	}
+
+	pending := &PendingTransaction{
+		TransactionHash: tx.Hash().Hex(),
+		From:            from.Hex(),
+		Input:           fmt.Sprintf("0x%x", tx.Data()),
+		Value:           tx.Value().String(),
+		Type:            tx.Type(),
+		Nonce:           tx.Nonce(),
+		GasLimit:        tx.Gas(),
+	}
+	if tx.To() != nil {
+		pending.To = toPtr(tx.To().Hex())
+	}
+
+	switch tx.Type() {
+	case types.LegacyTxType, types.AccessListTxType:
+		pending.GasPrice = toPtr(tx.GasPrice().String())
+	default:
+		pending.MaxFeePerGas = toPtr(tx.GasFeeCap().String())
+		pending.MaxPriorityFeePerGas = toPtr(tx.GasTipCap().String())
+	}
+
+	return pending
+}
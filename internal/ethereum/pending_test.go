@@ -0,0 +1,59 @@
+package ethereum_test
+
+import (
+	"context"
+	"errors"
+	"fethcher/internal/ethereum"
+	"fethcher/internal/ethereum/fake"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FetchNonce", func() {
+	var (
+		service    *ethereum.EthService
+		fakeClient *fake.EthClient
+		ctx        context.Context
+		address    string
+		nonce      uint64
+		err        error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeClient = new(fake.EthClient)
+		service = ethereum.NewEthService(fakeClient, ethereum.FamilyEthereum, false, 100, 4)
+
+		address = "0xFE3B557E8Fb62b89F4916B721be55cEb828dBd73"
+		fakeClient.CallContextStub = func(_ context.Context, result any, _ string, _ ...any) error {
+			*result.(*hexutil.Uint64) = 7
+			return nil
+		}
+	})
+
+	JustBeforeEach(func() {
+		nonce, err = service.FetchNonce(ctx, address)
+	})
+
+	It("should return the transaction count of the address at the latest block", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nonce).To(Equal(uint64(7)))
+		_, _, method, args := fakeClient.CallContextArgsForCall(0)
+		Expect(method).To(Equal("eth_getTransactionCount"))
+		Expect(args).To(Equal([]any{common.HexToAddress(address), "latest"}))
+	})
+
+	When("the node fails", func() {
+		BeforeEach(func() {
+			fakeClient.CallContextStub = nil
+			fakeClient.CallContextReturns(errors.New("node down"))
+		})
+
+		It("should return an error", func() {
+			Expect(err).To(MatchError(ContainSubstring("node down")))
+		})
+	})
+})
@@ -0,0 +1,102 @@
+package ethereum
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// rpcExecutionError is the JSON-RPC error code of a call that reverted with revert data.
+const rpcExecutionError = 3
+
+// executionErrors are the errors of the EVM that a node reports when a call fails while executing, as opposed to the node
+// failing to run it.
+var executionErrors = []string{
+	"execution reverted",
+	"out of gas",
+	"invalid opcode",
+	"stack underflow",
+	"stack overflow",
+	"invalid jump destination",
+	"write protection",
+	"return data out of bounds",
+	"max code size exceeded",
+	"contract address collision",
+	"insufficient balance for transfer",
+	"max call depth exceeded",
+}
+
+// Revert is the outcome of replaying a failed transaction. Reproduced is false when the replayed call succeeded, which happens
+// when the transaction failed because of a transaction before it in its block. Otherwise Message is the error the node reported
+// and Data the revert data of the call, "0x" when it failed without any, e.g. by running out of gas.
+type Revert struct {
+	Reproduced bool
+	Message    string
+	Data       string
+}
+
+// ReplayTransaction replays the transaction with eth_call, with its sender, recipient, gas limit, value and input, on the state
+// of its parent block, to find out why it failed. The fee fields are left out, as the base fee of the parent block may exceed
+// them.
+func (s *EthService) ReplayTransaction(ctx context.Context, tx *Transaction) (*Revert, error) {
+	if tx.BlockNumber == 0 {
+		return nil, errors.New("replay transaction: the genesis block has no parent")
+	}
+
+	call := map[string]any{
+		"from": tx.From,
+		"gas":  hexutil.Uint64(tx.GasLimit),
+		"data": tx.Input,
+	}
+	if tx.To != nil {
+		call["to"] = *tx.To
+	}
+	if value, ok := new(big.Int).SetString(tx.Value, 10); ok {
+		call["value"] = (*hexutil.Big)(value)
+	}
+
+	var result hexutil.Bytes
+	err := s.client.CallContext(ctx, &result, "eth_call", call, hexutil.EncodeUint64(tx.BlockNumber-1))
+	if err == nil {
+		return &Revert{Data: "0x"}, nil
+	}
+	if !isExecutionError(err) {
+		return nil, fmt.Errorf("replay transaction: %w", err)
+	}
+
+	revert := &Revert{Reproduced: true, Message: err.Error(), Data: "0x"}
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if data, ok := dataErr.ErrorData().(string); ok {
+			if _, err := hexutil.Decode(data); err == nil {
+				revert.Data = data
+			}
+		}
+	}
+	return revert, nil
+}
+
+// isExecutionError reports whether err means that a call failed while executing, which is a legitimate answer of the node.
+func isExecutionError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == rpcExecutionError {
+		return true
+	}
+
+	msg := strings.ToLower(err.Error())
+	for _, executionError := range executionErrors {
+		if strings.Contains(msg, executionError) {
+			return true
+		}
+	}
+	return false
+}
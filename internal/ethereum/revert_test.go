@@ -0,0 +1,125 @@
+package ethereum_test
+
+import (
+	"context"
+	"errors"
+	"fethcher/internal/ethereum"
+	"fethcher/internal/ethereum/fake"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ReplayTransaction", func() {
+	var (
+		service    *ethereum.EthService
+		fakeClient *fake.EthClient
+		ctx        context.Context
+		tx         *ethereum.Transaction
+		revert     *ethereum.Revert
+		err        error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeClient = new(fake.EthClient)
+		service = ethereum.NewEthService(fakeClient, ethereum.FamilyEthereum, false, 100, 4)
+
+		to := "0x7a250d5630B4cF539739dF2C5dAcb4c659F2488D"
+		tx = &ethereum.Transaction{
+			BlockNumber: 100,
+			From:        "0xFE3B557E8Fb62b89F4916B721be55cEb828dBd73",
+			To:          &to,
+			GasLimit:    21000,
+			Value:       "1000",
+			Input:       "0x7ff36ab5",
+		}
+		fakeClient.CallContextReturns(rpcError{
+			code:    3,
+			message: "execution reverted: UniswapV2Router: EXPIRED",
+			data:    "0x08c379a0",
+		})
+	})
+
+	JustBeforeEach(func() {
+		revert, err = service.ReplayTransaction(ctx, tx)
+	})
+
+	It("should call the transaction on the state of its parent block", func() {
+		Expect(err).NotTo(HaveOccurred())
+		_, _, method, args := fakeClient.CallContextArgsForCall(0)
+		Expect(method).To(Equal("eth_call"))
+		Expect(args).To(HaveLen(2))
+		Expect(args[0]).To(Equal(map[string]any{
+			"from":  tx.From,
+			"to":    *tx.To,
+			"gas":   hexutil.Uint64(21000),
+			"value": (*hexutil.Big)(hexutil.MustDecodeBig("0x3e8")),
+			"data":  "0x7ff36ab5",
+		}))
+		Expect(args[1]).To(Equal("0x63"))
+	})
+
+	It("should return the revert data and message", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(revert).To(Equal(&ethereum.Revert{
+			Reproduced: true,
+			Message:    "execution reverted: UniswapV2Router: EXPIRED",
+			Data:       "0x08c379a0",
+		}))
+	})
+
+	When("the call fails without revert data", func() {
+		BeforeEach(func() {
+			fakeClient.CallContextReturns(rpcError{code: -32000, message: "out of gas"})
+		})
+
+		It("should return the message only", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(revert).To(Equal(&ethereum.Revert{Reproduced: true, Message: "out of gas", Data: "0x"}))
+		})
+	})
+
+	When("the call succeeds", func() {
+		BeforeEach(func() {
+			fakeClient.CallContextReturns(nil)
+		})
+
+		It("should report that the failure was not reproduced", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(revert.Reproduced).To(BeFalse())
+		})
+	})
+
+	When("the node cannot run the call", func() {
+		BeforeEach(func() {
+			fakeClient.CallContextReturns(rpcError{code: -32000, message: "missing trie node"})
+		})
+
+		It("should return the error", func() {
+			Expect(err).To(MatchError(ContainSubstring("missing trie node")))
+		})
+	})
+
+	When("the transaction is in the genesis block", func() {
+		BeforeEach(func() {
+			tx.BlockNumber = 0
+		})
+
+		It("should return an error without calling the node", func() {
+			Expect(err).To(HaveOccurred())
+			Expect(fakeClient.CallContextCallCount()).To(BeZero())
+		})
+	})
+
+	When("the call is cancelled", func() {
+		BeforeEach(func() {
+			fakeClient.CallContextReturns(context.Canceled)
+		})
+
+		It("should return the error", func() {
+			Expect(errors.Is(err, context.Canceled)).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,135 @@
+package ethereum
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ErrTracingUnsupported is returned when none of the nodes serves the debug namespace that call traces are read from.
+var ErrTracingUnsupported = errors.New("call tracing is not supported by the node")
+
+// The JSON-RPC error codes of a method that the node does not serve: the standard "method not found" and the "method not
+// supported" of EIP-1474.
+const (
+	rpcMethodNotFound     = -32601
+	rpcMethodNotSupported = -32004
+)
+
+// CallFrame is a call made while executing a transaction, as reported by the callTracer. The frames of a transaction are listed
+// depth first, starting with the top-level call. TraceAddress is the path of call indexes that leads to the frame from the
+// top-level call, which has an empty one. Reverted is set when the frame or one of its callers failed, in which case its state
+// changes, value transfers included, were undone.
+type CallFrame struct {
+	TraceAddress []int
+	Type         string
+	From         string
+	To           string
+	Value        string
+	Gas          uint64
+	GasUsed      uint64
+	Input        string
+	Output       string
+	Error        string
+	Reverted     bool
+}
+
+// callFrame is a frame of the call tree returned by the callTracer.
+type callFrame struct {
+	Type    string          `json:"type"`
+	From    common.Address  `json:"from"`
+	To      *common.Address `json:"to"`
+	Value   *hexutil.Big    `json:"value"`
+	Gas     hexutil.Uint64  `json:"gas"`
+	GasUsed hexutil.Uint64  `json:"gasUsed"`
+	Input   hexutil.Bytes   `json:"input"`
+	Output  hexutil.Bytes   `json:"output"`
+	Error   string          `json:"error"`
+	Calls   []callFrame     `json:"calls"`
+}
+
+// FetchCallTrace traces the transaction with the given hash with the callTracer of debug_traceTransaction and returns its call
+// tree flattened into frames. It returns an error wrapping ErrTracingUnsupported when the nodes do not serve the debug
+// namespace and ErrTransactionNotFound when they do not know the transaction.
+func (s *EthService) FetchCallTrace(ctx context.Context, hash string) ([]CallFrame, error) {
+	var root *callFrame
+	err := s.client.CallContext(ctx, &root, "debug_traceTransaction", common.HexToHash(hash), map[string]any{"tracer": "callTracer"})
+	if err != nil {
+		if isMethodUnsupported(err) {
+			return nil, fmt.Errorf("%w: %w", ErrTracingUnsupported, err)
+		}
+		if strings.Contains(err.Error(), "not found") {
+			return nil, fmt.Errorf("%w: %w", ErrTransactionNotFound, err)
+		}
+		return nil, fmt.Errorf("trace transaction: %w", err)
+	}
+	if root == nil {
+		return nil, ErrTransactionNotFound
+	}
+
+	frames := make([]CallFrame, 0, 1)
+	return flattenCalls(frames, *root, []int{}, false), nil
+}
+
+// flattenCalls appends the frame and all of its subcalls to frames, depth first.
+func flattenCalls(frames []CallFrame, frame callFrame, traceAddress []int, reverted bool) []CallFrame {
+	reverted = reverted || frame.Error != ""
+
+	var to string
+	if frame.To != nil {
+		to = frame.To.Hex()
+	}
+
+	value := "0"
+	if frame.Value != nil {
+		value = frame.Value.ToInt().String()
+	}
+
+	frames = append(frames, CallFrame{
+		TraceAddress: traceAddress,
+		Type:         strings.ToUpper(frame.Type),
+		From:         frame.From.Hex(),
+		To:           to,
+		Value:        value,
+		Gas:          uint64(frame.Gas),
+		GasUsed:      uint64(frame.GasUsed),
+		Input:        hexutil.Encode(frame.Input),
+		Output:       hexutil.Encode(frame.Output),
+		Error:        frame.Error,
+		Reverted:     reverted,
+	})
+
+	for i, call := range frame.Calls {
+		callAddress := make([]int, len(traceAddress), len(traceAddress)+1)
+		copy(callAddress, traceAddress)
+		frames = flattenCalls(frames, call, append(callAddress, i), reverted)
+	}
+	return frames
+}
+
+// isMethodUnsupported reports whether err means that the node does not serve the method called, as opposed to the call failing.
+func isMethodUnsupported(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		switch rpcErr.ErrorCode() {
+		case rpcMethodNotFound, rpcMethodNotSupported:
+			return true
+		}
+	}
+
+	// hosted nodes that leave out the debug namespace do not agree on an error code, but they do on the wording
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "does not exist/is not available") ||
+		strings.Contains(msg, "method not found") ||
+		strings.Contains(msg, "method not supported") ||
+		strings.Contains(msg, "method not allowed")
+}
@@ -0,0 +1,115 @@
+package ethereum_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fethcher/internal/ethereum"
+	"fethcher/internal/ethereum/fake"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FetchCallTrace", func() {
+	var (
+		service    *ethereum.EthService
+		fakeClient *fake.EthClient
+		ctx        context.Context
+		hash       string
+		frames     []ethereum.CallFrame
+		err        error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		hash = "0xac8d0406d6e1a1680f1cc8ff2f7a8a51e42cd60d64b142ab5e2c2a5b8a00d6b7"
+		fakeClient = new(fake.EthClient)
+		fakeClient.CallContextStub = func(_ context.Context, result any, _ string, _ ...any) error {
+			recorded, err := os.ReadFile("testdata/trace_swap.json")
+			if err != nil {
+				return err
+			}
+			return json.Unmarshal(recorded, result)
+		}
+		service = ethereum.NewEthService(fakeClient, ethereum.FamilyEthereum, false, 100, 4)
+	})
+
+	JustBeforeEach(func() {
+		frames, err = service.FetchCallTrace(ctx, hash)
+	})
+
+	It("should trace the transaction with the callTracer", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeClient.CallContextCallCount()).To(Equal(1))
+		_, _, method, args := fakeClient.CallContextArgsForCall(0)
+		Expect(method).To(Equal("debug_traceTransaction"))
+		Expect(args).To(Equal([]any{common.HexToHash(hash), map[string]any{"tracer": "callTracer"}}))
+	})
+
+	It("should flatten the call tree depth first", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(frames).To(HaveLen(7))
+
+		addresses := make([][]int, 0, len(frames))
+		for _, frame := range frames {
+			addresses = append(addresses, frame.TraceAddress)
+		}
+		Expect(addresses).To(Equal([][]int{{}, {0}, {1}, {2}, {2, 0}, {3}, {4}}))
+
+		Expect(frames[0].Type).To(Equal("CALL"))
+		Expect(frames[0].From).To(Equal("0xFE3B557E8Fb62b89F4916B721be55cEb828dBd73"))
+		Expect(frames[0].Value).To(Equal("1000000000000000000"))
+		Expect(frames[0].GasUsed).To(Equal(uint64(0x2a3c1)))
+		Expect(frames[1].Type).To(Equal("STATICCALL"))
+		Expect(frames[1].Value).To(Equal("0"))
+		Expect(frames[1].Input).To(Equal("0x0902f1ac"))
+		Expect(frames[2].Value).To(Equal("900000000000000000"))
+		Expect(frames[6].Type).To(Equal("DELEGATECALL"))
+	})
+
+	It("should mark the failed calls and their subcalls as reverted", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(frames[3].Error).To(Equal("execution reverted"))
+		Expect(frames[3].Reverted).To(BeTrue())
+		Expect(frames[4].Error).To(BeEmpty())
+		Expect(frames[4].Reverted).To(BeTrue())
+		Expect(frames[5].Reverted).To(BeFalse())
+	})
+
+	When("the node does not serve the debug namespace", func() {
+		BeforeEach(func() {
+			fakeClient.CallContextStub = nil
+			fakeClient.CallContextReturns(rpcError{code: -32601, message: "the method debug_traceTransaction does not exist/is not available"})
+		})
+
+		It("should return a capability error", func() {
+			Expect(err).To(MatchError(ethereum.ErrTracingUnsupported))
+		})
+	})
+
+	When("the node does not know the transaction", func() {
+		BeforeEach(func() {
+			fakeClient.CallContextStub = nil
+			fakeClient.CallContextReturns(rpcError{code: -32000, message: "transaction " + hash + " not found"})
+		})
+
+		It("should return not found", func() {
+			Expect(err).To(MatchError(ethereum.ErrTransactionNotFound))
+		})
+	})
+
+	When("tracing fails", func() {
+		BeforeEach(func() {
+			fakeClient.CallContextStub = nil
+			fakeClient.CallContextReturns(errors.New("execution timeout"))
+		})
+
+		It("should return the error", func() {
+			Expect(err).To(HaveOccurred())
+			Expect(err).NotTo(MatchError(ethereum.ErrTracingUnsupported))
+		})
+	})
+})
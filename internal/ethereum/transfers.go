@@ -0,0 +1,137 @@
+package ethereum
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Token standards of the transfers extracted from receipt logs.
+const (
+	StandardERC20   = "erc20"
+	StandardERC721  = "erc721"
+	StandardERC1155 = "erc1155"
+)
+
+var (
+	transferTopic       = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	transferSingleTopic = crypto.Keccak256Hash([]byte("TransferSingle(address,address,address,uint256,uint256)"))
+	transferBatchTopic  = crypto.Keccak256Hash([]byte("TransferBatch(address,address,address,uint256[],uint256[])"))
+
+	uint256Type, _      = abi.NewType("uint256", "", nil)
+	uint256ArrayType, _ = abi.NewType("uint256[]", "", nil)
+
+	transferSingleData = abi.Arguments{{Type: uint256Type}, {Type: uint256Type}}
+	transferBatchData  = abi.Arguments{{Type: uint256ArrayType}, {Type: uint256ArrayType}}
+)
+
+// tokenTransfers extracts the ERC-20, ERC-721 and ERC-1155 token movements from the logs of a receipt. ERC-20 and ERC-721 share
+// the Transfer event and are told apart by whether the last argument is indexed. Logs that only resemble a standard event, e.g.
+// with a different number of indexed arguments, are skipped.
+func tokenTransfers(logs []*types.Log) []TokenTransfer {
+	transfers := make([]TokenTransfer, 0)
+	for _, l := range logs {
+		if len(l.Topics) == 0 {
+			continue
+		}
+
+		switch l.Topics[0] {
+		case transferTopic:
+			if transfer, ok := parseTransfer(l); ok {
+				transfers = append(transfers, transfer)
+			}
+		case transferSingleTopic:
+			if transfer, ok := parseTransferSingle(l); ok {
+				transfers = append(transfers, transfer)
+			}
+		case transferBatchTopic:
+			transfers = append(transfers, parseTransferBatch(l)...)
+		}
+	}
+	return transfers
+}
+
+func parseTransfer(l *types.Log) (TokenTransfer, bool) {
+	transfer := TokenTransfer{
+		Token:    l.Address.Hex(),
+		LogIndex: l.Index,
+	}
+
+	switch {
+	case len(l.Topics) == 3 && len(l.Data) == 32:
+		transfer.Standard = StandardERC20
+		transfer.Amount = new(big.Int).SetBytes(l.Data).String()
+	case len(l.Topics) == 4 && len(l.Data) == 0:
+		tokenID := l.Topics[3].Big().String()
+		transfer.Standard = StandardERC721
+		transfer.Amount = "1"
+		transfer.TokenID = &tokenID
+	default:
+		return TokenTransfer{}, false
+	}
+
+	transfer.From = topicAddress(l.Topics[1])
+	transfer.To = topicAddress(l.Topics[2])
+	return transfer, true
+}
+
+func parseTransferSingle(l *types.Log) (TokenTransfer, bool) {
+	if len(l.Topics) != 4 {
+		return TokenTransfer{}, false
+	}
+
+	values, err := transferSingleData.Unpack(l.Data)
+	if err != nil {
+		return TokenTransfer{}, false
+	}
+
+	tokenID := values[0].(*big.Int).String()
+	return TokenTransfer{
+		Token:    l.Address.Hex(),
+		From:     topicAddress(l.Topics[2]),
+		To:       topicAddress(l.Topics[3]),
+		Amount:   values[1].(*big.Int).String(),
+		TokenID:  &tokenID,
+		Standard: StandardERC1155,
+		LogIndex: l.Index,
+	}, true
+}
+
+func parseTransferBatch(l *types.Log) []TokenTransfer {
+	if len(l.Topics) != 4 {
+		return nil
+	}
+
+	values, err := transferBatchData.Unpack(l.Data)
+	if err != nil {
+		return nil
+	}
+
+	ids, amounts := values[0].([]*big.Int), values[1].([]*big.Int)
+	if len(ids) != len(amounts) {
+		return nil
+	}
+
+	transfers := make([]TokenTransfer, 0, len(ids))
+	for i := range ids {
+		tokenID := ids[i].String()
+		transfers = append(transfers, TokenTransfer{
+			Token:      l.Address.Hex(),
+			From:       topicAddress(l.Topics[2]),
+			To:         topicAddress(l.Topics[3]),
+			Amount:     amounts[i].String(),
+			TokenID:    &tokenID,
+			Standard:   StandardERC1155,
+			LogIndex:   l.Index,
+			BatchIndex: uint(i),
+		})
+	}
+	return transfers
+}
+
+func topicAddress(topic common.Hash) string {
+	return common.BytesToAddress(topic.Bytes()).Hex()
+}
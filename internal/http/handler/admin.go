@@ -0,0 +1,201 @@
+package handler
+
+import (
+	"errors"
+	"fethcher/internal/cache"
+	"fethcher/internal/ethereum"
+	"fethcher/internal/http/handler/middleware"
+	"fethcher/internal/indexer"
+	"fethcher/internal/queue"
+	"fmt"
+	"net/http"
+
+	"go.uber.org/zap"
+)
+
+var (
+	StartIndexer     = "POST /lime/admin/indexer/start"
+	PauseIndexer     = "POST /lime/admin/indexer/pause"
+	GetIndexerStatus = "GET /lime/admin/indexer/status"
+	GetWriteQueue    = "GET /lime/admin/queue"
+	GetCache         = "GET /lime/admin/cache"
+	GetNodes         = "GET /lime/admin/nodes"
+)
+
+type AdminHandler struct {
+	logs     *zap.SugaredLogger
+	indexers Indexers
+	nodes    NodePools
+	writes   WriteQueue
+	cache    TransactionCache
+}
+
+func NewAdminHandler(logger *zap.SugaredLogger, indexers Indexers, nodes NodePools, writes WriteQueue, cache TransactionCache) *AdminHandler {
+	return &AdminHandler{
+		logs:     logger,
+		indexers: indexers,
+		nodes:    nodes,
+		writes:   writes,
+		cache:    cache,
+	}
+}
+
+func (h *AdminHandler) HandleStartIndexer(w http.ResponseWriter, r *http.Request) {
+	requestId := ""
+	reqIdCtx := r.Context().Value(middleware.RequestIDKey)
+	if reqIdCtx != nil {
+		requestId = reqIdCtx.(string)
+	}
+
+	idx, ok := h.indexer(w, r, StartIndexer, requestId)
+	if !ok {
+		return
+	}
+
+	err := idx.Start(r.Context())
+	if err != nil {
+		httpCode := http.StatusInternalServerError
+		if errors.Is(err, indexer.ErrRunning) {
+			httpCode = http.StatusConflict
+		}
+		h.respond(w, Response{
+			Message: "Could not start indexer",
+			Error:   fmt.Errorf("start indexer: %w", err).Error(),
+		}, httpCode,
+			requestId)
+		h.logs.Errorw("failed to start indexer",
+			"error", err,
+			"handler", StartIndexer,
+			"request_id", requestId)
+		return
+	}
+
+	h.respond(w, map[string]indexer.Status{
+		"indexer": idx.Status(),
+	}, http.StatusOK, requestId)
+}
+
+func (h *AdminHandler) HandlePauseIndexer(w http.ResponseWriter, r *http.Request) {
+	requestId := ""
+	reqIdCtx := r.Context().Value(middleware.RequestIDKey)
+	if reqIdCtx != nil {
+		requestId = reqIdCtx.(string)
+	}
+
+	idx, ok := h.indexer(w, r, PauseIndexer, requestId)
+	if !ok {
+		return
+	}
+
+	err := idx.Pause()
+	if err != nil {
+		httpCode := http.StatusInternalServerError
+		if errors.Is(err, indexer.ErrNotRunning) {
+			httpCode = http.StatusConflict
+		}
+		h.respond(w, Response{
+			Message: "Could not pause indexer",
+			Error:   fmt.Errorf("pause indexer: %w", err).Error(),
+		}, httpCode,
+			requestId)
+		h.logs.Errorw("failed to pause indexer",
+			"error", err,
+			"handler", PauseIndexer,
+			"request_id", requestId)
+		return
+	}
+
+	h.respond(w, map[string]indexer.Status{
+		"indexer": idx.Status(),
+	}, http.StatusOK, requestId)
+}
+
+func (h *AdminHandler) HandleGetIndexerStatus(w http.ResponseWriter, r *http.Request) {
+	requestId := ""
+	reqIdCtx := r.Context().Value(middleware.RequestIDKey)
+	if reqIdCtx != nil {
+		requestId = reqIdCtx.(string)
+	}
+
+	idx, ok := h.indexer(w, r, GetIndexerStatus, requestId)
+	if !ok {
+		return
+	}
+
+	h.respond(w, map[string]indexer.Status{
+		"indexer": idx.Status(),
+	}, http.StatusOK, requestId)
+}
+
+func (h *AdminHandler) HandleGetWriteQueue(w http.ResponseWriter, r *http.Request) {
+	requestId := ""
+	reqIdCtx := r.Context().Value(middleware.RequestIDKey)
+	if reqIdCtx != nil {
+		requestId = reqIdCtx.(string)
+	}
+
+	h.respond(w, map[string]queue.Stats{
+		"queue": h.writes.Stats(),
+	}, http.StatusOK, requestId)
+}
+
+func (h *AdminHandler) HandleGetCache(w http.ResponseWriter, r *http.Request) {
+	requestId := ""
+	reqIdCtx := r.Context().Value(middleware.RequestIDKey)
+	if reqIdCtx != nil {
+		requestId = reqIdCtx.(string)
+	}
+
+	h.respond(w, map[string]cache.Stats{
+		"cache": h.cache.Stats(),
+	}, http.StatusOK, requestId)
+}
+
+func (h *AdminHandler) HandleGetNodes(w http.ResponseWriter, r *http.Request) {
+	requestId := ""
+	reqIdCtx := r.Context().Value(middleware.RequestIDKey)
+	if reqIdCtx != nil {
+		requestId = reqIdCtx.(string)
+	}
+
+	pool, err := h.nodes.Get(r.URL.Query().Get(chainParam))
+	if err != nil {
+		h.respond(w, Response{
+			Message: "Request failed",
+			Error:   fmt.Errorf("select chain: %w", err).Error(),
+		}, http.StatusBadRequest,
+			requestId)
+		h.logs.Errorw("failed to select chain",
+			"error", err,
+			"handler", GetNodes,
+			"request_id", requestId)
+		return
+	}
+
+	h.respond(w, map[string][]ethereum.NodeStatus{
+		"nodes": pool.Status(),
+	}, http.StatusOK, requestId)
+}
+
+// indexer returns the indexer of the chain selected by the chain query parameter. When the chain is not configured it responds
+// with 400 Bad Request and returns false.
+func (h *AdminHandler) indexer(w http.ResponseWriter, r *http.Request, handler string, requestId string) (Indexer, bool) {
+	idx, err := h.indexers.Get(r.URL.Query().Get(chainParam))
+	if err != nil {
+		h.respond(w, Response{
+			Message: "Request failed",
+			Error:   fmt.Errorf("select chain: %w", err).Error(),
+		}, http.StatusBadRequest,
+			requestId)
+		h.logs.Errorw("failed to select chain",
+			"error", err,
+			"handler", handler,
+			"request_id", requestId)
+		return nil, false
+	}
+	return idx, true
+}
+
+func (h *AdminHandler) respond(w http.ResponseWriter, resp any, code int, requestId string) {
+	respond(h.logs, w, resp, code, requestId)
+}
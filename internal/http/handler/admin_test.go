@@ -0,0 +1,183 @@
+package handler_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"fethcher/internal/cache"
+	"fethcher/internal/chain"
+	"fethcher/internal/ethereum"
+	"fethcher/internal/http/handler"
+	"fethcher/internal/http/handler/fake"
+	"fethcher/internal/indexer"
+	"fethcher/internal/queue"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+)
+
+var _ = Describe("AdminHandler", func() {
+	var (
+		adminHandler *handler.AdminHandler
+		fakeIndexers *fake.Indexers
+		fakeIndexer  *fake.Indexer
+		fakeWrites   *fake.WriteQueue
+		fakeCache    *fake.TransactionCache
+		fakeNodes    *fake.NodePools
+		fakePool     *fake.NodePool
+		w            *httptest.ResponseRecorder
+		req          *http.Request
+		fakeErr      error
+	)
+
+	BeforeEach(func() {
+		fakeErr = errors.New("fake-error")
+		fakeIndexer = new(fake.Indexer)
+		fakeIndexer.StatusReturns(indexer.Status{State: indexer.StateRunning, NextBlock: 100})
+		fakeIndexers = new(fake.Indexers)
+		fakeIndexers.GetReturns(fakeIndexer, nil)
+		fakeWrites = new(fake.WriteQueue)
+		fakeWrites.StatsReturns(queue.Stats{Depth: 3, Capacity: 10, Failed: 1})
+
+		w = httptest.NewRecorder()
+		fakeCache = new(fake.TransactionCache)
+		fakeCache.StatsReturns(cache.Stats{Entries: 2, Hits: 5, Misses: 1})
+		fakePool = new(fake.NodePool)
+		fakePool.StatusReturns([]ethereum.NodeStatus{
+			{Name: "node-0(primary)", Healthy: true},
+			{Name: "node-1(secondary)", ConsecutiveFailures: 3, LastError: "connection refused"},
+		})
+		fakeNodes = new(fake.NodePools)
+		fakeNodes.GetReturns(fakePool, nil)
+		adminHandler = handler.NewAdminHandler(zap.NewNop().Sugar(), fakeIndexers, fakeNodes, fakeWrites, fakeCache)
+	})
+
+	Describe("HandleStartIndexer", func() {
+		BeforeEach(func() {
+			req = httptest.NewRequest(http.MethodPost, "/lime/admin/indexer/start", nil)
+		})
+
+		JustBeforeEach(func() {
+			adminHandler.HandleStartIndexer(w, req)
+		})
+
+		When("the indexer starts", func() {
+			It("should return 200 OK and the status", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(ContainSubstring(`"state":"running"`))
+				Expect(fakeIndexer.StartCallCount()).To(Equal(1))
+			})
+		})
+
+		When("the indexer is already running", func() {
+			BeforeEach(func() {
+				fakeIndexer.StartReturns(indexer.ErrRunning)
+			})
+
+			It("should return 409 Conflict", func() {
+				Expect(w.Code).To(Equal(http.StatusConflict))
+			})
+		})
+
+		When("the indexer fails to start", func() {
+			BeforeEach(func() {
+				fakeIndexer.StartReturns(fakeErr)
+			})
+
+			It("should return 500 Internal Server Error", func() {
+				Expect(w.Code).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+
+	Describe("HandlePauseIndexer", func() {
+		BeforeEach(func() {
+			req = httptest.NewRequest(http.MethodPost, "/lime/admin/indexer/pause", nil)
+		})
+
+		JustBeforeEach(func() {
+			adminHandler.HandlePauseIndexer(w, req)
+		})
+
+		When("the indexer pauses", func() {
+			It("should return 200 OK", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(fakeIndexer.PauseCallCount()).To(Equal(1))
+			})
+		})
+
+		When("the indexer is not running", func() {
+			BeforeEach(func() {
+				fakeIndexer.PauseReturns(indexer.ErrNotRunning)
+			})
+
+			It("should return 409 Conflict", func() {
+				Expect(w.Code).To(Equal(http.StatusConflict))
+			})
+		})
+	})
+
+	Describe("HandleGetIndexerStatus", func() {
+		It("should return 200 OK and the status", func() {
+			req = httptest.NewRequest(http.MethodGet, "/lime/admin/indexer/status", nil)
+			adminHandler.HandleGetIndexerStatus(w, req)
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Body.String()).To(ContainSubstring(`"nextBlock":100`))
+		})
+
+		It("should return the status of the selected chain", func() {
+			req = httptest.NewRequest(http.MethodGet, "/lime/admin/indexer/status?chain=8453", nil)
+			adminHandler.HandleGetIndexerStatus(w, req)
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(fakeIndexers.GetArgsForCall(0)).To(Equal("8453"))
+		})
+
+		It("should return 400 Bad Request for a chain that is not configured", func() {
+			fakeIndexers.GetReturns(nil, chain.ErrUnknownChain)
+			req = httptest.NewRequest(http.MethodGet, "/lime/admin/indexer/status?chain=polygon", nil)
+			adminHandler.HandleGetIndexerStatus(w, req)
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+			Expect(fakeIndexer.StatusCallCount()).To(Equal(0))
+		})
+	})
+
+	Describe("HandleGetWriteQueue", func() {
+		It("should return 200 OK and the queue stats", func() {
+			req = httptest.NewRequest(http.MethodGet, "/lime/admin/queue", nil)
+			adminHandler.HandleGetWriteQueue(w, req)
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Body.String()).To(ContainSubstring(`"depth":3`))
+			Expect(w.Body.String()).To(ContainSubstring(`"failed":1`))
+		})
+	})
+
+	Describe("HandleGetCache", func() {
+		It("should return 200 OK and the cache stats", func() {
+			req = httptest.NewRequest(http.MethodGet, "/lime/admin/cache", nil)
+			adminHandler.HandleGetCache(w, req)
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Body.String()).To(ContainSubstring(`"entries":2`))
+			Expect(w.Body.String()).To(ContainSubstring(`"hits":5`))
+		})
+	})
+
+	Describe("HandleGetNodes", func() {
+		It("should return 200 OK and the health of the nodes of the selected chain", func() {
+			req = httptest.NewRequest(http.MethodGet, "/lime/admin/nodes?chain=8453", nil)
+			adminHandler.HandleGetNodes(w, req)
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(fakeNodes.GetArgsForCall(0)).To(Equal("8453"))
+			Expect(w.Body.String()).To(ContainSubstring(`"name":"node-0(primary)","healthy":true`))
+			Expect(w.Body.String()).To(ContainSubstring(`"consecutiveFailures":3,"lastError":"connection refused"`))
+		})
+
+		It("should return 400 Bad Request for a chain that is not configured", func() {
+			fakeNodes.GetReturns(nil, chain.ErrUnknownChain)
+			req = httptest.NewRequest(http.MethodGet, "/lime/admin/nodes?chain=polygon", nil)
+			adminHandler.HandleGetNodes(w, req)
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+})
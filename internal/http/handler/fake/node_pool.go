@@ -0,0 +1,102 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"fethcher/internal/ethereum"
+	"fethcher/internal/http/handler"
+	"sync"
+)
+
+type NodePool struct {
+	StatusStub        func() []ethereum.NodeStatus
+	statusMutex       sync.RWMutex
+	statusArgsForCall []struct {
+	}
+	statusReturns struct {
+		result1 []ethereum.NodeStatus
+	}
+	statusReturnsOnCall map[int]struct {
+		result1 []ethereum.NodeStatus
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *NodePool) Status() []ethereum.NodeStatus {
+	fake.statusMutex.Lock()
+	ret, specificReturn := fake.statusReturnsOnCall[len(fake.statusArgsForCall)]
+	fake.statusArgsForCall = append(fake.statusArgsForCall, struct {
+	}{})
+	stub := fake.StatusStub
+	fakeReturns := fake.statusReturns
+	fake.recordInvocation("Status", []interface{}{})
+	fake.statusMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *NodePool) StatusCallCount() int {
+	fake.statusMutex.RLock()
+	defer fake.statusMutex.RUnlock()
+	return len(fake.statusArgsForCall)
+}
+
+func (fake *NodePool) StatusCalls(stub func() []ethereum.NodeStatus) {
+	fake.statusMutex.Lock()
+	defer fake.statusMutex.Unlock()
+	fake.StatusStub = stub
+}
+
+func (fake *NodePool) StatusReturns(result1 []ethereum.NodeStatus) {
+	fake.statusMutex.Lock()
+	defer fake.statusMutex.Unlock()
+	fake.StatusStub = nil
+	fake.statusReturns = struct {
+		result1 []ethereum.NodeStatus
+	}{result1}
+}
+
+func (fake *NodePool) StatusReturnsOnCall(i int, result1 []ethereum.NodeStatus) {
+	fake.statusMutex.Lock()
+	defer fake.statusMutex.Unlock()
+	fake.StatusStub = nil
+	if fake.statusReturnsOnCall == nil {
+		fake.statusReturnsOnCall = make(map[int]struct {
+			result1 []ethereum.NodeStatus
+		})
+	}
+	fake.statusReturnsOnCall[i] = struct {
+		result1 []ethereum.NodeStatus
+	}{result1}
+}
+
+func (fake *NodePool) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.statusMutex.RLock()
+	defer fake.statusMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *NodePool) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handler.NodePool = new(NodePool)
@@ -0,0 +1,102 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"fethcher/internal/cache"
+	"fethcher/internal/http/handler"
+	"sync"
+)
+
+type TransactionCache struct {
+	StatsStub        func() cache.Stats
+	statsMutex       sync.RWMutex
+	statsArgsForCall []struct {
+	}
+	statsReturns struct {
+		result1 cache.Stats
+	}
+	statsReturnsOnCall map[int]struct {
+		result1 cache.Stats
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *TransactionCache) Stats() cache.Stats {
+	fake.statsMutex.Lock()
+	ret, specificReturn := fake.statsReturnsOnCall[len(fake.statsArgsForCall)]
+	fake.statsArgsForCall = append(fake.statsArgsForCall, struct {
+	}{})
+	stub := fake.StatsStub
+	fakeReturns := fake.statsReturns
+	fake.recordInvocation("Stats", []interface{}{})
+	fake.statsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *TransactionCache) StatsCallCount() int {
+	fake.statsMutex.RLock()
+	defer fake.statsMutex.RUnlock()
+	return len(fake.statsArgsForCall)
+}
+
+func (fake *TransactionCache) StatsCalls(stub func() cache.Stats) {
+	fake.statsMutex.Lock()
+	defer fake.statsMutex.Unlock()
+	fake.StatsStub = stub
+}
+
+func (fake *TransactionCache) StatsReturns(result1 cache.Stats) {
+	fake.statsMutex.Lock()
+	defer fake.statsMutex.Unlock()
+	fake.StatsStub = nil
+	fake.statsReturns = struct {
+		result1 cache.Stats
+	}{result1}
+}
+
+func (fake *TransactionCache) StatsReturnsOnCall(i int, result1 cache.Stats) {
+	fake.statsMutex.Lock()
+	defer fake.statsMutex.Unlock()
+	fake.StatsStub = nil
+	if fake.statsReturnsOnCall == nil {
+		fake.statsReturnsOnCall = make(map[int]struct {
+			result1 cache.Stats
+		})
+	}
+	fake.statsReturnsOnCall[i] = struct {
+		result1 cache.Stats
+	}{result1}
+}
+
+func (fake *TransactionCache) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.statsMutex.RLock()
+	defer fake.statsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *TransactionCache) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handler.TransactionCache = new(TransactionCache)
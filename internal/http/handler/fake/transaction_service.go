@@ -23,45 +23,145 @@ type TransactionService struct {
 		result1 string
 		result2 error
 	}
-	GetAllDBTransactionsStub        func(context.Context) ([]core.TransactionRecord, error)
+	ClearUserTransactionsHistoryStub        func(context.Context, string) (int64, error)
+	clearUserTransactionsHistoryMutex       sync.RWMutex
+	clearUserTransactionsHistoryArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	clearUserTransactionsHistoryReturns struct {
+		result1 int64
+		result2 error
+	}
+	clearUserTransactionsHistoryReturnsOnCall map[int]struct {
+		result1 int64
+		result2 error
+	}
+	DeleteUserHistoryEntryStub        func(context.Context, string, string) error
+	deleteUserHistoryEntryMutex       sync.RWMutex
+	deleteUserHistoryEntryArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	deleteUserHistoryEntryReturns struct {
+		result1 error
+	}
+	deleteUserHistoryEntryReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetAddressTransactionsStub        func(context.Context, core.AddressFilter) (core.TransactionPage, error)
+	getAddressTransactionsMutex       sync.RWMutex
+	getAddressTransactionsArgsForCall []struct {
+		arg1 context.Context
+		arg2 core.AddressFilter
+	}
+	getAddressTransactionsReturns struct {
+		result1 core.TransactionPage
+		result2 error
+	}
+	getAddressTransactionsReturnsOnCall map[int]struct {
+		result1 core.TransactionPage
+		result2 error
+	}
+	GetAllDBTransactionsStub        func(context.Context, core.TransactionFilter) (core.TransactionPage, error)
 	getAllDBTransactionsMutex       sync.RWMutex
 	getAllDBTransactionsArgsForCall []struct {
 		arg1 context.Context
+		arg2 core.TransactionFilter
 	}
 	getAllDBTransactionsReturns struct {
-		result1 []core.TransactionRecord
+		result1 core.TransactionPage
 		result2 error
 	}
 	getAllDBTransactionsReturnsOnCall map[int]struct {
-		result1 []core.TransactionRecord
+		result1 core.TransactionPage
+		result2 error
+	}
+	GetBlockStub        func(context.Context, string) (core.BlockRecord, error)
+	getBlockMutex       sync.RWMutex
+	getBlockArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getBlockReturns struct {
+		result1 core.BlockRecord
+		result2 error
+	}
+	getBlockReturnsOnCall map[int]struct {
+		result1 core.BlockRecord
+		result2 error
+	}
+	GetCallTraceStub        func(context.Context, string) (core.TraceRecord, error)
+	getCallTraceMutex       sync.RWMutex
+	getCallTraceArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getCallTraceReturns struct {
+		result1 core.TraceRecord
+		result2 error
+	}
+	getCallTraceReturnsOnCall map[int]struct {
+		result1 core.TraceRecord
+		result2 error
+	}
+	GetLogsStub        func(context.Context, core.LogFilter) ([]core.LogRecord, error)
+	getLogsMutex       sync.RWMutex
+	getLogsArgsForCall []struct {
+		arg1 context.Context
+		arg2 core.LogFilter
+	}
+	getLogsReturns struct {
+		result1 []core.LogRecord
 		result2 error
 	}
-	GetTransactionsStub        func(context.Context, []string) ([]core.TransactionRecord, error)
+	getLogsReturnsOnCall map[int]struct {
+		result1 []core.LogRecord
+		result2 error
+	}
+	GetTokenTransfersStub        func(context.Context, core.TransferFilter) ([]core.TransferRecord, error)
+	getTokenTransfersMutex       sync.RWMutex
+	getTokenTransfersArgsForCall []struct {
+		arg1 context.Context
+		arg2 core.TransferFilter
+	}
+	getTokenTransfersReturns struct {
+		result1 []core.TransferRecord
+		result2 error
+	}
+	getTokenTransfersReturnsOnCall map[int]struct {
+		result1 []core.TransferRecord
+		result2 error
+	}
+	GetTransactionsStub        func(context.Context, []string, core.IncludeOptions) ([]core.TransactionResult, error)
 	getTransactionsMutex       sync.RWMutex
 	getTransactionsArgsForCall []struct {
 		arg1 context.Context
 		arg2 []string
+		arg3 core.IncludeOptions
 	}
 	getTransactionsReturns struct {
-		result1 []core.TransactionRecord
+		result1 []core.TransactionResult
 		result2 error
 	}
 	getTransactionsReturnsOnCall map[int]struct {
-		result1 []core.TransactionRecord
+		result1 []core.TransactionResult
 		result2 error
 	}
-	GetUserTransactionsHistoryStub        func(context.Context, string) ([]core.TransactionRecord, error)
+	GetUserTransactionsHistoryStub        func(context.Context, string, core.HistoryFilter) (core.HistoryPage, error)
 	getUserTransactionsHistoryMutex       sync.RWMutex
 	getUserTransactionsHistoryArgsForCall []struct {
 		arg1 context.Context
 		arg2 string
+		arg3 core.HistoryFilter
 	}
 	getUserTransactionsHistoryReturns struct {
-		result1 []core.TransactionRecord
+		result1 core.HistoryPage
 		result2 error
 	}
 	getUserTransactionsHistoryReturnsOnCall map[int]struct {
-		result1 []core.TransactionRecord
+		result1 core.HistoryPage
 		result2 error
 	}
 	ParseRLPStub        func(string) ([]string, error)
@@ -77,6 +177,19 @@ type TransactionService struct {
 		result1 []string
 		result2 error
 	}
+	SaveContractABIStub        func(context.Context, string, string) error
+	saveContractABIMutex       sync.RWMutex
+	saveContractABIArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	saveContractABIReturns struct {
+		result1 error
+	}
+	saveContractABIReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SaveUserTransactionsHistoryStub        func(context.Context, string, []string) error
 	saveUserTransactionsHistoryMutex       sync.RWMutex
 	saveUserTransactionsHistoryArgsForCall []struct {
@@ -159,18 +272,212 @@ func (fake *TransactionService) AuthenticateReturnsOnCall(i int, result1 string,
 	}{result1, result2}
 }
 
-func (fake *TransactionService) GetAllDBTransactions(arg1 context.Context) ([]core.TransactionRecord, error) {
+func (fake *TransactionService) ClearUserTransactionsHistory(arg1 context.Context, arg2 string) (int64, error) {
+	fake.clearUserTransactionsHistoryMutex.Lock()
+	ret, specificReturn := fake.clearUserTransactionsHistoryReturnsOnCall[len(fake.clearUserTransactionsHistoryArgsForCall)]
+	fake.clearUserTransactionsHistoryArgsForCall = append(fake.clearUserTransactionsHistoryArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ClearUserTransactionsHistoryStub
+	fakeReturns := fake.clearUserTransactionsHistoryReturns
+	fake.recordInvocation("ClearUserTransactionsHistory", []interface{}{arg1, arg2})
+	fake.clearUserTransactionsHistoryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *TransactionService) ClearUserTransactionsHistoryCallCount() int {
+	fake.clearUserTransactionsHistoryMutex.RLock()
+	defer fake.clearUserTransactionsHistoryMutex.RUnlock()
+	return len(fake.clearUserTransactionsHistoryArgsForCall)
+}
+
+func (fake *TransactionService) ClearUserTransactionsHistoryCalls(stub func(context.Context, string) (int64, error)) {
+	fake.clearUserTransactionsHistoryMutex.Lock()
+	defer fake.clearUserTransactionsHistoryMutex.Unlock()
+	fake.ClearUserTransactionsHistoryStub = stub
+}
+
+func (fake *TransactionService) ClearUserTransactionsHistoryArgsForCall(i int) (context.Context, string) {
+	fake.clearUserTransactionsHistoryMutex.RLock()
+	defer fake.clearUserTransactionsHistoryMutex.RUnlock()
+	argsForCall := fake.clearUserTransactionsHistoryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *TransactionService) ClearUserTransactionsHistoryReturns(result1 int64, result2 error) {
+	fake.clearUserTransactionsHistoryMutex.Lock()
+	defer fake.clearUserTransactionsHistoryMutex.Unlock()
+	fake.ClearUserTransactionsHistoryStub = nil
+	fake.clearUserTransactionsHistoryReturns = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TransactionService) ClearUserTransactionsHistoryReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.clearUserTransactionsHistoryMutex.Lock()
+	defer fake.clearUserTransactionsHistoryMutex.Unlock()
+	fake.ClearUserTransactionsHistoryStub = nil
+	if fake.clearUserTransactionsHistoryReturnsOnCall == nil {
+		fake.clearUserTransactionsHistoryReturnsOnCall = make(map[int]struct {
+			result1 int64
+			result2 error
+		})
+	}
+	fake.clearUserTransactionsHistoryReturnsOnCall[i] = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TransactionService) DeleteUserHistoryEntry(arg1 context.Context, arg2 string, arg3 string) error {
+	fake.deleteUserHistoryEntryMutex.Lock()
+	ret, specificReturn := fake.deleteUserHistoryEntryReturnsOnCall[len(fake.deleteUserHistoryEntryArgsForCall)]
+	fake.deleteUserHistoryEntryArgsForCall = append(fake.deleteUserHistoryEntryArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.DeleteUserHistoryEntryStub
+	fakeReturns := fake.deleteUserHistoryEntryReturns
+	fake.recordInvocation("DeleteUserHistoryEntry", []interface{}{arg1, arg2, arg3})
+	fake.deleteUserHistoryEntryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *TransactionService) DeleteUserHistoryEntryCallCount() int {
+	fake.deleteUserHistoryEntryMutex.RLock()
+	defer fake.deleteUserHistoryEntryMutex.RUnlock()
+	return len(fake.deleteUserHistoryEntryArgsForCall)
+}
+
+func (fake *TransactionService) DeleteUserHistoryEntryCalls(stub func(context.Context, string, string) error) {
+	fake.deleteUserHistoryEntryMutex.Lock()
+	defer fake.deleteUserHistoryEntryMutex.Unlock()
+	fake.DeleteUserHistoryEntryStub = stub
+}
+
+func (fake *TransactionService) DeleteUserHistoryEntryArgsForCall(i int) (context.Context, string, string) {
+	fake.deleteUserHistoryEntryMutex.RLock()
+	defer fake.deleteUserHistoryEntryMutex.RUnlock()
+	argsForCall := fake.deleteUserHistoryEntryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *TransactionService) DeleteUserHistoryEntryReturns(result1 error) {
+	fake.deleteUserHistoryEntryMutex.Lock()
+	defer fake.deleteUserHistoryEntryMutex.Unlock()
+	fake.DeleteUserHistoryEntryStub = nil
+	fake.deleteUserHistoryEntryReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *TransactionService) DeleteUserHistoryEntryReturnsOnCall(i int, result1 error) {
+	fake.deleteUserHistoryEntryMutex.Lock()
+	defer fake.deleteUserHistoryEntryMutex.Unlock()
+	fake.DeleteUserHistoryEntryStub = nil
+	if fake.deleteUserHistoryEntryReturnsOnCall == nil {
+		fake.deleteUserHistoryEntryReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteUserHistoryEntryReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *TransactionService) GetAddressTransactions(arg1 context.Context, arg2 core.AddressFilter) (core.TransactionPage, error) {
+	fake.getAddressTransactionsMutex.Lock()
+	ret, specificReturn := fake.getAddressTransactionsReturnsOnCall[len(fake.getAddressTransactionsArgsForCall)]
+	fake.getAddressTransactionsArgsForCall = append(fake.getAddressTransactionsArgsForCall, struct {
+		arg1 context.Context
+		arg2 core.AddressFilter
+	}{arg1, arg2})
+	stub := fake.GetAddressTransactionsStub
+	fakeReturns := fake.getAddressTransactionsReturns
+	fake.recordInvocation("GetAddressTransactions", []interface{}{arg1, arg2})
+	fake.getAddressTransactionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *TransactionService) GetAddressTransactionsCallCount() int {
+	fake.getAddressTransactionsMutex.RLock()
+	defer fake.getAddressTransactionsMutex.RUnlock()
+	return len(fake.getAddressTransactionsArgsForCall)
+}
+
+func (fake *TransactionService) GetAddressTransactionsCalls(stub func(context.Context, core.AddressFilter) (core.TransactionPage, error)) {
+	fake.getAddressTransactionsMutex.Lock()
+	defer fake.getAddressTransactionsMutex.Unlock()
+	fake.GetAddressTransactionsStub = stub
+}
+
+func (fake *TransactionService) GetAddressTransactionsArgsForCall(i int) (context.Context, core.AddressFilter) {
+	fake.getAddressTransactionsMutex.RLock()
+	defer fake.getAddressTransactionsMutex.RUnlock()
+	argsForCall := fake.getAddressTransactionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *TransactionService) GetAddressTransactionsReturns(result1 core.TransactionPage, result2 error) {
+	fake.getAddressTransactionsMutex.Lock()
+	defer fake.getAddressTransactionsMutex.Unlock()
+	fake.GetAddressTransactionsStub = nil
+	fake.getAddressTransactionsReturns = struct {
+		result1 core.TransactionPage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TransactionService) GetAddressTransactionsReturnsOnCall(i int, result1 core.TransactionPage, result2 error) {
+	fake.getAddressTransactionsMutex.Lock()
+	defer fake.getAddressTransactionsMutex.Unlock()
+	fake.GetAddressTransactionsStub = nil
+	if fake.getAddressTransactionsReturnsOnCall == nil {
+		fake.getAddressTransactionsReturnsOnCall = make(map[int]struct {
+			result1 core.TransactionPage
+			result2 error
+		})
+	}
+	fake.getAddressTransactionsReturnsOnCall[i] = struct {
+		result1 core.TransactionPage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TransactionService) GetAllDBTransactions(arg1 context.Context, arg2 core.TransactionFilter) (core.TransactionPage, error) {
 	fake.getAllDBTransactionsMutex.Lock()
 	ret, specificReturn := fake.getAllDBTransactionsReturnsOnCall[len(fake.getAllDBTransactionsArgsForCall)]
 	fake.getAllDBTransactionsArgsForCall = append(fake.getAllDBTransactionsArgsForCall, struct {
 		arg1 context.Context
-	}{arg1})
+		arg2 core.TransactionFilter
+	}{arg1, arg2})
 	stub := fake.GetAllDBTransactionsStub
 	fakeReturns := fake.getAllDBTransactionsReturns
-	fake.recordInvocation("GetAllDBTransactions", []interface{}{arg1})
+	fake.recordInvocation("GetAllDBTransactions", []interface{}{arg1, arg2})
 	fake.getAllDBTransactionsMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -184,46 +491,306 @@ func (fake *TransactionService) GetAllDBTransactionsCallCount() int {
 	return len(fake.getAllDBTransactionsArgsForCall)
 }
 
-func (fake *TransactionService) GetAllDBTransactionsCalls(stub func(context.Context) ([]core.TransactionRecord, error)) {
+func (fake *TransactionService) GetAllDBTransactionsCalls(stub func(context.Context, core.TransactionFilter) (core.TransactionPage, error)) {
 	fake.getAllDBTransactionsMutex.Lock()
 	defer fake.getAllDBTransactionsMutex.Unlock()
 	fake.GetAllDBTransactionsStub = stub
 }
 
-func (fake *TransactionService) GetAllDBTransactionsArgsForCall(i int) context.Context {
+func (fake *TransactionService) GetAllDBTransactionsArgsForCall(i int) (context.Context, core.TransactionFilter) {
 	fake.getAllDBTransactionsMutex.RLock()
 	defer fake.getAllDBTransactionsMutex.RUnlock()
 	argsForCall := fake.getAllDBTransactionsArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *TransactionService) GetAllDBTransactionsReturns(result1 []core.TransactionRecord, result2 error) {
+func (fake *TransactionService) GetAllDBTransactionsReturns(result1 core.TransactionPage, result2 error) {
 	fake.getAllDBTransactionsMutex.Lock()
 	defer fake.getAllDBTransactionsMutex.Unlock()
 	fake.GetAllDBTransactionsStub = nil
 	fake.getAllDBTransactionsReturns = struct {
-		result1 []core.TransactionRecord
+		result1 core.TransactionPage
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *TransactionService) GetAllDBTransactionsReturnsOnCall(i int, result1 []core.TransactionRecord, result2 error) {
+func (fake *TransactionService) GetAllDBTransactionsReturnsOnCall(i int, result1 core.TransactionPage, result2 error) {
 	fake.getAllDBTransactionsMutex.Lock()
 	defer fake.getAllDBTransactionsMutex.Unlock()
 	fake.GetAllDBTransactionsStub = nil
 	if fake.getAllDBTransactionsReturnsOnCall == nil {
 		fake.getAllDBTransactionsReturnsOnCall = make(map[int]struct {
-			result1 []core.TransactionRecord
+			result1 core.TransactionPage
 			result2 error
 		})
 	}
 	fake.getAllDBTransactionsReturnsOnCall[i] = struct {
-		result1 []core.TransactionRecord
+		result1 core.TransactionPage
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *TransactionService) GetTransactions(arg1 context.Context, arg2 []string) ([]core.TransactionRecord, error) {
+func (fake *TransactionService) GetBlock(arg1 context.Context, arg2 string) (core.BlockRecord, error) {
+	fake.getBlockMutex.Lock()
+	ret, specificReturn := fake.getBlockReturnsOnCall[len(fake.getBlockArgsForCall)]
+	fake.getBlockArgsForCall = append(fake.getBlockArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetBlockStub
+	fakeReturns := fake.getBlockReturns
+	fake.recordInvocation("GetBlock", []interface{}{arg1, arg2})
+	fake.getBlockMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *TransactionService) GetBlockCallCount() int {
+	fake.getBlockMutex.RLock()
+	defer fake.getBlockMutex.RUnlock()
+	return len(fake.getBlockArgsForCall)
+}
+
+func (fake *TransactionService) GetBlockCalls(stub func(context.Context, string) (core.BlockRecord, error)) {
+	fake.getBlockMutex.Lock()
+	defer fake.getBlockMutex.Unlock()
+	fake.GetBlockStub = stub
+}
+
+func (fake *TransactionService) GetBlockArgsForCall(i int) (context.Context, string) {
+	fake.getBlockMutex.RLock()
+	defer fake.getBlockMutex.RUnlock()
+	argsForCall := fake.getBlockArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *TransactionService) GetBlockReturns(result1 core.BlockRecord, result2 error) {
+	fake.getBlockMutex.Lock()
+	defer fake.getBlockMutex.Unlock()
+	fake.GetBlockStub = nil
+	fake.getBlockReturns = struct {
+		result1 core.BlockRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TransactionService) GetBlockReturnsOnCall(i int, result1 core.BlockRecord, result2 error) {
+	fake.getBlockMutex.Lock()
+	defer fake.getBlockMutex.Unlock()
+	fake.GetBlockStub = nil
+	if fake.getBlockReturnsOnCall == nil {
+		fake.getBlockReturnsOnCall = make(map[int]struct {
+			result1 core.BlockRecord
+			result2 error
+		})
+	}
+	fake.getBlockReturnsOnCall[i] = struct {
+		result1 core.BlockRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TransactionService) GetCallTrace(arg1 context.Context, arg2 string) (core.TraceRecord, error) {
+	fake.getCallTraceMutex.Lock()
+	ret, specificReturn := fake.getCallTraceReturnsOnCall[len(fake.getCallTraceArgsForCall)]
+	fake.getCallTraceArgsForCall = append(fake.getCallTraceArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetCallTraceStub
+	fakeReturns := fake.getCallTraceReturns
+	fake.recordInvocation("GetCallTrace", []interface{}{arg1, arg2})
+	fake.getCallTraceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *TransactionService) GetCallTraceCallCount() int {
+	fake.getCallTraceMutex.RLock()
+	defer fake.getCallTraceMutex.RUnlock()
+	return len(fake.getCallTraceArgsForCall)
+}
+
+func (fake *TransactionService) GetCallTraceCalls(stub func(context.Context, string) (core.TraceRecord, error)) {
+	fake.getCallTraceMutex.Lock()
+	defer fake.getCallTraceMutex.Unlock()
+	fake.GetCallTraceStub = stub
+}
+
+func (fake *TransactionService) GetCallTraceArgsForCall(i int) (context.Context, string) {
+	fake.getCallTraceMutex.RLock()
+	defer fake.getCallTraceMutex.RUnlock()
+	argsForCall := fake.getCallTraceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *TransactionService) GetCallTraceReturns(result1 core.TraceRecord, result2 error) {
+	fake.getCallTraceMutex.Lock()
+	defer fake.getCallTraceMutex.Unlock()
+	fake.GetCallTraceStub = nil
+	fake.getCallTraceReturns = struct {
+		result1 core.TraceRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TransactionService) GetCallTraceReturnsOnCall(i int, result1 core.TraceRecord, result2 error) {
+	fake.getCallTraceMutex.Lock()
+	defer fake.getCallTraceMutex.Unlock()
+	fake.GetCallTraceStub = nil
+	if fake.getCallTraceReturnsOnCall == nil {
+		fake.getCallTraceReturnsOnCall = make(map[int]struct {
+			result1 core.TraceRecord
+			result2 error
+		})
+	}
+	fake.getCallTraceReturnsOnCall[i] = struct {
+		result1 core.TraceRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TransactionService) GetLogs(arg1 context.Context, arg2 core.LogFilter) ([]core.LogRecord, error) {
+	fake.getLogsMutex.Lock()
+	ret, specificReturn := fake.getLogsReturnsOnCall[len(fake.getLogsArgsForCall)]
+	fake.getLogsArgsForCall = append(fake.getLogsArgsForCall, struct {
+		arg1 context.Context
+		arg2 core.LogFilter
+	}{arg1, arg2})
+	stub := fake.GetLogsStub
+	fakeReturns := fake.getLogsReturns
+	fake.recordInvocation("GetLogs", []interface{}{arg1, arg2})
+	fake.getLogsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *TransactionService) GetLogsCallCount() int {
+	fake.getLogsMutex.RLock()
+	defer fake.getLogsMutex.RUnlock()
+	return len(fake.getLogsArgsForCall)
+}
+
+func (fake *TransactionService) GetLogsCalls(stub func(context.Context, core.LogFilter) ([]core.LogRecord, error)) {
+	fake.getLogsMutex.Lock()
+	defer fake.getLogsMutex.Unlock()
+	fake.GetLogsStub = stub
+}
+
+func (fake *TransactionService) GetLogsArgsForCall(i int) (context.Context, core.LogFilter) {
+	fake.getLogsMutex.RLock()
+	defer fake.getLogsMutex.RUnlock()
+	argsForCall := fake.getLogsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *TransactionService) GetLogsReturns(result1 []core.LogRecord, result2 error) {
+	fake.getLogsMutex.Lock()
+	defer fake.getLogsMutex.Unlock()
+	fake.GetLogsStub = nil
+	fake.getLogsReturns = struct {
+		result1 []core.LogRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TransactionService) GetLogsReturnsOnCall(i int, result1 []core.LogRecord, result2 error) {
+	fake.getLogsMutex.Lock()
+	defer fake.getLogsMutex.Unlock()
+	fake.GetLogsStub = nil
+	if fake.getLogsReturnsOnCall == nil {
+		fake.getLogsReturnsOnCall = make(map[int]struct {
+			result1 []core.LogRecord
+			result2 error
+		})
+	}
+	fake.getLogsReturnsOnCall[i] = struct {
+		result1 []core.LogRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TransactionService) GetTokenTransfers(arg1 context.Context, arg2 core.TransferFilter) ([]core.TransferRecord, error) {
+	fake.getTokenTransfersMutex.Lock()
+	ret, specificReturn := fake.getTokenTransfersReturnsOnCall[len(fake.getTokenTransfersArgsForCall)]
+	fake.getTokenTransfersArgsForCall = append(fake.getTokenTransfersArgsForCall, struct {
+		arg1 context.Context
+		arg2 core.TransferFilter
+	}{arg1, arg2})
+	stub := fake.GetTokenTransfersStub
+	fakeReturns := fake.getTokenTransfersReturns
+	fake.recordInvocation("GetTokenTransfers", []interface{}{arg1, arg2})
+	fake.getTokenTransfersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *TransactionService) GetTokenTransfersCallCount() int {
+	fake.getTokenTransfersMutex.RLock()
+	defer fake.getTokenTransfersMutex.RUnlock()
+	return len(fake.getTokenTransfersArgsForCall)
+}
+
+func (fake *TransactionService) GetTokenTransfersCalls(stub func(context.Context, core.TransferFilter) ([]core.TransferRecord, error)) {
+	fake.getTokenTransfersMutex.Lock()
+	defer fake.getTokenTransfersMutex.Unlock()
+	fake.GetTokenTransfersStub = stub
+}
+
+func (fake *TransactionService) GetTokenTransfersArgsForCall(i int) (context.Context, core.TransferFilter) {
+	fake.getTokenTransfersMutex.RLock()
+	defer fake.getTokenTransfersMutex.RUnlock()
+	argsForCall := fake.getTokenTransfersArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *TransactionService) GetTokenTransfersReturns(result1 []core.TransferRecord, result2 error) {
+	fake.getTokenTransfersMutex.Lock()
+	defer fake.getTokenTransfersMutex.Unlock()
+	fake.GetTokenTransfersStub = nil
+	fake.getTokenTransfersReturns = struct {
+		result1 []core.TransferRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TransactionService) GetTokenTransfersReturnsOnCall(i int, result1 []core.TransferRecord, result2 error) {
+	fake.getTokenTransfersMutex.Lock()
+	defer fake.getTokenTransfersMutex.Unlock()
+	fake.GetTokenTransfersStub = nil
+	if fake.getTokenTransfersReturnsOnCall == nil {
+		fake.getTokenTransfersReturnsOnCall = make(map[int]struct {
+			result1 []core.TransferRecord
+			result2 error
+		})
+	}
+	fake.getTokenTransfersReturnsOnCall[i] = struct {
+		result1 []core.TransferRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TransactionService) GetTransactions(arg1 context.Context, arg2 []string, arg3 core.IncludeOptions) ([]core.TransactionResult, error) {
 	var arg2Copy []string
 	if arg2 != nil {
 		arg2Copy = make([]string, len(arg2))
@@ -234,13 +801,14 @@ func (fake *TransactionService) GetTransactions(arg1 context.Context, arg2 []str
 	fake.getTransactionsArgsForCall = append(fake.getTransactionsArgsForCall, struct {
 		arg1 context.Context
 		arg2 []string
-	}{arg1, arg2Copy})
+		arg3 core.IncludeOptions
+	}{arg1, arg2Copy, arg3})
 	stub := fake.GetTransactionsStub
 	fakeReturns := fake.getTransactionsReturns
-	fake.recordInvocation("GetTransactions", []interface{}{arg1, arg2Copy})
+	fake.recordInvocation("GetTransactions", []interface{}{arg1, arg2Copy, arg3})
 	fake.getTransactionsMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -254,58 +822,59 @@ func (fake *TransactionService) GetTransactionsCallCount() int {
 	return len(fake.getTransactionsArgsForCall)
 }
 
-func (fake *TransactionService) GetTransactionsCalls(stub func(context.Context, []string) ([]core.TransactionRecord, error)) {
+func (fake *TransactionService) GetTransactionsCalls(stub func(context.Context, []string, core.IncludeOptions) ([]core.TransactionResult, error)) {
 	fake.getTransactionsMutex.Lock()
 	defer fake.getTransactionsMutex.Unlock()
 	fake.GetTransactionsStub = stub
 }
 
-func (fake *TransactionService) GetTransactionsArgsForCall(i int) (context.Context, []string) {
+func (fake *TransactionService) GetTransactionsArgsForCall(i int) (context.Context, []string, core.IncludeOptions) {
 	fake.getTransactionsMutex.RLock()
 	defer fake.getTransactionsMutex.RUnlock()
 	argsForCall := fake.getTransactionsArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
-func (fake *TransactionService) GetTransactionsReturns(result1 []core.TransactionRecord, result2 error) {
+func (fake *TransactionService) GetTransactionsReturns(result1 []core.TransactionResult, result2 error) {
 	fake.getTransactionsMutex.Lock()
 	defer fake.getTransactionsMutex.Unlock()
 	fake.GetTransactionsStub = nil
 	fake.getTransactionsReturns = struct {
-		result1 []core.TransactionRecord
+		result1 []core.TransactionResult
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *TransactionService) GetTransactionsReturnsOnCall(i int, result1 []core.TransactionRecord, result2 error) {
+func (fake *TransactionService) GetTransactionsReturnsOnCall(i int, result1 []core.TransactionResult, result2 error) {
 	fake.getTransactionsMutex.Lock()
 	defer fake.getTransactionsMutex.Unlock()
 	fake.GetTransactionsStub = nil
 	if fake.getTransactionsReturnsOnCall == nil {
 		fake.getTransactionsReturnsOnCall = make(map[int]struct {
-			result1 []core.TransactionRecord
+			result1 []core.TransactionResult
 			result2 error
 		})
 	}
 	fake.getTransactionsReturnsOnCall[i] = struct {
-		result1 []core.TransactionRecord
+		result1 []core.TransactionResult
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *TransactionService) GetUserTransactionsHistory(arg1 context.Context, arg2 string) ([]core.TransactionRecord, error) {
+func (fake *TransactionService) GetUserTransactionsHistory(arg1 context.Context, arg2 string, arg3 core.HistoryFilter) (core.HistoryPage, error) {
 	fake.getUserTransactionsHistoryMutex.Lock()
 	ret, specificReturn := fake.getUserTransactionsHistoryReturnsOnCall[len(fake.getUserTransactionsHistoryArgsForCall)]
 	fake.getUserTransactionsHistoryArgsForCall = append(fake.getUserTransactionsHistoryArgsForCall, struct {
 		arg1 context.Context
 		arg2 string
-	}{arg1, arg2})
+		arg3 core.HistoryFilter
+	}{arg1, arg2, arg3})
 	stub := fake.GetUserTransactionsHistoryStub
 	fakeReturns := fake.getUserTransactionsHistoryReturns
-	fake.recordInvocation("GetUserTransactionsHistory", []interface{}{arg1, arg2})
+	fake.recordInvocation("GetUserTransactionsHistory", []interface{}{arg1, arg2, arg3})
 	fake.getUserTransactionsHistoryMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -319,41 +888,41 @@ func (fake *TransactionService) GetUserTransactionsHistoryCallCount() int {
 	return len(fake.getUserTransactionsHistoryArgsForCall)
 }
 
-func (fake *TransactionService) GetUserTransactionsHistoryCalls(stub func(context.Context, string) ([]core.TransactionRecord, error)) {
+func (fake *TransactionService) GetUserTransactionsHistoryCalls(stub func(context.Context, string, core.HistoryFilter) (core.HistoryPage, error)) {
 	fake.getUserTransactionsHistoryMutex.Lock()
 	defer fake.getUserTransactionsHistoryMutex.Unlock()
 	fake.GetUserTransactionsHistoryStub = stub
 }
 
-func (fake *TransactionService) GetUserTransactionsHistoryArgsForCall(i int) (context.Context, string) {
+func (fake *TransactionService) GetUserTransactionsHistoryArgsForCall(i int) (context.Context, string, core.HistoryFilter) {
 	fake.getUserTransactionsHistoryMutex.RLock()
 	defer fake.getUserTransactionsHistoryMutex.RUnlock()
 	argsForCall := fake.getUserTransactionsHistoryArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
-func (fake *TransactionService) GetUserTransactionsHistoryReturns(result1 []core.TransactionRecord, result2 error) {
+func (fake *TransactionService) GetUserTransactionsHistoryReturns(result1 core.HistoryPage, result2 error) {
 	fake.getUserTransactionsHistoryMutex.Lock()
 	defer fake.getUserTransactionsHistoryMutex.Unlock()
 	fake.GetUserTransactionsHistoryStub = nil
 	fake.getUserTransactionsHistoryReturns = struct {
-		result1 []core.TransactionRecord
+		result1 core.HistoryPage
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *TransactionService) GetUserTransactionsHistoryReturnsOnCall(i int, result1 []core.TransactionRecord, result2 error) {
+func (fake *TransactionService) GetUserTransactionsHistoryReturnsOnCall(i int, result1 core.HistoryPage, result2 error) {
 	fake.getUserTransactionsHistoryMutex.Lock()
 	defer fake.getUserTransactionsHistoryMutex.Unlock()
 	fake.GetUserTransactionsHistoryStub = nil
 	if fake.getUserTransactionsHistoryReturnsOnCall == nil {
 		fake.getUserTransactionsHistoryReturnsOnCall = make(map[int]struct {
-			result1 []core.TransactionRecord
+			result1 core.HistoryPage
 			result2 error
 		})
 	}
 	fake.getUserTransactionsHistoryReturnsOnCall[i] = struct {
-		result1 []core.TransactionRecord
+		result1 core.HistoryPage
 		result2 error
 	}{result1, result2}
 }
@@ -422,6 +991,69 @@ func (fake *TransactionService) ParseRLPReturnsOnCall(i int, result1 []string, r
 	}{result1, result2}
 }
 
+func (fake *TransactionService) SaveContractABI(arg1 context.Context, arg2 string, arg3 string) error {
+	fake.saveContractABIMutex.Lock()
+	ret, specificReturn := fake.saveContractABIReturnsOnCall[len(fake.saveContractABIArgsForCall)]
+	fake.saveContractABIArgsForCall = append(fake.saveContractABIArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.SaveContractABIStub
+	fakeReturns := fake.saveContractABIReturns
+	fake.recordInvocation("SaveContractABI", []interface{}{arg1, arg2, arg3})
+	fake.saveContractABIMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *TransactionService) SaveContractABICallCount() int {
+	fake.saveContractABIMutex.RLock()
+	defer fake.saveContractABIMutex.RUnlock()
+	return len(fake.saveContractABIArgsForCall)
+}
+
+func (fake *TransactionService) SaveContractABICalls(stub func(context.Context, string, string) error) {
+	fake.saveContractABIMutex.Lock()
+	defer fake.saveContractABIMutex.Unlock()
+	fake.SaveContractABIStub = stub
+}
+
+func (fake *TransactionService) SaveContractABIArgsForCall(i int) (context.Context, string, string) {
+	fake.saveContractABIMutex.RLock()
+	defer fake.saveContractABIMutex.RUnlock()
+	argsForCall := fake.saveContractABIArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *TransactionService) SaveContractABIReturns(result1 error) {
+	fake.saveContractABIMutex.Lock()
+	defer fake.saveContractABIMutex.Unlock()
+	fake.SaveContractABIStub = nil
+	fake.saveContractABIReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *TransactionService) SaveContractABIReturnsOnCall(i int, result1 error) {
+	fake.saveContractABIMutex.Lock()
+	defer fake.saveContractABIMutex.Unlock()
+	fake.SaveContractABIStub = nil
+	if fake.saveContractABIReturnsOnCall == nil {
+		fake.saveContractABIReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveContractABIReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *TransactionService) SaveUserTransactionsHistory(arg1 context.Context, arg2 string, arg3 []string) error {
 	var arg3Copy []string
 	if arg3 != nil {
@@ -495,14 +1127,30 @@ func (fake *TransactionService) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.authenticateMutex.RLock()
 	defer fake.authenticateMutex.RUnlock()
+	fake.clearUserTransactionsHistoryMutex.RLock()
+	defer fake.clearUserTransactionsHistoryMutex.RUnlock()
+	fake.deleteUserHistoryEntryMutex.RLock()
+	defer fake.deleteUserHistoryEntryMutex.RUnlock()
+	fake.getAddressTransactionsMutex.RLock()
+	defer fake.getAddressTransactionsMutex.RUnlock()
 	fake.getAllDBTransactionsMutex.RLock()
 	defer fake.getAllDBTransactionsMutex.RUnlock()
+	fake.getBlockMutex.RLock()
+	defer fake.getBlockMutex.RUnlock()
+	fake.getCallTraceMutex.RLock()
+	defer fake.getCallTraceMutex.RUnlock()
+	fake.getLogsMutex.RLock()
+	defer fake.getLogsMutex.RUnlock()
+	fake.getTokenTransfersMutex.RLock()
+	defer fake.getTokenTransfersMutex.RUnlock()
 	fake.getTransactionsMutex.RLock()
 	defer fake.getTransactionsMutex.RUnlock()
 	fake.getUserTransactionsHistoryMutex.RLock()
 	defer fake.getUserTransactionsHistoryMutex.RUnlock()
 	fake.parseRLPMutex.RLock()
 	defer fake.parseRLPMutex.RUnlock()
+	fake.saveContractABIMutex.RLock()
+	defer fake.saveContractABIMutex.RUnlock()
 	fake.saveUserTransactionsHistoryMutex.RLock()
 	defer fake.saveUserTransactionsHistoryMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
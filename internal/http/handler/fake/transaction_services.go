@@ -0,0 +1,181 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"fethcher/internal/chain"
+	"fethcher/internal/http/handler"
+	"sync"
+)
+
+type TransactionServices struct {
+	ChainsStub        func() []chain.Chain
+	chainsMutex       sync.RWMutex
+	chainsArgsForCall []struct {
+	}
+	chainsReturns struct {
+		result1 []chain.Chain
+	}
+	chainsReturnsOnCall map[int]struct {
+		result1 []chain.Chain
+	}
+	GetStub        func(string) (handler.TransactionService, error)
+	getMutex       sync.RWMutex
+	getArgsForCall []struct {
+		arg1 string
+	}
+	getReturns struct {
+		result1 handler.TransactionService
+		result2 error
+	}
+	getReturnsOnCall map[int]struct {
+		result1 handler.TransactionService
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *TransactionServices) Chains() []chain.Chain {
+	fake.chainsMutex.Lock()
+	ret, specificReturn := fake.chainsReturnsOnCall[len(fake.chainsArgsForCall)]
+	fake.chainsArgsForCall = append(fake.chainsArgsForCall, struct {
+	}{})
+	stub := fake.ChainsStub
+	fakeReturns := fake.chainsReturns
+	fake.recordInvocation("Chains", []interface{}{})
+	fake.chainsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *TransactionServices) ChainsCallCount() int {
+	fake.chainsMutex.RLock()
+	defer fake.chainsMutex.RUnlock()
+	return len(fake.chainsArgsForCall)
+}
+
+func (fake *TransactionServices) ChainsCalls(stub func() []chain.Chain) {
+	fake.chainsMutex.Lock()
+	defer fake.chainsMutex.Unlock()
+	fake.ChainsStub = stub
+}
+
+func (fake *TransactionServices) ChainsReturns(result1 []chain.Chain) {
+	fake.chainsMutex.Lock()
+	defer fake.chainsMutex.Unlock()
+	fake.ChainsStub = nil
+	fake.chainsReturns = struct {
+		result1 []chain.Chain
+	}{result1}
+}
+
+func (fake *TransactionServices) ChainsReturnsOnCall(i int, result1 []chain.Chain) {
+	fake.chainsMutex.Lock()
+	defer fake.chainsMutex.Unlock()
+	fake.ChainsStub = nil
+	if fake.chainsReturnsOnCall == nil {
+		fake.chainsReturnsOnCall = make(map[int]struct {
+			result1 []chain.Chain
+		})
+	}
+	fake.chainsReturnsOnCall[i] = struct {
+		result1 []chain.Chain
+	}{result1}
+}
+
+func (fake *TransactionServices) Get(arg1 string) (handler.TransactionService, error) {
+	fake.getMutex.Lock()
+	ret, specificReturn := fake.getReturnsOnCall[len(fake.getArgsForCall)]
+	fake.getArgsForCall = append(fake.getArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.GetStub
+	fakeReturns := fake.getReturns
+	fake.recordInvocation("Get", []interface{}{arg1})
+	fake.getMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *TransactionServices) GetCallCount() int {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	return len(fake.getArgsForCall)
+}
+
+func (fake *TransactionServices) GetCalls(stub func(string) (handler.TransactionService, error)) {
+	fake.getMutex.Lock()
+	defer fake.getMutex.Unlock()
+	fake.GetStub = stub
+}
+
+func (fake *TransactionServices) GetArgsForCall(i int) string {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	argsForCall := fake.getArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *TransactionServices) GetReturns(result1 handler.TransactionService, result2 error) {
+	fake.getMutex.Lock()
+	defer fake.getMutex.Unlock()
+	fake.GetStub = nil
+	fake.getReturns = struct {
+		result1 handler.TransactionService
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TransactionServices) GetReturnsOnCall(i int, result1 handler.TransactionService, result2 error) {
+	fake.getMutex.Lock()
+	defer fake.getMutex.Unlock()
+	fake.GetStub = nil
+	if fake.getReturnsOnCall == nil {
+		fake.getReturnsOnCall = make(map[int]struct {
+			result1 handler.TransactionService
+			result2 error
+		})
+	}
+	fake.getReturnsOnCall[i] = struct {
+		result1 handler.TransactionService
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TransactionServices) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.chainsMutex.RLock()
+	defer fake.chainsMutex.RUnlock()
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *TransactionServices) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handler.TransactionServices = new(TransactionServices)
@@ -0,0 +1,102 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"fethcher/internal/http/handler"
+	"fethcher/internal/queue"
+	"sync"
+)
+
+type WriteQueue struct {
+	StatsStub        func() queue.Stats
+	statsMutex       sync.RWMutex
+	statsArgsForCall []struct {
+	}
+	statsReturns struct {
+		result1 queue.Stats
+	}
+	statsReturnsOnCall map[int]struct {
+		result1 queue.Stats
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *WriteQueue) Stats() queue.Stats {
+	fake.statsMutex.Lock()
+	ret, specificReturn := fake.statsReturnsOnCall[len(fake.statsArgsForCall)]
+	fake.statsArgsForCall = append(fake.statsArgsForCall, struct {
+	}{})
+	stub := fake.StatsStub
+	fakeReturns := fake.statsReturns
+	fake.recordInvocation("Stats", []interface{}{})
+	fake.statsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *WriteQueue) StatsCallCount() int {
+	fake.statsMutex.RLock()
+	defer fake.statsMutex.RUnlock()
+	return len(fake.statsArgsForCall)
+}
+
+func (fake *WriteQueue) StatsCalls(stub func() queue.Stats) {
+	fake.statsMutex.Lock()
+	defer fake.statsMutex.Unlock()
+	fake.StatsStub = stub
+}
+
+func (fake *WriteQueue) StatsReturns(result1 queue.Stats) {
+	fake.statsMutex.Lock()
+	defer fake.statsMutex.Unlock()
+	fake.StatsStub = nil
+	fake.statsReturns = struct {
+		result1 queue.Stats
+	}{result1}
+}
+
+func (fake *WriteQueue) StatsReturnsOnCall(i int, result1 queue.Stats) {
+	fake.statsMutex.Lock()
+	defer fake.statsMutex.Unlock()
+	fake.StatsStub = nil
+	if fake.statsReturnsOnCall == nil {
+		fake.statsReturnsOnCall = make(map[int]struct {
+			result1 queue.Stats
+		})
+	}
+	fake.statsReturnsOnCall[i] = struct {
+		result1 queue.Stats
+	}{result1}
+}
+
+func (fake *WriteQueue) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.statsMutex.RLock()
+	defer fake.statsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *WriteQueue) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handler.WriteQueue = new(WriteQueue)
@@ -3,36 +3,54 @@ package handler
 import (
 	"encoding/json"
 	"errors"
+	"fethcher/internal/chain"
 	"fethcher/internal/core"
+	"fethcher/internal/decoder"
 	"fethcher/internal/http/handler/middleware"
 	"fethcher/internal/http/payload"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"go.uber.org/zap"
 )
 
 var (
-	Authenticate       = "POST /lime/authenticate"
-	GetTransactions    = "GET /lime/eth"
-	GetTransactionsRLP = "GET /lime/eth/{rlpHash}"
-	GetAllTransactions = "GET /lime/all"
-	GetMyTransactions  = "GET /lime/my"
+	Authenticate           = "POST /lime/authenticate"
+	GetTransactions        = "GET /lime/eth"
+	GetTransactionsRLP     = "GET /lime/eth/{rlpHash}"
+	GetTransactionTrace    = "GET /lime/eth/{transactionHash}/trace"
+	GetAllTransactions     = "GET /lime/all"
+	GetMyTransactions      = "GET /lime/my"
+	DeleteMyTransaction    = "DELETE /lime/my/{transactionHash}"
+	ClearMyTransactions    = "DELETE /lime/my"
+	GetLogs                = "GET /lime/logs"
+	GetTransfers           = "GET /lime/transfers"
+	GetBlock               = "GET /lime/blocks/{numberOrHash}"
+	GetAddressTransactions = "GET /lime/addresses/{address}/transactions"
+	PutContractABI         = "PUT /lime/abis/{address}"
+	GetChains              = "GET /lime/chains"
 )
 
+// chainParam is the query parameter that selects the chain a request is served from. Requests without it are served from the
+// default chain.
+const chainParam = "chain"
+
 type FethHandler struct {
 	logs             *zap.SugaredLogger
 	requestValidator RequestValidator
-	fethcher         TransactionService
+	services         TransactionServices
 }
 
-func NewFethHandler(logger *zap.SugaredLogger, requestValidator RequestValidator, transactionService TransactionService) *FethHandler {
+func NewFethHandler(logger *zap.SugaredLogger, requestValidator RequestValidator, transactionServices TransactionServices) *FethHandler {
 	return &FethHandler{
 		logs:             logger,
 		requestValidator: requestValidator,
-		fethcher:         transactionService,
+		services:         transactionServices,
 	}
 }
 
@@ -43,6 +61,11 @@ func (h *FethHandler) HandleAuthenticate(w http.ResponseWriter, r *http.Request)
 		requestId = reqIdCtx.(string)
 	}
 
+	fethcher, ok := h.service(w, r, Authenticate, requestId)
+	if !ok {
+		return
+	}
+
 	var payload payload.AuthRequest
 	err := h.requestValidator.DecodeJSONPayload(r, &payload)
 	if err != nil || payload.Validate() != nil {
@@ -58,7 +81,7 @@ func (h *FethHandler) HandleAuthenticate(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	token, err := h.fethcher.Authenticate(r.Context(), payload.ToMessage())
+	token, err := fethcher.Authenticate(r.Context(), payload.ToMessage())
 	if err != nil {
 		resp := Response{
 			Message: "Login failed",
@@ -96,6 +119,11 @@ func (h *FethHandler) HandleGetTransactions(w http.ResponseWriter, r *http.Reque
 		requestId = reqIdCtx.(string)
 	}
 
+	fethcher, ok := h.service(w, r, GetTransactions, requestId)
+	if !ok {
+		return
+	}
+
 	values, err := url.ParseQuery(r.URL.RawQuery)
 	if err != nil {
 		h.respond(w, Response{
@@ -112,6 +140,7 @@ func (h *FethHandler) HandleGetTransactions(w http.ResponseWriter, r *http.Reque
 
 	txRequest := payload.TransactionsRequest{
 		Transactions: values["transactionHashes"],
+		Include:      splitQueryValues(values["include"]),
 	}
 	if err := txRequest.Validate(); err != nil {
 		h.respond(w, Response{
@@ -131,7 +160,7 @@ func (h *FethHandler) HandleGetTransactions(w http.ResponseWriter, r *http.Reque
 		"handler", GetTransactions,
 		"request_id", requestId)
 
-	transactions, err := h.fethcher.GetTransactions(r.Context(), txRequest.Transactions)
+	results, err := fethcher.GetTransactions(r.Context(), txRequest.Transactions, txRequest.ToIncludeOptions())
 	if err != nil {
 		h.respond(w, Response{
 			Message: "Could not retrieve transactions",
@@ -145,41 +174,37 @@ func (h *FethHandler) HandleGetTransactions(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	transactionHashes := foundHashes(results)
+
 	h.logs.Infow("transactions retrieved",
-		"transactions", transactions,
+		"transactions", transactionHashes,
 		"handler", GetTransactions,
 		"request_id", requestId)
 
-	transactionHashes := make([]string, 0, len(transactions))
-	for _, tx := range transactions {
-		transactionHashes = append(transactionHashes, tx.TransactionHash)
-	}
-
 	// save to user history
 	authToken := r.Header.Get("AUTH_TOKEN")
 	if authToken != "" && len(transactionHashes) > 0 {
-		go func() {
-			err = h.fethcher.SaveUserTransactionsHistory(r.Context(), authToken, transactionHashes)
-			if err != nil {
-				h.logs.Errorw("failed to save user history",
-					"error", err,
-					"handler", GetTransactions,
-					"request_id", requestId)
-			} else {
-				h.logs.Infow("user history saved successfully",
-					"num_of_transactions", len(transactionHashes),
-					"handler", GetTransactions,
-					"request_id", requestId)
-			}
-		}()
+		err = fethcher.SaveUserTransactionsHistory(r.Context(), authToken, transactionHashes)
+		if err != nil {
+			h.logs.Errorw("failed to save user history",
+				"error", err,
+				"handler", GetTransactions,
+				"request_id", requestId)
+		} else {
+			h.logs.Infow("user history queued",
+				"num_of_transactions", len(transactionHashes),
+				"handler", GetTransactions,
+				"request_id", requestId)
+		}
 	}
 
-	resp := map[string][]core.TransactionRecord{
-		"transactions": transactions,
+	resp := map[string]any{
+		"results": results,
 	}
 
 	h.respond(w, resp, http.StatusOK, requestId)
 }
+
 func (h *FethHandler) HandleGetTransactionsRLP(w http.ResponseWriter, r *http.Request) {
 	requestId := ""
 	reqIdCtx := r.Context().Value(middleware.RequestIDKey)
@@ -187,6 +212,11 @@ func (h *FethHandler) HandleGetTransactionsRLP(w http.ResponseWriter, r *http.Re
 		requestId = reqIdCtx.(string)
 	}
 
+	fethcher, ok := h.service(w, r, GetTransactionsRLP, requestId)
+	if !ok {
+		return
+	}
+
 	path := r.URL.Path
 	prefix := "/lime/eth/"
 
@@ -204,7 +234,7 @@ func (h *FethHandler) HandleGetTransactionsRLP(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	transactionHashes, err := h.fethcher.ParseRLP(rlphex)
+	transactionHashes, err := fethcher.ParseRLP(rlphex)
 	if err != nil {
 		h.respond(w, Response{
 			Message: "Request failed",
@@ -225,6 +255,7 @@ func (h *FethHandler) HandleGetTransactionsRLP(w http.ResponseWriter, r *http.Re
 
 	transactionRequest := payload.TransactionsRequest{
 		Transactions: transactionHashes,
+		Include:      splitQueryValues(r.URL.Query()["include"]),
 	}
 	if err = transactionRequest.Validate(); err != nil {
 		h.respond(w, Response{
@@ -239,7 +270,7 @@ func (h *FethHandler) HandleGetTransactionsRLP(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	transactions, err := h.fethcher.GetTransactions(r.Context(), transactionRequest.Transactions)
+	results, err := fethcher.GetTransactions(r.Context(), transactionRequest.Transactions, transactionRequest.ToIncludeOptions())
 	if err != nil {
 		h.respond(w, Response{
 			Message: "Request failed",
@@ -253,32 +284,27 @@ func (h *FethHandler) HandleGetTransactionsRLP(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	txsFound := make([]string, 0, len(transactions))
-	for _, tx := range transactions {
-		txsFound = append(txsFound, tx.TransactionHash)
-	}
+	txsFound := foundHashes(results)
 
 	// save to user history
 	authToken := r.Header.Get("AUTH_TOKEN")
 	if authToken != "" && len(txsFound) > 0 {
-		go func() {
-			err = h.fethcher.SaveUserTransactionsHistory(r.Context(), authToken, txsFound)
-			if err != nil {
-				h.logs.Errorw("failed to save user history",
-					"error", err,
-					"handler", GetTransactionsRLP,
-					"request_id", requestId)
-			} else {
-				h.logs.Infow("user history saved successfully",
-					"num_of_transactions", len(transactionHashes),
-					"handler", GetTransactionsRLP,
-					"request_id", requestId)
-			}
-		}()
+		err = fethcher.SaveUserTransactionsHistory(r.Context(), authToken, txsFound)
+		if err != nil {
+			h.logs.Errorw("failed to save user history",
+				"error", err,
+				"handler", GetTransactionsRLP,
+				"request_id", requestId)
+		} else {
+			h.logs.Infow("user history queued",
+				"num_of_transactions", len(txsFound),
+				"handler", GetTransactionsRLP,
+				"request_id", requestId)
+		}
 	}
 
-	resp := map[string][]core.TransactionRecord{
-		"transactions": transactions,
+	resp := map[string]any{
+		"results": results,
 	}
 
 	h.respond(w, resp, http.StatusOK, requestId)
@@ -292,6 +318,11 @@ func (h *FethHandler) HandleGetMyTransactions(w http.ResponseWriter, r *http.Req
 		requestId = reqIdCtx.(string)
 	}
 
+	fethcher, ok := h.service(w, r, GetMyTransactions, requestId)
+	if !ok {
+		return
+	}
+
 	authToken := r.Header.Get("AUTH_TOKEN")
 	if authToken == "" {
 		h.respond(w, Response{
@@ -305,19 +336,146 @@ func (h *FethHandler) HandleGetMyTransactions(w http.ResponseWriter, r *http.Req
 
 	h.logs.Infow("user transactions request received", "authToken", authToken, "handler", GetMyTransactions, "request_id", requestId)
 
-	transactions, err := h.fethcher.GetUserTransactionsHistory(r.Context(), authToken)
+	values := r.URL.Query()
+	historyRequest := payload.HistoryRequest{
+		Cursor: values.Get("cursor"),
+	}
+
+	var err error
+	historyRequest.Since, err = parseTimeParam(values, "since")
+	if err == nil {
+		historyRequest.Until, err = parseTimeParam(values, "until")
+	}
+	if limit := values.Get("limit"); err == nil && limit != "" {
+		historyRequest.Limit, err = strconv.Atoi(limit)
+	}
+	if err == nil {
+		err = historyRequest.Validate()
+	}
 	if err != nil {
+		h.respond(w, Response{
+			Message: "Request failed",
+			Error:   fmt.Errorf("validate request parameters: %w", err).Error(),
+		}, http.StatusBadRequest,
+			requestId)
+		h.logs.Errorw("failed to validate request parameters",
+			"error", err,
+			"handler", GetMyTransactions,
+			"request_id", requestId)
+		return
+	}
+
+	page, err := fethcher.GetUserTransactionsHistory(r.Context(), authToken, historyRequest.ToFilter())
+	if err != nil {
+		httpCode := http.StatusInternalServerError
+		if errors.Is(err, core.ErrInvalidCursor) {
+			httpCode = http.StatusBadRequest
+		}
 		h.respond(w, Response{
 			Message: "Failed to get user transactions",
 			Error:   fmt.Errorf("get user transactions: %w", err).Error(),
+		}, httpCode,
+			requestId)
+		h.logs.Errorw("failed to get user transactions", "error", err, "handler", GetMyTransactions, "request_id", requestId)
+		return
+	}
+
+	h.respond(w, page, http.StatusOK, requestId)
+}
+
+func (h *FethHandler) HandleDeleteMyTransaction(w http.ResponseWriter, r *http.Request) {
+	requestId := ""
+	reqIdCtx := r.Context().Value(middleware.RequestIDKey)
+	if reqIdCtx != nil {
+		requestId = reqIdCtx.(string)
+	}
+
+	fethcher, ok := h.service(w, r, DeleteMyTransaction, requestId)
+	if !ok {
+		return
+	}
+
+	authToken := r.Header.Get("AUTH_TOKEN")
+	if authToken == "" {
+		h.respond(w, Response{
+			Message: "Authentication failed",
+			Error:   "AUTH_TOKEN header is required",
+		}, http.StatusUnauthorized,
+			requestId)
+		h.logs.Errorw("missing AUTH_TOKEN header", "handler", DeleteMyTransaction, "request_id", requestId)
+		return
+	}
+
+	entryRequest := payload.HistoryEntryRequest{
+		TransactionHash: r.PathValue("transactionHash"),
+	}
+	if err := entryRequest.Validate(); err != nil {
+		h.respond(w, Response{
+			Message: "Request failed",
+			Error:   fmt.Errorf("validate request parameters: %w", err).Error(),
+		}, http.StatusBadRequest,
+			requestId)
+		h.logs.Errorw("failed to validate request parameters",
+			"error", err,
+			"handler", DeleteMyTransaction,
+			"request_id", requestId)
+		return
+	}
+
+	err := fethcher.DeleteUserHistoryEntry(r.Context(), authToken, entryRequest.TransactionHash)
+	if err != nil {
+		httpCode := http.StatusInternalServerError
+		if errors.Is(err, core.ErrHistoryEntryNotFound) {
+			httpCode = http.StatusNotFound
+		}
+		h.respond(w, Response{
+			Message: "Failed to delete user transaction",
+			Error:   fmt.Errorf("delete user transaction: %w", err).Error(),
+		}, httpCode,
+			requestId)
+		h.logs.Errorw("failed to delete user transaction", "error", err, "handler", DeleteMyTransaction, "request_id", requestId)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *FethHandler) HandleClearMyTransactions(w http.ResponseWriter, r *http.Request) {
+	requestId := ""
+	reqIdCtx := r.Context().Value(middleware.RequestIDKey)
+	if reqIdCtx != nil {
+		requestId = reqIdCtx.(string)
+	}
+
+	fethcher, ok := h.service(w, r, ClearMyTransactions, requestId)
+	if !ok {
+		return
+	}
+
+	authToken := r.Header.Get("AUTH_TOKEN")
+	if authToken == "" {
+		h.respond(w, Response{
+			Message: "Authentication failed",
+			Error:   "AUTH_TOKEN header is required",
+		}, http.StatusUnauthorized,
+			requestId)
+		h.logs.Errorw("missing AUTH_TOKEN header", "handler", ClearMyTransactions, "request_id", requestId)
+		return
+	}
+
+	deleted, err := fethcher.ClearUserTransactionsHistory(r.Context(), authToken)
+	if err != nil {
+		h.respond(w, Response{
+			Message: "Failed to clear user transactions",
+			Error:   fmt.Errorf("clear user transactions: %w", err).Error(),
 		}, http.StatusInternalServerError,
 			requestId)
-		h.logs.Errorw("failed to get user transactions", "error", err, "handler", GetMyTransactions)
+		h.logs.Errorw("failed to clear user transactions", "error", err, "handler", ClearMyTransactions, "request_id", requestId)
 		return
 	}
 
-	resp := map[string][]core.TransactionRecord{
-		"transactions": transactions,
+	resp := map[string]int64{
+		"deleted": deleted,
 	}
 
 	h.respond(w, resp, http.StatusOK, requestId)
@@ -330,12 +488,60 @@ func (h *FethHandler) HandleGetAllTransactions(w http.ResponseWriter, r *http.Re
 		requestId = reqIdCtx.(string)
 	}
 
-	transactions, err := h.fethcher.GetAllDBTransactions(r.Context())
+	fethcher, ok := h.service(w, r, GetAllTransactions, requestId)
+	if !ok {
+		return
+	}
+
+	values := r.URL.Query()
+	allRequest := payload.AllTransactionsRequest{
+		From:     values.Get("from"),
+		To:       values.Get("to"),
+		MinValue: values.Get("minValue"),
+		Sort:     values.Get("sort"),
+		Cursor:   values.Get("cursor"),
+	}
+
+	var err error
+	allRequest.Status, err = parseUintParam(values, "status")
+	if err == nil {
+		allRequest.FromBlock, err = parseUintParam(values, "fromBlock")
+	}
+	if err == nil {
+		allRequest.ToBlock, err = parseUintParam(values, "toBlock")
+	}
+	if err == nil {
+		allRequest.ContractCreation, err = parseBoolParam(values, "contractCreation")
+	}
+	if limit := values.Get("limit"); err == nil && limit != "" {
+		allRequest.Limit, err = strconv.Atoi(limit)
+	}
+	if err == nil {
+		err = allRequest.Validate()
+	}
+	if err != nil {
+		h.respond(w, Response{
+			Message: "Request failed",
+			Error:   fmt.Errorf("validate request parameters: %w", err).Error(),
+		}, http.StatusBadRequest,
+			requestId)
+		h.logs.Errorw("failed to validate request parameters",
+			"error", err,
+			"handler", GetAllTransactions,
+			"request_id", requestId)
+		return
+	}
+
+	page, err := fethcher.GetAllDBTransactions(r.Context(), allRequest.ToFilter())
 	if err != nil {
+		httpCode := http.StatusInternalServerError
+		if errors.Is(err, core.ErrInvalidCursor) {
+			httpCode = http.StatusBadRequest
+		}
 		h.respond(w, Response{
 			Message: "Request failed",
 			Error:   fmt.Errorf("get all transactions: %w", err).Error(),
-		}, http.StatusInternalServerError,
+		}, httpCode,
 			requestId)
 		h.logs.Errorw("failed to get all transactions",
 			"error", err,
@@ -347,23 +553,471 @@ func (h *FethHandler) HandleGetAllTransactions(w http.ResponseWriter, r *http.Re
 	h.logs.Infow("transactions retrieved from DB",
 		"request_id", requestId,
 		"handler", GetAllTransactions,
-		"transactions", transactions,
+		"count", len(page.Transactions),
 	)
 
-	resp := map[string][]core.TransactionRecord{
-		"transactions": transactions,
+	h.respond(w, page, http.StatusOK, requestId)
+}
+
+func (h *FethHandler) HandleGetLogs(w http.ResponseWriter, r *http.Request) {
+	requestId := ""
+	reqIdCtx := r.Context().Value(middleware.RequestIDKey)
+	if reqIdCtx != nil {
+		requestId = reqIdCtx.(string)
+	}
+
+	fethcher, ok := h.service(w, r, GetLogs, requestId)
+	if !ok {
+		return
+	}
+
+	values := r.URL.Query()
+	logsRequest := payload.LogsRequest{
+		Address: values.Get("address"),
+		Topic0:  values.Get("topic0"),
+	}
+
+	var err error
+	if limit := values.Get("limit"); limit != "" {
+		logsRequest.Limit, err = strconv.Atoi(limit)
+	}
+	if err == nil {
+		err = logsRequest.Validate()
+	}
+	if err != nil {
+		h.respond(w, Response{
+			Message: "Request failed",
+			Error:   fmt.Errorf("validate request parameters: %w", err).Error(),
+		}, http.StatusBadRequest,
+			requestId)
+		h.logs.Errorw("failed to validate request parameters",
+			"error", err,
+			"handler", GetLogs,
+			"request_id", requestId)
+		return
+	}
+
+	logs, err := fethcher.GetLogs(r.Context(), logsRequest.ToFilter())
+	if err != nil {
+		h.respond(w, Response{
+			Message: "Request failed",
+			Error:   fmt.Errorf("get logs: %w", err).Error(),
+		}, http.StatusInternalServerError,
+			requestId)
+		h.logs.Errorw("failed to get logs",
+			"error", err,
+			"handler", GetLogs,
+			"request_id", requestId)
+		return
+	}
+
+	resp := map[string][]core.LogRecord{
+		"logs": logs,
+	}
+
+	h.respond(w, resp, http.StatusOK, requestId)
+}
+
+func (h *FethHandler) HandleGetTransfers(w http.ResponseWriter, r *http.Request) {
+	requestId := ""
+	reqIdCtx := r.Context().Value(middleware.RequestIDKey)
+	if reqIdCtx != nil {
+		requestId = reqIdCtx.(string)
+	}
+
+	fethcher, ok := h.service(w, r, GetTransfers, requestId)
+	if !ok {
+		return
+	}
+
+	values := r.URL.Query()
+	transfersRequest := payload.TransfersRequest{
+		Token:  values.Get("token"),
+		Holder: values.Get("holder"),
+	}
+
+	var err error
+	if limit := values.Get("limit"); limit != "" {
+		transfersRequest.Limit, err = strconv.Atoi(limit)
+	}
+	if err == nil {
+		err = transfersRequest.Validate()
+	}
+	if err != nil {
+		h.respond(w, Response{
+			Message: "Request failed",
+			Error:   fmt.Errorf("validate request parameters: %w", err).Error(),
+		}, http.StatusBadRequest,
+			requestId)
+		h.logs.Errorw("failed to validate request parameters",
+			"error", err,
+			"handler", GetTransfers,
+			"request_id", requestId)
+		return
+	}
+
+	transfers, err := fethcher.GetTokenTransfers(r.Context(), transfersRequest.ToFilter())
+	if err != nil {
+		h.respond(w, Response{
+			Message: "Request failed",
+			Error:   fmt.Errorf("get token transfers: %w", err).Error(),
+		}, http.StatusInternalServerError,
+			requestId)
+		h.logs.Errorw("failed to get token transfers",
+			"error", err,
+			"handler", GetTransfers,
+			"request_id", requestId)
+		return
+	}
+
+	resp := map[string][]core.TransferRecord{
+		"transfers": transfers,
+	}
+
+	h.respond(w, resp, http.StatusOK, requestId)
+}
+
+func (h *FethHandler) HandleGetAddressTransactions(w http.ResponseWriter, r *http.Request) {
+	requestId := ""
+	reqIdCtx := r.Context().Value(middleware.RequestIDKey)
+	if reqIdCtx != nil {
+		requestId = reqIdCtx.(string)
+	}
+
+	fethcher, ok := h.service(w, r, GetAddressTransactions, requestId)
+	if !ok {
+		return
+	}
+
+	values := r.URL.Query()
+	addressRequest := payload.AddressTransactionsRequest{
+		Address:   r.PathValue("address"),
+		Direction: values.Get("direction"),
+		Cursor:    values.Get("cursor"),
+	}
+
+	var err error
+	addressRequest.FromBlock, err = parseUintParam(values, "fromBlock")
+	if err == nil {
+		addressRequest.ToBlock, err = parseUintParam(values, "toBlock")
+	}
+	if limit := values.Get("limit"); err == nil && limit != "" {
+		addressRequest.Limit, err = strconv.Atoi(limit)
+	}
+	if err == nil {
+		err = addressRequest.Validate()
+	}
+	if err != nil {
+		h.respond(w, Response{
+			Message: "Request failed",
+			Error:   fmt.Errorf("validate request parameters: %w", err).Error(),
+		}, http.StatusBadRequest,
+			requestId)
+		h.logs.Errorw("failed to validate request parameters",
+			"error", err,
+			"handler", GetAddressTransactions,
+			"request_id", requestId)
+		return
+	}
+
+	page, err := fethcher.GetAddressTransactions(r.Context(), addressRequest.ToFilter())
+	if err != nil {
+		httpCode := http.StatusInternalServerError
+		if errors.Is(err, core.ErrInvalidCursor) {
+			httpCode = http.StatusBadRequest
+		}
+		h.respond(w, Response{
+			Message: "Request failed",
+			Error:   fmt.Errorf("get address transactions: %w", err).Error(),
+		}, httpCode,
+			requestId)
+		h.logs.Errorw("failed to get address transactions",
+			"error", err,
+			"handler", GetAddressTransactions,
+			"request_id", requestId)
+		return
+	}
+
+	h.respond(w, page, http.StatusOK, requestId)
+}
+
+func (h *FethHandler) HandleGetBlock(w http.ResponseWriter, r *http.Request) {
+	requestId := ""
+	reqIdCtx := r.Context().Value(middleware.RequestIDKey)
+	if reqIdCtx != nil {
+		requestId = reqIdCtx.(string)
+	}
+
+	fethcher, ok := h.service(w, r, GetBlock, requestId)
+	if !ok {
+		return
+	}
+
+	blockRequest := payload.BlockRequest{
+		NumberOrHash: r.PathValue("numberOrHash"),
+	}
+	if err := blockRequest.Validate(); err != nil {
+		h.respond(w, Response{
+			Message: "Request failed",
+			Error:   fmt.Errorf("validate request parameters: %w", err).Error(),
+		}, http.StatusBadRequest,
+			requestId)
+		h.logs.Errorw("failed to validate request parameters",
+			"error", err,
+			"handler", GetBlock,
+			"request_id", requestId)
+		return
+	}
+
+	block, err := fethcher.GetBlock(r.Context(), blockRequest.NumberOrHash)
+	if err != nil {
+		httpCode := http.StatusInternalServerError
+		if errors.Is(err, core.ErrBlockNotFound) {
+			httpCode = http.StatusNotFound
+		}
+		h.respond(w, Response{
+			Message: "Request failed",
+			Error:   fmt.Errorf("get block: %w", err).Error(),
+		}, httpCode,
+			requestId)
+		h.logs.Errorw("failed to get block",
+			"error", err,
+			"handler", GetBlock,
+			"request_id", requestId)
+		return
+	}
+
+	resp := map[string]core.BlockRecord{
+		"block": block,
+	}
+
+	h.respond(w, resp, http.StatusOK, requestId)
+}
+
+// HandleGetTransactionTrace serves the internal calls of a transaction. Nodes that do not serve call traces are reported with
+// 501 Not Implemented, so that clients can tell a missing capability from a failure.
+func (h *FethHandler) HandleGetTransactionTrace(w http.ResponseWriter, r *http.Request) {
+	requestId := ""
+	reqIdCtx := r.Context().Value(middleware.RequestIDKey)
+	if reqIdCtx != nil {
+		requestId = reqIdCtx.(string)
+	}
+
+	fethcher, ok := h.service(w, r, GetTransactionTrace, requestId)
+	if !ok {
+		return
+	}
+
+	traceRequest := payload.TraceRequest{
+		TransactionHash: r.PathValue("transactionHash"),
+	}
+	if err := traceRequest.Validate(); err != nil {
+		h.respond(w, Response{
+			Message: "Request failed",
+			Error:   fmt.Errorf("validate request parameters: %w", err).Error(),
+		}, http.StatusBadRequest,
+			requestId)
+		h.logs.Errorw("failed to validate request parameters",
+			"error", err,
+			"handler", GetTransactionTrace,
+			"request_id", requestId)
+		return
+	}
+
+	trace, err := fethcher.GetCallTrace(r.Context(), traceRequest.TransactionHash)
+	if err != nil {
+		httpCode := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, core.ErrTracingUnsupported):
+			httpCode = http.StatusNotImplemented
+		case errors.Is(err, core.ErrTransactionNotFound):
+			httpCode = http.StatusNotFound
+		}
+		h.respond(w, Response{
+			Message: "Request failed",
+			Error:   fmt.Errorf("get call trace: %w", err).Error(),
+		}, httpCode,
+			requestId)
+		h.logs.Errorw("failed to get call trace",
+			"error", err,
+			"handler", GetTransactionTrace,
+			"request_id", requestId)
+		return
+	}
+
+	resp := map[string]core.TraceRecord{
+		"trace": trace,
 	}
 
 	h.respond(w, resp, http.StatusOK, requestId)
 }
 
+func (h *FethHandler) HandlePutContractABI(w http.ResponseWriter, r *http.Request) {
+	requestId := ""
+	reqIdCtx := r.Context().Value(middleware.RequestIDKey)
+	if reqIdCtx != nil {
+		requestId = reqIdCtx.(string)
+	}
+
+	fethcher, ok := h.service(w, r, PutContractABI, requestId)
+	if !ok {
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxABISize))
+	abiRequest := payload.ABIRequest{
+		Address: r.PathValue("address"),
+		ABI:     string(body),
+	}
+	if err == nil {
+		err = abiRequest.Validate()
+	}
+	if err != nil {
+		h.respond(w, Response{
+			Message: "Request failed",
+			Error:   fmt.Errorf("invalid request payload: %w", err).Error(),
+		}, http.StatusBadRequest,
+			requestId)
+		h.logs.Errorw("failed to read and validate request payload",
+			"error", err,
+			"handler", PutContractABI,
+			"request_id", requestId)
+		return
+	}
+
+	err = fethcher.SaveContractABI(r.Context(), abiRequest.Address, abiRequest.ABI)
+	if err != nil {
+		httpCode := http.StatusInternalServerError
+		if errors.Is(err, decoder.ErrInvalidABI) {
+			httpCode = http.StatusBadRequest
+		}
+		h.respond(w, Response{
+			Message: "Could not save abi",
+			Error:   fmt.Errorf("save contract abi: %w", err).Error(),
+		}, httpCode,
+			requestId)
+		h.logs.Errorw("failed to save contract abi",
+			"error", err,
+			"handler", PutContractABI,
+			"request_id", requestId)
+		return
+	}
+
+	h.respond(w, Response{
+		Message: "ABI saved",
+	}, http.StatusOK, requestId)
+}
+
+func (h *FethHandler) HandleGetChains(w http.ResponseWriter, r *http.Request) {
+	requestId := ""
+	reqIdCtx := r.Context().Value(middleware.RequestIDKey)
+	if reqIdCtx != nil {
+		requestId = reqIdCtx.(string)
+	}
+
+	resp := map[string][]chain.Chain{
+		"chains": h.services.Chains(),
+	}
+
+	h.respond(w, resp, http.StatusOK, requestId)
+}
+
+// service returns the transaction service of the chain selected by the chain query parameter. When the chain is not configured
+// it responds with 400 Bad Request and returns false.
+func (h *FethHandler) service(w http.ResponseWriter, r *http.Request, handler string, requestId string) (TransactionService, bool) {
+	service, err := h.services.Get(r.URL.Query().Get(chainParam))
+	if err != nil {
+		h.respond(w, Response{
+			Message: "Request failed",
+			Error:   fmt.Errorf("select chain: %w", err).Error(),
+		}, http.StatusBadRequest,
+			requestId)
+		h.logs.Errorw("failed to select chain",
+			"error", err,
+			"handler", handler,
+			"request_id", requestId)
+		return nil, false
+	}
+	return service, true
+}
+
+// parseUintParam parses the optional unsigned integer query parameter with the given key.
+func parseUintParam(values url.Values, key string) (*uint64, error) {
+	value := values.Get(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	number, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", key, err)
+	}
+	return &number, nil
+}
+
+// parseBoolParam parses the optional boolean query parameter with the given key.
+func parseBoolParam(values url.Values, key string) (*bool, error) {
+	value := values.Get(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", key, err)
+	}
+	return &parsed, nil
+}
+
+// parseTimeParam parses the optional RFC 3339 timestamp query parameter with the given key.
+func parseTimeParam(values url.Values, key string) (*time.Time, error) {
+	value := values.Get(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", key, err)
+	}
+	return &parsed, nil
+}
+
+// splitQueryValues splits comma separated query parameter values, so that both ?include=a,b and ?include=a&include=b work.
+func splitQueryValues(values []string) []string {
+	var split []string
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				split = append(split, part)
+			}
+		}
+	}
+	return split
+}
+
+// foundHashes returns the hashes of the transactions that could be retrieved, in request order.
+func foundHashes(results []core.TransactionResult) []string {
+	hashes := make([]string, 0, len(results))
+	for _, res := range results {
+		if res.Transaction != nil {
+			hashes = append(hashes, res.TransactionHash)
+		}
+	}
+	return hashes
+}
+
 func (h *FethHandler) respond(w http.ResponseWriter, resp any, code int, requestId string) {
+	respond(h.logs, w, resp, code, requestId)
+}
+
+func respond(logs *zap.SugaredLogger, w http.ResponseWriter, resp any, code int, requestId string) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(code)
 
 	if err := json.NewEncoder(w).Encode(resp); err != nil {
 		http.Error(w, oopsErr, http.StatusInternalServerError)
-		h.logs.Errorw("failed to encode response",
+		logs.Errorw("failed to encode response",
 			"error", err,
 			"request_id", requestId)
 	}
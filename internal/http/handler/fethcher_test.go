@@ -3,13 +3,18 @@ package handler_test
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"time"
 
+	"fethcher/internal/chain"
 	"fethcher/internal/core"
+	"fethcher/internal/decoder"
 	"fethcher/internal/http/handler"
 	"fethcher/internal/http/handler/fake"
+	"fethcher/internal/http/handler/middleware"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -19,6 +24,7 @@ import (
 var _ = Describe("FethHandler", func() {
 	var (
 		fethHandler   *handler.FethHandler
+		fakeServices  *fake.TransactionServices
 		fakeService   *fake.TransactionService
 		fakeValidator *fake.RequestValidator
 		fakeLogger    *zap.SugaredLogger
@@ -35,10 +41,12 @@ var _ = Describe("FethHandler", func() {
 		fakeService = new(fake.TransactionService)
 		fakeService.AuthenticateReturns(testToken, nil)
 		fakeService.SaveUserTransactionsHistoryReturns(nil)
+		fakeServices = new(fake.TransactionServices)
+		fakeServices.GetReturns(fakeService, nil)
 		fakeValidator = new(fake.RequestValidator)
 
 		w = httptest.NewRecorder()
-		fethHandler = handler.NewFethHandler(fakeLogger, fakeValidator, fakeService)
+		fethHandler = handler.NewFethHandler(fakeLogger, fakeValidator, fakeServices)
 	})
 
 	Describe("HandleAuthenticate", func() {
@@ -117,32 +125,97 @@ var _ = Describe("FethHandler", func() {
 
 		When("transactions are fetched successfully", func() {
 			BeforeEach(func() {
-				fakeService.GetTransactionsReturns([]core.TransactionRecord{
-					{TransactionHash: "0x1"},
-					{TransactionHash: "0x2"},
+				fakeService.GetTransactionsReturns([]core.TransactionResult{
+					{TransactionHash: "0x1", Status: core.StatusCached, Transaction: &core.TransactionRecord{TransactionHash: "0x1"}},
+					{TransactionHash: "0x2", Status: core.StatusFetched, Transaction: &core.TransactionRecord{TransactionHash: "0x2"}},
 				}, nil)
 			})
 
 			It("should return the transactions", func() {
 				Expect(w.Code).To(Equal(http.StatusOK))
-				var response map[string][]core.TransactionRecord
+				var response map[string][]core.TransactionResult
 				err := json.NewDecoder(w.Body).Decode(&response)
 				Expect(err).NotTo(HaveOccurred())
-				Expect(response["transactions"]).To(HaveLen(2))
+				Expect(response).To(HaveLen(1))
+				Expect(response["results"]).To(Equal([]core.TransactionResult{
+					{TransactionHash: "0x1", Status: core.StatusCached, Transaction: &core.TransactionRecord{TransactionHash: "0x1"}},
+					{TransactionHash: "0x2", Status: core.StatusFetched, Transaction: &core.TransactionRecord{TransactionHash: "0x2"}},
+				}))
 			})
 			When("auth token is provided", func() {
 				BeforeEach(func() {
 					req.Header.Set("AUTH_TOKEN", testToken)
 				})
 				It("should save to user history", func() {
-					Eventually(func() int {
-						return fakeService.SaveUserTransactionsHistoryCallCount()
-					}).Should(Equal(1))
+					Expect(fakeService.SaveUserTransactionsHistoryCallCount()).To(Equal(1))
 				})
 			})
 
 		})
 
+		When("some transactions cannot be retrieved", func() {
+			BeforeEach(func() {
+				fakeService.GetTransactionsReturns([]core.TransactionResult{
+					{TransactionHash: "0x1", Status: core.StatusNotFound, Reason: "transaction not found"},
+					{TransactionHash: "0x2", Status: core.StatusFetched, Transaction: &core.TransactionRecord{TransactionHash: "0x2"}},
+				}, nil)
+				req.Header.Set("AUTH_TOKEN", testToken)
+			})
+
+			It("should report the status of every hash and only return the retrieved transactions", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				var response struct {
+					Results []core.TransactionResult `json:"results"`
+				}
+				err := json.NewDecoder(w.Body).Decode(&response)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.Results).To(HaveLen(2))
+				Expect(response.Results[0].Status).To(Equal(core.StatusNotFound))
+				Expect(response.Results[0].Reason).To(Equal("transaction not found"))
+				Expect(response.Results[0].Transaction).To(BeNil())
+				Expect(response.Results[1].Transaction.TransactionHash).To(Equal("0x2"))
+
+				Expect(fakeService.SaveUserTransactionsHistoryCallCount()).To(Equal(1))
+				_, _, saved := fakeService.SaveUserTransactionsHistoryArgsForCall(0)
+				Expect(saved).To(Equal([]string{"0x2"}))
+			})
+		})
+
+		When("logs are included", func() {
+			BeforeEach(func() {
+				req = httptest.NewRequest(http.MethodGet, "/lime/eth?transactionHashes=0x1&include=logs", nil)
+			})
+
+			It("should ask for the logs", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				_, _, include := fakeService.GetTransactionsArgsForCall(0)
+				Expect(include.Logs).To(BeTrue())
+			})
+		})
+
+		When("logs and transfers are included", func() {
+			BeforeEach(func() {
+				req = httptest.NewRequest(http.MethodGet, "/lime/eth?transactionHashes=0x1&include=logs,transfers", nil)
+			})
+
+			It("should ask for both", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				_, _, include := fakeService.GetTransactionsArgsForCall(0)
+				Expect(include).To(Equal(core.IncludeOptions{Logs: true, Transfers: true}))
+			})
+		})
+
+		When("an unknown section is included", func() {
+			BeforeEach(func() {
+				req = httptest.NewRequest(http.MethodGet, "/lime/eth?transactionHashes=0x1&include=logs,traces", nil)
+			})
+
+			It("should return 400 Bad Request", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(fakeService.GetTransactionsCallCount()).To(Equal(0))
+			})
+		})
+
 		When("query parameters are invalid", func() {
 			BeforeEach(func() {
 				req = httptest.NewRequest(http.MethodGet, "/lime/eth?invalid=param", nil)
@@ -219,8 +292,8 @@ var _ = Describe("FethHandler", func() {
 		When("rlp is valid", func() {
 			BeforeEach(func() {
 				fakeService.ParseRLPReturns([]string{"0x12"}, nil)
-				fakeService.GetTransactionsReturns([]core.TransactionRecord{
-					{TransactionHash: "0x12"},
+				fakeService.GetTransactionsReturns([]core.TransactionResult{
+					{TransactionHash: "0x12", Status: core.StatusCached, Transaction: &core.TransactionRecord{TransactionHash: "0x12"}},
 				}, nil)
 			})
 
@@ -234,29 +307,89 @@ var _ = Describe("FethHandler", func() {
 	})
 
 	Describe("HandleGetAll", func() {
+		BeforeEach(func() {
+			req = httptest.NewRequest("GET", "/lime/all", nil)
+		})
+
 		JustBeforeEach(func() {
 			fethHandler.HandleGetAllTransactions(w, req)
 		})
 
 		When("GetAllDBTransactions succeeds", func() {
 			BeforeEach(func() {
-				req = httptest.NewRequest("GET", "/lime/all", nil)
-				fakeService.GetAllDBTransactionsReturns([]core.TransactionRecord{
-					{TransactionHash: "0x12"},
+				fakeService.GetAllDBTransactionsReturns(core.TransactionPage{
+					Transactions: []core.TransactionRecord{{TransactionHash: "0x12"}},
+					NextCursor:   "next",
 				}, nil)
 			})
 
-			It("should return 200 OK and transactions", func() {
+			It("should return 200 OK and a page of transactions", func() {
 				Expect(w.Code).To(Equal(http.StatusOK))
 				Expect(w.Body.String()).To(ContainSubstring("0x12"))
-				Expect(fakeService.GetAllDBTransactionsCallCount()).To(Equal(1))
+				Expect(w.Body.String()).To(ContainSubstring(`"nextCursor":"next"`))
+				_, filter := fakeService.GetAllDBTransactionsArgsForCall(0)
+				Expect(filter).To(Equal(core.TransactionFilter{Limit: 100}))
+			})
+		})
+
+		When("filters, sort order and cursor are given", func() {
+			BeforeEach(func() {
+				req = httptest.NewRequest("GET", "/lime/all?status=0&fromBlock=1&toBlock=2&from=0x00000000000000000000000000000000000b0b00"+
+					"&contractCreation=true&minValue=1000&sort=asc&cursor=abc&limit=10", nil)
+			})
+
+			It("should pass them on", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				_, filter := fakeService.GetAllDBTransactionsArgsForCall(0)
+				status, from, to, creation := uint64(0), uint64(1), uint64(2), true
+				Expect(filter).To(Equal(core.TransactionFilter{
+					Status:           &status,
+					FromBlock:        &from,
+					ToBlock:          &to,
+					From:             "0x00000000000000000000000000000000000b0b00",
+					ContractCreation: &creation,
+					MinValue:         "1000",
+					Ascending:        true,
+					Cursor:           "abc",
+					Limit:            10,
+				}))
+			})
+		})
+
+		When("a filter is invalid", func() {
+			BeforeEach(func() {
+				req = httptest.NewRequest("GET", "/lime/all?minValue=-1", nil)
+			})
+
+			It("should return 400 Bad Request", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(fakeService.GetAllDBTransactionsCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the sort order is unknown", func() {
+			BeforeEach(func() {
+				req = httptest.NewRequest("GET", "/lime/all?sort=random", nil)
+			})
+
+			It("should return 400 Bad Request", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("the page size is too large", func() {
+			BeforeEach(func() {
+				req = httptest.NewRequest("GET", "/lime/all?limit=5000", nil)
+			})
+
+			It("should return 400 Bad Request", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
 			})
 		})
 
 		When("GetAllDBTransactions fails", func() {
 			BeforeEach(func() {
-				req = httptest.NewRequest("GET", "/lime/all", nil)
-				fakeService.GetAllDBTransactionsReturns(nil, fakeErr)
+				fakeService.GetAllDBTransactionsReturns(core.TransactionPage{}, fakeErr)
 			})
 
 			It("should return 500 Internal Server Error", func() {
@@ -277,20 +410,81 @@ var _ = Describe("FethHandler", func() {
 
 		When("GetUserTransactionsHistory succeeds", func() {
 			BeforeEach(func() {
-				fakeService.GetUserTransactionsHistoryReturns([]core.TransactionRecord{
-					{TransactionHash: "0xuser"},
+				fakeService.GetUserTransactionsHistoryReturns(core.HistoryPage{
+					Entries: []core.HistoryEntry{{
+						TransactionHash: "0xuser",
+						QueryCount:      2,
+						Transaction:     &core.TransactionRecord{TransactionHash: "0xuser"},
+					}},
+					NextCursor: "next",
 				}, nil)
 			})
 
 			It("should return 200 OK and user transactions", func() {
 				Expect(w.Code).To(Equal(http.StatusOK))
 				Expect(w.Body.String()).To(ContainSubstring("0xuser"))
+				Expect(w.Body.String()).To(ContainSubstring(`"queryCount":2`))
+				Expect(w.Body.String()).To(ContainSubstring(`"nextCursor":"next"`))
+
+				_, token, filter := fakeService.GetUserTransactionsHistoryArgsForCall(0)
+				Expect(token).To(Equal(testToken))
+				Expect(filter.Limit).To(Equal(100))
+			})
+		})
+
+		When("a date range, cursor and limit are given", func() {
+			BeforeEach(func() {
+				req = httptest.NewRequest("GET", "/lime/my?since=2025-01-01T00:00:00Z&until=2025-02-01T00:00:00Z&cursor=abc&limit=10", nil)
+				req.Header.Set("AUTH_TOKEN", testToken)
+			})
+
+			It("should pass them to the service", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				_, _, filter := fakeService.GetUserTransactionsHistoryArgsForCall(0)
+				Expect(*filter.QueriedFrom).To(Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)))
+				Expect(*filter.QueriedTo).To(Equal(time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)))
+				Expect(filter.Cursor).To(Equal("abc"))
+				Expect(filter.Limit).To(Equal(10))
+			})
+		})
+
+		When("the date range is reversed", func() {
+			BeforeEach(func() {
+				req = httptest.NewRequest("GET", "/lime/my?since=2025-02-01T00:00:00Z&until=2025-01-01T00:00:00Z", nil)
+				req.Header.Set("AUTH_TOKEN", testToken)
+			})
+
+			It("should return 400 Bad Request", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(fakeService.GetUserTransactionsHistoryCallCount()).To(Equal(0))
+			})
+		})
+
+		When("a timestamp is malformed", func() {
+			BeforeEach(func() {
+				req = httptest.NewRequest("GET", "/lime/my?since=yesterday", nil)
+				req.Header.Set("AUTH_TOKEN", testToken)
+			})
+
+			It("should return 400 Bad Request", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("the cursor is invalid", func() {
+			BeforeEach(func() {
+				fakeService.GetUserTransactionsHistoryReturns(core.HistoryPage{}, core.ErrInvalidCursor)
+			})
+
+			It("should return 400 Bad Request", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
 			})
 		})
 
 		When("GetUserTransactionsHistory fails", func() {
 			BeforeEach(func() {
-				fakeService.GetUserTransactionsHistoryReturns(nil, fakeErr)
+				fakeService.GetUserTransactionsHistoryReturns(core.HistoryPage{}, fakeErr)
 			})
 
 			It("should return 500 Internal Server Error", func() {
@@ -310,4 +504,579 @@ var _ = Describe("FethHandler", func() {
 			})
 		})
 	})
+
+	Describe("HandleDeleteMyTransaction", func() {
+		var txHash string
+
+		BeforeEach(func() {
+			txHash = "0x" + strings.Repeat("ab", 32)
+			req = httptest.NewRequest("DELETE", "/lime/my/"+txHash, nil)
+			req.SetPathValue("transactionHash", txHash)
+			req.Header.Set("AUTH_TOKEN", testToken)
+		})
+		JustBeforeEach(func() {
+			fethHandler.HandleDeleteMyTransaction(w, req)
+		})
+
+		When("the transaction is in the history", func() {
+			It("should return 204 No Content", func() {
+				Expect(w.Code).To(Equal(http.StatusNoContent))
+
+				_, token, hash := fakeService.DeleteUserHistoryEntryArgsForCall(0)
+				Expect(token).To(Equal(testToken))
+				Expect(hash).To(Equal(txHash))
+			})
+		})
+
+		When("the transaction is not in the history", func() {
+			BeforeEach(func() {
+				fakeService.DeleteUserHistoryEntryReturns(core.ErrHistoryEntryNotFound)
+			})
+
+			It("should return 404 Not Found", func() {
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		When("the hash is malformed", func() {
+			BeforeEach(func() {
+				req.SetPathValue("transactionHash", "0x123")
+			})
+
+			It("should return 400 Bad Request", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(fakeService.DeleteUserHistoryEntryCallCount()).To(Equal(0))
+			})
+		})
+
+		When("DeleteUserHistoryEntry fails", func() {
+			BeforeEach(func() {
+				fakeService.DeleteUserHistoryEntryReturns(fakeErr)
+			})
+
+			It("should return 500 Internal Server Error", func() {
+				Expect(w.Code).To(Equal(http.StatusInternalServerError))
+			})
+		})
+
+		When("no auth token is provided", func() {
+			BeforeEach(func() {
+				req.Header.Set("AUTH_TOKEN", "")
+			})
+
+			It("should return 401 Unauthorized", func() {
+				Expect(w.Code).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("HandleClearMyTransactions", func() {
+		BeforeEach(func() {
+			req = httptest.NewRequest("DELETE", "/lime/my", nil)
+			req.Header.Set("AUTH_TOKEN", testToken)
+		})
+		JustBeforeEach(func() {
+			fethHandler.HandleClearMyTransactions(w, req)
+		})
+
+		When("the history is cleared", func() {
+			BeforeEach(func() {
+				fakeService.ClearUserTransactionsHistoryReturns(3, nil)
+			})
+
+			It("should return 200 OK and the number of removed entries", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(ContainSubstring(`"deleted":3`))
+			})
+		})
+
+		When("ClearUserTransactionsHistory fails", func() {
+			BeforeEach(func() {
+				fakeService.ClearUserTransactionsHistoryReturns(0, fakeErr)
+			})
+
+			It("should return 500 Internal Server Error", func() {
+				Expect(w.Code).To(Equal(http.StatusInternalServerError))
+			})
+		})
+
+		When("no auth token is provided", func() {
+			BeforeEach(func() {
+				req.Header.Set("AUTH_TOKEN", "")
+			})
+
+			It("should return 401 Unauthorized", func() {
+				Expect(w.Code).To(Equal(http.StatusUnauthorized))
+				Expect(fakeService.ClearUserTransactionsHistoryCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("HandleGetLogs", func() {
+		var address string
+
+		BeforeEach(func() {
+			address = "0x00000000000000000000000000000000c0ffee00"
+			req = httptest.NewRequest(http.MethodGet, "/lime/logs?address="+address+"&limit=5", nil)
+		})
+
+		JustBeforeEach(func() {
+			fethHandler.HandleGetLogs(w, req)
+		})
+
+		When("the logs are found", func() {
+			BeforeEach(func() {
+				fakeService.GetLogsReturns([]core.LogRecord{
+					{TransactionHash: "0xlog", Address: address},
+				}, nil)
+			})
+
+			It("should return 200 OK and the logs", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(ContainSubstring("0xlog"))
+				_, filter := fakeService.GetLogsArgsForCall(0)
+				Expect(filter).To(Equal(core.LogFilter{Address: address, Limit: 5}))
+			})
+		})
+
+		When("neither address nor topic0 is given", func() {
+			BeforeEach(func() {
+				req = httptest.NewRequest(http.MethodGet, "/lime/logs", nil)
+			})
+
+			It("should return 400 Bad Request", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(fakeService.GetLogsCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the limit is not a number", func() {
+			BeforeEach(func() {
+				req = httptest.NewRequest(http.MethodGet, "/lime/logs?address="+address+"&limit=many", nil)
+			})
+
+			It("should return 400 Bad Request", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("the lookup fails", func() {
+			BeforeEach(func() {
+				fakeService.GetLogsReturns(nil, fakeErr)
+			})
+
+			It("should return 500 Internal Server Error", func() {
+				Expect(w.Code).To(Equal(http.StatusInternalServerError))
+				Expect(w.Body.String()).To(ContainSubstring(fakeErr.Error()))
+			})
+		})
+	})
+
+	Describe("HandleGetTransfers", func() {
+		var holder string
+
+		BeforeEach(func() {
+			holder = "0x00000000000000000000000000000000000b0b00"
+			req = httptest.NewRequest(http.MethodGet, "/lime/transfers?holder="+holder+"&limit=5", nil)
+		})
+
+		JustBeforeEach(func() {
+			fethHandler.HandleGetTransfers(w, req)
+		})
+
+		When("the transfers are found", func() {
+			BeforeEach(func() {
+				fakeService.GetTokenTransfersReturns([]core.TransferRecord{
+					{TransactionHash: "0xtransfer", To: holder},
+				}, nil)
+			})
+
+			It("should return 200 OK and the transfers", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(ContainSubstring("0xtransfer"))
+				_, filter := fakeService.GetTokenTransfersArgsForCall(0)
+				Expect(filter).To(Equal(core.TransferFilter{Holder: holder, Limit: 5}))
+			})
+		})
+
+		When("neither token nor holder is given", func() {
+			BeforeEach(func() {
+				req = httptest.NewRequest(http.MethodGet, "/lime/transfers", nil)
+			})
+
+			It("should return 400 Bad Request", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(fakeService.GetTokenTransfersCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the token is not an address", func() {
+			BeforeEach(func() {
+				req = httptest.NewRequest(http.MethodGet, "/lime/transfers?token=usdc", nil)
+			})
+
+			It("should return 400 Bad Request", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("the lookup fails", func() {
+			BeforeEach(func() {
+				fakeService.GetTokenTransfersReturns(nil, fakeErr)
+			})
+
+			It("should return 500 Internal Server Error", func() {
+				Expect(w.Code).To(Equal(http.StatusInternalServerError))
+				Expect(w.Body.String()).To(ContainSubstring(fakeErr.Error()))
+			})
+		})
+	})
+
+	Describe("HandleGetAddressTransactions", func() {
+		var address string
+
+		BeforeEach(func() {
+			address = "0x00000000000000000000000000000000000b0b00"
+			req = httptest.NewRequest(http.MethodGet, "/lime/addresses/"+address+"/transactions?direction=in&fromBlock=10&toBlock=20&cursor=abc&limit=5", nil)
+			req.SetPathValue("address", address)
+		})
+
+		JustBeforeEach(func() {
+			fethHandler.HandleGetAddressTransactions(w, req)
+		})
+
+		When("the transactions are found", func() {
+			BeforeEach(func() {
+				fakeService.GetAddressTransactionsReturns(core.TransactionPage{
+					Transactions: []core.TransactionRecord{{TransactionHash: "0xaddresstx"}},
+					NextCursor:   "next",
+				}, nil)
+			})
+
+			It("should return 200 OK and the page", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(ContainSubstring("0xaddresstx"))
+				Expect(w.Body.String()).To(ContainSubstring(`"nextCursor":"next"`))
+
+				_, filter := fakeService.GetAddressTransactionsArgsForCall(0)
+				from, to := uint64(10), uint64(20)
+				Expect(filter).To(Equal(core.AddressFilter{
+					Address:   address,
+					Direction: core.DirectionIn,
+					FromBlock: &from,
+					ToBlock:   &to,
+					Cursor:    "abc",
+					Limit:     5,
+				}))
+			})
+		})
+
+		When("the direction is unknown", func() {
+			BeforeEach(func() {
+				req = httptest.NewRequest(http.MethodGet, "/lime/addresses/"+address+"/transactions?direction=sideways", nil)
+				req.SetPathValue("address", address)
+			})
+
+			It("should return 400 Bad Request", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(fakeService.GetAddressTransactionsCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the block range is reversed", func() {
+			BeforeEach(func() {
+				req = httptest.NewRequest(http.MethodGet, "/lime/addresses/"+address+"/transactions?fromBlock=20&toBlock=10", nil)
+				req.SetPathValue("address", address)
+			})
+
+			It("should return 400 Bad Request", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("the cursor is invalid", func() {
+			BeforeEach(func() {
+				fakeService.GetAddressTransactionsReturns(core.TransactionPage{}, core.ErrInvalidCursor)
+			})
+
+			It("should return 400 Bad Request", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("the lookup fails", func() {
+			BeforeEach(func() {
+				fakeService.GetAddressTransactionsReturns(core.TransactionPage{}, fakeErr)
+			})
+
+			It("should return 500 Internal Server Error", func() {
+				Expect(w.Code).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+
+	Describe("HandleGetBlock", func() {
+		BeforeEach(func() {
+			req = httptest.NewRequest(http.MethodGet, "/lime/blocks/100", nil)
+			req.SetPathValue("numberOrHash", "100")
+		})
+
+		JustBeforeEach(func() {
+			fethHandler.HandleGetBlock(w, req)
+		})
+
+		When("the block is found", func() {
+			BeforeEach(func() {
+				fakeService.GetBlockReturns(core.BlockRecord{Number: 100, Hash: "0xblock"}, nil)
+			})
+
+			It("should return 200 OK and the block", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(ContainSubstring("0xblock"))
+				_, numberOrHash := fakeService.GetBlockArgsForCall(0)
+				Expect(numberOrHash).To(Equal("100"))
+			})
+		})
+
+		When("a chain is selected", func() {
+			BeforeEach(func() {
+				req = httptest.NewRequest(http.MethodGet, "/lime/blocks/100?chain=base", nil)
+				req.SetPathValue("numberOrHash", "100")
+			})
+
+			It("should serve the request from that chain", func() {
+				Expect(fakeServices.GetArgsForCall(0)).To(Equal("base"))
+			})
+		})
+
+		When("the chain is not configured", func() {
+			BeforeEach(func() {
+				fakeServices.GetReturns(nil, fmt.Errorf("%w: %q", chain.ErrUnknownChain, "polygon"))
+			})
+
+			It("should return 400 Bad Request", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(ContainSubstring("unknown chain"))
+				Expect(fakeService.GetBlockCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the block id is neither a number nor a hash", func() {
+			BeforeEach(func() {
+				req.SetPathValue("numberOrHash", "latest")
+			})
+
+			It("should return 400 Bad Request", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(fakeService.GetBlockCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the block does not exist", func() {
+			BeforeEach(func() {
+				fakeService.GetBlockReturns(core.BlockRecord{}, core.ErrBlockNotFound)
+			})
+
+			It("should return 404 Not Found", func() {
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		When("the lookup fails", func() {
+			BeforeEach(func() {
+				fakeService.GetBlockReturns(core.BlockRecord{}, fakeErr)
+			})
+
+			It("should return 500 Internal Server Error", func() {
+				Expect(w.Code).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+
+	Describe("HandleGetTransactionTrace", func() {
+		var hash string
+
+		BeforeEach(func() {
+			hash = "0x00000000000000000000000000000000000000000000000000000000000000aa"
+			req = httptest.NewRequest(http.MethodGet, "/lime/eth/"+hash+"/trace", nil)
+			req.SetPathValue("transactionHash", hash)
+		})
+
+		JustBeforeEach(func() {
+			fethHandler.HandleGetTransactionTrace(w, req)
+		})
+
+		When("the transaction is traced", func() {
+			BeforeEach(func() {
+				fakeService.GetCallTraceReturns(core.TraceRecord{
+					TransactionHash:   hash,
+					Calls:             []core.CallRecord{{TraceAddress: []int{}, Type: "CALL", Value: "0"}},
+					InternalTransfers: []core.InternalTransfer{},
+					InternalValue:     "0",
+				}, nil)
+			})
+
+			It("should return 200 OK and the trace", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(ContainSubstring(`"InternalValue":"0"`))
+				_, tracedHash := fakeService.GetCallTraceArgsForCall(0)
+				Expect(tracedHash).To(Equal(hash))
+			})
+		})
+
+		When("the hash is malformed", func() {
+			BeforeEach(func() {
+				req.SetPathValue("transactionHash", "0x1234")
+			})
+
+			It("should return 400 Bad Request", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(fakeService.GetCallTraceCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the node does not serve call traces", func() {
+			BeforeEach(func() {
+				fakeService.GetCallTraceReturns(core.TraceRecord{}, core.ErrTracingUnsupported)
+			})
+
+			It("should return 501 Not Implemented", func() {
+				Expect(w.Code).To(Equal(http.StatusNotImplemented))
+				Expect(w.Body.String()).To(ContainSubstring("call tracing is not supported"))
+			})
+		})
+
+		When("the transaction does not exist", func() {
+			BeforeEach(func() {
+				fakeService.GetCallTraceReturns(core.TraceRecord{}, core.ErrTransactionNotFound)
+			})
+
+			It("should return 404 Not Found", func() {
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		When("tracing fails", func() {
+			BeforeEach(func() {
+				fakeService.GetCallTraceReturns(core.TraceRecord{}, fakeErr)
+			})
+
+			It("should return 500 Internal Server Error", func() {
+				Expect(w.Code).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+
+	Describe("HandleGetChains", func() {
+		It("should return 200 OK and the configured chains", func() {
+			fakeServices.ChainsReturns([]chain.Chain{
+				{ID: 1, Name: "mainnet", Default: true},
+				{ID: 8453, Name: "base"},
+			})
+			req = httptest.NewRequest(http.MethodGet, "/lime/chains", nil)
+			fethHandler.HandleGetChains(w, req)
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Body.String()).To(ContainSubstring(`{"id":1,"name":"mainnet","default":true}`))
+			Expect(w.Body.String()).To(ContainSubstring(`{"id":8453,"name":"base","default":false}`))
+		})
+	})
+
+	Describe("HandlePutContractABI behind the admin auth", func() {
+		var adminAuth *middleware.AdminAuth
+
+		BeforeEach(func() {
+			adminAuth = middleware.NewAdminAuthMiddleware(fakeLogger, "admin-token")
+			address := "0x00000000000000000000000000000000c0ffee00"
+			req = httptest.NewRequest(http.MethodPut, "/lime/abis/"+address, strings.NewReader(`[]`))
+			req.SetPathValue("address", address)
+		})
+
+		JustBeforeEach(func() {
+			adminAuth.AdminAuth(http.HandlerFunc(fethHandler.HandlePutContractABI)).ServeHTTP(w, req)
+		})
+
+		When("the upload carries no credentials", func() {
+			It("should return 401 Unauthorized without saving the abi", func() {
+				Expect(w.Code).To(Equal(http.StatusUnauthorized))
+				Expect(fakeService.SaveContractABICallCount()).To(Equal(0))
+			})
+		})
+
+		When("the upload carries the admin token", func() {
+			BeforeEach(func() {
+				req.Header.Set("ADMIN_TOKEN", "admin-token")
+			})
+
+			It("should save the abi", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(fakeService.SaveContractABICallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Describe("HandlePutContractABI", func() {
+		var address string
+
+		BeforeEach(func() {
+			address = "0x00000000000000000000000000000000c0ffee00"
+			req = httptest.NewRequest(http.MethodPut, "/lime/abis/"+address, strings.NewReader(`[]`))
+			req.SetPathValue("address", address)
+		})
+
+		JustBeforeEach(func() {
+			fethHandler.HandlePutContractABI(w, req)
+		})
+
+		When("the abi is saved", func() {
+			It("should return 200 OK", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				_, savedAddress, abiJSON := fakeService.SaveContractABIArgsForCall(0)
+				Expect(savedAddress).To(Equal(address))
+				Expect(abiJSON).To(Equal(`[]`))
+			})
+		})
+
+		When("the address is invalid", func() {
+			BeforeEach(func() {
+				req.SetPathValue("address", "0xc0ffee")
+			})
+
+			It("should return 400 Bad Request", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(fakeService.SaveContractABICallCount()).To(Equal(0))
+			})
+		})
+
+		When("the body is not JSON", func() {
+			BeforeEach(func() {
+				req = httptest.NewRequest(http.MethodPut, "/lime/abis/"+address, strings.NewReader(`not-json`))
+				req.SetPathValue("address", address)
+			})
+
+			It("should return 400 Bad Request", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(fakeService.SaveContractABICallCount()).To(Equal(0))
+			})
+		})
+
+		When("the abi cannot be parsed", func() {
+			BeforeEach(func() {
+				fakeService.SaveContractABIReturns(fmt.Errorf("register abi: %w", decoder.ErrInvalidABI))
+			})
+
+			It("should return 400 Bad Request", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("saving fails", func() {
+			BeforeEach(func() {
+				fakeService.SaveContractABIReturns(fakeErr)
+			})
+
+			It("should return 500 Internal Server Error", func() {
+				Expect(w.Code).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
 })
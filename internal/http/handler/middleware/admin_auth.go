@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+
+	"go.uber.org/zap"
+)
+
+type AdminAuth struct {
+	logs  *zap.SugaredLogger
+	token []byte
+}
+
+func NewAdminAuthMiddleware(logger *zap.SugaredLogger, token string) *AdminAuth {
+	return &AdminAuth{
+		logs:  logger,
+		token: []byte(token),
+	}
+}
+
+// AdminAuth only lets requests through whose ADMIN_TOKEN header matches the configured admin token.
+func (a *AdminAuth) AdminAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := []byte(r.Header.Get("ADMIN_TOKEN"))
+		if len(a.token) == 0 || subtle.ConstantTimeCompare(token, a.token) != 1 {
+			var requestId string = "None"
+
+			req := r.Context().Value(RequestIDKey)
+			if req != nil {
+				requestId = req.(string)
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid or missing ADMIN_TOKEN header"})
+
+			a.logs.Errorw("unauthorized admin request",
+				"remote_addr", r.RemoteAddr,
+				"request_id", requestId)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -2,6 +2,9 @@ package handler
 
 const oopsErr = "Oops! Something went wrong. Please try again later."
 
+// maxABISize limits the size of an uploaded contract ABI.
+const maxABISize = 1 << 20
+
 type Response struct {
 	Message string      `json:"message,omitempty"`
 	Data    interface{} `json:"data,omitempty"`
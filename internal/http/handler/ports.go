@@ -2,7 +2,12 @@ package handler
 
 import (
 	"context"
+	"fethcher/internal/cache"
+	"fethcher/internal/chain"
 	"fethcher/internal/core"
+	"fethcher/internal/ethereum"
+	"fethcher/internal/indexer"
+	"fethcher/internal/queue"
 	"net/http"
 )
 
@@ -16,9 +21,61 @@ type RequestValidator interface {
 //counterfeiter:generate -o fake -fake-name TransactionService . TransactionService
 type TransactionService interface {
 	Authenticate(ctx context.Context, msg core.AuthMessage) (string, error)
-	GetTransactions(ctx context.Context, transactionsHashes []string) ([]core.TransactionRecord, error)
+	GetTransactions(ctx context.Context, transactionsHashes []string, include core.IncludeOptions) ([]core.TransactionResult, error)
 	SaveUserTransactionsHistory(ctx context.Context, token string, transactionsHashes []string) error
-	GetUserTransactionsHistory(ctx context.Context, token string) ([]core.TransactionRecord, error)
-	GetAllDBTransactions(ctx context.Context) ([]core.TransactionRecord, error)
+	GetUserTransactionsHistory(ctx context.Context, token string, filter core.HistoryFilter) (core.HistoryPage, error)
+	DeleteUserHistoryEntry(ctx context.Context, token string, transactionHash string) error
+	ClearUserTransactionsHistory(ctx context.Context, token string) (int64, error)
+	GetAllDBTransactions(ctx context.Context, filter core.TransactionFilter) (core.TransactionPage, error)
 	ParseRLP(rlphex string) ([]string, error)
+	GetLogs(ctx context.Context, filter core.LogFilter) ([]core.LogRecord, error)
+	GetTokenTransfers(ctx context.Context, filter core.TransferFilter) ([]core.TransferRecord, error)
+	GetAddressTransactions(ctx context.Context, filter core.AddressFilter) (core.TransactionPage, error)
+	GetBlock(ctx context.Context, numberOrHash string) (core.BlockRecord, error)
+	GetCallTrace(ctx context.Context, transactionHash string) (core.TraceRecord, error)
+	SaveContractABI(ctx context.Context, address string, abiJSON string) error
+}
+
+// TransactionServices selects the TransactionService of a chain by its ID or name, or of the default chain when none is given.
+//
+//counterfeiter:generate -o fake -fake-name TransactionServices . TransactionServices
+type TransactionServices interface {
+	Get(chain string) (TransactionService, error)
+	Chains() []chain.Chain
+}
+
+//counterfeiter:generate -o fake -fake-name Indexer . Indexer
+type Indexer interface {
+	Start(ctx context.Context) error
+	Pause() error
+	Status() indexer.Status
+}
+
+// Indexers selects the Indexer of a chain by its ID or name, or of the default chain when none is given.
+//
+//counterfeiter:generate -o fake -fake-name Indexers . Indexers
+type Indexers interface {
+	Get(chain string) (Indexer, error)
+}
+
+//counterfeiter:generate -o fake -fake-name NodePool . NodePool
+type NodePool interface {
+	Status() []ethereum.NodeStatus
+}
+
+// NodePools selects the NodePool of a chain by its ID or name, or of the default chain when none is given.
+//
+//counterfeiter:generate -o fake -fake-name NodePools . NodePools
+type NodePools interface {
+	Get(chain string) (NodePool, error)
+}
+
+//counterfeiter:generate -o fake -fake-name WriteQueue . WriteQueue
+type WriteQueue interface {
+	Stats() queue.Stats
+}
+
+//counterfeiter:generate -o fake -fake-name TransactionCache . TransactionCache
+type TransactionCache interface {
+	Stats() cache.Stats
 }
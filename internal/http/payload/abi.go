@@ -0,0 +1,31 @@
+package payload
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/jellydator/validation"
+)
+
+type ABIRequest struct {
+	Address string
+	ABI     string
+}
+
+func (a ABIRequest) Validate() error {
+	err := validation.ValidateStruct(&a,
+		validation.Field(&a.Address, validation.Required, validation.Match(regexp.MustCompile(`^0x[a-fA-F0-9]{40}$`))),
+		validation.Field(&a.ABI, validation.Required, validation.By(func(value any) error {
+			if !json.Valid([]byte(value.(string))) {
+				return errors.New("must be valid JSON")
+			}
+			return nil
+		})),
+	)
+	if err != nil {
+		return fmt.Errorf("validate struct: %w", err)
+	}
+	return nil
+}
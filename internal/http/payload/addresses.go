@@ -0,0 +1,58 @@
+package payload
+
+import (
+	"errors"
+	"fethcher/internal/core"
+	"fmt"
+	"regexp"
+
+	"github.com/jellydator/validation"
+)
+
+const (
+	defaultAddressTransactionsLimit = 100
+	maxAddressTransactionsLimit     = 1000
+)
+
+type AddressTransactionsRequest struct {
+	Address   string
+	Direction string
+	FromBlock *uint64
+	ToBlock   *uint64
+	Cursor    string
+	Limit     int
+}
+
+func (a AddressTransactionsRequest) Validate() error {
+	err := validation.ValidateStruct(&a,
+		validation.Field(&a.Address, validation.Required, validation.Match(regexp.MustCompile(`^0x[a-fA-F0-9]{40}$`))),
+		validation.Field(&a.Direction, validation.In(core.DirectionAll, core.DirectionIn, core.DirectionOut)),
+		validation.Field(&a.ToBlock, validation.By(func(value any) error {
+			if a.FromBlock != nil && a.ToBlock != nil && *a.ToBlock < *a.FromBlock {
+				return errors.New("must not be lower than fromBlock")
+			}
+			return nil
+		})),
+		validation.Field(&a.Limit, validation.Min(0), validation.Max(maxAddressTransactionsLimit)),
+	)
+	if err != nil {
+		return fmt.Errorf("validate struct: %w", err)
+	}
+	return nil
+}
+
+func (a AddressTransactionsRequest) ToFilter() core.AddressFilter {
+	limit := a.Limit
+	if limit == 0 {
+		limit = defaultAddressTransactionsLimit
+	}
+
+	return core.AddressFilter{
+		Address:   a.Address,
+		Direction: a.Direction,
+		FromBlock: a.FromBlock,
+		ToBlock:   a.ToBlock,
+		Cursor:    a.Cursor,
+		Limit:     limit,
+	}
+}
@@ -0,0 +1,82 @@
+package payload
+
+import (
+	"errors"
+	"fethcher/internal/core"
+	"fmt"
+	"regexp"
+
+	"github.com/jellydator/validation"
+)
+
+// Sort orders accepted by the transaction listing.
+const (
+	SortAsc  = "asc"
+	SortDesc = "desc"
+)
+
+const (
+	defaultAllTransactionsLimit = 100
+	maxAllTransactionsLimit     = 1000
+)
+
+type AllTransactionsRequest struct {
+	Status           *uint64
+	FromBlock        *uint64
+	ToBlock          *uint64
+	From             string
+	To               string
+	ContractCreation *bool
+	MinValue         string
+	Sort             string
+	Cursor           string
+	Limit            int
+}
+
+func (a AllTransactionsRequest) Validate() error {
+	addressRegex := regexp.MustCompile(`^0x[a-fA-F0-9]{40}$`)
+
+	err := validation.ValidateStruct(&a,
+		validation.Field(&a.Status, validation.By(func(value any) error {
+			if a.Status != nil && *a.Status > 1 {
+				return errors.New("must be 0 or 1")
+			}
+			return nil
+		})),
+		validation.Field(&a.ToBlock, validation.By(func(value any) error {
+			if a.FromBlock != nil && a.ToBlock != nil && *a.ToBlock < *a.FromBlock {
+				return errors.New("must not be lower than fromBlock")
+			}
+			return nil
+		})),
+		validation.Field(&a.From, validation.Match(addressRegex)),
+		validation.Field(&a.To, validation.Match(addressRegex)),
+		validation.Field(&a.MinValue, validation.Length(0, 78), validation.Match(regexp.MustCompile(`^[0-9]+$`))),
+		validation.Field(&a.Sort, validation.In(SortAsc, SortDesc)),
+		validation.Field(&a.Limit, validation.Min(0), validation.Max(maxAllTransactionsLimit)),
+	)
+	if err != nil {
+		return fmt.Errorf("validate struct: %w", err)
+	}
+	return nil
+}
+
+func (a AllTransactionsRequest) ToFilter() core.TransactionFilter {
+	limit := a.Limit
+	if limit == 0 {
+		limit = defaultAllTransactionsLimit
+	}
+
+	return core.TransactionFilter{
+		Status:           a.Status,
+		FromBlock:        a.FromBlock,
+		ToBlock:          a.ToBlock,
+		From:             a.From,
+		To:               a.To,
+		ContractCreation: a.ContractCreation,
+		MinValue:         a.MinValue,
+		Ascending:        a.Sort == SortAsc,
+		Cursor:           a.Cursor,
+		Limit:            limit,
+	}
+}
@@ -0,0 +1,22 @@
+package payload
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/jellydator/validation"
+)
+
+type BlockRequest struct {
+	NumberOrHash string
+}
+
+func (b BlockRequest) Validate() error {
+	err := validation.ValidateStruct(&b,
+		validation.Field(&b.NumberOrHash, validation.Required, validation.Match(regexp.MustCompile(`^([0-9]{1,20}|0x[a-fA-F0-9]{64})$`))),
+	)
+	if err != nil {
+		return fmt.Errorf("validate struct: %w", err)
+	}
+	return nil
+}
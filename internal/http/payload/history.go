@@ -0,0 +1,67 @@
+package payload
+
+import (
+	"errors"
+	"fethcher/internal/core"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/jellydator/validation"
+)
+
+const (
+	defaultHistoryLimit = 100
+	maxHistoryLimit     = 1000
+)
+
+type HistoryRequest struct {
+	Since  *time.Time
+	Until  *time.Time
+	Cursor string
+	Limit  int
+}
+
+func (h HistoryRequest) Validate() error {
+	err := validation.ValidateStruct(&h,
+		validation.Field(&h.Until, validation.By(func(value any) error {
+			if h.Since != nil && h.Until != nil && h.Until.Before(*h.Since) {
+				return errors.New("must not be before since")
+			}
+			return nil
+		})),
+		validation.Field(&h.Limit, validation.Min(0), validation.Max(maxHistoryLimit)),
+	)
+	if err != nil {
+		return fmt.Errorf("validate struct: %w", err)
+	}
+	return nil
+}
+
+func (h HistoryRequest) ToFilter() core.HistoryFilter {
+	limit := h.Limit
+	if limit == 0 {
+		limit = defaultHistoryLimit
+	}
+
+	return core.HistoryFilter{
+		QueriedFrom: h.Since,
+		QueriedTo:   h.Until,
+		Cursor:      h.Cursor,
+		Limit:       limit,
+	}
+}
+
+type HistoryEntryRequest struct {
+	TransactionHash string
+}
+
+func (h HistoryEntryRequest) Validate() error {
+	err := validation.ValidateStruct(&h,
+		validation.Field(&h.TransactionHash, validation.Required, validation.Match(regexp.MustCompile(`^0x[a-fA-F0-9]{64}$`))),
+	)
+	if err != nil {
+		return fmt.Errorf("validate struct: %w", err)
+	}
+	return nil
+}
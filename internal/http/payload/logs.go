@@ -0,0 +1,47 @@
+package payload
+
+import (
+	"fethcher/internal/core"
+	"fmt"
+	"regexp"
+
+	"github.com/jellydator/validation"
+)
+
+const (
+	defaultLogsLimit = 100
+	maxLogsLimit     = 1000
+)
+
+type LogsRequest struct {
+	Address string
+	Topic0  string
+	Limit   int
+}
+
+func (l LogsRequest) Validate() error {
+	err := validation.ValidateStruct(&l,
+		validation.Field(&l.Address,
+			validation.Required.When(l.Topic0 == "").Error("address or topic0 is required"),
+			validation.Match(regexp.MustCompile(`^0x[a-fA-F0-9]{40}$`))),
+		validation.Field(&l.Topic0, validation.Match(regexp.MustCompile(`^0x[a-fA-F0-9]{64}$`))),
+		validation.Field(&l.Limit, validation.Min(0), validation.Max(maxLogsLimit)),
+	)
+	if err != nil {
+		return fmt.Errorf("validate struct: %w", err)
+	}
+	return nil
+}
+
+func (l LogsRequest) ToFilter() core.LogFilter {
+	limit := l.Limit
+	if limit == 0 {
+		limit = defaultLogsLimit
+	}
+
+	return core.LogFilter{
+		Address: l.Address,
+		Topic0:  l.Topic0,
+		Limit:   limit,
+	}
+}
@@ -0,0 +1,22 @@
+package payload
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/jellydator/validation"
+)
+
+type TraceRequest struct {
+	TransactionHash string
+}
+
+func (t TraceRequest) Validate() error {
+	err := validation.ValidateStruct(&t,
+		validation.Field(&t.TransactionHash, validation.Required, validation.Match(regexp.MustCompile(`^0x[a-fA-F0-9]{64}$`))),
+	)
+	if err != nil {
+		return fmt.Errorf("validate struct: %w", err)
+	}
+	return nil
+}
@@ -1,14 +1,22 @@
 package payload
 
 import (
+	"fethcher/internal/core"
 	"fmt"
 	"regexp"
 
 	"github.com/jellydator/validation"
 )
 
+// Include values accepted by the transaction endpoints.
+const (
+	IncludeLogs      = "logs"
+	IncludeTransfers = "transfers"
+)
+
 type TransactionsRequest struct {
 	Transactions []string
+	Include      []string
 }
 
 func (t TransactionsRequest) Validate() error {
@@ -20,9 +28,23 @@ func (t TransactionsRequest) Validate() error {
 	err = validation.ValidateStruct(&t,
 		validation.Field(&t.Transactions, validation.Required),
 		validation.Field(&t.Transactions, validation.Each(validation.Match(regex))),
+		validation.Field(&t.Include, validation.Each(validation.In(IncludeLogs, IncludeTransfers))),
 	)
 	if err != nil {
 		return fmt.Errorf("validate struct: %w", err)
 	}
 	return nil
 }
+
+func (t TransactionsRequest) ToIncludeOptions() core.IncludeOptions {
+	var include core.IncludeOptions
+	for _, section := range t.Include {
+		switch section {
+		case IncludeLogs:
+			include.Logs = true
+		case IncludeTransfers:
+			include.Transfers = true
+		}
+	}
+	return include
+}
@@ -0,0 +1,49 @@
+package payload
+
+import (
+	"fethcher/internal/core"
+	"fmt"
+	"regexp"
+
+	"github.com/jellydator/validation"
+)
+
+const (
+	defaultTransfersLimit = 100
+	maxTransfersLimit     = 1000
+)
+
+type TransfersRequest struct {
+	Token  string
+	Holder string
+	Limit  int
+}
+
+func (t TransfersRequest) Validate() error {
+	addressRegex := regexp.MustCompile(`^0x[a-fA-F0-9]{40}$`)
+
+	err := validation.ValidateStruct(&t,
+		validation.Field(&t.Token,
+			validation.Required.When(t.Holder == "").Error("token or holder is required"),
+			validation.Match(addressRegex)),
+		validation.Field(&t.Holder, validation.Match(addressRegex)),
+		validation.Field(&t.Limit, validation.Min(0), validation.Max(maxTransfersLimit)),
+	)
+	if err != nil {
+		return fmt.Errorf("validate struct: %w", err)
+	}
+	return nil
+}
+
+func (t TransfersRequest) ToFilter() core.TransferFilter {
+	limit := t.Limit
+	if limit == 0 {
+		limit = defaultTransfersLimit
+	}
+
+	return core.TransferFilter{
+		Token:  t.Token,
+		Holder: t.Holder,
+		Limit:  limit,
+	}
+}
@@ -40,10 +40,11 @@ func (s *HTTPServer) Run() <-chan error {
 	return errChan
 }
 
-func (s *HTTPServer) Shutdown() error {
+// Shutdown stops accepting requests and waits for the ones in flight until ctx is done.
+func (s *HTTPServer) Shutdown(ctx context.Context) error {
 	s.logs.Info("shutting down server...")
 
-	if err := s.server.Shutdown(context.Background()); err != nil {
+	if err := s.server.Shutdown(ctx); err != nil {
 		s.logs.Error(
 			"server shutdown failed",
 			"error", err,
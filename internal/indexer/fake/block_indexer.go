@@ -0,0 +1,197 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"fethcher/internal/indexer"
+	"sync"
+)
+
+type BlockIndexer struct {
+	FinalizedBlockNumberStub        func(context.Context) (uint64, error)
+	finalizedBlockNumberMutex       sync.RWMutex
+	finalizedBlockNumberArgsForCall []struct {
+		arg1 context.Context
+	}
+	finalizedBlockNumberReturns struct {
+		result1 uint64
+		result2 error
+	}
+	finalizedBlockNumberReturnsOnCall map[int]struct {
+		result1 uint64
+		result2 error
+	}
+	IndexBlockStub        func(context.Context, uint64) (int, error)
+	indexBlockMutex       sync.RWMutex
+	indexBlockArgsForCall []struct {
+		arg1 context.Context
+		arg2 uint64
+	}
+	indexBlockReturns struct {
+		result1 int
+		result2 error
+	}
+	indexBlockReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *BlockIndexer) FinalizedBlockNumber(arg1 context.Context) (uint64, error) {
+	fake.finalizedBlockNumberMutex.Lock()
+	ret, specificReturn := fake.finalizedBlockNumberReturnsOnCall[len(fake.finalizedBlockNumberArgsForCall)]
+	fake.finalizedBlockNumberArgsForCall = append(fake.finalizedBlockNumberArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.FinalizedBlockNumberStub
+	fakeReturns := fake.finalizedBlockNumberReturns
+	fake.recordInvocation("FinalizedBlockNumber", []interface{}{arg1})
+	fake.finalizedBlockNumberMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *BlockIndexer) FinalizedBlockNumberCallCount() int {
+	fake.finalizedBlockNumberMutex.RLock()
+	defer fake.finalizedBlockNumberMutex.RUnlock()
+	return len(fake.finalizedBlockNumberArgsForCall)
+}
+
+func (fake *BlockIndexer) FinalizedBlockNumberCalls(stub func(context.Context) (uint64, error)) {
+	fake.finalizedBlockNumberMutex.Lock()
+	defer fake.finalizedBlockNumberMutex.Unlock()
+	fake.FinalizedBlockNumberStub = stub
+}
+
+func (fake *BlockIndexer) FinalizedBlockNumberArgsForCall(i int) context.Context {
+	fake.finalizedBlockNumberMutex.RLock()
+	defer fake.finalizedBlockNumberMutex.RUnlock()
+	argsForCall := fake.finalizedBlockNumberArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *BlockIndexer) FinalizedBlockNumberReturns(result1 uint64, result2 error) {
+	fake.finalizedBlockNumberMutex.Lock()
+	defer fake.finalizedBlockNumberMutex.Unlock()
+	fake.FinalizedBlockNumberStub = nil
+	fake.finalizedBlockNumberReturns = struct {
+		result1 uint64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BlockIndexer) FinalizedBlockNumberReturnsOnCall(i int, result1 uint64, result2 error) {
+	fake.finalizedBlockNumberMutex.Lock()
+	defer fake.finalizedBlockNumberMutex.Unlock()
+	fake.FinalizedBlockNumberStub = nil
+	if fake.finalizedBlockNumberReturnsOnCall == nil {
+		fake.finalizedBlockNumberReturnsOnCall = make(map[int]struct {
+			result1 uint64
+			result2 error
+		})
+	}
+	fake.finalizedBlockNumberReturnsOnCall[i] = struct {
+		result1 uint64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BlockIndexer) IndexBlock(arg1 context.Context, arg2 uint64) (int, error) {
+	fake.indexBlockMutex.Lock()
+	ret, specificReturn := fake.indexBlockReturnsOnCall[len(fake.indexBlockArgsForCall)]
+	fake.indexBlockArgsForCall = append(fake.indexBlockArgsForCall, struct {
+		arg1 context.Context
+		arg2 uint64
+	}{arg1, arg2})
+	stub := fake.IndexBlockStub
+	fakeReturns := fake.indexBlockReturns
+	fake.recordInvocation("IndexBlock", []interface{}{arg1, arg2})
+	fake.indexBlockMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *BlockIndexer) IndexBlockCallCount() int {
+	fake.indexBlockMutex.RLock()
+	defer fake.indexBlockMutex.RUnlock()
+	return len(fake.indexBlockArgsForCall)
+}
+
+func (fake *BlockIndexer) IndexBlockCalls(stub func(context.Context, uint64) (int, error)) {
+	fake.indexBlockMutex.Lock()
+	defer fake.indexBlockMutex.Unlock()
+	fake.IndexBlockStub = stub
+}
+
+func (fake *BlockIndexer) IndexBlockArgsForCall(i int) (context.Context, uint64) {
+	fake.indexBlockMutex.RLock()
+	defer fake.indexBlockMutex.RUnlock()
+	argsForCall := fake.indexBlockArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *BlockIndexer) IndexBlockReturns(result1 int, result2 error) {
+	fake.indexBlockMutex.Lock()
+	defer fake.indexBlockMutex.Unlock()
+	fake.IndexBlockStub = nil
+	fake.indexBlockReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BlockIndexer) IndexBlockReturnsOnCall(i int, result1 int, result2 error) {
+	fake.indexBlockMutex.Lock()
+	defer fake.indexBlockMutex.Unlock()
+	fake.IndexBlockStub = nil
+	if fake.indexBlockReturnsOnCall == nil {
+		fake.indexBlockReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.indexBlockReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BlockIndexer) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.finalizedBlockNumberMutex.RLock()
+	defer fake.finalizedBlockNumberMutex.RUnlock()
+	fake.indexBlockMutex.RLock()
+	defer fake.indexBlockMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *BlockIndexer) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ indexer.BlockIndexer = new(BlockIndexer)
@@ -0,0 +1,195 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"fethcher/internal/indexer"
+	"fethcher/internal/repository"
+	"sync"
+)
+
+type CheckpointStore struct {
+	GetIndexerCheckpointStub        func(context.Context, string) (repository.IndexerCheckpoint, error)
+	getIndexerCheckpointMutex       sync.RWMutex
+	getIndexerCheckpointArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getIndexerCheckpointReturns struct {
+		result1 repository.IndexerCheckpoint
+		result2 error
+	}
+	getIndexerCheckpointReturnsOnCall map[int]struct {
+		result1 repository.IndexerCheckpoint
+		result2 error
+	}
+	SaveIndexerCheckpointStub        func(context.Context, repository.IndexerCheckpoint) error
+	saveIndexerCheckpointMutex       sync.RWMutex
+	saveIndexerCheckpointArgsForCall []struct {
+		arg1 context.Context
+		arg2 repository.IndexerCheckpoint
+	}
+	saveIndexerCheckpointReturns struct {
+		result1 error
+	}
+	saveIndexerCheckpointReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *CheckpointStore) GetIndexerCheckpoint(arg1 context.Context, arg2 string) (repository.IndexerCheckpoint, error) {
+	fake.getIndexerCheckpointMutex.Lock()
+	ret, specificReturn := fake.getIndexerCheckpointReturnsOnCall[len(fake.getIndexerCheckpointArgsForCall)]
+	fake.getIndexerCheckpointArgsForCall = append(fake.getIndexerCheckpointArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetIndexerCheckpointStub
+	fakeReturns := fake.getIndexerCheckpointReturns
+	fake.recordInvocation("GetIndexerCheckpoint", []interface{}{arg1, arg2})
+	fake.getIndexerCheckpointMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CheckpointStore) GetIndexerCheckpointCallCount() int {
+	fake.getIndexerCheckpointMutex.RLock()
+	defer fake.getIndexerCheckpointMutex.RUnlock()
+	return len(fake.getIndexerCheckpointArgsForCall)
+}
+
+func (fake *CheckpointStore) GetIndexerCheckpointCalls(stub func(context.Context, string) (repository.IndexerCheckpoint, error)) {
+	fake.getIndexerCheckpointMutex.Lock()
+	defer fake.getIndexerCheckpointMutex.Unlock()
+	fake.GetIndexerCheckpointStub = stub
+}
+
+func (fake *CheckpointStore) GetIndexerCheckpointArgsForCall(i int) (context.Context, string) {
+	fake.getIndexerCheckpointMutex.RLock()
+	defer fake.getIndexerCheckpointMutex.RUnlock()
+	argsForCall := fake.getIndexerCheckpointArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *CheckpointStore) GetIndexerCheckpointReturns(result1 repository.IndexerCheckpoint, result2 error) {
+	fake.getIndexerCheckpointMutex.Lock()
+	defer fake.getIndexerCheckpointMutex.Unlock()
+	fake.GetIndexerCheckpointStub = nil
+	fake.getIndexerCheckpointReturns = struct {
+		result1 repository.IndexerCheckpoint
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CheckpointStore) GetIndexerCheckpointReturnsOnCall(i int, result1 repository.IndexerCheckpoint, result2 error) {
+	fake.getIndexerCheckpointMutex.Lock()
+	defer fake.getIndexerCheckpointMutex.Unlock()
+	fake.GetIndexerCheckpointStub = nil
+	if fake.getIndexerCheckpointReturnsOnCall == nil {
+		fake.getIndexerCheckpointReturnsOnCall = make(map[int]struct {
+			result1 repository.IndexerCheckpoint
+			result2 error
+		})
+	}
+	fake.getIndexerCheckpointReturnsOnCall[i] = struct {
+		result1 repository.IndexerCheckpoint
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CheckpointStore) SaveIndexerCheckpoint(arg1 context.Context, arg2 repository.IndexerCheckpoint) error {
+	fake.saveIndexerCheckpointMutex.Lock()
+	ret, specificReturn := fake.saveIndexerCheckpointReturnsOnCall[len(fake.saveIndexerCheckpointArgsForCall)]
+	fake.saveIndexerCheckpointArgsForCall = append(fake.saveIndexerCheckpointArgsForCall, struct {
+		arg1 context.Context
+		arg2 repository.IndexerCheckpoint
+	}{arg1, arg2})
+	stub := fake.SaveIndexerCheckpointStub
+	fakeReturns := fake.saveIndexerCheckpointReturns
+	fake.recordInvocation("SaveIndexerCheckpoint", []interface{}{arg1, arg2})
+	fake.saveIndexerCheckpointMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *CheckpointStore) SaveIndexerCheckpointCallCount() int {
+	fake.saveIndexerCheckpointMutex.RLock()
+	defer fake.saveIndexerCheckpointMutex.RUnlock()
+	return len(fake.saveIndexerCheckpointArgsForCall)
+}
+
+func (fake *CheckpointStore) SaveIndexerCheckpointCalls(stub func(context.Context, repository.IndexerCheckpoint) error) {
+	fake.saveIndexerCheckpointMutex.Lock()
+	defer fake.saveIndexerCheckpointMutex.Unlock()
+	fake.SaveIndexerCheckpointStub = stub
+}
+
+func (fake *CheckpointStore) SaveIndexerCheckpointArgsForCall(i int) (context.Context, repository.IndexerCheckpoint) {
+	fake.saveIndexerCheckpointMutex.RLock()
+	defer fake.saveIndexerCheckpointMutex.RUnlock()
+	argsForCall := fake.saveIndexerCheckpointArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *CheckpointStore) SaveIndexerCheckpointReturns(result1 error) {
+	fake.saveIndexerCheckpointMutex.Lock()
+	defer fake.saveIndexerCheckpointMutex.Unlock()
+	fake.SaveIndexerCheckpointStub = nil
+	fake.saveIndexerCheckpointReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CheckpointStore) SaveIndexerCheckpointReturnsOnCall(i int, result1 error) {
+	fake.saveIndexerCheckpointMutex.Lock()
+	defer fake.saveIndexerCheckpointMutex.Unlock()
+	fake.SaveIndexerCheckpointStub = nil
+	if fake.saveIndexerCheckpointReturnsOnCall == nil {
+		fake.saveIndexerCheckpointReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveIndexerCheckpointReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CheckpointStore) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getIndexerCheckpointMutex.RLock()
+	defer fake.getIndexerCheckpointMutex.RUnlock()
+	fake.saveIndexerCheckpointMutex.RLock()
+	defer fake.saveIndexerCheckpointMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *CheckpointStore) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ indexer.CheckpointStore = new(CheckpointStore)
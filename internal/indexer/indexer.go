@@ -0,0 +1,257 @@
+// Package indexer walks a range of blocks in the background and caches every transaction in it, persisting a checkpoint after
+// every block so that it resumes where it stopped after a pause or a restart.
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fethcher/internal/repository"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var (
+	ErrRunning    = errors.New("indexer is already running")
+	ErrNotRunning = errors.New("indexer is not running")
+)
+
+// checkpointName identifies the checkpoint of the indexer.
+const checkpointName = "blocks"
+
+// Indexer caches the transactions of the blocks from its first to its last block, or of every final block from its first one
+// on when it follows the chain head. Blocks are indexed one at a time; a block that fails is retried after the poll interval,
+// which is also how long the indexer waits for new final blocks once it caught up with the chain head.
+type Indexer struct {
+	logs         *zap.SugaredLogger
+	blocks       BlockIndexer
+	checkpoints  CheckpointStore
+	from         uint64
+	to           uint64
+	pollInterval time.Duration
+
+	mu                  sync.Mutex
+	state               State
+	stop                context.CancelFunc
+	done                chan struct{}
+	next                uint64
+	head                uint64
+	indexedBlocks       uint64
+	indexedTransactions uint64
+	runStarted          time.Time
+	runStopped          time.Time
+	runBlocks           uint64
+	lastError           string
+}
+
+// NewIndexer is a constructor function for the Indexer type. A to block of 0 makes the indexer follow the chain head.
+func NewIndexer(logger *zap.SugaredLogger, blocks BlockIndexer, checkpoints CheckpointStore, from uint64, to uint64, pollInterval time.Duration) *Indexer {
+	return &Indexer{
+		logs:         logger,
+		blocks:       blocks,
+		checkpoints:  checkpoints,
+		from:         from,
+		to:           to,
+		pollInterval: pollInterval,
+		state:        StateIdle,
+		next:         from,
+	}
+}
+
+// Start loads the checkpoint and indexes the remaining blocks in the background until Pause is called or, unless the indexer
+// follows the chain head, the last block is indexed. A checkpoint of another block range is discarded.
+func (i *Indexer) Start(ctx context.Context) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.state == StateRunning {
+		return ErrRunning
+	}
+
+	next, err := i.loadCheckpoint(ctx)
+	if err != nil {
+		return err
+	}
+
+	runCtx, stop := context.WithCancel(context.Background())
+	i.state = StateRunning
+	i.stop = stop
+	i.done = make(chan struct{})
+	i.next = next
+	i.runStarted = time.Now()
+	i.runBlocks = 0
+	i.lastError = ""
+
+	i.logs.Infow("indexer started", "from_block", i.from, "to_block", i.to, "next_block", next)
+
+	go i.run(runCtx, i.done)
+	return nil
+}
+
+// Pause stops the indexer once the block that is being indexed is done. The checkpoint is kept, so Start resumes from there.
+func (i *Indexer) Pause() error {
+	i.mu.Lock()
+	if i.state != StateRunning {
+		i.mu.Unlock()
+		return ErrNotRunning
+	}
+	stop, done := i.stop, i.done
+	i.mu.Unlock()
+
+	stop()
+	<-done
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.state == StateRunning {
+		i.state = StatePaused
+	}
+
+	i.logs.Infow("indexer paused", "next_block", i.next)
+	return nil
+}
+
+// Status returns the progress of the indexer.
+func (i *Indexer) Status() Status {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	status := Status{
+		State:               i.state,
+		FromBlock:           i.from,
+		ToBlock:             i.to,
+		NextBlock:           i.next,
+		Head:                i.head,
+		IndexedBlocks:       i.indexedBlocks,
+		IndexedTransactions: i.indexedTransactions,
+		LastError:           i.lastError,
+	}
+
+	if !i.runStarted.IsZero() {
+		startedAt := i.runStarted
+		status.StartedAt = &startedAt
+
+		end := time.Now()
+		if i.state != StateRunning {
+			end = i.runStopped
+		}
+		if elapsed := end.Sub(i.runStarted).Seconds(); elapsed > 0 {
+			status.BlocksPerSecond = float64(i.runBlocks) / elapsed
+		}
+	}
+
+	return status
+}
+
+func (i *Indexer) loadCheckpoint(ctx context.Context) (uint64, error) {
+	checkpoint, err := i.checkpoints.GetIndexerCheckpoint(ctx, checkpointName)
+	if err != nil {
+		if errors.Is(err, repository.ErrCheckpointNotFound) {
+			return i.from, nil
+		}
+		return 0, fmt.Errorf("get checkpoint: %w", err)
+	}
+
+	if checkpoint.FromBlock != i.from || checkpoint.ToBlock != i.to {
+		i.logs.Infow("block range changed, discarding checkpoint",
+			"checkpoint_from_block", checkpoint.FromBlock,
+			"checkpoint_to_block", checkpoint.ToBlock,
+			"from_block", i.from,
+			"to_block", i.to)
+		return i.from, nil
+	}
+	return checkpoint.NextBlock, nil
+}
+
+func (i *Indexer) run(ctx context.Context, done chan struct{}) {
+	defer close(done)
+	defer func() {
+		i.mu.Lock()
+		i.runStopped = time.Now()
+		i.mu.Unlock()
+	}()
+
+	for ctx.Err() == nil {
+		i.mu.Lock()
+		next, head := i.next, i.head
+		i.mu.Unlock()
+
+		if i.to != 0 && next > i.to {
+			i.mu.Lock()
+			i.state = StateCompleted
+			i.runStopped = time.Now()
+			i.mu.Unlock()
+			i.logs.Infow("indexer completed", "from_block", i.from, "to_block", i.to)
+			return
+		}
+
+		if next > head {
+			var err error
+			head, err = i.blocks.FinalizedBlockNumber(ctx)
+			if err != nil {
+				i.fail(ctx, fmt.Errorf("get finalized block number: %w", err))
+				continue
+			}
+
+			i.mu.Lock()
+			i.head = head
+			i.mu.Unlock()
+
+			if next > head {
+				i.wait(ctx)
+				continue
+			}
+		}
+
+		indexed, err := i.blocks.IndexBlock(ctx, next)
+		if err != nil {
+			i.fail(ctx, fmt.Errorf("index block %d: %w", next, err))
+			continue
+		}
+
+		i.mu.Lock()
+		i.next = next + 1
+		i.indexedBlocks++
+		i.indexedTransactions += uint64(indexed)
+		i.runBlocks++
+		i.lastError = ""
+		i.mu.Unlock()
+
+		err = i.checkpoints.SaveIndexerCheckpoint(ctx, repository.IndexerCheckpoint{
+			Name:      checkpointName,
+			FromBlock: i.from,
+			ToBlock:   i.to,
+			NextBlock: next + 1,
+		})
+		if err != nil {
+			i.logs.Errorw("failed to save indexer checkpoint", "error", err, "next_block", next+1)
+		}
+	}
+}
+
+// fail records the error and waits for the poll interval before the indexer retries. Errors caused by pausing the indexer are
+// ignored.
+func (i *Indexer) fail(ctx context.Context, err error) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	i.mu.Lock()
+	i.lastError = err.Error()
+	i.mu.Unlock()
+
+	i.logs.Errorw("indexer failed, retrying", "error", err, "retry_in", i.pollInterval)
+	i.wait(ctx)
+}
+
+func (i *Indexer) wait(ctx context.Context) {
+	timer := time.NewTimer(i.pollInterval)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
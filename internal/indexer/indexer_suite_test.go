@@ -0,0 +1,13 @@
+package indexer_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestIndexer(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Indexer Suite")
+}
@@ -0,0 +1,168 @@
+package indexer_test
+
+import (
+	"context"
+	"errors"
+	"fethcher/internal/indexer"
+	"fethcher/internal/indexer/fake"
+	"fethcher/internal/repository"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+)
+
+var _ = Describe("Indexer", func() {
+	var (
+		fakeBlocks      *fake.BlockIndexer
+		fakeCheckpoints *fake.CheckpointStore
+		ctx             context.Context
+		from, to        uint64
+		idx             *indexer.Indexer
+		fakeErr         error
+	)
+
+	BeforeEach(func() {
+		fakeBlocks = new(fake.BlockIndexer)
+		fakeCheckpoints = new(fake.CheckpointStore)
+		ctx = context.Background()
+		fakeErr = errors.New("fake error")
+		from, to = 100, 104
+
+		fakeCheckpoints.GetIndexerCheckpointReturns(repository.IndexerCheckpoint{}, repository.ErrCheckpointNotFound)
+		fakeBlocks.FinalizedBlockNumberReturns(1000, nil)
+		fakeBlocks.IndexBlockReturns(2, nil)
+	})
+
+	JustBeforeEach(func() {
+		idx = indexer.NewIndexer(zap.NewNop().Sugar(), fakeBlocks, fakeCheckpoints, from, to, 10*time.Millisecond)
+	})
+
+	AfterEach(func() {
+		_ = idx.Pause()
+	})
+
+	indexedBlocks := func() []uint64 {
+		numbers := make([]uint64, 0, fakeBlocks.IndexBlockCallCount())
+		for i := range fakeBlocks.IndexBlockCallCount() {
+			_, number := fakeBlocks.IndexBlockArgsForCall(i)
+			numbers = append(numbers, number)
+		}
+		return numbers
+	}
+
+	It("starts idle", func() {
+		Expect(idx.Status().State).To(Equal(indexer.StateIdle))
+		Expect(idx.Status().NextBlock).To(Equal(from))
+	})
+
+	When("the range is indexed", func() {
+		JustBeforeEach(func() {
+			Expect(idx.Start(ctx)).To(Succeed())
+		})
+
+		It("indexes every block of the range in order and completes", func() {
+			Eventually(func() indexer.State { return idx.Status().State }).Should(Equal(indexer.StateCompleted))
+			Expect(indexedBlocks()).To(Equal([]uint64{100, 101, 102, 103, 104}))
+
+			status := idx.Status()
+			Expect(status.NextBlock).To(Equal(uint64(105)))
+			Expect(status.IndexedBlocks).To(Equal(uint64(5)))
+			Expect(status.IndexedTransactions).To(Equal(uint64(10)))
+			Expect(status.BlocksPerSecond).To(BeNumerically(">", 0))
+		})
+
+		It("saves a checkpoint after every block", func() {
+			Eventually(fakeCheckpoints.SaveIndexerCheckpointCallCount).Should(Equal(5))
+			_, checkpoint := fakeCheckpoints.SaveIndexerCheckpointArgsForCall(4)
+			Expect(checkpoint).To(Equal(repository.IndexerCheckpoint{Name: "blocks", FromBlock: 100, ToBlock: 104, NextBlock: 105}))
+		})
+	})
+
+	When("a checkpoint of the same range exists", func() {
+		BeforeEach(func() {
+			fakeCheckpoints.GetIndexerCheckpointReturns(repository.IndexerCheckpoint{FromBlock: 100, ToBlock: 104, NextBlock: 103}, nil)
+		})
+
+		It("resumes from the checkpoint", func() {
+			Expect(idx.Start(ctx)).To(Succeed())
+			Eventually(func() indexer.State { return idx.Status().State }).Should(Equal(indexer.StateCompleted))
+			Expect(indexedBlocks()).To(Equal([]uint64{103, 104}))
+		})
+	})
+
+	When("the checkpoint belongs to another range", func() {
+		BeforeEach(func() {
+			fakeCheckpoints.GetIndexerCheckpointReturns(repository.IndexerCheckpoint{FromBlock: 50, ToBlock: 104, NextBlock: 103}, nil)
+		})
+
+		It("starts over from the first block", func() {
+			Expect(idx.Start(ctx)).To(Succeed())
+			Eventually(func() indexer.State { return idx.Status().State }).Should(Equal(indexer.StateCompleted))
+			Expect(indexedBlocks()).To(HaveLen(5))
+		})
+	})
+
+	When("the checkpoint cannot be loaded", func() {
+		BeforeEach(func() {
+			fakeCheckpoints.GetIndexerCheckpointReturns(repository.IndexerCheckpoint{}, fakeErr)
+		})
+
+		It("does not start", func() {
+			Expect(idx.Start(ctx)).To(MatchError(fakeErr))
+			Expect(idx.Status().State).To(Equal(indexer.StateIdle))
+		})
+	})
+
+	When("a block fails", func() {
+		BeforeEach(func() {
+			fakeBlocks.IndexBlockReturnsOnCall(1, 0, fakeErr)
+		})
+
+		It("retries the block", func() {
+			Expect(idx.Start(ctx)).To(Succeed())
+			Eventually(func() indexer.State { return idx.Status().State }).Should(Equal(indexer.StateCompleted))
+			Expect(indexedBlocks()).To(Equal([]uint64{100, 101, 101, 102, 103, 104}))
+			Expect(idx.Status().LastError).To(BeEmpty())
+		})
+	})
+
+	When("the indexer follows the chain head", func() {
+		BeforeEach(func() {
+			to = 0
+			fakeBlocks.FinalizedBlockNumberReturns(102, nil)
+		})
+
+		It("stops at the finalized head and waits for new blocks", func() {
+			Expect(idx.Start(ctx)).To(Succeed())
+			Eventually(fakeBlocks.IndexBlockCallCount).Should(Equal(3))
+			Consistently(fakeBlocks.IndexBlockCallCount, 50*time.Millisecond).Should(Equal(3))
+			Expect(idx.Status().State).To(Equal(indexer.StateRunning))
+			Expect(idx.Status().Head).To(Equal(uint64(102)))
+
+			fakeBlocks.FinalizedBlockNumberReturns(103, nil)
+			Eventually(fakeBlocks.IndexBlockCallCount).Should(Equal(4))
+		})
+
+		It("cannot be started twice", func() {
+			Expect(idx.Start(ctx)).To(Succeed())
+			Expect(idx.Start(ctx)).To(MatchError(indexer.ErrRunning))
+		})
+
+		It("can be paused and resumed", func() {
+			Expect(idx.Start(ctx)).To(Succeed())
+			Eventually(fakeBlocks.IndexBlockCallCount).Should(Equal(3))
+
+			Expect(idx.Pause()).To(Succeed())
+			Expect(idx.Status().State).To(Equal(indexer.StatePaused))
+			Expect(idx.Pause()).To(MatchError(indexer.ErrNotRunning))
+
+			fakeCheckpoints.GetIndexerCheckpointReturns(repository.IndexerCheckpoint{FromBlock: 100, NextBlock: 103}, nil)
+			fakeBlocks.FinalizedBlockNumberReturns(103, nil)
+			Expect(idx.Start(ctx)).To(Succeed())
+			Eventually(fakeBlocks.IndexBlockCallCount).Should(Equal(4))
+			Expect(indexedBlocks()[3]).To(Equal(uint64(103)))
+		})
+	})
+})
@@ -0,0 +1,28 @@
+package indexer
+
+import "time"
+
+// State is the lifecycle state of the indexer.
+type State string
+
+const (
+	StateIdle      State = "idle"
+	StateRunning   State = "running"
+	StatePaused    State = "paused"
+	StateCompleted State = "completed"
+)
+
+// Status is a snapshot of the progress of the indexer. ToBlock is 0 when the indexer follows the chain head, Head is the latest
+// block it may index. BlocksPerSecond is measured over the current run, or the last one when the indexer is not running.
+type Status struct {
+	State               State      `json:"state"`
+	FromBlock           uint64     `json:"fromBlock"`
+	ToBlock             uint64     `json:"toBlock"`
+	NextBlock           uint64     `json:"nextBlock"`
+	Head                uint64     `json:"head"`
+	IndexedBlocks       uint64     `json:"indexedBlocks"`
+	IndexedTransactions uint64     `json:"indexedTransactions"`
+	BlocksPerSecond     float64    `json:"blocksPerSecond"`
+	StartedAt           *time.Time `json:"startedAt,omitempty"`
+	LastError           string     `json:"lastError,omitempty"`
+}
@@ -0,0 +1,20 @@
+package indexer
+
+import (
+	"context"
+	"fethcher/internal/repository"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+//counterfeiter:generate -o fake -fake-name BlockIndexer . BlockIndexer
+type BlockIndexer interface {
+	IndexBlock(ctx context.Context, number uint64) (int, error)
+	FinalizedBlockNumber(ctx context.Context) (uint64, error)
+}
+
+//counterfeiter:generate -o fake -fake-name CheckpointStore . CheckpointStore
+type CheckpointStore interface {
+	GetIndexerCheckpoint(ctx context.Context, name string) (repository.IndexerCheckpoint, error)
+	SaveIndexerCheckpoint(ctx context.Context, checkpoint repository.IndexerCheckpoint) error
+}
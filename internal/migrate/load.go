@@ -0,0 +1,67 @@
+package migrate
+
+import (
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// fileName matches the names of migration files, e.g. 0001_initial_schema.up.sql.
+var fileName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Load reads the migrations in the root of source, ordered by version. Every version needs both an up and a down file with the
+// same name; other files are ignored.
+func Load(source fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(source, ".")
+	if err != nil {
+		return nil, fmt.Errorf("read migrations: %w", err)
+	}
+
+	byVersion := make(map[uint]*Migration)
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseUint(match[1], 10, 32)
+		if err != nil || version == 0 {
+			return nil, fmt.Errorf("migration %s: invalid version", entry.Name())
+		}
+
+		content, err := fs.ReadFile(source, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("read migration %s: %w", entry.Name(), err)
+		}
+
+		migration, ok := byVersion[uint(version)]
+		if !ok {
+			migration = &Migration{Version: uint(version), Name: match[2]}
+			byVersion[uint(version)] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d: named both %q and %q", version, migration.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %d: needs both an up and a down file", migration.Version)
+		}
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
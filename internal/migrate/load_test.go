@@ -0,0 +1,42 @@
+package migrate_test
+
+import (
+	"testing/fstest"
+
+	"fethcher/internal/migrate"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Load", func() {
+	It("should pair the up and down files and order the migrations by version", func() {
+		migrations, err := migrate.Load(fstest.MapFS{
+			"0002_add_column.up.sql":     {Data: []byte("ALTER TABLE a ADD COLUMN b int")},
+			"0002_add_column.down.sql":   {Data: []byte("ALTER TABLE a DROP COLUMN b")},
+			"0001_create_table.up.sql":   {Data: []byte("CREATE TABLE a (id int)")},
+			"0001_create_table.down.sql": {Data: []byte("DROP TABLE a")},
+			"README.md":                  {Data: []byte("not a migration")},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(migrations).To(Equal([]migrate.Migration{
+			{Version: 1, Name: "create_table", Up: "CREATE TABLE a (id int)", Down: "DROP TABLE a"},
+			{Version: 2, Name: "add_column", Up: "ALTER TABLE a ADD COLUMN b int", Down: "ALTER TABLE a DROP COLUMN b"},
+		}))
+	})
+
+	It("should reject a migration without a down file", func() {
+		_, err := migrate.Load(fstest.MapFS{
+			"0001_create_table.up.sql": {Data: []byte("CREATE TABLE a (id int)")},
+		})
+		Expect(err).To(MatchError(ContainSubstring("needs both an up and a down file")))
+	})
+
+	It("should reject a version with two names", func() {
+		_, err := migrate.Load(fstest.MapFS{
+			"0001_create_table.up.sql": {Data: []byte("CREATE TABLE a (id int)")},
+			"0001_other_name.down.sql": {Data: []byte("DROP TABLE a")},
+		})
+		Expect(err).To(MatchError(ContainSubstring("named both")))
+	})
+})
@@ -0,0 +1,221 @@
+// Package migrate applies and rolls back the versioned SQL migrations of the database schema. The migrations are embedded SQL
+// files kept per database dialect and the applied ones are recorded in the schema_migrations table.
+package migrate
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrUnknownVersion = errors.New("unknown migration version")
+	ErrNothingApplied = errors.New("no migration is applied")
+)
+
+//go:embed migrations
+var migrationFiles embed.FS
+
+// createTable creates the table that records the applied migrations.
+const createTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version    bigint PRIMARY KEY,
+    name       text NOT NULL,
+    applied_at timestamp NOT NULL
+)`
+
+// Migrator migrates the database schema. Every migration runs in its own database transaction together with the update of
+// the schema_migrations table, so a failed migration leaves the schema at the previous version.
+type Migrator struct {
+	logs       *zap.SugaredLogger
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// NewMigrator is a constructor function for the Migrator type. It loads the embedded migrations of the dialect of db.
+func NewMigrator(logger *zap.SugaredLogger, db *gorm.DB) (*Migrator, error) {
+	source, err := fs.Sub(migrationFiles, path.Join("migrations", db.Dialector.Name()))
+	if err != nil {
+		return nil, fmt.Errorf("open %s migrations: %w", db.Dialector.Name(), err)
+	}
+
+	migrations, err := Load(source)
+	if err != nil {
+		return nil, fmt.Errorf("load %s migrations: %w", db.Dialector.Name(), err)
+	}
+
+	return NewMigratorWith(logger, db, migrations), nil
+}
+
+// NewMigratorWith is a constructor function for a Migrator that applies the given migrations, which must be ordered by version.
+func NewMigratorWith(logger *zap.SugaredLogger, db *gorm.DB, migrations []Migration) *Migrator {
+	return &Migrator{
+		logs:       logger,
+		db:         db,
+		migrations: migrations,
+	}
+}
+
+// Status returns the status of every migration, ordered by version.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{Version: migration.Version, Name: migration.Name}
+		if row, ok := applied[migration.Version]; ok {
+			appliedAt := row.AppliedAt
+			status.Applied, status.AppliedAt = true, &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// Pending returns the migrations that are not applied yet, ordered by version.
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := make([]Migration, 0)
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; !ok {
+			pending = append(pending, migration)
+		}
+	}
+	return pending, nil
+}
+
+// Up applies every pending migration and returns how many were applied.
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	if len(m.migrations) == 0 {
+		return 0, nil
+	}
+	return m.To(ctx, m.migrations[len(m.migrations)-1].Version)
+}
+
+// Down rolls back the most recently applied migration.
+func (m *Migrator) Down(ctx context.Context) error {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		if _, ok := applied[m.migrations[i].Version]; ok {
+			return m.rollback(ctx, m.migrations[i])
+		}
+	}
+	return ErrNothingApplied
+}
+
+// To applies or rolls back migrations until exactly the migrations up to and including version are applied and returns how
+// many migrations were applied or rolled back. A version of 0 rolls back every migration.
+func (m *Migrator) To(ctx context.Context, version uint) (int, error) {
+	if version != 0 && !m.known(version) {
+		return 0, fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+	}
+
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for appliedVersion := range applied {
+		if !m.known(appliedVersion) {
+			return 0, fmt.Errorf("%w: %d is applied to the database but not known to this build", ErrUnknownVersion, appliedVersion)
+		}
+	}
+
+	changed := 0
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		migration := m.migrations[i]
+		if _, ok := applied[migration.Version]; ok && migration.Version > version {
+			if err := m.rollback(ctx, migration); err != nil {
+				return changed, err
+			}
+			changed++
+		}
+	}
+
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; !ok && migration.Version <= version {
+			if err := m.apply(ctx, migration); err != nil {
+				return changed, err
+			}
+			changed++
+		}
+	}
+	return changed, nil
+}
+
+func (m *Migrator) apply(ctx context.Context, migration Migration) error {
+	err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(migration.Up).Error; err != nil {
+			return err
+		}
+		return tx.Create(&schemaMigration{
+			Version:   migration.Version,
+			Name:      migration.Name,
+			AppliedAt: time.Now().UTC(),
+		}).Error
+	})
+	if err != nil {
+		return fmt.Errorf("apply migration %d_%s: %w", migration.Version, migration.Name, err)
+	}
+
+	m.logs.Infow("migration applied", "version", migration.Version, "name", migration.Name)
+	return nil
+}
+
+func (m *Migrator) rollback(ctx context.Context, migration Migration) error {
+	err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(migration.Down).Error; err != nil {
+			return err
+		}
+		return tx.Where("version = ?", migration.Version).Delete(&schemaMigration{}).Error
+	})
+	if err != nil {
+		return fmt.Errorf("roll back migration %d_%s: %w", migration.Version, migration.Name, err)
+	}
+
+	m.logs.Infow("migration rolled back", "version", migration.Version, "name", migration.Name)
+	return nil
+}
+
+// applied creates the schema_migrations table when it is missing and returns its rows by version.
+func (m *Migrator) applied(ctx context.Context) (map[uint]schemaMigration, error) {
+	if err := m.db.WithContext(ctx).Exec(createTable).Error; err != nil {
+		return nil, fmt.Errorf("create schema_migrations table: %w", err)
+	}
+
+	var rows []schemaMigration
+	if err := m.db.WithContext(ctx).Order("version").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("get applied migrations: %w", err)
+	}
+
+	applied := make(map[uint]schemaMigration, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = row
+	}
+	return applied, nil
+}
+
+func (m *Migrator) known(version uint) bool {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,165 @@
+package migrate_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"time"
+
+	"fethcher/internal/migrate"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("Migrator", func() {
+	var (
+		mock       sqlmock.Sqlmock
+		mockDb     *sql.DB
+		gormDB     *gorm.DB
+		migrator   *migrate.Migrator
+		ctx        context.Context
+		migrations []migrate.Migration
+	)
+
+	expectApplied := func(versions ...uint) {
+		mock.ExpectExec(`^CREATE TABLE IF NOT EXISTS schema_migrations`).WillReturnResult(sqlmock.NewResult(0, 0))
+		rows := sqlmock.NewRows([]string{"version", "name", "applied_at"})
+		for _, version := range versions {
+			rows.AddRow(version, migrations[version-1].Name, time.Now())
+		}
+		mock.ExpectQuery(`^SELECT \* FROM "schema_migrations" ORDER BY version$`).WillReturnRows(rows)
+	}
+
+	BeforeEach(func() {
+		var err error
+		mockDb, mock, err = sqlmock.New()
+		Expect(err).NotTo(HaveOccurred())
+
+		gormDB, err = gorm.Open(postgres.New(postgres.Config{Conn: mockDb, DriverName: "postgres"}), &gorm.Config{})
+		Expect(err).NotTo(HaveOccurred())
+
+		ctx = context.Background()
+		migrations = []migrate.Migration{
+			{Version: 1, Name: "create_table", Up: "CREATE TABLE a (id int)", Down: "DROP TABLE a"},
+			{Version: 2, Name: "add_column", Up: "ALTER TABLE a ADD COLUMN b int", Down: "ALTER TABLE a DROP COLUMN b"},
+		}
+		migrator = migrate.NewMigratorWith(zap.NewNop().Sugar(), gormDB, migrations)
+	})
+
+	AfterEach(func() {
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+		mock.ExpectClose()
+		Expect(mockDb.Close()).To(Succeed())
+	})
+
+	Describe("NewMigrator", func() {
+		It("should load the embedded migrations of the dialect", func() {
+			migrator, err := migrate.NewMigrator(zap.NewNop().Sugar(), gormDB)
+			Expect(err).NotTo(HaveOccurred())
+
+			expectApplied()
+			statuses, err := migrator.Status(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(statuses).NotTo(BeEmpty())
+			Expect(statuses[0]).To(Equal(migrate.Status{Version: 1, Name: "initial_schema"}))
+		})
+	})
+
+	Describe("Status", func() {
+		It("should tell which migrations are applied", func() {
+			expectApplied(1)
+
+			statuses, err := migrator.Status(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(statuses).To(HaveLen(2))
+			Expect(statuses[0].Applied).To(BeTrue())
+			Expect(statuses[0].AppliedAt).NotTo(BeNil())
+			Expect(statuses[1].Applied).To(BeFalse())
+		})
+	})
+
+	Describe("Up", func() {
+		It("should apply the pending migrations in order, each in its own transaction", func() {
+			expectApplied(1)
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE a ADD COLUMN b int")).WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(`^INSERT INTO "schema_migrations"`).
+				WithArgs(2, "add_column", sqlmock.AnyArg()).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+
+			applied, err := migrator.Up(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(applied).To(Equal(1))
+		})
+
+		It("should roll back a migration that fails", func() {
+			expectApplied()
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE a (id int)")).WillReturnError(errors.New("syntax error"))
+			mock.ExpectRollback()
+
+			applied, err := migrator.Up(ctx)
+			Expect(err).To(MatchError(ContainSubstring("apply migration 1_create_table: syntax error")))
+			Expect(applied).To(BeZero())
+		})
+
+		It("should refuse a database migrated by a newer build", func() {
+			migrations = append(migrations, migrate.Migration{Version: 3, Name: "newer"})
+			expectApplied(1, 2, 3)
+			migrator = migrate.NewMigratorWith(zap.NewNop().Sugar(), gormDB, migrations[:2])
+
+			_, err := migrator.Up(ctx)
+			Expect(err).To(MatchError(migrate.ErrUnknownVersion))
+		})
+	})
+
+	Describe("Down", func() {
+		It("should roll back the most recently applied migration", func() {
+			expectApplied(1, 2)
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE a DROP COLUMN b")).WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(`^DELETE FROM "schema_migrations" WHERE version = \$1$`).
+				WithArgs(2).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+
+			Expect(migrator.Down(ctx)).To(Succeed())
+		})
+
+		It("should fail when no migration is applied", func() {
+			expectApplied()
+
+			Expect(migrator.Down(ctx)).To(MatchError(migrate.ErrNothingApplied))
+		})
+	})
+
+	Describe("To", func() {
+		It("should roll back the migrations above the version", func() {
+			expectApplied(1, 2)
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE a DROP COLUMN b")).WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(`^DELETE FROM "schema_migrations"`).WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta("DROP TABLE a")).WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(`^DELETE FROM "schema_migrations"`).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+
+			changed, err := migrator.To(ctx, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(Equal(2))
+		})
+
+		It("should reject an unknown version", func() {
+			_, err := migrator.To(ctx, 7)
+			Expect(err).To(MatchError(migrate.ErrUnknownVersion))
+		})
+	})
+})
@@ -0,0 +1,123 @@
+-- The schema as it was created by gorm's AutoMigrate. Every statement is idempotent, so that databases migrated before versioned
+-- migrations were introduced are adopted as they are.
+
+CREATE TABLE IF NOT EXISTS transactions (
+    transaction_hash         varchar(66) NOT NULL,
+    transaction_status       bigint NOT NULL,
+    block_hash               varchar(66) NOT NULL,
+    block_number             bigint NOT NULL,
+    block_timestamp          bigint,
+    "from"                   varchar(42) NOT NULL,
+    "to"                     varchar(42),
+    contract_address         varchar(42),
+    logs_count               bigint NOT NULL DEFAULT 0,
+    input                    text NOT NULL,
+    value                    varchar(100) NOT NULL,
+    verified                 boolean NOT NULL DEFAULT false,
+    confirmations            bigint NOT NULL DEFAULT 0,
+    tentative                boolean NOT NULL DEFAULT false,
+    tx_type                  smallint,
+    nonce                    bigint,
+    gas_limit                bigint,
+    gas_used                 bigint,
+    effective_gas_price      varchar(78),
+    gas_price                varchar(78),
+    max_fee_per_gas          varchar(78),
+    max_priority_fee_per_gas varchar(78),
+    access_list              text,
+    blob_versioned_hashes    text,
+    max_fee_per_blob_gas     varchar(78),
+    blob_gas_used            bigint,
+    blob_gas_price           varchar(78),
+    authorization_list       text
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_transactions_transaction_hash ON transactions (transaction_hash);
+CREATE INDEX IF NOT EXISTS idx_tx_block_hash ON transactions (block_number, transaction_hash);
+CREATE INDEX IF NOT EXISTS idx_tx_from_block ON transactions ("from", block_number, transaction_hash);
+CREATE INDEX IF NOT EXISTS idx_tx_to_block ON transactions ("to", block_number, transaction_hash);
+CREATE INDEX IF NOT EXISTS idx_tx_contract_block ON transactions (contract_address, block_number, transaction_hash);
+CREATE INDEX IF NOT EXISTS idx_transactions_tentative ON transactions (tentative);
+CREATE INDEX IF NOT EXISTS idx_transactions_tx_type ON transactions (tx_type);
+
+CREATE TABLE IF NOT EXISTS transaction_logs (
+    transaction_hash varchar(66) NOT NULL,
+    log_index        bigint NOT NULL,
+    block_number     bigint NOT NULL,
+    address          varchar(42) NOT NULL,
+    topic0           varchar(66),
+    topic1           varchar(66),
+    topic2           varchar(66),
+    topic3           varchar(66),
+    data             text NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tx_log ON transaction_logs (transaction_hash, log_index);
+CREATE INDEX IF NOT EXISTS idx_log_address_topic0 ON transaction_logs (address, topic0);
+CREATE INDEX IF NOT EXISTS idx_transaction_logs_topic0 ON transaction_logs (topic0);
+
+CREATE TABLE IF NOT EXISTS token_transfers (
+    transaction_hash varchar(66) NOT NULL,
+    log_index        bigint NOT NULL,
+    batch_index      bigint NOT NULL DEFAULT 0,
+    block_number     bigint NOT NULL,
+    token            varchar(42) NOT NULL,
+    from_address     varchar(42) NOT NULL,
+    to_address       varchar(42) NOT NULL,
+    amount           varchar(78) NOT NULL,
+    token_id         varchar(78),
+    standard         varchar(7) NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tx_transfer ON token_transfers (transaction_hash, log_index, batch_index);
+CREATE INDEX IF NOT EXISTS idx_token_transfers_token ON token_transfers (token);
+CREATE INDEX IF NOT EXISTS idx_token_transfers_from_address ON token_transfers (from_address);
+CREATE INDEX IF NOT EXISTS idx_token_transfers_to_address ON token_transfers (to_address);
+
+CREATE TABLE IF NOT EXISTS blocks (
+    hash               varchar(66) PRIMARY KEY,
+    number             bigint NOT NULL,
+    parent_hash        varchar(66) NOT NULL,
+    timestamp          bigint NOT NULL,
+    miner              varchar(42) NOT NULL,
+    base_fee           varchar(78),
+    gas_used           bigint NOT NULL,
+    gas_limit          bigint NOT NULL,
+    state_root         varchar(66) NOT NULL,
+    transactions_root  varchar(66) NOT NULL,
+    receipts_root      varchar(66) NOT NULL,
+    transaction_hashes text
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_blocks_number ON blocks (number);
+
+CREATE TABLE IF NOT EXISTS contract_abis (
+    address varchar(42) PRIMARY KEY,
+    abi     text NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS indexer_checkpoints (
+    name       varchar(64) PRIMARY KEY,
+    from_block bigint NOT NULL,
+    to_block   bigint NOT NULL,
+    next_block bigint NOT NULL,
+    updated_at timestamptz
+);
+
+CREATE TABLE IF NOT EXISTS users (
+    id            text PRIMARY KEY,
+    username      varchar(255) NOT NULL,
+    password_hash text NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_username ON users (username);
+
+CREATE TABLE IF NOT EXISTS user_transactions (
+    user_id          text NOT NULL,
+    transaction_hash text NOT NULL,
+    queried_at       timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    query_count      bigint NOT NULL DEFAULT 1
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_tx ON user_transactions (user_id, transaction_hash);
+CREATE INDEX IF NOT EXISTS idx_user_queried ON user_transactions (user_id, queried_at, transaction_hash);
@@ -0,0 +1,44 @@
+-- Rolling back fails while rows of more than one chain are cached, since they collide on the single chain keys; delete the rows
+-- of the other chains first.
+
+DROP INDEX idx_user_queried;
+ALTER TABLE user_transactions DROP CONSTRAINT user_transactions_pkey;
+ALTER TABLE user_transactions DROP COLUMN chain_id;
+CREATE UNIQUE INDEX idx_user_tx ON user_transactions (user_id, transaction_hash);
+CREATE INDEX idx_user_queried ON user_transactions (user_id, queried_at, transaction_hash);
+
+ALTER TABLE indexer_checkpoints DROP CONSTRAINT indexer_checkpoints_pkey;
+ALTER TABLE indexer_checkpoints DROP COLUMN chain_id;
+ALTER TABLE indexer_checkpoints ADD PRIMARY KEY (name);
+
+ALTER TABLE contract_abis DROP CONSTRAINT contract_abis_pkey;
+ALTER TABLE contract_abis DROP COLUMN chain_id;
+ALTER TABLE contract_abis ADD PRIMARY KEY (address);
+
+DROP INDEX idx_blocks_number;
+ALTER TABLE blocks DROP CONSTRAINT blocks_pkey;
+ALTER TABLE blocks DROP COLUMN chain_id;
+ALTER TABLE blocks ADD PRIMARY KEY (hash);
+CREATE UNIQUE INDEX idx_blocks_number ON blocks (number);
+
+DROP INDEX idx_tx_transfer;
+ALTER TABLE token_transfers DROP COLUMN chain_id;
+CREATE UNIQUE INDEX idx_tx_transfer ON token_transfers (transaction_hash, log_index, batch_index);
+
+DROP INDEX idx_tx_log;
+DROP INDEX idx_log_address_topic0;
+ALTER TABLE transaction_logs DROP COLUMN chain_id;
+CREATE UNIQUE INDEX idx_tx_log ON transaction_logs (transaction_hash, log_index);
+CREATE INDEX idx_log_address_topic0 ON transaction_logs (address, topic0);
+
+DROP INDEX idx_tx_block_hash;
+DROP INDEX idx_tx_from_block;
+DROP INDEX idx_tx_to_block;
+DROP INDEX idx_tx_contract_block;
+ALTER TABLE transactions DROP CONSTRAINT transactions_pkey;
+ALTER TABLE transactions DROP COLUMN chain_id;
+CREATE UNIQUE INDEX idx_transactions_transaction_hash ON transactions (transaction_hash);
+CREATE INDEX idx_tx_block_hash ON transactions (block_number, transaction_hash);
+CREATE INDEX idx_tx_from_block ON transactions ("from", block_number, transaction_hash);
+CREATE INDEX idx_tx_to_block ON transactions ("to", block_number, transaction_hash);
+CREATE INDEX idx_tx_contract_block ON transactions (contract_address, block_number, transaction_hash);
@@ -0,0 +1,51 @@
+-- Every cached row belongs to the chain it was read from. Rows cached before chains were configured get chain 0, which the
+-- default chain claims when the server starts, since only the server knows which chain it was pointed at.
+
+ALTER TABLE transactions ADD COLUMN chain_id bigint NOT NULL DEFAULT 0;
+ALTER TABLE transactions ALTER COLUMN chain_id DROP DEFAULT;
+DROP INDEX idx_transactions_transaction_hash;
+DROP INDEX idx_tx_block_hash;
+DROP INDEX idx_tx_from_block;
+DROP INDEX idx_tx_to_block;
+DROP INDEX idx_tx_contract_block;
+ALTER TABLE transactions ADD PRIMARY KEY (chain_id, transaction_hash);
+CREATE INDEX idx_tx_block_hash ON transactions (chain_id, block_number, transaction_hash);
+CREATE INDEX idx_tx_from_block ON transactions (chain_id, "from", block_number, transaction_hash);
+CREATE INDEX idx_tx_to_block ON transactions (chain_id, "to", block_number, transaction_hash);
+CREATE INDEX idx_tx_contract_block ON transactions (chain_id, contract_address, block_number, transaction_hash);
+
+ALTER TABLE transaction_logs ADD COLUMN chain_id bigint NOT NULL DEFAULT 0;
+ALTER TABLE transaction_logs ALTER COLUMN chain_id DROP DEFAULT;
+DROP INDEX idx_tx_log;
+DROP INDEX idx_log_address_topic0;
+CREATE UNIQUE INDEX idx_tx_log ON transaction_logs (chain_id, transaction_hash, log_index);
+CREATE INDEX idx_log_address_topic0 ON transaction_logs (chain_id, address, topic0);
+
+ALTER TABLE token_transfers ADD COLUMN chain_id bigint NOT NULL DEFAULT 0;
+ALTER TABLE token_transfers ALTER COLUMN chain_id DROP DEFAULT;
+DROP INDEX idx_tx_transfer;
+CREATE UNIQUE INDEX idx_tx_transfer ON token_transfers (chain_id, transaction_hash, log_index, batch_index);
+
+ALTER TABLE blocks ADD COLUMN chain_id bigint NOT NULL DEFAULT 0;
+ALTER TABLE blocks ALTER COLUMN chain_id DROP DEFAULT;
+ALTER TABLE blocks DROP CONSTRAINT blocks_pkey;
+ALTER TABLE blocks ADD PRIMARY KEY (chain_id, hash);
+DROP INDEX idx_blocks_number;
+CREATE UNIQUE INDEX idx_blocks_number ON blocks (chain_id, number);
+
+ALTER TABLE contract_abis ADD COLUMN chain_id bigint NOT NULL DEFAULT 0;
+ALTER TABLE contract_abis ALTER COLUMN chain_id DROP DEFAULT;
+ALTER TABLE contract_abis DROP CONSTRAINT contract_abis_pkey;
+ALTER TABLE contract_abis ADD PRIMARY KEY (chain_id, address);
+
+ALTER TABLE indexer_checkpoints ADD COLUMN chain_id bigint NOT NULL DEFAULT 0;
+ALTER TABLE indexer_checkpoints ALTER COLUMN chain_id DROP DEFAULT;
+ALTER TABLE indexer_checkpoints DROP CONSTRAINT indexer_checkpoints_pkey;
+ALTER TABLE indexer_checkpoints ADD PRIMARY KEY (chain_id, name);
+
+ALTER TABLE user_transactions ADD COLUMN chain_id bigint NOT NULL DEFAULT 0;
+ALTER TABLE user_transactions ALTER COLUMN chain_id DROP DEFAULT;
+DROP INDEX idx_user_tx;
+DROP INDEX idx_user_queried;
+ALTER TABLE user_transactions ADD PRIMARY KEY (user_id, chain_id, transaction_hash);
+CREATE INDEX idx_user_queried ON user_transactions (user_id, chain_id, queried_at, transaction_hash);
@@ -0,0 +1,11 @@
+-- The L1 fee fields that OP-stack and Arbitrum nodes add to receipts and the fields that tie L2 system transactions to L1. They
+-- stay NULL on Ethereum chains.
+
+ALTER TABLE transactions ADD COLUMN l1_fee varchar(78);
+ALTER TABLE transactions ADD COLUMN l1_gas_used bigint;
+ALTER TABLE transactions ADD COLUMN l1_gas_price varchar(78);
+ALTER TABLE transactions ADD COLUMN l1_blob_base_fee varchar(78);
+ALTER TABLE transactions ADD COLUMN gas_used_for_l1 bigint;
+ALTER TABLE transactions ADD COLUMN source_hash varchar(66);
+ALTER TABLE transactions ADD COLUMN mint varchar(78);
+ALTER TABLE transactions ADD COLUMN request_id varchar(66);
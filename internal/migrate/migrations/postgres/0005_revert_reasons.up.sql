@@ -0,0 +1,5 @@
+-- The outcome of replaying a failed transaction on the state of its parent block. Both stay NULL for successful transactions
+-- and for the failed ones that were not replayed.
+
+ALTER TABLE transactions ADD COLUMN revert_message text;
+ALTER TABLE transactions ADD COLUMN revert_data text;
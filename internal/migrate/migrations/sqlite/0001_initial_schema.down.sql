@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS user_transactions;
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS indexer_checkpoints;
+DROP TABLE IF EXISTS contract_abis;
+DROP TABLE IF EXISTS blocks;
+DROP TABLE IF EXISTS token_transfers;
+DROP TABLE IF EXISTS transaction_logs;
+DROP TABLE IF EXISTS transactions;
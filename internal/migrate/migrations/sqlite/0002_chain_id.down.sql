@@ -0,0 +1,116 @@
+-- Rolling back fails while rows of more than one chain are cached, since they collide on the single chain keys; delete the rows
+-- of the other chains first.
+
+CREATE TABLE user_transactions_old (
+    user_id          text NOT NULL,
+    transaction_hash text NOT NULL,
+    queried_at       datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    query_count      bigint NOT NULL DEFAULT 1
+);
+INSERT INTO user_transactions_old (user_id, transaction_hash, queried_at, query_count)
+SELECT user_id, transaction_hash, queried_at, query_count FROM user_transactions;
+DROP TABLE user_transactions;
+ALTER TABLE user_transactions_old RENAME TO user_transactions;
+CREATE UNIQUE INDEX idx_user_tx ON user_transactions (user_id, transaction_hash);
+CREATE INDEX idx_user_queried ON user_transactions (user_id, queried_at, transaction_hash);
+
+CREATE TABLE indexer_checkpoints_old (
+    name       varchar(64) PRIMARY KEY,
+    from_block bigint NOT NULL,
+    to_block   bigint NOT NULL,
+    next_block bigint NOT NULL,
+    updated_at datetime
+);
+INSERT INTO indexer_checkpoints_old (name, from_block, to_block, next_block, updated_at)
+SELECT name, from_block, to_block, next_block, updated_at FROM indexer_checkpoints;
+DROP TABLE indexer_checkpoints;
+ALTER TABLE indexer_checkpoints_old RENAME TO indexer_checkpoints;
+
+CREATE TABLE contract_abis_old (
+    address varchar(42) PRIMARY KEY,
+    abi     text NOT NULL
+);
+INSERT INTO contract_abis_old (address, abi)
+SELECT address, abi FROM contract_abis;
+DROP TABLE contract_abis;
+ALTER TABLE contract_abis_old RENAME TO contract_abis;
+
+CREATE TABLE blocks_old (
+    hash               varchar(66) PRIMARY KEY,
+    number             bigint NOT NULL,
+    parent_hash        varchar(66) NOT NULL,
+    timestamp          bigint NOT NULL,
+    miner              varchar(42) NOT NULL,
+    base_fee           varchar(78),
+    gas_used           bigint NOT NULL,
+    gas_limit          bigint NOT NULL,
+    state_root         varchar(66) NOT NULL,
+    transactions_root  varchar(66) NOT NULL,
+    receipts_root      varchar(66) NOT NULL,
+    transaction_hashes text
+);
+INSERT INTO blocks_old (hash, number, parent_hash, timestamp, miner, base_fee, gas_used, gas_limit, state_root,
+    transactions_root, receipts_root, transaction_hashes)
+SELECT hash, number, parent_hash, timestamp, miner, base_fee, gas_used, gas_limit, state_root, transactions_root, receipts_root,
+    transaction_hashes FROM blocks;
+DROP TABLE blocks;
+ALTER TABLE blocks_old RENAME TO blocks;
+CREATE UNIQUE INDEX idx_blocks_number ON blocks (number);
+
+DROP INDEX idx_tx_transfer;
+ALTER TABLE token_transfers DROP COLUMN chain_id;
+CREATE UNIQUE INDEX idx_tx_transfer ON token_transfers (transaction_hash, log_index, batch_index);
+
+DROP INDEX idx_tx_log;
+DROP INDEX idx_log_address_topic0;
+ALTER TABLE transaction_logs DROP COLUMN chain_id;
+CREATE UNIQUE INDEX idx_tx_log ON transaction_logs (transaction_hash, log_index);
+CREATE INDEX idx_log_address_topic0 ON transaction_logs (address, topic0);
+
+CREATE TABLE transactions_old (
+    transaction_hash         varchar(66) NOT NULL,
+    transaction_status       bigint NOT NULL,
+    block_hash               varchar(66) NOT NULL,
+    block_number             bigint NOT NULL,
+    block_timestamp          bigint,
+    "from"                   varchar(42) NOT NULL,
+    "to"                     varchar(42),
+    contract_address         varchar(42),
+    logs_count               bigint NOT NULL DEFAULT 0,
+    input                    text NOT NULL,
+    value                    varchar(100) NOT NULL,
+    verified                 boolean NOT NULL DEFAULT false,
+    confirmations            bigint NOT NULL DEFAULT 0,
+    tentative                boolean NOT NULL DEFAULT false,
+    tx_type                  smallint,
+    nonce                    bigint,
+    gas_limit                bigint,
+    gas_used                 bigint,
+    effective_gas_price      varchar(78),
+    gas_price                varchar(78),
+    max_fee_per_gas          varchar(78),
+    max_priority_fee_per_gas varchar(78),
+    access_list              text,
+    blob_versioned_hashes    text,
+    max_fee_per_blob_gas     varchar(78),
+    blob_gas_used            bigint,
+    blob_gas_price           varchar(78),
+    authorization_list       text
+);
+INSERT INTO transactions_old (transaction_hash, transaction_status, block_hash, block_number, block_timestamp, "from", "to",
+    contract_address, logs_count, input, value, verified, confirmations, tentative, tx_type, nonce, gas_limit, gas_used,
+    effective_gas_price, gas_price, max_fee_per_gas, max_priority_fee_per_gas, access_list, blob_versioned_hashes,
+    max_fee_per_blob_gas, blob_gas_used, blob_gas_price, authorization_list)
+SELECT transaction_hash, transaction_status, block_hash, block_number, block_timestamp, "from", "to", contract_address,
+    logs_count, input, value, verified, confirmations, tentative, tx_type, nonce, gas_limit, gas_used, effective_gas_price,
+    gas_price, max_fee_per_gas, max_priority_fee_per_gas, access_list, blob_versioned_hashes, max_fee_per_blob_gas,
+    blob_gas_used, blob_gas_price, authorization_list FROM transactions;
+DROP TABLE transactions;
+ALTER TABLE transactions_old RENAME TO transactions;
+CREATE UNIQUE INDEX idx_transactions_transaction_hash ON transactions (transaction_hash);
+CREATE INDEX idx_tx_block_hash ON transactions (block_number, transaction_hash);
+CREATE INDEX idx_tx_from_block ON transactions ("from", block_number, transaction_hash);
+CREATE INDEX idx_tx_to_block ON transactions ("to", block_number, transaction_hash);
+CREATE INDEX idx_tx_contract_block ON transactions (contract_address, block_number, transaction_hash);
+CREATE INDEX idx_transactions_tentative ON transactions (tentative);
+CREATE INDEX idx_transactions_tx_type ON transactions (tx_type);
@@ -0,0 +1,124 @@
+-- The chain ID of migration 2 of PostgreSQL. SQLite cannot change the primary key of a table, so the tables whose key now
+-- includes the chain ID are rebuilt.
+
+CREATE TABLE transactions_new (
+    transaction_hash         varchar(66) NOT NULL,
+    transaction_status       bigint NOT NULL,
+    block_hash               varchar(66) NOT NULL,
+    block_number             bigint NOT NULL,
+    block_timestamp          bigint,
+    "from"                   varchar(42) NOT NULL,
+    "to"                     varchar(42),
+    contract_address         varchar(42),
+    logs_count               bigint NOT NULL DEFAULT 0,
+    input                    text NOT NULL,
+    value                    varchar(100) NOT NULL,
+    verified                 boolean NOT NULL DEFAULT false,
+    confirmations            bigint NOT NULL DEFAULT 0,
+    tentative                boolean NOT NULL DEFAULT false,
+    tx_type                  smallint,
+    nonce                    bigint,
+    gas_limit                bigint,
+    gas_used                 bigint,
+    effective_gas_price      varchar(78),
+    gas_price                varchar(78),
+    max_fee_per_gas          varchar(78),
+    max_priority_fee_per_gas varchar(78),
+    access_list              text,
+    blob_versioned_hashes    text,
+    max_fee_per_blob_gas     varchar(78),
+    blob_gas_used            bigint,
+    blob_gas_price           varchar(78),
+    authorization_list       text,
+    chain_id                 bigint NOT NULL,
+    PRIMARY KEY (chain_id, transaction_hash)
+);
+INSERT INTO transactions_new (transaction_hash, transaction_status, block_hash, block_number, block_timestamp, "from", "to",
+    contract_address, logs_count, input, value, verified, confirmations, tentative, tx_type, nonce, gas_limit, gas_used,
+    effective_gas_price, gas_price, max_fee_per_gas, max_priority_fee_per_gas, access_list, blob_versioned_hashes,
+    max_fee_per_blob_gas, blob_gas_used, blob_gas_price, authorization_list, chain_id)
+SELECT transaction_hash, transaction_status, block_hash, block_number, block_timestamp, "from", "to", contract_address,
+    logs_count, input, value, verified, confirmations, tentative, tx_type, nonce, gas_limit, gas_used, effective_gas_price,
+    gas_price, max_fee_per_gas, max_priority_fee_per_gas, access_list, blob_versioned_hashes, max_fee_per_blob_gas,
+    blob_gas_used, blob_gas_price, authorization_list, 0 FROM transactions;
+DROP TABLE transactions;
+ALTER TABLE transactions_new RENAME TO transactions;
+CREATE INDEX idx_tx_block_hash ON transactions (chain_id, block_number, transaction_hash);
+CREATE INDEX idx_tx_from_block ON transactions (chain_id, "from", block_number, transaction_hash);
+CREATE INDEX idx_tx_to_block ON transactions (chain_id, "to", block_number, transaction_hash);
+CREATE INDEX idx_tx_contract_block ON transactions (chain_id, contract_address, block_number, transaction_hash);
+CREATE INDEX idx_transactions_tentative ON transactions (tentative);
+CREATE INDEX idx_transactions_tx_type ON transactions (tx_type);
+
+ALTER TABLE transaction_logs ADD COLUMN chain_id bigint NOT NULL DEFAULT 0;
+DROP INDEX idx_tx_log;
+DROP INDEX idx_log_address_topic0;
+CREATE UNIQUE INDEX idx_tx_log ON transaction_logs (chain_id, transaction_hash, log_index);
+CREATE INDEX idx_log_address_topic0 ON transaction_logs (chain_id, address, topic0);
+
+ALTER TABLE token_transfers ADD COLUMN chain_id bigint NOT NULL DEFAULT 0;
+DROP INDEX idx_tx_transfer;
+CREATE UNIQUE INDEX idx_tx_transfer ON token_transfers (chain_id, transaction_hash, log_index, batch_index);
+
+CREATE TABLE blocks_new (
+    hash               varchar(66) NOT NULL,
+    number             bigint NOT NULL,
+    parent_hash        varchar(66) NOT NULL,
+    timestamp          bigint NOT NULL,
+    miner              varchar(42) NOT NULL,
+    base_fee           varchar(78),
+    gas_used           bigint NOT NULL,
+    gas_limit          bigint NOT NULL,
+    state_root         varchar(66) NOT NULL,
+    transactions_root  varchar(66) NOT NULL,
+    receipts_root      varchar(66) NOT NULL,
+    transaction_hashes text,
+    chain_id           bigint NOT NULL,
+    PRIMARY KEY (chain_id, hash)
+);
+INSERT INTO blocks_new (hash, number, parent_hash, timestamp, miner, base_fee, gas_used, gas_limit, state_root,
+    transactions_root, receipts_root, transaction_hashes, chain_id)
+SELECT hash, number, parent_hash, timestamp, miner, base_fee, gas_used, gas_limit, state_root, transactions_root, receipts_root,
+    transaction_hashes, 0 FROM blocks;
+DROP TABLE blocks;
+ALTER TABLE blocks_new RENAME TO blocks;
+CREATE UNIQUE INDEX idx_blocks_number ON blocks (chain_id, number);
+
+CREATE TABLE contract_abis_new (
+    address  varchar(42) NOT NULL,
+    abi      text NOT NULL,
+    chain_id bigint NOT NULL,
+    PRIMARY KEY (chain_id, address)
+);
+INSERT INTO contract_abis_new (address, abi, chain_id)
+SELECT address, abi, 0 FROM contract_abis;
+DROP TABLE contract_abis;
+ALTER TABLE contract_abis_new RENAME TO contract_abis;
+
+CREATE TABLE indexer_checkpoints_new (
+    name       varchar(64) NOT NULL,
+    from_block bigint NOT NULL,
+    to_block   bigint NOT NULL,
+    next_block bigint NOT NULL,
+    updated_at datetime,
+    chain_id   bigint NOT NULL,
+    PRIMARY KEY (chain_id, name)
+);
+INSERT INTO indexer_checkpoints_new (name, from_block, to_block, next_block, updated_at, chain_id)
+SELECT name, from_block, to_block, next_block, updated_at, 0 FROM indexer_checkpoints;
+DROP TABLE indexer_checkpoints;
+ALTER TABLE indexer_checkpoints_new RENAME TO indexer_checkpoints;
+
+CREATE TABLE user_transactions_new (
+    user_id          text NOT NULL,
+    transaction_hash text NOT NULL,
+    queried_at       datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    query_count      bigint NOT NULL DEFAULT 1,
+    chain_id         bigint NOT NULL,
+    PRIMARY KEY (user_id, chain_id, transaction_hash)
+);
+INSERT INTO user_transactions_new (user_id, transaction_hash, queried_at, query_count, chain_id)
+SELECT user_id, transaction_hash, queried_at, query_count, 0 FROM user_transactions;
+DROP TABLE user_transactions;
+ALTER TABLE user_transactions_new RENAME TO user_transactions;
+CREATE INDEX idx_user_queried ON user_transactions (user_id, chain_id, queried_at, transaction_hash);
@@ -0,0 +1,8 @@
+ALTER TABLE transactions DROP COLUMN request_id;
+ALTER TABLE transactions DROP COLUMN mint;
+ALTER TABLE transactions DROP COLUMN source_hash;
+ALTER TABLE transactions DROP COLUMN gas_used_for_l1;
+ALTER TABLE transactions DROP COLUMN l1_blob_base_fee;
+ALTER TABLE transactions DROP COLUMN l1_gas_price;
+ALTER TABLE transactions DROP COLUMN l1_gas_used;
+ALTER TABLE transactions DROP COLUMN l1_fee;
@@ -0,0 +1,10 @@
+-- The L1 fee and deposit fields of migration 3 of PostgreSQL.
+
+ALTER TABLE transactions ADD COLUMN l1_fee varchar(78);
+ALTER TABLE transactions ADD COLUMN l1_gas_used bigint;
+ALTER TABLE transactions ADD COLUMN l1_gas_price varchar(78);
+ALTER TABLE transactions ADD COLUMN l1_blob_base_fee varchar(78);
+ALTER TABLE transactions ADD COLUMN gas_used_for_l1 bigint;
+ALTER TABLE transactions ADD COLUMN source_hash varchar(66);
+ALTER TABLE transactions ADD COLUMN mint varchar(78);
+ALTER TABLE transactions ADD COLUMN request_id varchar(66);
@@ -0,0 +1 @@
+DROP TABLE call_traces;
@@ -0,0 +1,20 @@
+-- The call traces of migration 4 of PostgreSQL.
+
+CREATE TABLE IF NOT EXISTS call_traces (
+    chain_id         bigint NOT NULL,
+    transaction_hash varchar(66) NOT NULL,
+    trace_index      bigint NOT NULL,
+    trace_address    text,
+    call_type        varchar(12) NOT NULL,
+    from_address     varchar(42) NOT NULL,
+    to_address       varchar(42) NOT NULL,
+    value            varchar(78) NOT NULL,
+    gas              bigint NOT NULL,
+    gas_used         bigint NOT NULL,
+    input            text NOT NULL,
+    output           text NOT NULL,
+    error            text NOT NULL,
+    reverted         boolean NOT NULL DEFAULT false
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tx_trace ON call_traces (chain_id, transaction_hash, trace_index);
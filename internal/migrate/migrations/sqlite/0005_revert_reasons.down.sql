@@ -0,0 +1,2 @@
+ALTER TABLE transactions DROP COLUMN revert_data;
+ALTER TABLE transactions DROP COLUMN revert_message;
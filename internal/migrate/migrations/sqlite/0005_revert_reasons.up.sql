@@ -0,0 +1,4 @@
+-- The revert fields of migration 5 of PostgreSQL.
+
+ALTER TABLE transactions ADD COLUMN revert_message text;
+ALTER TABLE transactions ADD COLUMN revert_data text;
@@ -0,0 +1,30 @@
+package migrate
+
+import "time"
+
+// Migration is a versioned change of the database schema together with the SQL that reverts it.
+type Migration struct {
+	Version uint
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status tells whether a migration is applied to the database and since when.
+type Status struct {
+	Version   uint       `json:"version"`
+	Name      string     `json:"name"`
+	Applied   bool       `json:"applied"`
+	AppliedAt *time.Time `json:"appliedAt,omitempty"`
+}
+
+// schemaMigration is a row of the schema_migrations table, which records the applied migrations.
+type schemaMigration struct {
+	Version   uint
+	Name      string
+	AppliedAt time.Time
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
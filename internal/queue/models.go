@@ -0,0 +1,16 @@
+package queue
+
+// Stats is a snapshot of the queue. Depth is the number of writes waiting for a worker and InFlight the number of writes being
+// attempted. Retried counts the failed attempts that were retried, Failed the writes that were given up on, either after
+// exhausting their attempts or because the queue was shut down before they were done, and Rejected the writes that were not
+// queued because the queue was full or closed.
+type Stats struct {
+	Depth     int    `json:"depth"`
+	Capacity  int    `json:"capacity"`
+	InFlight  int64  `json:"inFlight"`
+	Enqueued  uint64 `json:"enqueued"`
+	Completed uint64 `json:"completed"`
+	Retried   uint64 `json:"retried"`
+	Failed    uint64 `json:"failed"`
+	Rejected  uint64 `json:"rejected"`
+}
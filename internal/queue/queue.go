@@ -0,0 +1,209 @@
+// Package queue runs database writes in the background, outside of the request that triggered them, retrying failed writes with
+// exponential backoff and draining the writes that are still queued when the service shuts down.
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var (
+	ErrFull   = errors.New("write queue is full")
+	ErrClosed = errors.New("write queue is closed")
+)
+
+// maxBackoff caps the delay between two attempts of a write.
+const maxBackoff = time.Minute
+
+type job struct {
+	name  string
+	write func(ctx context.Context) error
+}
+
+// Queue holds up to size writes and runs them on a fixed number of workers. A write that fails is attempted again after the
+// backoff, which doubles with every attempt, until it succeeds or maxAttempts attempts failed. It is safe for concurrent use.
+type Queue struct {
+	logs        *zap.SugaredLogger
+	jobs        chan job
+	workers     int
+	maxAttempts int
+	backoff     time.Duration
+
+	// ctx is passed to the writes instead of the context of the request that queued them. It is only cancelled when the queue
+	// could not be drained in time.
+	ctx   context.Context
+	abort context.CancelFunc
+
+	mu      sync.RWMutex
+	closed  bool
+	started bool
+	wg      sync.WaitGroup
+
+	inFlight  atomic.Int64
+	enqueued  atomic.Uint64
+	completed atomic.Uint64
+	retried   atomic.Uint64
+	failed    atomic.Uint64
+	rejected  atomic.Uint64
+}
+
+// NewQueue is a constructor function for the Queue type. Writes are only run once Start is called.
+func NewQueue(logger *zap.SugaredLogger, size int, workers int, maxAttempts int, backoff time.Duration) *Queue {
+	ctx, abort := context.WithCancel(context.Background())
+	return &Queue{
+		logs:        logger,
+		jobs:        make(chan job, size),
+		workers:     max(workers, 1),
+		maxAttempts: max(maxAttempts, 1),
+		backoff:     backoff,
+		ctx:         ctx,
+		abort:       abort,
+	}
+}
+
+// Start starts the workers. Calling it more than once has no effect.
+func (q *Queue) Start() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.started {
+		return
+	}
+	q.started = true
+
+	q.wg.Add(q.workers)
+	for range q.workers {
+		go q.work()
+	}
+}
+
+// Enqueue queues the write under the given name, which identifies it in the logs. It does not block: it returns ErrFull when
+// the queue is full and ErrClosed once Shutdown was called.
+func (q *Queue) Enqueue(name string, write func(ctx context.Context) error) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	if q.closed {
+		q.rejected.Add(1)
+		return ErrClosed
+	}
+
+	select {
+	case q.jobs <- job{name: name, write: write}:
+		q.enqueued.Add(1)
+		return nil
+	default:
+		q.rejected.Add(1)
+		return ErrFull
+	}
+}
+
+// Shutdown stops accepting writes and waits until the queued ones are done. When ctx is done first, the writes that are still
+// queued or waiting for a retry are given up on and the error of ctx is returned.
+func (q *Queue) Shutdown(ctx context.Context) error {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.jobs)
+	}
+	started := q.started
+	q.mu.Unlock()
+
+	if !started {
+		q.Start()
+	}
+
+	q.logs.Infow("draining write queue", "depth", len(q.jobs), "in_flight", q.inFlight.Load())
+
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		q.logs.Infow("write queue drained", "completed", q.completed.Load(), "failed", q.failed.Load())
+		return nil
+	case <-ctx.Done():
+		q.abort()
+		q.logs.Errorw("write queue not drained in time", "depth", len(q.jobs), "in_flight", q.inFlight.Load())
+		return fmt.Errorf("drain write queue: %w", ctx.Err())
+	}
+}
+
+// Stats returns a snapshot of the queue.
+func (q *Queue) Stats() Stats {
+	return Stats{
+		Depth:     len(q.jobs),
+		Capacity:  cap(q.jobs),
+		InFlight:  q.inFlight.Load(),
+		Enqueued:  q.enqueued.Load(),
+		Completed: q.completed.Load(),
+		Retried:   q.retried.Load(),
+		Failed:    q.failed.Load(),
+		Rejected:  q.rejected.Load(),
+	}
+}
+
+func (q *Queue) work() {
+	defer q.wg.Done()
+
+	for j := range q.jobs {
+		q.inFlight.Add(1)
+		q.run(j)
+		q.inFlight.Add(-1)
+	}
+}
+
+// run attempts the write until it succeeds, runs out of attempts or the queue is aborted.
+func (q *Queue) run(j job) {
+	for attempt := 1; ; attempt++ {
+		if q.ctx.Err() != nil {
+			q.failed.Add(1)
+			q.logs.Errorw("write abandoned on shutdown", "job", j.name, "attempts", attempt-1)
+			return
+		}
+
+		err := j.write(q.ctx)
+		if err == nil {
+			q.completed.Add(1)
+			return
+		}
+
+		if attempt >= q.maxAttempts {
+			q.failed.Add(1)
+			q.logs.Errorw("write failed, giving up", "error", err, "job", j.name, "attempts", attempt)
+			return
+		}
+
+		delay := q.delay(attempt)
+		q.retried.Add(1)
+		q.logs.Errorw("write failed, retrying", "error", err, "job", j.name, "attempt", attempt, "retry_in", delay)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-q.ctx.Done():
+		case <-timer.C:
+		}
+		timer.Stop()
+	}
+}
+
+// delay is the backoff before the attempt that follows the given one.
+func (q *Queue) delay(attempt int) time.Duration {
+	delay := q.backoff
+	for range attempt - 1 {
+		delay *= 2
+		if delay >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return delay
+}
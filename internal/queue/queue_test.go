@@ -0,0 +1,152 @@
+package queue_test
+
+import (
+	"context"
+	"errors"
+	"fethcher/internal/queue"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+)
+
+var _ = Describe("Queue", func() {
+	var (
+		q           *queue.Queue
+		size        int
+		maxAttempts int
+		backoff     time.Duration
+		fakeErr     error
+	)
+
+	BeforeEach(func() {
+		size = 10
+		maxAttempts = 3
+		backoff = time.Millisecond
+		fakeErr = errors.New("fake error")
+	})
+
+	JustBeforeEach(func() {
+		q = queue.NewQueue(zap.NewNop().Sugar(), size, 2, maxAttempts, backoff)
+	})
+
+	AfterEach(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = q.Shutdown(ctx)
+	})
+
+	// failing returns a write that fails the given number of times before it succeeds, counting its attempts.
+	failing := func(failures int32, attempts *atomic.Int32) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			if attempts.Add(1) <= failures {
+				return fakeErr
+			}
+			return nil
+		}
+	}
+
+	When("the queue is started", func() {
+		JustBeforeEach(func() {
+			q.Start()
+		})
+
+		It("runs the queued writes", func() {
+			var attempts atomic.Int32
+			Expect(q.Enqueue("write", failing(0, &attempts))).To(Succeed())
+
+			Eventually(func() uint64 { return q.Stats().Completed }).Should(Equal(uint64(1)))
+			Expect(attempts.Load()).To(Equal(int32(1)))
+			Expect(q.Stats().Enqueued).To(Equal(uint64(1)))
+		})
+
+		It("does not pass a cancelled context to the writes", func() {
+			ctxErrs := make(chan error, 1)
+			Expect(q.Enqueue("write", func(ctx context.Context) error {
+				ctxErrs <- ctx.Err()
+				return nil
+			})).To(Succeed())
+
+			Eventually(ctxErrs).Should(Receive(BeNil()))
+		})
+
+		It("retries a failing write until it succeeds", func() {
+			var attempts atomic.Int32
+			Expect(q.Enqueue("write", failing(2, &attempts))).To(Succeed())
+
+			Eventually(func() uint64 { return q.Stats().Completed }).Should(Equal(uint64(1)))
+			Expect(attempts.Load()).To(Equal(int32(3)))
+			Expect(q.Stats().Retried).To(Equal(uint64(2)))
+			Expect(q.Stats().Failed).To(BeZero())
+		})
+
+		It("gives up on a write after its last attempt", func() {
+			var attempts atomic.Int32
+			Expect(q.Enqueue("write", failing(10, &attempts))).To(Succeed())
+
+			Eventually(func() uint64 { return q.Stats().Failed }).Should(Equal(uint64(1)))
+			Expect(attempts.Load()).To(Equal(int32(3)))
+			Expect(q.Stats().Completed).To(BeZero())
+		})
+	})
+
+	When("the queue is full", func() {
+		BeforeEach(func() {
+			size = 1
+		})
+
+		It("rejects the write", func() {
+			var attempts atomic.Int32
+			Expect(q.Enqueue("first", failing(0, &attempts))).To(Succeed())
+			Expect(q.Enqueue("second", failing(0, &attempts))).To(MatchError(queue.ErrFull))
+
+			stats := q.Stats()
+			Expect(stats.Depth).To(Equal(1))
+			Expect(stats.Capacity).To(Equal(1))
+			Expect(stats.Rejected).To(Equal(uint64(1)))
+		})
+	})
+
+	When("the queue is shut down", func() {
+		It("runs the writes that are still queued", func() {
+			var attempts atomic.Int32
+			for range 5 {
+				Expect(q.Enqueue("write", failing(0, &attempts))).To(Succeed())
+			}
+
+			Expect(q.Shutdown(context.Background())).To(Succeed())
+			Expect(attempts.Load()).To(Equal(int32(5)))
+			Expect(q.Stats().Completed).To(Equal(uint64(5)))
+			Expect(q.Stats().Depth).To(BeZero())
+		})
+
+		It("rejects new writes", func() {
+			Expect(q.Shutdown(context.Background())).To(Succeed())
+
+			Expect(q.Enqueue("write", func(ctx context.Context) error { return nil })).To(MatchError(queue.ErrClosed))
+			Expect(q.Stats().Rejected).To(Equal(uint64(1)))
+		})
+	})
+
+	When("the queue cannot be drained in time", func() {
+		BeforeEach(func() {
+			backoff = time.Hour
+		})
+
+		It("gives up on the writes waiting for a retry", func() {
+			var attempts atomic.Int32
+			q.Start()
+			Expect(q.Enqueue("write", failing(10, &attempts))).To(Succeed())
+			Eventually(func() uint64 { return q.Stats().Retried }).Should(Equal(uint64(1)))
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			Expect(q.Shutdown(ctx)).To(MatchError(context.DeadlineExceeded))
+
+			Eventually(func() uint64 { return q.Stats().Failed }).Should(Equal(uint64(1)))
+			Expect(attempts.Load()).To(Equal(int32(1)))
+		})
+	})
+})
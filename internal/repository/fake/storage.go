@@ -3,35 +3,52 @@ package fake
 
 import (
 	"context"
+	"fethcher/internal/db"
 	"fethcher/internal/repository"
 	"sync"
 )
 
 type Storage struct {
-	GetAllStub        func(context.Context, any) error
-	getAllMutex       sync.RWMutex
-	getAllArgsForCall []struct {
+	DeleteWhereStub        func(context.Context, []db.Condition, any) (int64, error)
+	deleteWhereMutex       sync.RWMutex
+	deleteWhereArgsForCall []struct {
 		arg1 context.Context
-		arg2 any
+		arg2 []db.Condition
+		arg3 any
+	}
+	deleteWhereReturns struct {
+		result1 int64
+		result2 error
 	}
-	getAllReturns struct {
+	deleteWhereReturnsOnCall map[int]struct {
+		result1 int64
+		result2 error
+	}
+	FindStub        func(context.Context, db.Query, any) error
+	findMutex       sync.RWMutex
+	findArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.Query
+		arg3 any
+	}
+	findReturns struct {
 		result1 error
 	}
-	getAllReturnsOnCall map[int]struct {
+	findReturnsOnCall map[int]struct {
 		result1 error
 	}
-	GetAllByStub        func(context.Context, string, any, any) error
-	getAllByMutex       sync.RWMutex
-	getAllByArgsForCall []struct {
+	FindPageStub        func(context.Context, db.Query, db.Keyset, any) error
+	findPageMutex       sync.RWMutex
+	findPageArgsForCall []struct {
 		arg1 context.Context
-		arg2 string
-		arg3 any
+		arg2 db.Query
+		arg3 db.Keyset
 		arg4 any
 	}
-	getAllByReturns struct {
+	findPageReturns struct {
 		result1 error
 	}
-	getAllByReturnsOnCall map[int]struct {
+	findPageReturnsOnCall map[int]struct {
 		result1 error
 	}
 	GetOneByStub        func(context.Context, string, any, any) error
@@ -48,58 +65,161 @@ type Storage struct {
 	getOneByReturnsOnCall map[int]struct {
 		result1 error
 	}
-	InsertToTableStub        func(context.Context, any) error
-	insertToTableMutex       sync.RWMutex
-	insertToTableArgsForCall []struct {
+	OverwriteWhereStub        func(context.Context, []db.Condition, any) error
+	overwriteWhereMutex       sync.RWMutex
+	overwriteWhereArgsForCall []struct {
+		arg1 context.Context
+		arg2 []db.Condition
+		arg3 any
+	}
+	overwriteWhereReturns struct {
+		result1 error
+	}
+	overwriteWhereReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SeedTableStub        func(context.Context, any) error
+	seedTableMutex       sync.RWMutex
+	seedTableArgsForCall []struct {
 		arg1 context.Context
 		arg2 any
 	}
-	insertToTableReturns struct {
+	seedTableReturns struct {
 		result1 error
 	}
-	insertToTableReturnsOnCall map[int]struct {
+	seedTableReturnsOnCall map[int]struct {
 		result1 error
 	}
-	MigrateTableStub        func(...any) error
-	migrateTableMutex       sync.RWMutex
-	migrateTableArgsForCall []struct {
-		arg1 []any
+	TransactionStub        func(context.Context, func(ctx context.Context) error) error
+	transactionMutex       sync.RWMutex
+	transactionArgsForCall []struct {
+		arg1 context.Context
+		arg2 func(ctx context.Context) error
 	}
-	migrateTableReturns struct {
+	transactionReturns struct {
 		result1 error
 	}
-	migrateTableReturnsOnCall map[int]struct {
+	transactionReturnsOnCall map[int]struct {
 		result1 error
 	}
-	SeedTableStub        func(context.Context, any) error
-	seedTableMutex       sync.RWMutex
-	seedTableArgsForCall []struct {
+	UpdateWhereStub        func(context.Context, []db.Condition, map[string]any, any) (int64, error)
+	updateWhereMutex       sync.RWMutex
+	updateWhereArgsForCall []struct {
+		arg1 context.Context
+		arg2 []db.Condition
+		arg3 map[string]any
+		arg4 any
+	}
+	updateWhereReturns struct {
+		result1 int64
+		result2 error
+	}
+	updateWhereReturnsOnCall map[int]struct {
+		result1 int64
+		result2 error
+	}
+	UpsertStub        func(context.Context, any, db.Conflict) error
+	upsertMutex       sync.RWMutex
+	upsertArgsForCall []struct {
 		arg1 context.Context
 		arg2 any
+		arg3 db.Conflict
 	}
-	seedTableReturns struct {
+	upsertReturns struct {
 		result1 error
 	}
-	seedTableReturnsOnCall map[int]struct {
+	upsertReturnsOnCall map[int]struct {
 		result1 error
 	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *Storage) GetAll(arg1 context.Context, arg2 any) error {
-	fake.getAllMutex.Lock()
-	ret, specificReturn := fake.getAllReturnsOnCall[len(fake.getAllArgsForCall)]
-	fake.getAllArgsForCall = append(fake.getAllArgsForCall, struct {
+func (fake *Storage) DeleteWhere(arg1 context.Context, arg2 []db.Condition, arg3 any) (int64, error) {
+	var arg2Copy []db.Condition
+	if arg2 != nil {
+		arg2Copy = make([]db.Condition, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.deleteWhereMutex.Lock()
+	ret, specificReturn := fake.deleteWhereReturnsOnCall[len(fake.deleteWhereArgsForCall)]
+	fake.deleteWhereArgsForCall = append(fake.deleteWhereArgsForCall, struct {
 		arg1 context.Context
-		arg2 any
-	}{arg1, arg2})
-	stub := fake.GetAllStub
-	fakeReturns := fake.getAllReturns
-	fake.recordInvocation("GetAll", []interface{}{arg1, arg2})
-	fake.getAllMutex.Unlock()
+		arg2 []db.Condition
+		arg3 any
+	}{arg1, arg2Copy, arg3})
+	stub := fake.DeleteWhereStub
+	fakeReturns := fake.deleteWhereReturns
+	fake.recordInvocation("DeleteWhere", []interface{}{arg1, arg2Copy, arg3})
+	fake.deleteWhereMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Storage) DeleteWhereCallCount() int {
+	fake.deleteWhereMutex.RLock()
+	defer fake.deleteWhereMutex.RUnlock()
+	return len(fake.deleteWhereArgsForCall)
+}
+
+func (fake *Storage) DeleteWhereCalls(stub func(context.Context, []db.Condition, any) (int64, error)) {
+	fake.deleteWhereMutex.Lock()
+	defer fake.deleteWhereMutex.Unlock()
+	fake.DeleteWhereStub = stub
+}
+
+func (fake *Storage) DeleteWhereArgsForCall(i int) (context.Context, []db.Condition, any) {
+	fake.deleteWhereMutex.RLock()
+	defer fake.deleteWhereMutex.RUnlock()
+	argsForCall := fake.deleteWhereArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Storage) DeleteWhereReturns(result1 int64, result2 error) {
+	fake.deleteWhereMutex.Lock()
+	defer fake.deleteWhereMutex.Unlock()
+	fake.DeleteWhereStub = nil
+	fake.deleteWhereReturns = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Storage) DeleteWhereReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.deleteWhereMutex.Lock()
+	defer fake.deleteWhereMutex.Unlock()
+	fake.DeleteWhereStub = nil
+	if fake.deleteWhereReturnsOnCall == nil {
+		fake.deleteWhereReturnsOnCall = make(map[int]struct {
+			result1 int64
+			result2 error
+		})
+	}
+	fake.deleteWhereReturnsOnCall[i] = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Storage) Find(arg1 context.Context, arg2 db.Query, arg3 any) error {
+	fake.findMutex.Lock()
+	ret, specificReturn := fake.findReturnsOnCall[len(fake.findArgsForCall)]
+	fake.findArgsForCall = append(fake.findArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.Query
+		arg3 any
+	}{arg1, arg2, arg3})
+	stub := fake.FindStub
+	fakeReturns := fake.findReturns
+	fake.recordInvocation("Find", []interface{}{arg1, arg2, arg3})
+	fake.findMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -107,61 +227,61 @@ func (fake *Storage) GetAll(arg1 context.Context, arg2 any) error {
 	return fakeReturns.result1
 }
 
-func (fake *Storage) GetAllCallCount() int {
-	fake.getAllMutex.RLock()
-	defer fake.getAllMutex.RUnlock()
-	return len(fake.getAllArgsForCall)
+func (fake *Storage) FindCallCount() int {
+	fake.findMutex.RLock()
+	defer fake.findMutex.RUnlock()
+	return len(fake.findArgsForCall)
 }
 
-func (fake *Storage) GetAllCalls(stub func(context.Context, any) error) {
-	fake.getAllMutex.Lock()
-	defer fake.getAllMutex.Unlock()
-	fake.GetAllStub = stub
+func (fake *Storage) FindCalls(stub func(context.Context, db.Query, any) error) {
+	fake.findMutex.Lock()
+	defer fake.findMutex.Unlock()
+	fake.FindStub = stub
 }
 
-func (fake *Storage) GetAllArgsForCall(i int) (context.Context, any) {
-	fake.getAllMutex.RLock()
-	defer fake.getAllMutex.RUnlock()
-	argsForCall := fake.getAllArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+func (fake *Storage) FindArgsForCall(i int) (context.Context, db.Query, any) {
+	fake.findMutex.RLock()
+	defer fake.findMutex.RUnlock()
+	argsForCall := fake.findArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
-func (fake *Storage) GetAllReturns(result1 error) {
-	fake.getAllMutex.Lock()
-	defer fake.getAllMutex.Unlock()
-	fake.GetAllStub = nil
-	fake.getAllReturns = struct {
+func (fake *Storage) FindReturns(result1 error) {
+	fake.findMutex.Lock()
+	defer fake.findMutex.Unlock()
+	fake.FindStub = nil
+	fake.findReturns = struct {
 		result1 error
 	}{result1}
 }
 
-func (fake *Storage) GetAllReturnsOnCall(i int, result1 error) {
-	fake.getAllMutex.Lock()
-	defer fake.getAllMutex.Unlock()
-	fake.GetAllStub = nil
-	if fake.getAllReturnsOnCall == nil {
-		fake.getAllReturnsOnCall = make(map[int]struct {
+func (fake *Storage) FindReturnsOnCall(i int, result1 error) {
+	fake.findMutex.Lock()
+	defer fake.findMutex.Unlock()
+	fake.FindStub = nil
+	if fake.findReturnsOnCall == nil {
+		fake.findReturnsOnCall = make(map[int]struct {
 			result1 error
 		})
 	}
-	fake.getAllReturnsOnCall[i] = struct {
+	fake.findReturnsOnCall[i] = struct {
 		result1 error
 	}{result1}
 }
 
-func (fake *Storage) GetAllBy(arg1 context.Context, arg2 string, arg3 any, arg4 any) error {
-	fake.getAllByMutex.Lock()
-	ret, specificReturn := fake.getAllByReturnsOnCall[len(fake.getAllByArgsForCall)]
-	fake.getAllByArgsForCall = append(fake.getAllByArgsForCall, struct {
+func (fake *Storage) FindPage(arg1 context.Context, arg2 db.Query, arg3 db.Keyset, arg4 any) error {
+	fake.findPageMutex.Lock()
+	ret, specificReturn := fake.findPageReturnsOnCall[len(fake.findPageArgsForCall)]
+	fake.findPageArgsForCall = append(fake.findPageArgsForCall, struct {
 		arg1 context.Context
-		arg2 string
-		arg3 any
+		arg2 db.Query
+		arg3 db.Keyset
 		arg4 any
 	}{arg1, arg2, arg3, arg4})
-	stub := fake.GetAllByStub
-	fakeReturns := fake.getAllByReturns
-	fake.recordInvocation("GetAllBy", []interface{}{arg1, arg2, arg3, arg4})
-	fake.getAllByMutex.Unlock()
+	stub := fake.FindPageStub
+	fakeReturns := fake.findPageReturns
+	fake.recordInvocation("FindPage", []interface{}{arg1, arg2, arg3, arg4})
+	fake.findPageMutex.Unlock()
 	if stub != nil {
 		return stub(arg1, arg2, arg3, arg4)
 	}
@@ -171,44 +291,44 @@ func (fake *Storage) GetAllBy(arg1 context.Context, arg2 string, arg3 any, arg4
 	return fakeReturns.result1
 }
 
-func (fake *Storage) GetAllByCallCount() int {
-	fake.getAllByMutex.RLock()
-	defer fake.getAllByMutex.RUnlock()
-	return len(fake.getAllByArgsForCall)
+func (fake *Storage) FindPageCallCount() int {
+	fake.findPageMutex.RLock()
+	defer fake.findPageMutex.RUnlock()
+	return len(fake.findPageArgsForCall)
 }
 
-func (fake *Storage) GetAllByCalls(stub func(context.Context, string, any, any) error) {
-	fake.getAllByMutex.Lock()
-	defer fake.getAllByMutex.Unlock()
-	fake.GetAllByStub = stub
+func (fake *Storage) FindPageCalls(stub func(context.Context, db.Query, db.Keyset, any) error) {
+	fake.findPageMutex.Lock()
+	defer fake.findPageMutex.Unlock()
+	fake.FindPageStub = stub
 }
 
-func (fake *Storage) GetAllByArgsForCall(i int) (context.Context, string, any, any) {
-	fake.getAllByMutex.RLock()
-	defer fake.getAllByMutex.RUnlock()
-	argsForCall := fake.getAllByArgsForCall[i]
+func (fake *Storage) FindPageArgsForCall(i int) (context.Context, db.Query, db.Keyset, any) {
+	fake.findPageMutex.RLock()
+	defer fake.findPageMutex.RUnlock()
+	argsForCall := fake.findPageArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
-func (fake *Storage) GetAllByReturns(result1 error) {
-	fake.getAllByMutex.Lock()
-	defer fake.getAllByMutex.Unlock()
-	fake.GetAllByStub = nil
-	fake.getAllByReturns = struct {
+func (fake *Storage) FindPageReturns(result1 error) {
+	fake.findPageMutex.Lock()
+	defer fake.findPageMutex.Unlock()
+	fake.FindPageStub = nil
+	fake.findPageReturns = struct {
 		result1 error
 	}{result1}
 }
 
-func (fake *Storage) GetAllByReturnsOnCall(i int, result1 error) {
-	fake.getAllByMutex.Lock()
-	defer fake.getAllByMutex.Unlock()
-	fake.GetAllByStub = nil
-	if fake.getAllByReturnsOnCall == nil {
-		fake.getAllByReturnsOnCall = make(map[int]struct {
+func (fake *Storage) FindPageReturnsOnCall(i int, result1 error) {
+	fake.findPageMutex.Lock()
+	defer fake.findPageMutex.Unlock()
+	fake.FindPageStub = nil
+	if fake.findPageReturnsOnCall == nil {
+		fake.findPageReturnsOnCall = make(map[int]struct {
 			result1 error
 		})
 	}
-	fake.getAllByReturnsOnCall[i] = struct {
+	fake.findPageReturnsOnCall[i] = struct {
 		result1 error
 	}{result1}
 }
@@ -277,80 +397,25 @@ func (fake *Storage) GetOneByReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *Storage) InsertToTable(arg1 context.Context, arg2 any) error {
-	fake.insertToTableMutex.Lock()
-	ret, specificReturn := fake.insertToTableReturnsOnCall[len(fake.insertToTableArgsForCall)]
-	fake.insertToTableArgsForCall = append(fake.insertToTableArgsForCall, struct {
-		arg1 context.Context
-		arg2 any
-	}{arg1, arg2})
-	stub := fake.InsertToTableStub
-	fakeReturns := fake.insertToTableReturns
-	fake.recordInvocation("InsertToTable", []interface{}{arg1, arg2})
-	fake.insertToTableMutex.Unlock()
-	if stub != nil {
-		return stub(arg1, arg2)
-	}
-	if specificReturn {
-		return ret.result1
-	}
-	return fakeReturns.result1
-}
-
-func (fake *Storage) InsertToTableCallCount() int {
-	fake.insertToTableMutex.RLock()
-	defer fake.insertToTableMutex.RUnlock()
-	return len(fake.insertToTableArgsForCall)
-}
-
-func (fake *Storage) InsertToTableCalls(stub func(context.Context, any) error) {
-	fake.insertToTableMutex.Lock()
-	defer fake.insertToTableMutex.Unlock()
-	fake.InsertToTableStub = stub
-}
-
-func (fake *Storage) InsertToTableArgsForCall(i int) (context.Context, any) {
-	fake.insertToTableMutex.RLock()
-	defer fake.insertToTableMutex.RUnlock()
-	argsForCall := fake.insertToTableArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
-}
-
-func (fake *Storage) InsertToTableReturns(result1 error) {
-	fake.insertToTableMutex.Lock()
-	defer fake.insertToTableMutex.Unlock()
-	fake.InsertToTableStub = nil
-	fake.insertToTableReturns = struct {
-		result1 error
-	}{result1}
-}
-
-func (fake *Storage) InsertToTableReturnsOnCall(i int, result1 error) {
-	fake.insertToTableMutex.Lock()
-	defer fake.insertToTableMutex.Unlock()
-	fake.InsertToTableStub = nil
-	if fake.insertToTableReturnsOnCall == nil {
-		fake.insertToTableReturnsOnCall = make(map[int]struct {
-			result1 error
-		})
+func (fake *Storage) OverwriteWhere(arg1 context.Context, arg2 []db.Condition, arg3 any) error {
+	var arg2Copy []db.Condition
+	if arg2 != nil {
+		arg2Copy = make([]db.Condition, len(arg2))
+		copy(arg2Copy, arg2)
 	}
-	fake.insertToTableReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
-}
-
-func (fake *Storage) MigrateTable(arg1 ...any) error {
-	fake.migrateTableMutex.Lock()
-	ret, specificReturn := fake.migrateTableReturnsOnCall[len(fake.migrateTableArgsForCall)]
-	fake.migrateTableArgsForCall = append(fake.migrateTableArgsForCall, struct {
-		arg1 []any
-	}{arg1})
-	stub := fake.MigrateTableStub
-	fakeReturns := fake.migrateTableReturns
-	fake.recordInvocation("MigrateTable", []interface{}{arg1})
-	fake.migrateTableMutex.Unlock()
+	fake.overwriteWhereMutex.Lock()
+	ret, specificReturn := fake.overwriteWhereReturnsOnCall[len(fake.overwriteWhereArgsForCall)]
+	fake.overwriteWhereArgsForCall = append(fake.overwriteWhereArgsForCall, struct {
+		arg1 context.Context
+		arg2 []db.Condition
+		arg3 any
+	}{arg1, arg2Copy, arg3})
+	stub := fake.OverwriteWhereStub
+	fakeReturns := fake.overwriteWhereReturns
+	fake.recordInvocation("OverwriteWhere", []interface{}{arg1, arg2Copy, arg3})
+	fake.overwriteWhereMutex.Unlock()
 	if stub != nil {
-		return stub(arg1...)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -358,44 +423,44 @@ func (fake *Storage) MigrateTable(arg1 ...any) error {
 	return fakeReturns.result1
 }
 
-func (fake *Storage) MigrateTableCallCount() int {
-	fake.migrateTableMutex.RLock()
-	defer fake.migrateTableMutex.RUnlock()
-	return len(fake.migrateTableArgsForCall)
+func (fake *Storage) OverwriteWhereCallCount() int {
+	fake.overwriteWhereMutex.RLock()
+	defer fake.overwriteWhereMutex.RUnlock()
+	return len(fake.overwriteWhereArgsForCall)
 }
 
-func (fake *Storage) MigrateTableCalls(stub func(...any) error) {
-	fake.migrateTableMutex.Lock()
-	defer fake.migrateTableMutex.Unlock()
-	fake.MigrateTableStub = stub
+func (fake *Storage) OverwriteWhereCalls(stub func(context.Context, []db.Condition, any) error) {
+	fake.overwriteWhereMutex.Lock()
+	defer fake.overwriteWhereMutex.Unlock()
+	fake.OverwriteWhereStub = stub
 }
 
-func (fake *Storage) MigrateTableArgsForCall(i int) []any {
-	fake.migrateTableMutex.RLock()
-	defer fake.migrateTableMutex.RUnlock()
-	argsForCall := fake.migrateTableArgsForCall[i]
-	return argsForCall.arg1
+func (fake *Storage) OverwriteWhereArgsForCall(i int) (context.Context, []db.Condition, any) {
+	fake.overwriteWhereMutex.RLock()
+	defer fake.overwriteWhereMutex.RUnlock()
+	argsForCall := fake.overwriteWhereArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
-func (fake *Storage) MigrateTableReturns(result1 error) {
-	fake.migrateTableMutex.Lock()
-	defer fake.migrateTableMutex.Unlock()
-	fake.MigrateTableStub = nil
-	fake.migrateTableReturns = struct {
+func (fake *Storage) OverwriteWhereReturns(result1 error) {
+	fake.overwriteWhereMutex.Lock()
+	defer fake.overwriteWhereMutex.Unlock()
+	fake.OverwriteWhereStub = nil
+	fake.overwriteWhereReturns = struct {
 		result1 error
 	}{result1}
 }
 
-func (fake *Storage) MigrateTableReturnsOnCall(i int, result1 error) {
-	fake.migrateTableMutex.Lock()
-	defer fake.migrateTableMutex.Unlock()
-	fake.MigrateTableStub = nil
-	if fake.migrateTableReturnsOnCall == nil {
-		fake.migrateTableReturnsOnCall = make(map[int]struct {
+func (fake *Storage) OverwriteWhereReturnsOnCall(i int, result1 error) {
+	fake.overwriteWhereMutex.Lock()
+	defer fake.overwriteWhereMutex.Unlock()
+	fake.OverwriteWhereStub = nil
+	if fake.overwriteWhereReturnsOnCall == nil {
+		fake.overwriteWhereReturnsOnCall = make(map[int]struct {
 			result1 error
 		})
 	}
-	fake.migrateTableReturnsOnCall[i] = struct {
+	fake.overwriteWhereReturnsOnCall[i] = struct {
 		result1 error
 	}{result1}
 }
@@ -462,21 +527,224 @@ func (fake *Storage) SeedTableReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *Storage) Transaction(arg1 context.Context, arg2 func(ctx context.Context) error) error {
+	fake.transactionMutex.Lock()
+	ret, specificReturn := fake.transactionReturnsOnCall[len(fake.transactionArgsForCall)]
+	fake.transactionArgsForCall = append(fake.transactionArgsForCall, struct {
+		arg1 context.Context
+		arg2 func(ctx context.Context) error
+	}{arg1, arg2})
+	stub := fake.TransactionStub
+	fakeReturns := fake.transactionReturns
+	fake.recordInvocation("Transaction", []interface{}{arg1, arg2})
+	fake.transactionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Storage) TransactionCallCount() int {
+	fake.transactionMutex.RLock()
+	defer fake.transactionMutex.RUnlock()
+	return len(fake.transactionArgsForCall)
+}
+
+func (fake *Storage) TransactionCalls(stub func(context.Context, func(ctx context.Context) error) error) {
+	fake.transactionMutex.Lock()
+	defer fake.transactionMutex.Unlock()
+	fake.TransactionStub = stub
+}
+
+func (fake *Storage) TransactionArgsForCall(i int) (context.Context, func(ctx context.Context) error) {
+	fake.transactionMutex.RLock()
+	defer fake.transactionMutex.RUnlock()
+	argsForCall := fake.transactionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Storage) TransactionReturns(result1 error) {
+	fake.transactionMutex.Lock()
+	defer fake.transactionMutex.Unlock()
+	fake.TransactionStub = nil
+	fake.transactionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Storage) TransactionReturnsOnCall(i int, result1 error) {
+	fake.transactionMutex.Lock()
+	defer fake.transactionMutex.Unlock()
+	fake.TransactionStub = nil
+	if fake.transactionReturnsOnCall == nil {
+		fake.transactionReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.transactionReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Storage) UpdateWhere(arg1 context.Context, arg2 []db.Condition, arg3 map[string]any, arg4 any) (int64, error) {
+	var arg2Copy []db.Condition
+	if arg2 != nil {
+		arg2Copy = make([]db.Condition, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.updateWhereMutex.Lock()
+	ret, specificReturn := fake.updateWhereReturnsOnCall[len(fake.updateWhereArgsForCall)]
+	fake.updateWhereArgsForCall = append(fake.updateWhereArgsForCall, struct {
+		arg1 context.Context
+		arg2 []db.Condition
+		arg3 map[string]any
+		arg4 any
+	}{arg1, arg2Copy, arg3, arg4})
+	stub := fake.UpdateWhereStub
+	fakeReturns := fake.updateWhereReturns
+	fake.recordInvocation("UpdateWhere", []interface{}{arg1, arg2Copy, arg3, arg4})
+	fake.updateWhereMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Storage) UpdateWhereCallCount() int {
+	fake.updateWhereMutex.RLock()
+	defer fake.updateWhereMutex.RUnlock()
+	return len(fake.updateWhereArgsForCall)
+}
+
+func (fake *Storage) UpdateWhereCalls(stub func(context.Context, []db.Condition, map[string]any, any) (int64, error)) {
+	fake.updateWhereMutex.Lock()
+	defer fake.updateWhereMutex.Unlock()
+	fake.UpdateWhereStub = stub
+}
+
+func (fake *Storage) UpdateWhereArgsForCall(i int) (context.Context, []db.Condition, map[string]any, any) {
+	fake.updateWhereMutex.RLock()
+	defer fake.updateWhereMutex.RUnlock()
+	argsForCall := fake.updateWhereArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *Storage) UpdateWhereReturns(result1 int64, result2 error) {
+	fake.updateWhereMutex.Lock()
+	defer fake.updateWhereMutex.Unlock()
+	fake.UpdateWhereStub = nil
+	fake.updateWhereReturns = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Storage) UpdateWhereReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.updateWhereMutex.Lock()
+	defer fake.updateWhereMutex.Unlock()
+	fake.UpdateWhereStub = nil
+	if fake.updateWhereReturnsOnCall == nil {
+		fake.updateWhereReturnsOnCall = make(map[int]struct {
+			result1 int64
+			result2 error
+		})
+	}
+	fake.updateWhereReturnsOnCall[i] = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Storage) Upsert(arg1 context.Context, arg2 any, arg3 db.Conflict) error {
+	fake.upsertMutex.Lock()
+	ret, specificReturn := fake.upsertReturnsOnCall[len(fake.upsertArgsForCall)]
+	fake.upsertArgsForCall = append(fake.upsertArgsForCall, struct {
+		arg1 context.Context
+		arg2 any
+		arg3 db.Conflict
+	}{arg1, arg2, arg3})
+	stub := fake.UpsertStub
+	fakeReturns := fake.upsertReturns
+	fake.recordInvocation("Upsert", []interface{}{arg1, arg2, arg3})
+	fake.upsertMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Storage) UpsertCallCount() int {
+	fake.upsertMutex.RLock()
+	defer fake.upsertMutex.RUnlock()
+	return len(fake.upsertArgsForCall)
+}
+
+func (fake *Storage) UpsertCalls(stub func(context.Context, any, db.Conflict) error) {
+	fake.upsertMutex.Lock()
+	defer fake.upsertMutex.Unlock()
+	fake.UpsertStub = stub
+}
+
+func (fake *Storage) UpsertArgsForCall(i int) (context.Context, any, db.Conflict) {
+	fake.upsertMutex.RLock()
+	defer fake.upsertMutex.RUnlock()
+	argsForCall := fake.upsertArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Storage) UpsertReturns(result1 error) {
+	fake.upsertMutex.Lock()
+	defer fake.upsertMutex.Unlock()
+	fake.UpsertStub = nil
+	fake.upsertReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Storage) UpsertReturnsOnCall(i int, result1 error) {
+	fake.upsertMutex.Lock()
+	defer fake.upsertMutex.Unlock()
+	fake.UpsertStub = nil
+	if fake.upsertReturnsOnCall == nil {
+		fake.upsertReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.upsertReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *Storage) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
-	fake.getAllMutex.RLock()
-	defer fake.getAllMutex.RUnlock()
-	fake.getAllByMutex.RLock()
-	defer fake.getAllByMutex.RUnlock()
+	fake.deleteWhereMutex.RLock()
+	defer fake.deleteWhereMutex.RUnlock()
+	fake.findMutex.RLock()
+	defer fake.findMutex.RUnlock()
+	fake.findPageMutex.RLock()
+	defer fake.findPageMutex.RUnlock()
 	fake.getOneByMutex.RLock()
 	defer fake.getOneByMutex.RUnlock()
-	fake.insertToTableMutex.RLock()
-	defer fake.insertToTableMutex.RUnlock()
-	fake.migrateTableMutex.RLock()
-	defer fake.migrateTableMutex.RUnlock()
+	fake.overwriteWhereMutex.RLock()
+	defer fake.overwriteWhereMutex.RUnlock()
 	fake.seedTableMutex.RLock()
 	defer fake.seedTableMutex.RUnlock()
+	fake.transactionMutex.RLock()
+	defer fake.transactionMutex.RUnlock()
+	fake.updateWhereMutex.RLock()
+	defer fake.updateWhereMutex.RUnlock()
+	fake.upsertMutex.RLock()
+	defer fake.upsertMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -1,16 +1,214 @@
 package repository
 
+import "time"
+
+// Transaction is a cached transaction, identified by its chain and hash. The block number and the transaction hash are indexed
+// together, on their own and after every address column, so that all transactions and the transactions of an address of a
+// chain can be paged through in block order. Confirmations is the count at the time the row was last written, it is not
+// refreshed once the transaction is final.
 type Transaction struct {
-	TransactionHash   string  `gorm:"size:66;uniqueIndex;not null"`
-	TransactionStatus uint64  `gorm:"not null"`
-	BlockHash         string  `gorm:"size:66;not null"`
-	BlockNumber       uint64  `gorm:"not null;index"`
-	From              string  `gorm:"size:42;not null"`
-	To                *string `gorm:"size:42"`
-	ContractAddress   *string `gorm:"size:42"`
+	ChainID           uint64 `gorm:"primaryKey;autoIncrement:false;index:idx_tx_block_hash,priority:1;index:idx_tx_from_block,priority:1;index:idx_tx_to_block,priority:1;index:idx_tx_contract_block,priority:1"`
+	TransactionHash   string `gorm:"size:66;primaryKey;index:idx_tx_block_hash,priority:3;index:idx_tx_from_block,priority:4;index:idx_tx_to_block,priority:4;index:idx_tx_contract_block,priority:4"`
+	TransactionStatus uint64 `gorm:"not null"`
+	BlockHash         string `gorm:"size:66;not null"`
+	BlockNumber       uint64 `gorm:"not null;index:idx_tx_block_hash,priority:2;index:idx_tx_from_block,priority:3;index:idx_tx_to_block,priority:3;index:idx_tx_contract_block,priority:3"`
+	BlockTimestamp    *uint64
+	From              string  `gorm:"size:42;not null;index:idx_tx_from_block,priority:2"`
+	To                *string `gorm:"size:42;index:idx_tx_to_block,priority:2"`
+	ContractAddress   *string `gorm:"size:42;index:idx_tx_contract_block,priority:2"`
 	LogsCount         int     `gorm:"not null;default:0"`
 	Input             string  `gorm:"type:text;not null"`
 	Value             string  `gorm:"size:100;not null"`
+	Verified          bool    `gorm:"not null;default:false"`
+	Confirmations     uint64  `gorm:"not null;default:0"`
+	Tentative         bool    `gorm:"not null;default:false;index"`
+
+	// The block timestamp and the type, gas and fee fields were added after the first transactions were cached, so they are
+	// nullable. A NULL BlockTimestamp or TxType marks a row that still has to be backfilled.
+	TxType               *uint8 `gorm:"index"`
+	Nonce                *uint64
+	GasLimit             *uint64
+	GasUsed              *uint64
+	EffectiveGasPrice    *string       `gorm:"size:78"`
+	GasPrice             *string       `gorm:"size:78"`
+	MaxFeePerGas         *string       `gorm:"size:78"`
+	MaxPriorityFeePerGas *string       `gorm:"size:78"`
+	AccessList           []AccessTuple `gorm:"type:text;serializer:json"`
+	BlobVersionedHashes  []string      `gorm:"type:text;serializer:json"`
+	MaxFeePerBlobGas     *string       `gorm:"size:78"`
+	BlobGasUsed          *uint64
+	BlobGasPrice         *string         `gorm:"size:78"`
+	AuthorizationList    []Authorization `gorm:"type:text;serializer:json"`
+
+	// The L1 fee and deposit fields are only set on L2 chains.
+	L1Fee         *string `gorm:"size:78"`
+	L1GasUsed     *uint64
+	L1GasPrice    *string `gorm:"size:78"`
+	L1BlobBaseFee *string `gorm:"size:78"`
+	GasUsedForL1  *uint64
+	SourceHash    *string `gorm:"size:66"`
+	Mint          *string `gorm:"size:78"`
+	RequestID     *string `gorm:"size:66"`
+
+	// The revert fields are only set for failed transactions that were replayed. RevertMessage is empty when the replay did not
+	// fail and RevertData is "0x" when it failed without revert data.
+	RevertMessage *string `gorm:"type:text"`
+	RevertData    *string `gorm:"type:text"`
+}
+
+// Block is a cached block header together with the hashes of its transactions. Only blocks that reached the confirmation depth
+// are cached, so a block number identifies a single block of a chain.
+type Block struct {
+	ChainID           uint64   `gorm:"primaryKey;autoIncrement:false;uniqueIndex:idx_blocks_number"`
+	Hash              string   `gorm:"size:66;primaryKey"`
+	Number            uint64   `gorm:"not null;uniqueIndex:idx_blocks_number"`
+	ParentHash        string   `gorm:"size:66;not null"`
+	Timestamp         uint64   `gorm:"not null"`
+	Miner             string   `gorm:"size:42;not null"`
+	BaseFee           *string  `gorm:"size:78"`
+	GasUsed           uint64   `gorm:"not null"`
+	GasLimit          uint64   `gorm:"not null"`
+	StateRoot         string   `gorm:"size:66;not null"`
+	TransactionsRoot  string   `gorm:"size:66;not null"`
+	ReceiptsRoot      string   `gorm:"size:66;not null"`
+	TransactionHashes []string `gorm:"type:text;serializer:json"`
+}
+
+// AccessTuple is an entry of an EIP-2930 access list, stored as JSON.
+type AccessTuple struct {
+	Address     string   `json:"address"`
+	StorageKeys []string `json:"storageKeys"`
+}
+
+// Authorization is an entry of an EIP-7702 authorization list, stored as JSON.
+type Authorization struct {
+	ChainID   string  `json:"chainId"`
+	Address   string  `json:"address"`
+	Nonce     uint64  `json:"nonce"`
+	YParity   uint8   `json:"yParity"`
+	R         string  `json:"r"`
+	S         string  `json:"s"`
+	Authority *string `json:"authority,omitempty"`
+}
+
+// TransactionLog is an event emitted by a cached transaction. Topic0, the event signature for events that are not anonymous, is
+// indexed together with the emitting contract address so that logs can be looked up by either.
+type TransactionLog struct {
+	ChainID         uint64  `gorm:"not null;uniqueIndex:idx_tx_log;index:idx_log_address_topic0"`
+	TransactionHash string  `gorm:"size:66;not null;uniqueIndex:idx_tx_log"`
+	LogIndex        uint    `gorm:"not null;uniqueIndex:idx_tx_log"`
+	BlockNumber     uint64  `gorm:"not null"`
+	Address         string  `gorm:"size:42;not null;index:idx_log_address_topic0"`
+	Topic0          *string `gorm:"size:66;index:idx_log_address_topic0;index"`
+	Topic1          *string `gorm:"size:66"`
+	Topic2          *string `gorm:"size:66"`
+	Topic3          *string `gorm:"size:66"`
+	Data            string  `gorm:"type:text;not null"`
+}
+
+// LogFilter selects logs by emitting contract address and/or topic0. Empty fields match every log.
+type LogFilter struct {
+	Address string
+	Topic0  string
+	Limit   int
+}
+
+// TokenTransfer is a token movement parsed from a standard ERC-20, ERC-721 or ERC-1155 transfer event of a cached transaction.
+// TokenID is only set for ERC-721 and ERC-1155 transfers.
+type TokenTransfer struct {
+	ChainID         uint64  `gorm:"not null;uniqueIndex:idx_tx_transfer"`
+	TransactionHash string  `gorm:"size:66;not null;uniqueIndex:idx_tx_transfer"`
+	LogIndex        uint    `gorm:"not null;uniqueIndex:idx_tx_transfer"`
+	BatchIndex      uint    `gorm:"not null;default:0;uniqueIndex:idx_tx_transfer"`
+	BlockNumber     uint64  `gorm:"not null"`
+	Token           string  `gorm:"size:42;not null;index"`
+	FromAddress     string  `gorm:"size:42;not null;index"`
+	ToAddress       string  `gorm:"size:42;not null;index"`
+	Amount          string  `gorm:"size:78;not null"`
+	TokenID         *string `gorm:"size:78"`
+	Standard        string  `gorm:"size:7;not null"`
+}
+
+// CallTrace is a call made while executing a cached transaction, as reported by the callTracer of the node. TraceIndex is the
+// position of the call in the depth first order of the call tree and TraceAddress, stored as JSON, the path of call indexes that
+// leads to it from the top-level call.
+type CallTrace struct {
+	ChainID         uint64 `gorm:"not null;uniqueIndex:idx_tx_trace"`
+	TransactionHash string `gorm:"size:66;not null;uniqueIndex:idx_tx_trace"`
+	TraceIndex      uint   `gorm:"not null;uniqueIndex:idx_tx_trace"`
+	TraceAddress    []int  `gorm:"type:text;serializer:json"`
+	CallType        string `gorm:"size:12;not null"`
+	FromAddress     string `gorm:"size:42;not null"`
+	ToAddress       string `gorm:"size:42;not null"`
+	Value           string `gorm:"size:78;not null"`
+	Gas             uint64 `gorm:"not null"`
+	GasUsed         uint64 `gorm:"not null"`
+	Input           string `gorm:"type:text;not null"`
+	Output          string `gorm:"type:text;not null"`
+	Error           string `gorm:"type:text;not null"`
+	Reverted        bool   `gorm:"not null;default:false"`
+}
+
+// TransferFilter selects token transfers by token contract and/or by holder, which matches both the sender and the recipient.
+// Empty fields match every transfer.
+type TransferFilter struct {
+	Token  string
+	Holder string
+	Limit  int
+}
+
+// TransactionFilter selects cached transactions; nil and empty fields match every transaction. ContractCreation selects contract
+// creations when true and every other transaction when false, MinValue is a decimal amount of wei and the block range bounds are
+// inclusive. Transactions are returned newest first unless Ascending is set, and After, when set, skips every transaction up to
+// and including the given one in that order.
+type TransactionFilter struct {
+	Status           *uint64
+	FromBlock        *uint64
+	ToBlock          *uint64
+	From             string
+	To               string
+	ContractCreation *bool
+	MinValue         string
+	Ascending        bool
+	After            *TransactionCursor
+	Limit            int
+}
+
+// AddressFilter selects the transactions that involve Address, newest first. Sent matches the transactions sent from the
+// address, Received the ones sent to it or that created it, and both match when neither is set. The block range bounds are
+// inclusive and After, when set, skips every transaction up to and including the given one, which is how pages are walked.
+type AddressFilter struct {
+	Address   string
+	Sent      bool
+	Received  bool
+	FromBlock *uint64
+	ToBlock   *uint64
+	After     *TransactionCursor
+	Limit     int
+}
+
+// TransactionCursor is the position of a transaction in the block number and hash order of the transaction filters.
+type TransactionCursor struct {
+	BlockNumber     uint64
+	TransactionHash string
+}
+
+// ContractABI is the JSON ABI uploaded for a contract of a chain, used to decode its transactions and logs.
+type ContractABI struct {
+	ChainID uint64 `gorm:"primaryKey;autoIncrement:false"`
+	Address string `gorm:"size:42;primaryKey"`
+	ABI     string `gorm:"type:text;not null"`
+}
+
+// IndexerCheckpoint is the progress of a block indexer over its block range, so that it resumes where it stopped after a
+// restart. ToBlock is 0 when the indexer follows the chain head.
+type IndexerCheckpoint struct {
+	ChainID   uint64 `gorm:"primaryKey;autoIncrement:false"`
+	Name      string `gorm:"size:64;primaryKey"`
+	FromBlock uint64 `gorm:"not null"`
+	ToBlock   uint64 `gorm:"not null"`
+	NextBlock uint64 `gorm:"not null"`
+	UpdatedAt time.Time
 }
 
 type User struct {
@@ -19,7 +217,30 @@ type User struct {
 	PasswordHash string `gorm:"not null"`
 }
 
+// UserTransaction is an entry of the query history of a user on a chain. QueriedAt is when the user last looked the transaction
+// up and QueryCount how many times they did; entries recorded before both were tracked default to the time of the migration and
+// a single query. The user ID, chain, query time and hash are indexed together so that the history can be paged through newest
+// first.
 type UserTransaction struct {
-	UserID          string `gorm:"uniqueIndex:idx_user_tx;not null"`
-	TransactionHash string `gorm:"uniqueIndex:idx_user_tx;not null"`
+	UserID          string    `gorm:"primaryKey;index:idx_user_queried,priority:1"`
+	ChainID         uint64    `gorm:"primaryKey;autoIncrement:false;index:idx_user_queried,priority:2"`
+	TransactionHash string    `gorm:"primaryKey;index:idx_user_queried,priority:4"`
+	QueriedAt       time.Time `gorm:"not null;default:CURRENT_TIMESTAMP;index:idx_user_queried,priority:3"`
+	QueryCount      uint      `gorm:"not null;default:1"`
+}
+
+// HistoryFilter selects the query history of a user, most recently queried first. The query time bounds are inclusive and
+// After, when set, skips every entry up to and including the given one, which is how pages are walked.
+type HistoryFilter struct {
+	UserID      string
+	QueriedFrom *time.Time
+	QueriedTo   *time.Time
+	After       *HistoryCursor
+	Limit       int
+}
+
+// HistoryCursor is the position of an entry in the query time and hash order of the query history.
+type HistoryCursor struct {
+	QueriedAt       time.Time
+	TransactionHash string
 }
@@ -1,15 +1,21 @@
 package repository
 
-import "context"
+import (
+	"context"
+	"fethcher/internal/db"
+)
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
 
 //counterfeiter:generate -o fake -fake-name Storage . Storage
 type Storage interface {
-	MigrateTable(tbl ...any) error
-	InsertToTable(rctx context.Context, records any) error
+	Upsert(ctx context.Context, records any, conflict db.Conflict) error
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
 	SeedTable(ctx context.Context, records any) error
 	GetOneBy(ctx context.Context, column string, value any, entity any) error
-	GetAllBy(ctx context.Context, column string, value any, entity any) error
-	GetAll(ctx context.Context, entity any) error
+	OverwriteWhere(ctx context.Context, conditions []db.Condition, record any) error
+	UpdateWhere(ctx context.Context, conditions []db.Condition, values map[string]any, entity any) (int64, error)
+	DeleteWhere(ctx context.Context, conditions []db.Condition, entity any) (int64, error)
+	Find(ctx context.Context, query db.Query, entity any) error
+	FindPage(ctx context.Context, query db.Query, keyset db.Keyset, entity any) error
 }
@@ -6,31 +6,61 @@ import (
 	"errors"
 	"fethcher/internal/db"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 var ErrUserNotFound error = errors.New("user not found")
+var ErrBlockNotFound error = errors.New("block not found")
+var ErrCheckpointNotFound error = errors.New("checkpoint not found")
 
-// TransactionRepository is a type that is used to interact with the database for transaction-related operations.
+// TransactionRepository is a type that is used to interact with the database for transaction-related operations. It is bound to
+// a single chain: every row it reads, writes or deletes belongs to that chain, except for the users, which all chains share.
 type TransactionRepository struct {
-	db Storage
+	db      Storage
+	chainID uint64
 }
 
 // NewTransactionRepository is a constructor function for the TransactionRepository type.
-func NewTransactionRepository(db Storage) *TransactionRepository {
+func NewTransactionRepository(db Storage, chainID uint64) *TransactionRepository {
 	return &TransactionRepository{
-		db: db,
+		db:      db,
+		chainID: chainID,
 	}
 }
 
-// MigrateTables migrates the given tables to the database.
-func (r *TransactionRepository) MigrateTables(tables ...any) error {
-	err := r.db.MigrateTable(tables...)
+// ChainID returns the ID of the chain the repository is bound to.
+func (r *TransactionRepository) ChainID() uint64 {
+	return r.chainID
+}
+
+// onChain returns the given conditions together with the one that restricts them to the rows of the chain of the repository.
+func (r *TransactionRepository) onChain(conditions ...db.Condition) []db.Condition {
+	return append([]db.Condition{{Column: "chain_id", Operator: "=", Value: r.chainID}}, conditions...)
+}
+
+// ClaimUnassignedRows assigns the rows that were cached before chains were configured, which the schema migration left on chain
+// 0, to the chain of the repository and returns how many rows were assigned. It is meant to be run for the default chain.
+func (r *TransactionRepository) ClaimUnassignedRows(ctx context.Context) (int64, error) {
+	var claimed int64
+	err := r.db.Transaction(ctx, func(ctx context.Context) error {
+		for _, entity := range []any{&Transaction{}, &TransactionLog{}, &TokenTransfer{}, &Block{}, &ContractABI{},
+			&IndexerCheckpoint{}, &UserTransaction{}} {
+			updated, err := r.db.UpdateWhere(ctx, []db.Condition{{Column: "chain_id", Operator: "=", Value: 0}},
+				map[string]any{"chain_id": r.chainID}, entity)
+			if err != nil {
+				return fmt.Errorf("claim %T rows: %w", entity, err)
+			}
+			claimed += updated
+		}
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("migrate table(s): %w", err)
+		return 0, fmt.Errorf("claim unassigned rows: %w", err)
 	}
-	return err
+	return claimed, nil
 }
 
 // SeedUserTable seeds the user table with some initial data. This is useful for testing purposes only.
@@ -66,9 +96,24 @@ func (r *TransactionRepository) SeedUserTable(ctx context.Context) error {
 	return nil
 }
 
-// SaveTransactions receives a context and a slice of transactions and saves them to the DB.
+// SaveTransactions receives a context and a slice of transactions and saves them to the DB, on the chain of the repository, in
+// a single database transaction. A transaction that is already cached is only overwritten when it now has more confirmations,
+// so that concurrent or retried saves of the same transaction neither fail nor replace a newer copy with an older one.
 func (r *TransactionRepository) SaveTransactions(ctx context.Context, transactions []Transaction) error {
-	err := r.db.InsertToTable(ctx, &transactions)
+	if len(transactions) == 0 {
+		return nil
+	}
+	for i := range transactions {
+		transactions[i].ChainID = r.chainID
+	}
+
+	err := r.db.Transaction(ctx, func(ctx context.Context) error {
+		return r.db.Upsert(ctx, &transactions, db.Conflict{
+			Columns:   []string{"chain_id", "transaction_hash"},
+			UpdateAll: true,
+			UpdateIf:  "transactions.confirmations < excluded.confirmations",
+		})
+	})
 	if err != nil {
 		return fmt.Errorf("save to table: %w", err)
 	}
@@ -76,54 +121,100 @@ func (r *TransactionRepository) SaveTransactions(ctx context.Context, transactio
 	return nil
 }
 
-// GetUserHistory receives userID and retrieves the user transctions history.
-func (r *TransactionRepository) GetUserHistory(ctx context.Context, userID string) ([]string, error) {
-	var userTransactions []UserTransaction
-
-	err := r.db.GetAllBy(ctx, "user_id", userID, &userTransactions)
-	if err != nil {
-		if errors.Is(err, db.ErrNotFound) {
-			return nil, fmt.Errorf("get all by user_is: %w", ErrUserNotFound)
-		}
-		return nil, fmt.Errorf("get all by user_is: %w", err)
+// GetUserHistory retrieves a page of the query history of the user that matches the filter.
+func (r *TransactionRepository) GetUserHistory(ctx context.Context, filter HistoryFilter) ([]UserTransaction, error) {
+	query := db.Query{
+		Where: r.onChain(db.Condition{Column: "user_id", Operator: "=", Value: filter.UserID}),
+		Limit: filter.Limit,
+	}
+	if filter.QueriedFrom != nil {
+		query.Where = append(query.Where, db.Condition{Column: "queried_at", Operator: ">=", Value: *filter.QueriedFrom})
+	}
+	if filter.QueriedTo != nil {
+		query.Where = append(query.Where, db.Condition{Column: "queried_at", Operator: "<=", Value: *filter.QueriedTo})
 	}
 
-	txHashes := make([]string, 0, len(userTransactions))
-	for _, tx := range userTransactions {
-		txHashes = append(txHashes, tx.TransactionHash)
+	keyset := db.Keyset{
+		Columns:    []string{"queried_at", "transaction_hash"},
+		Descending: true,
+	}
+	if filter.After != nil {
+		keyset.After = []any{filter.After.QueriedAt, filter.After.TransactionHash}
 	}
 
-	return txHashes, nil
+	userTransactions := []UserTransaction{}
+	if err := r.db.FindPage(ctx, query, keyset, &userTransactions); err != nil {
+		return nil, fmt.Errorf("find user history: %w", err)
+	}
+	return userTransactions, nil
 }
 
-// SaveUserHistory receives an userID and a slice of transaction hashes and saves the user query history in the DB.
+// SaveUserHistory receives an userID and a slice of transaction hashes and saves the user query history in the DB in a single
+// database transaction. Transactions that are already in the history have their query time updated and their query count
+// incremented.
 func (r *TransactionRepository) SaveUserHistory(ctx context.Context, userID string, transactions []string) error {
 	if len(transactions) == 0 {
 		return nil
 	}
 
+	return r.db.Transaction(ctx, func(ctx context.Context) error {
+		return r.saveUserHistory(ctx, userID, transactions)
+	})
+}
+
+func (r *TransactionRepository) saveUserHistory(ctx context.Context, userID string, transactions []string) error {
+	queriedAt := time.Now().UTC()
+
 	var dbUserTransactions []UserTransaction
-	err := r.db.GetAllBy(ctx, "user_id", userID, &dbUserTransactions)
+	err := r.db.Find(ctx, db.Query{
+		Where: r.onChain(
+			db.Condition{Column: "user_id", Operator: "=", Value: userID},
+			db.Condition{Column: "transaction_hash", Operator: "IN", Value: transactions},
+		),
+	}, &dbUserTransactions)
 	if err != nil {
 		return fmt.Errorf("get user transactions from db: %w", err)
 	}
 
 	var dbUserTxsMap = make(map[string]struct{}, len(dbUserTransactions))
+	queried := make([]string, 0, len(dbUserTransactions))
 	for _, tx := range dbUserTransactions {
 		dbUserTxsMap[tx.TransactionHash] = struct{}{}
+		queried = append(queried, tx.TransactionHash)
+	}
+
+	if len(queried) > 0 {
+		_, err = r.db.UpdateWhere(ctx, r.onChain(
+			db.Condition{Column: "user_id", Operator: "=", Value: userID},
+			db.Condition{Column: "transaction_hash", Operator: "IN", Value: queried},
+		), map[string]any{
+			"queried_at":  queriedAt,
+			"query_count": db.Increment(1),
+		}, &UserTransaction{})
+		if err != nil {
+			return fmt.Errorf("update user transactions: %w", err)
+		}
 	}
 
 	userTransactions := []UserTransaction{}
 	for _, tx := range transactions {
 		if _, exists := dbUserTxsMap[tx]; !exists {
+			dbUserTxsMap[tx] = struct{}{}
 			userTransactions = append(userTransactions, UserTransaction{
 				UserID:          userID,
+				ChainID:         r.chainID,
 				TransactionHash: tx,
+				QueriedAt:       queriedAt,
+				QueryCount:      1,
 			})
 		}
 	}
+	if len(userTransactions) == 0 {
+		return nil
+	}
 
-	err = r.db.InsertToTable(ctx, &userTransactions)
+	// an entry inserted by a concurrent save since the lookup above is left as it is
+	err = r.db.Upsert(ctx, &userTransactions, db.Conflict{Columns: []string{"user_id", "chain_id", "transaction_hash"}})
 	if err != nil {
 		return fmt.Errorf("insert into table user_transactions: %w", err)
 	}
@@ -131,6 +222,35 @@ func (r *TransactionRepository) SaveUserHistory(ctx context.Context, userID stri
 	return nil
 }
 
+// DeleteUserHistory removes the transactions with the given hashes from the query history of the user and returns how many
+// entries were removed.
+func (r *TransactionRepository) DeleteUserHistory(ctx context.Context, userID string, txHashes []string) (int64, error) {
+	if len(txHashes) == 0 {
+		return 0, nil
+	}
+
+	deleted, err := r.db.DeleteWhere(ctx, r.onChain(
+		db.Condition{Column: "user_id", Operator: "=", Value: userID},
+		db.Condition{Column: "transaction_hash", Operator: "IN", Value: txHashes},
+	), &UserTransaction{})
+	if err != nil {
+		return 0, fmt.Errorf("delete user history: %w", err)
+	}
+	return deleted, nil
+}
+
+// ClearUserHistory removes the whole query history of the user on the chain of the repository and returns how many entries were
+// removed.
+func (r *TransactionRepository) ClearUserHistory(ctx context.Context, userID string) (int64, error) {
+	deleted, err := r.db.DeleteWhere(ctx, r.onChain(
+		db.Condition{Column: "user_id", Operator: "=", Value: userID},
+	), &UserTransaction{})
+	if err != nil {
+		return 0, fmt.Errorf("clear user history: %w", err)
+	}
+	return deleted, nil
+}
+
 // GetUserFromDB receives an username and retrieves the user data from the DB.
 func (r *TransactionRepository) GetUserFromDB(ctx context.Context, username string) (User, error) {
 	var user User
@@ -149,7 +269,9 @@ func (r *TransactionRepository) GetUserFromDB(ctx context.Context, username stri
 // GetTransactionsByHash receives a slice of transaction hashes and tries to retrieve them from the DB. The transactoins that cannot be found in the DB are then queried for from the Ethereum network and cached in the DB.
 func (r *TransactionRepository) GetTransactionsByHash(ctx context.Context, txHashes []string) ([]Transaction, error) {
 	transactions := []Transaction{}
-	err := r.db.GetAllBy(ctx, "transaction_hash", txHashes, &transactions)
+	err := r.db.Find(ctx, db.Query{
+		Where: r.onChain(db.Condition{Column: "transaction_hash", Operator: "IN", Value: txHashes}),
+	}, &transactions)
 	if err != nil {
 		return transactions, fmt.Errorf("get transaction by hash: %w", err)
 	}
@@ -157,12 +279,431 @@ func (r *TransactionRepository) GetTransactionsByHash(ctx context.Context, txHas
 	return transactions, nil
 }
 
-// GetAllTransactions retrieves all transactions from the DB
-func (r *TransactionRepository) GetAllTransactions(ctx context.Context) ([]Transaction, error) {
+// FindTransactions retrieves a page of the cached transactions that match the filter.
+func (r *TransactionRepository) FindTransactions(ctx context.Context, filter TransactionFilter) ([]Transaction, error) {
+	query := db.Query{Where: r.onChain(), Limit: filter.Limit}
+	if filter.Status != nil {
+		query.Where = append(query.Where, db.Condition{Column: "transaction_status", Operator: "=", Value: *filter.Status})
+	}
+	if filter.FromBlock != nil {
+		query.Where = append(query.Where, db.Condition{Column: "block_number", Operator: ">=", Value: *filter.FromBlock})
+	}
+	if filter.ToBlock != nil {
+		query.Where = append(query.Where, db.Condition{Column: "block_number", Operator: "<=", Value: *filter.ToBlock})
+	}
+	if filter.From != "" {
+		query.Where = append(query.Where, db.Condition{Column: `"from"`, Operator: "=", Value: filter.From})
+	}
+	if filter.To != "" {
+		query.Where = append(query.Where, db.Condition{Column: `"to"`, Operator: "=", Value: filter.To})
+	}
+	if filter.ContractCreation != nil {
+		operator := "IS NULL"
+		if *filter.ContractCreation {
+			operator = "IS NOT NULL"
+		}
+		query.Where = append(query.Where, db.Condition{Column: "contract_address", Operator: operator})
+	}
+	if filter.MinValue != "" {
+		query.Where = append(query.Where, minValueCondition(filter.MinValue))
+	}
+
+	transactions := []Transaction{}
+	if err := r.db.FindPage(ctx, query, transactionKeyset(filter.After, !filter.Ascending), &transactions); err != nil {
+		return nil, fmt.Errorf("find transactions: %w", err)
+	}
+	return transactions, nil
+}
+
+// minValueCondition restricts a query to the transactions whose value is at least minValue. Values are stored as decimal strings
+// without leading zeros, so they compare as numbers by their length first and as text among the ones of the same length. Unlike
+// a cast to a number, which SQLite rounds to a float above 2^63, this is exact at any size on every dialect.
+func minValueCondition(minValue string) db.Condition {
+	minValue = strings.TrimLeft(minValue, "0")
+	if minValue == "" {
+		minValue = "0"
+	}
+	return db.Condition{Column: "(LENGTH(value), value)", Operator: ">=", Value: []any{len(minValue), minValue}}
+}
+
+// GetTentativeTransactions retrieves the cached transactions that have not yet reached the required confirmation depth.
+func (r *TransactionRepository) GetTentativeTransactions(ctx context.Context) ([]Transaction, error) {
+	transactions := []Transaction{}
+	err := r.db.Find(ctx, db.Query{
+		Where: r.onChain(db.Condition{Column: "tentative", Operator: "=", Value: true}),
+	}, &transactions)
+	if err != nil {
+		return nil, fmt.Errorf("get tentative transactions: %w", err)
+	}
+	return transactions, nil
+}
+
+// GetIncompleteTransactions retrieves a page of at most limit cached transactions that were stored before their block timestamp
+// or their type, gas and fee fields were captured, ordered by hash and starting after the hash after, which is empty for the
+// first page.
+func (r *TransactionRepository) GetIncompleteTransactions(ctx context.Context, after string, limit int) ([]Transaction, error) {
+	keyset := db.Keyset{Columns: []string{"transaction_hash"}}
+	if after != "" {
+		keyset.After = []any{after}
+	}
+
 	transactions := []Transaction{}
-	err := r.db.GetAll(ctx, &transactions)
+	err := r.db.FindPage(ctx, db.Query{
+		Where: r.onChain(),
+		AnyOf: []db.Condition{
+			{Column: "tx_type", Operator: "IS NULL"},
+			{Column: "block_timestamp", Operator: "IS NULL"},
+		},
+		Limit: limit,
+	}, keyset, &transactions)
 	if err != nil {
-		return nil, fmt.Errorf("get all transactions from db: %w", err)
+		return nil, fmt.Errorf("get incomplete transactions: %w", err)
 	}
 	return transactions, nil
 }
+
+// FindAddressTransactions retrieves the cached transactions that match the filter, newest first.
+func (r *TransactionRepository) FindAddressTransactions(ctx context.Context, filter AddressFilter) ([]Transaction, error) {
+	query := db.Query{Where: r.onChain(), Limit: filter.Limit}
+	sent, received := filter.Sent, filter.Received
+	if !sent && !received {
+		sent, received = true, true
+	}
+	if sent {
+		query.AnyOf = append(query.AnyOf, db.Condition{Column: `"from"`, Operator: "=", Value: filter.Address})
+	}
+	if received {
+		query.AnyOf = append(query.AnyOf,
+			db.Condition{Column: `"to"`, Operator: "=", Value: filter.Address},
+			db.Condition{Column: "contract_address", Operator: "=", Value: filter.Address})
+	}
+	if filter.FromBlock != nil {
+		query.Where = append(query.Where, db.Condition{Column: "block_number", Operator: ">=", Value: *filter.FromBlock})
+	}
+	if filter.ToBlock != nil {
+		query.Where = append(query.Where, db.Condition{Column: "block_number", Operator: "<=", Value: *filter.ToBlock})
+	}
+
+	transactions := []Transaction{}
+	if err := r.db.FindPage(ctx, query, transactionKeyset(filter.After, true), &transactions); err != nil {
+		return nil, fmt.Errorf("find address transactions: %w", err)
+	}
+	return transactions, nil
+}
+
+// transactionKeyset orders transactions by block number and hash, continuing after the given cursor when it is set.
+func transactionKeyset(after *TransactionCursor, descending bool) db.Keyset {
+	keyset := db.Keyset{
+		Columns:    []string{"block_number", "transaction_hash"},
+		Descending: descending,
+	}
+	if after != nil {
+		keyset.After = []any{after.BlockNumber, after.TransactionHash}
+	}
+	return keyset
+}
+
+// UpdateTransaction overwrites the cached transaction with the same hash.
+func (r *TransactionRepository) UpdateTransaction(ctx context.Context, transaction Transaction) error {
+	transaction.ChainID = r.chainID
+	err := r.db.OverwriteWhere(ctx, r.onChain(
+		db.Condition{Column: "transaction_hash", Operator: "=", Value: transaction.TransactionHash},
+	), &transaction)
+	if err != nil {
+		return fmt.Errorf("update transaction %q: %w", transaction.TransactionHash, err)
+	}
+	return nil
+}
+
+// ReplaceTransaction overwrites the cached transaction with the same hash and replaces its logs and token transfers in a single
+// database transaction, so that a transaction that was reorged into another block is never read with the logs of the old one.
+func (r *TransactionRepository) ReplaceTransaction(ctx context.Context, transaction Transaction, logs []TransactionLog, transfers []TokenTransfer) error {
+	return r.db.Transaction(ctx, func(ctx context.Context) error {
+		if err := r.UpdateTransaction(ctx, transaction); err != nil {
+			return err
+		}
+
+		if err := r.ReplaceTransactionLogs(ctx, transaction.TransactionHash, logs); err != nil {
+			return err
+		}
+
+		return r.ReplaceTokenTransfers(ctx, transaction.TransactionHash, transfers)
+	})
+}
+
+// DeleteTransactions evicts the transactions with the given hashes, together with their logs, token transfers and call traces,
+// from the cache.
+func (r *TransactionRepository) DeleteTransactions(ctx context.Context, txHashes []string) error {
+	if len(txHashes) == 0 {
+		return nil
+	}
+
+	conditions := r.onChain(db.Condition{Column: "transaction_hash", Operator: "IN", Value: txHashes})
+
+	_, err := r.db.DeleteWhere(ctx, conditions, &CallTrace{})
+	if err != nil {
+		return fmt.Errorf("delete call traces: %w", err)
+	}
+
+	_, err = r.db.DeleteWhere(ctx, conditions, &TokenTransfer{})
+	if err != nil {
+		return fmt.Errorf("delete token transfers: %w", err)
+	}
+
+	_, err = r.db.DeleteWhere(ctx, conditions, &TransactionLog{})
+	if err != nil {
+		return fmt.Errorf("delete transaction logs: %w", err)
+	}
+
+	_, err = r.db.DeleteWhere(ctx, conditions, &Transaction{})
+	if err != nil {
+		return fmt.Errorf("delete transactions: %w", err)
+	}
+	return nil
+}
+
+// SaveTransactionLogs saves the given logs to the DB, on the chain of the repository, skipping the ones that are already cached.
+func (r *TransactionRepository) SaveTransactionLogs(ctx context.Context, logs []TransactionLog) error {
+	if len(logs) == 0 {
+		return nil
+	}
+	for i := range logs {
+		logs[i].ChainID = r.chainID
+	}
+
+	err := r.db.Upsert(ctx, &logs, db.Conflict{Columns: []string{"chain_id", "transaction_hash", "log_index"}})
+	if err != nil {
+		return fmt.Errorf("insert into table transaction_logs: %w", err)
+	}
+	return nil
+}
+
+// ReplaceTransactionLogs replaces the cached logs of the transaction with the given hash in a single database transaction, e.g.
+// after it was reorged into another block.
+func (r *TransactionRepository) ReplaceTransactionLogs(ctx context.Context, txHash string, logs []TransactionLog) error {
+	return r.db.Transaction(ctx, func(ctx context.Context) error {
+		_, err := r.db.DeleteWhere(ctx, r.onChain(
+			db.Condition{Column: "transaction_hash", Operator: "=", Value: txHash},
+		), &TransactionLog{})
+		if err != nil {
+			return fmt.Errorf("delete logs of %q: %w", txHash, err)
+		}
+
+		return r.SaveTransactionLogs(ctx, logs)
+	})
+}
+
+// GetTransactionLogs retrieves the logs of the transactions with the given hashes, ordered by transaction and log index.
+func (r *TransactionRepository) GetTransactionLogs(ctx context.Context, txHashes []string) ([]TransactionLog, error) {
+	logs := []TransactionLog{}
+	err := r.db.Find(ctx, db.Query{
+		Where:   r.onChain(db.Condition{Column: "transaction_hash", Operator: "IN", Value: txHashes}),
+		OrderBy: "transaction_hash, log_index",
+	}, &logs)
+	if err != nil {
+		return nil, fmt.Errorf("get logs by transaction hash: %w", err)
+	}
+	return logs, nil
+}
+
+// FindLogs retrieves the logs that match the filter, oldest first.
+func (r *TransactionRepository) FindLogs(ctx context.Context, filter LogFilter) ([]TransactionLog, error) {
+	query := db.Query{
+		Where:   r.onChain(),
+		OrderBy: "block_number, log_index",
+		Limit:   filter.Limit,
+	}
+	if filter.Address != "" {
+		query.Where = append(query.Where, db.Condition{Column: "address", Operator: "=", Value: filter.Address})
+	}
+	if filter.Topic0 != "" {
+		query.Where = append(query.Where, db.Condition{Column: "topic0", Operator: "=", Value: filter.Topic0})
+	}
+
+	logs := []TransactionLog{}
+	if err := r.db.Find(ctx, query, &logs); err != nil {
+		return nil, fmt.Errorf("find logs: %w", err)
+	}
+	return logs, nil
+}
+
+// SaveTokenTransfers saves the given token transfers to the DB, on the chain of the repository, skipping the ones that are
+// already cached.
+func (r *TransactionRepository) SaveTokenTransfers(ctx context.Context, transfers []TokenTransfer) error {
+	if len(transfers) == 0 {
+		return nil
+	}
+	for i := range transfers {
+		transfers[i].ChainID = r.chainID
+	}
+
+	err := r.db.Upsert(ctx, &transfers, db.Conflict{Columns: []string{"chain_id", "transaction_hash", "log_index", "batch_index"}})
+	if err != nil {
+		return fmt.Errorf("insert into table token_transfers: %w", err)
+	}
+	return nil
+}
+
+// ReplaceTokenTransfers replaces the cached token transfers of the transaction with the given hash in a single database
+// transaction.
+func (r *TransactionRepository) ReplaceTokenTransfers(ctx context.Context, txHash string, transfers []TokenTransfer) error {
+	return r.db.Transaction(ctx, func(ctx context.Context) error {
+		_, err := r.db.DeleteWhere(ctx, r.onChain(
+			db.Condition{Column: "transaction_hash", Operator: "=", Value: txHash},
+		), &TokenTransfer{})
+		if err != nil {
+			return fmt.Errorf("delete token transfers of %q: %w", txHash, err)
+		}
+
+		return r.SaveTokenTransfers(ctx, transfers)
+	})
+}
+
+// GetTokenTransfers retrieves the token transfers of the transactions with the given hashes, in the order they were emitted.
+func (r *TransactionRepository) GetTokenTransfers(ctx context.Context, txHashes []string) ([]TokenTransfer, error) {
+	transfers := []TokenTransfer{}
+	err := r.db.Find(ctx, db.Query{
+		Where:   r.onChain(db.Condition{Column: "transaction_hash", Operator: "IN", Value: txHashes}),
+		OrderBy: "transaction_hash, log_index, batch_index",
+	}, &transfers)
+	if err != nil {
+		return nil, fmt.Errorf("get token transfers by transaction hash: %w", err)
+	}
+	return transfers, nil
+}
+
+// FindTokenTransfers retrieves the token transfers that match the filter, oldest first.
+func (r *TransactionRepository) FindTokenTransfers(ctx context.Context, filter TransferFilter) ([]TokenTransfer, error) {
+	query := db.Query{
+		Where:   r.onChain(),
+		OrderBy: "block_number, log_index, batch_index",
+		Limit:   filter.Limit,
+	}
+	if filter.Token != "" {
+		query.Where = append(query.Where, db.Condition{Column: "token", Operator: "=", Value: filter.Token})
+	}
+	if filter.Holder != "" {
+		query.AnyOf = []db.Condition{
+			{Column: "from_address", Operator: "=", Value: filter.Holder},
+			{Column: "to_address", Operator: "=", Value: filter.Holder},
+		}
+	}
+
+	transfers := []TokenTransfer{}
+	if err := r.db.Find(ctx, query, &transfers); err != nil {
+		return nil, fmt.Errorf("find token transfers: %w", err)
+	}
+	return transfers, nil
+}
+
+// SaveCallTraces saves the given call traces to the DB, on the chain of the repository, skipping the ones that are already
+// cached.
+func (r *TransactionRepository) SaveCallTraces(ctx context.Context, traces []CallTrace) error {
+	if len(traces) == 0 {
+		return nil
+	}
+	for i := range traces {
+		traces[i].ChainID = r.chainID
+	}
+
+	err := r.db.Upsert(ctx, &traces, db.Conflict{Columns: []string{"chain_id", "transaction_hash", "trace_index"}})
+	if err != nil {
+		return fmt.Errorf("insert into table call_traces: %w", err)
+	}
+	return nil
+}
+
+// GetCallTraces retrieves the call traces of the transaction with the given hash, in the depth first order of its call tree.
+// It returns no traces when the transaction was not traced yet.
+func (r *TransactionRepository) GetCallTraces(ctx context.Context, txHash string) ([]CallTrace, error) {
+	traces := []CallTrace{}
+	err := r.db.Find(ctx, db.Query{
+		Where:   r.onChain(db.Condition{Column: "transaction_hash", Operator: "=", Value: txHash}),
+		OrderBy: "trace_index",
+	}, &traces)
+	if err != nil {
+		return nil, fmt.Errorf("get call traces of %q: %w", txHash, err)
+	}
+	return traces, nil
+}
+
+// SaveBlock caches the given block. A block that is already cached is overwritten.
+func (r *TransactionRepository) SaveBlock(ctx context.Context, block Block) error {
+	block.ChainID = r.chainID
+	err := r.db.Upsert(ctx, &block, db.Conflict{Columns: []string{"chain_id", "hash"}, UpdateAll: true})
+	if err != nil {
+		return fmt.Errorf("save block %q: %w", block.Hash, err)
+	}
+	return nil
+}
+
+// GetBlockByHash retrieves the cached block with the given hash.
+func (r *TransactionRepository) GetBlockByHash(ctx context.Context, hash string) (Block, error) {
+	return r.getBlockBy(ctx, "hash", hash)
+}
+
+// GetBlockByNumber retrieves the cached block with the given number.
+func (r *TransactionRepository) GetBlockByNumber(ctx context.Context, number uint64) (Block, error) {
+	return r.getBlockBy(ctx, "number", number)
+}
+
+func (r *TransactionRepository) getBlockBy(ctx context.Context, column string, value any) (Block, error) {
+	blocks := []Block{}
+	err := r.db.Find(ctx, db.Query{
+		Where: r.onChain(db.Condition{Column: column, Operator: "=", Value: value}),
+		Limit: 1,
+	}, &blocks)
+	if err != nil {
+		return Block{}, fmt.Errorf("get block by %s: %w", column, err)
+	}
+	if len(blocks) == 0 {
+		return Block{}, ErrBlockNotFound
+	}
+	return blocks[0], nil
+}
+
+// SaveIndexerCheckpoint stores the progress of an indexer, replacing the checkpoint stored before under the same name.
+func (r *TransactionRepository) SaveIndexerCheckpoint(ctx context.Context, checkpoint IndexerCheckpoint) error {
+	checkpoint.ChainID = r.chainID
+	err := r.db.Upsert(ctx, &checkpoint, db.Conflict{Columns: []string{"chain_id", "name"}, UpdateAll: true})
+	if err != nil {
+		return fmt.Errorf("save checkpoint %q: %w", checkpoint.Name, err)
+	}
+	return nil
+}
+
+// GetIndexerCheckpoint retrieves the checkpoint stored under the given name.
+func (r *TransactionRepository) GetIndexerCheckpoint(ctx context.Context, name string) (IndexerCheckpoint, error) {
+	checkpoints := []IndexerCheckpoint{}
+	err := r.db.Find(ctx, db.Query{
+		Where: r.onChain(db.Condition{Column: "name", Operator: "=", Value: name}),
+		Limit: 1,
+	}, &checkpoints)
+	if err != nil {
+		return IndexerCheckpoint{}, fmt.Errorf("get checkpoint %q: %w", name, err)
+	}
+	if len(checkpoints) == 0 {
+		return IndexerCheckpoint{}, ErrCheckpointNotFound
+	}
+	return checkpoints[0], nil
+}
+
+// SaveContractABI stores the ABI of a contract, replacing the one stored before.
+func (r *TransactionRepository) SaveContractABI(ctx context.Context, contractABI ContractABI) error {
+	contractABI.ChainID = r.chainID
+	err := r.db.Upsert(ctx, &contractABI, db.Conflict{Columns: []string{"chain_id", "address"}, UpdateAll: true})
+	if err != nil {
+		return fmt.Errorf("save abi of %q: %w", contractABI.Address, err)
+	}
+	return nil
+}
+
+// GetContractABIs retrieves the ABIs of all contracts of the chain.
+func (r *TransactionRepository) GetContractABIs(ctx context.Context) ([]ContractABI, error) {
+	abis := []ContractABI{}
+	err := r.db.Find(ctx, db.Query{Where: r.onChain()}, &abis)
+	if err != nil {
+		return nil, fmt.Errorf("get all contract abis: %w", err)
+	}
+	return abis, nil
+}
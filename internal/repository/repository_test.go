@@ -6,6 +6,7 @@ import (
 	"fethcher/internal/db"
 	"fethcher/internal/repository"
 	"fethcher/internal/repository/fake"
+	"time"
 
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
@@ -13,6 +14,9 @@ import (
 )
 
 var _ = Describe("TransactionRepository", func() {
+	const chainID = uint64(8453)
+	onChain := db.Condition{Column: "chain_id", Operator: "=", Value: chainID}
+
 	var (
 		repo        *repository.TransactionRepository
 		fakeStorage *fake.Storage
@@ -22,45 +26,14 @@ var _ = Describe("TransactionRepository", func() {
 
 	BeforeEach(func() {
 		fakeStorage = new(fake.Storage)
-		repo = repository.NewTransactionRepository(fakeStorage)
+		fakeStorage.TransactionStub = func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}
+		repo = repository.NewTransactionRepository(fakeStorage, chainID)
 		fakeErr = errors.New("fake error")
 		ctx = context.Background()
 	})
 
-	Describe("Migrate", func() {
-		var err error
-
-		JustBeforeEach(func() {
-			err = repo.MigrateTables(&repository.Transaction{}, &repository.User{}, &repository.UserTransaction{})
-		})
-
-		When("migration succeeds", func() {
-			BeforeEach(func() {
-				fakeStorage.MigrateTableReturns(nil)
-			})
-
-			It("should migrate tables", func() {
-				Expect(err).NotTo(HaveOccurred())
-				Expect(fakeStorage.MigrateTableCallCount()).To(Equal(1))
-				tables := fakeStorage.MigrateTableArgsForCall(0)
-				Expect(tables).To(HaveLen(3))
-				Expect(tables[0]).To(BeAssignableToTypeOf(&repository.Transaction{}))
-				Expect(tables[1]).To(BeAssignableToTypeOf(&repository.User{}))
-				Expect(tables[2]).To(BeAssignableToTypeOf(&repository.UserTransaction{}))
-			})
-		})
-
-		When("migration fails", func() {
-			BeforeEach(func() {
-				fakeStorage.MigrateTableReturns(errors.New("migration error"))
-			})
-
-			It("should return an error", func() {
-				Expect(err).To(MatchError("migrate table(s): migration error"))
-			})
-		})
-	})
-
 	Describe("SeedUserTable", func() {
 		var err error
 
@@ -123,81 +96,114 @@ var _ = Describe("TransactionRepository", func() {
 
 		When("save transactions succeeds", func() {
 			BeforeEach(func() {
-				fakeStorage.InsertToTableReturns(nil)
+				fakeStorage.UpsertReturns(nil)
 			})
 
-			It("should save transactions", func() {
+			It("should upsert the transactions in a database transaction", func() {
 				Expect(err).NotTo(HaveOccurred())
-				Expect(fakeStorage.InsertToTableCallCount()).To(Equal(1))
-				_, arg := fakeStorage.InsertToTableArgsForCall(0)
+				Expect(fakeStorage.TransactionCallCount()).To(Equal(1))
+				Expect(fakeStorage.UpsertCallCount()).To(Equal(1))
+				_, arg, conflict := fakeStorage.UpsertArgsForCall(0)
 				Expect(arg).To(Equal(&transactions))
+				Expect(transactions[0].ChainID).To(Equal(chainID))
+				Expect(conflict.Columns).To(Equal([]string{"chain_id", "transaction_hash"}))
+				Expect(conflict.UpdateAll).To(BeTrue())
+				Expect(conflict.UpdateIf).To(ContainSubstring("confirmations"))
 			})
 		})
 
 		When("save transactions fails", func() {
 			BeforeEach(func() {
-				fakeStorage.InsertToTableReturns(fakeErr)
+				fakeStorage.UpsertReturns(fakeErr)
 			})
 
 			It("should return an error", func() {
 				Expect(err).To(MatchError(fakeErr))
 			})
 		})
+
+		When("transactions are empty", func() {
+			BeforeEach(func() {
+				transactions = nil
+			})
+
+			It("should return immediately", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeStorage.TransactionCallCount()).To(Equal(0))
+			})
+		})
 	})
 
 	Describe("GetUserHistory", func() {
 		var (
-			userID string
-			err    error
-			hashes []string
+			userID  string
+			filter  repository.HistoryFilter
+			err     error
+			entries []repository.UserTransaction
 		)
 
 		BeforeEach(func() {
 			userID = uuid.NewString()
-			fakeErr = errors.New("fake error")
+			filter = repository.HistoryFilter{UserID: userID, Limit: 10}
 		})
 
 		JustBeforeEach(func() {
-			hashes, err = repo.GetUserHistory(ctx, userID)
+			entries, err = repo.GetUserHistory(ctx, filter)
 		})
 
 		When("user has history", func() {
 			BeforeEach(func() {
-				fakeStorage.GetAllByStub = func(ctx context.Context, column string, value any, dest any) error {
+				fakeStorage.FindPageStub = func(ctx context.Context, query db.Query, keyset db.Keyset, dest any) error {
 					userTxs := dest.(*[]repository.UserTransaction)
 					*userTxs = []repository.UserTransaction{
-						{TransactionHash: "0x1"},
 						{TransactionHash: "0x2"},
+						{TransactionHash: "0x1"},
 					}
 					return nil
 				}
 			})
 
-			It("should return transaction hashes", func() {
+			It("should return the most recently queried entries first", func() {
 				Expect(err).NotTo(HaveOccurred())
-				Expect(hashes).To(Equal([]string{"0x1", "0x2"}))
+				Expect(entries).To(HaveLen(2))
 
-				Expect(fakeStorage.GetAllByCallCount()).To(Equal(1))
-				_, col, val, usrTxs := fakeStorage.GetAllByArgsForCall(0)
-				Expect(col).To(Equal("user_id"))
-				Expect(val).To(Equal(userID))
-				Expect(usrTxs).To(BeAssignableToTypeOf(&[]repository.UserTransaction{}))
+				Expect(fakeStorage.FindPageCallCount()).To(Equal(1))
+				_, query, keyset, _ := fakeStorage.FindPageArgsForCall(0)
+				Expect(query.Where).To(Equal([]db.Condition{onChain, {Column: "user_id", Operator: "=", Value: userID}}))
+				Expect(query.Limit).To(Equal(10))
+				Expect(keyset.Columns).To(Equal([]string{"queried_at", "transaction_hash"}))
+				Expect(keyset.Descending).To(BeTrue())
+				Expect(keyset.After).To(BeEmpty())
 			})
 		})
 
-		When("user not found", func() {
+		When("a date range and a cursor are given", func() {
+			var from, to, last time.Time
+
 			BeforeEach(func() {
-				fakeStorage.GetAllByReturns(db.ErrNotFound)
+				from = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+				to = time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+				last = time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+				filter.QueriedFrom = &from
+				filter.QueriedTo = &to
+				filter.After = &repository.HistoryCursor{QueriedAt: last, TransactionHash: "0x9"}
 			})
 
-			It("should return user not found error", func() {
-				Expect(err).To(MatchError(repository.ErrUserNotFound))
+			It("should restrict the query time and continue after the cursor", func() {
+				Expect(err).NotTo(HaveOccurred())
+
+				_, query, keyset, _ := fakeStorage.FindPageArgsForCall(0)
+				Expect(query.Where).To(ContainElements(
+					db.Condition{Column: "queried_at", Operator: ">=", Value: from},
+					db.Condition{Column: "queried_at", Operator: "<=", Value: to},
+				))
+				Expect(keyset.After).To(Equal([]any{last, "0x9"}))
 			})
 		})
 
 		When("database error occurs", func() {
 			BeforeEach(func() {
-				fakeStorage.GetAllByReturns(fakeErr)
+				fakeStorage.FindPageReturns(fakeErr)
 			})
 
 			It("should return the error", func() {
@@ -224,21 +230,84 @@ var _ = Describe("TransactionRepository", func() {
 
 		When("save succeeds", func() {
 			BeforeEach(func() {
-				fakeStorage.InsertToTableReturns(nil)
+				fakeStorage.UpsertReturns(nil)
 			})
 
 			It("should save user transactions", func() {
 				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeStorage.TransactionCallCount()).To(Equal(1))
+
+				Expect(fakeStorage.UpdateWhereCallCount()).To(Equal(0))
+				Expect(fakeStorage.UpsertCallCount()).To(Equal(1))
+				_, arg, _ := fakeStorage.UpsertArgsForCall(0)
+				userTxs := arg.(*[]repository.UserTransaction)
+				Expect(*userTxs).To(HaveLen(2))
+				Expect((*userTxs)[0].ChainID).To(Equal(chainID))
+				Expect((*userTxs)[0].QueryCount).To(Equal(uint(1)))
+				Expect((*userTxs)[0].QueriedAt).NotTo(BeZero())
+
+				_, _, conflict := fakeStorage.UpsertArgsForCall(0)
+				Expect(conflict).To(Equal(db.Conflict{Columns: []string{"user_id", "chain_id", "transaction_hash"}}))
+			})
+		})
+
+		When("some transactions are already in the history", func() {
+			BeforeEach(func() {
+				fakeStorage.FindStub = func(ctx context.Context, query db.Query, dest any) error {
+					userTxs := dest.(*[]repository.UserTransaction)
+					*userTxs = []repository.UserTransaction{{UserID: userID, TransactionHash: "0x1", QueryCount: 3}}
+					return nil
+				}
+			})
+
+			It("should bump the existing entries and insert the new ones", func() {
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeStorage.UpdateWhereCallCount()).To(Equal(1))
+				_, conditions, values, _ := fakeStorage.UpdateWhereArgsForCall(0)
+				Expect(conditions).To(ContainElements(onChain, db.Condition{Column: "transaction_hash", Operator: "IN", Value: []string{"0x1"}}))
+				Expect(values).To(HaveKeyWithValue("query_count", db.Increment(1)))
+				Expect(values).To(HaveKey("queried_at"))
+
+				_, arg, _ := fakeStorage.UpsertArgsForCall(0)
+				userTxs := arg.(*[]repository.UserTransaction)
+				Expect(*userTxs).To(HaveLen(1))
+				Expect((*userTxs)[0].TransactionHash).To(Equal("0x2"))
+			})
+		})
+
+		When("all transactions are already in the history", func() {
+			BeforeEach(func() {
+				transactions = []string{"0x1", "0x1"}
+				fakeStorage.FindStub = func(ctx context.Context, query db.Query, dest any) error {
+					userTxs := dest.(*[]repository.UserTransaction)
+					*userTxs = []repository.UserTransaction{{UserID: userID, TransactionHash: "0x1"}}
+					return nil
+				}
+			})
+
+			It("should not insert anything", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeStorage.UpdateWhereCallCount()).To(Equal(1))
+				Expect(fakeStorage.UpsertCallCount()).To(Equal(0))
+			})
+		})
 
-				Expect(fakeStorage.InsertToTableCallCount()).To(Equal(1))
-				_, arg := fakeStorage.InsertToTableArgsForCall(0)
-				Expect(arg).To(BeAssignableToTypeOf(&[]repository.UserTransaction{}))
+		When("a transaction is repeated", func() {
+			BeforeEach(func() {
+				transactions = []string{"0x1", "0x1"}
+			})
+
+			It("should insert it once", func() {
+				Expect(err).NotTo(HaveOccurred())
+				_, arg, _ := fakeStorage.UpsertArgsForCall(0)
+				Expect(*arg.(*[]repository.UserTransaction)).To(HaveLen(1))
 			})
 		})
 
 		When("save fails", func() {
 			BeforeEach(func() {
-				fakeStorage.InsertToTableReturns(fakeErr)
+				fakeStorage.UpsertReturns(fakeErr)
 			})
 
 			It("should return an error", func() {
@@ -252,11 +321,76 @@ var _ = Describe("TransactionRepository", func() {
 			})
 			It("should return immediately", func() {
 				Expect(err).NotTo(HaveOccurred())
-				Expect(fakeStorage.InsertToTableCallCount()).To(Equal(0))
+				Expect(fakeStorage.UpsertCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("DeleteUserHistory", func() {
+		var (
+			userID  string
+			deleted int64
+			err     error
+		)
+
+		BeforeEach(func() {
+			userID = uuid.NewString()
+			fakeStorage.DeleteWhereReturns(1, nil)
+		})
+
+		JustBeforeEach(func() {
+			deleted, err = repo.DeleteUserHistory(ctx, userID, []string{"0x1"})
+		})
+
+		It("should delete the entries of the user", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleted).To(Equal(int64(1)))
+
+			_, conditions, entity := fakeStorage.DeleteWhereArgsForCall(0)
+			Expect(conditions).To(Equal([]db.Condition{
+				onChain,
+				{Column: "user_id", Operator: "=", Value: userID},
+				{Column: "transaction_hash", Operator: "IN", Value: []string{"0x1"}},
+			}))
+			Expect(entity).To(BeAssignableToTypeOf(&repository.UserTransaction{}))
+		})
+
+		When("database error occurs", func() {
+			BeforeEach(func() {
+				fakeStorage.DeleteWhereReturns(0, fakeErr)
+			})
+
+			It("should return the error", func() {
+				Expect(err).To(MatchError(fakeErr))
 			})
 		})
 	})
 
+	Describe("ClearUserHistory", func() {
+		var (
+			userID  string
+			deleted int64
+			err     error
+		)
+
+		BeforeEach(func() {
+			userID = uuid.NewString()
+			fakeStorage.DeleteWhereReturns(3, nil)
+		})
+
+		JustBeforeEach(func() {
+			deleted, err = repo.ClearUserHistory(ctx, userID)
+		})
+
+		It("should delete every entry of the user", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleted).To(Equal(int64(3)))
+
+			_, conditions, _ := fakeStorage.DeleteWhereArgsForCall(0)
+			Expect(conditions).To(Equal([]db.Condition{onChain, {Column: "user_id", Operator: "=", Value: userID}}))
+		})
+	})
+
 	Describe("GetUserFromDB", func() {
 		var (
 			user     repository.User
@@ -334,7 +468,7 @@ var _ = Describe("TransactionRepository", func() {
 
 		When("transactions exist", func() {
 			BeforeEach(func() {
-				fakeStorage.GetAllByStub = func(ctx context.Context, column string, value any, dest any) error {
+				fakeStorage.FindStub = func(ctx context.Context, query db.Query, dest any) error {
 					txs := dest.(*[]repository.Transaction)
 					*txs = []repository.Transaction{
 						{TransactionHash: "0x1"},
@@ -348,16 +482,18 @@ var _ = Describe("TransactionRepository", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(transactions).To(HaveLen(2))
 
-				Expect(fakeStorage.GetAllByCallCount()).To(Equal(1))
-				_, col, val, _ := fakeStorage.GetAllByArgsForCall(0)
-				Expect(col).To(Equal("transaction_hash"))
-				Expect(val).To(Equal(txHashes))
+				Expect(fakeStorage.FindCallCount()).To(Equal(1))
+				_, query, _ := fakeStorage.FindArgsForCall(0)
+				Expect(query.Where).To(Equal([]db.Condition{
+					onChain,
+					{Column: "transaction_hash", Operator: "IN", Value: txHashes},
+				}))
 			})
 		})
 
 		When("no transactions exist", func() {
 			BeforeEach(func() {
-				fakeStorage.GetAllByReturns(nil)
+				fakeStorage.FindReturns(nil)
 			})
 
 			It("should return empty slice", func() {
@@ -368,7 +504,968 @@ var _ = Describe("TransactionRepository", func() {
 
 		When("database error occurs", func() {
 			BeforeEach(func() {
-				fakeStorage.GetAllByReturns(fakeErr)
+				fakeStorage.FindReturns(fakeErr)
+			})
+
+			It("should return the error", func() {
+				Expect(err).To(MatchError(fakeErr))
+			})
+		})
+	})
+
+	Describe("GetTentativeTransactions", func() {
+		var (
+			transactions []repository.Transaction
+			err          error
+		)
+
+		JustBeforeEach(func() {
+			transactions, err = repo.GetTentativeTransactions(ctx)
+		})
+
+		When("tentative transactions exist", func() {
+			BeforeEach(func() {
+				fakeStorage.FindStub = func(ctx context.Context, query db.Query, dest any) error {
+					txs := dest.(*[]repository.Transaction)
+					*txs = []repository.Transaction{
+						{TransactionHash: "0x1", Tentative: true},
+					}
+					return nil
+				}
+			})
+
+			It("should return them", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(transactions).To(HaveLen(1))
+
+				_, query, _ := fakeStorage.FindArgsForCall(0)
+				Expect(query.Where).To(Equal([]db.Condition{onChain, {Column: "tentative", Operator: "=", Value: true}}))
+			})
+		})
+
+		When("database error occurs", func() {
+			BeforeEach(func() {
+				fakeStorage.FindReturns(fakeErr)
+			})
+
+			It("should return the error", func() {
+				Expect(err).To(MatchError(fakeErr))
+			})
+		})
+	})
+
+	Describe("GetIncompleteTransactions", func() {
+		var err error
+
+		JustBeforeEach(func() {
+			_, err = repo.GetIncompleteTransactions(ctx, "0x1", 100)
+		})
+
+		It("should find a page of the transactions without a type or block timestamp after the given hash", func() {
+			Expect(err).NotTo(HaveOccurred())
+			_, query, keyset, entity := fakeStorage.FindPageArgsForCall(0)
+			Expect(query.Where).To(Equal([]db.Condition{onChain}))
+			Expect(query.AnyOf).To(Equal([]db.Condition{
+				{Column: "tx_type", Operator: "IS NULL"},
+				{Column: "block_timestamp", Operator: "IS NULL"},
+			}))
+			Expect(query.Limit).To(Equal(100))
+			Expect(keyset).To(Equal(db.Keyset{Columns: []string{"transaction_hash"}, After: []any{"0x1"}}))
+			Expect(entity).To(BeAssignableToTypeOf(&[]repository.Transaction{}))
+		})
+
+		When("database error occurs", func() {
+			BeforeEach(func() {
+				fakeStorage.FindPageReturns(fakeErr)
+			})
+
+			It("should return the error", func() {
+				Expect(err).To(MatchError(fakeErr))
+			})
+		})
+	})
+
+	Describe("UpdateTransaction", func() {
+		var (
+			transaction repository.Transaction
+			err         error
+		)
+
+		BeforeEach(func() {
+			transaction = repository.Transaction{TransactionHash: "0x1", BlockHash: "0xbbb"}
+		})
+
+		JustBeforeEach(func() {
+			err = repo.UpdateTransaction(ctx, transaction)
+		})
+
+		When("update succeeds", func() {
+			It("should overwrite the transaction with the same chain and hash", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeStorage.OverwriteWhereCallCount()).To(Equal(1))
+				_, conditions, record := fakeStorage.OverwriteWhereArgsForCall(0)
+				Expect(conditions).To(Equal([]db.Condition{onChain, {Column: "transaction_hash", Operator: "=", Value: "0x1"}}))
+				transaction.ChainID = chainID
+				Expect(record).To(Equal(&transaction))
+			})
+		})
+
+		When("transaction is not cached", func() {
+			BeforeEach(func() {
+				fakeStorage.OverwriteWhereReturns(db.ErrNotFound)
+			})
+
+			It("should return the error", func() {
+				Expect(err).To(MatchError(db.ErrNotFound))
+			})
+		})
+	})
+
+	Describe("ReplaceTransaction", func() {
+		var err error
+
+		JustBeforeEach(func() {
+			err = repo.ReplaceTransaction(ctx, repository.Transaction{TransactionHash: "0x1", BlockHash: "0xbbb"},
+				[]repository.TransactionLog{{TransactionHash: "0x1", LogIndex: 3}},
+				[]repository.TokenTransfer{{TransactionHash: "0x1", LogIndex: 3}})
+		})
+
+		It("should overwrite the transaction and replace its logs and token transfers in one database transaction", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeStorage.OverwriteWhereCallCount()).To(Equal(1))
+			Expect(fakeStorage.DeleteWhereCallCount()).To(Equal(2))
+			Expect(fakeStorage.UpsertCallCount()).To(Equal(2))
+			Expect(fakeStorage.TransactionCallCount()).To(Equal(3))
+		})
+
+		When("overwriting the transaction fails", func() {
+			BeforeEach(func() {
+				fakeStorage.OverwriteWhereReturns(fakeErr)
+			})
+
+			It("should not replace its logs and token transfers", func() {
+				Expect(err).To(MatchError(fakeErr))
+				Expect(fakeStorage.DeleteWhereCallCount()).To(Equal(0))
+				Expect(fakeStorage.UpsertCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("DeleteTransactions", func() {
+		var (
+			txHashes []string
+			err      error
+		)
+
+		BeforeEach(func() {
+			txHashes = []string{"0x1", "0x2"}
+		})
+
+		JustBeforeEach(func() {
+			err = repo.DeleteTransactions(ctx, txHashes)
+		})
+
+		When("delete succeeds", func() {
+			It("should delete the transactions, their logs, token transfers and call traces by transaction hash", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeStorage.DeleteWhereCallCount()).To(Equal(4))
+				conditions := []db.Condition{onChain, {Column: "transaction_hash", Operator: "IN", Value: txHashes}}
+				_, cond, entity := fakeStorage.DeleteWhereArgsForCall(0)
+				Expect(cond).To(Equal(conditions))
+				Expect(entity).To(BeAssignableToTypeOf(&repository.CallTrace{}))
+				_, cond, entity = fakeStorage.DeleteWhereArgsForCall(1)
+				Expect(cond).To(Equal(conditions))
+				Expect(entity).To(BeAssignableToTypeOf(&repository.TokenTransfer{}))
+				_, cond, entity = fakeStorage.DeleteWhereArgsForCall(2)
+				Expect(cond).To(Equal(conditions))
+				Expect(entity).To(BeAssignableToTypeOf(&repository.TransactionLog{}))
+				_, cond, entity = fakeStorage.DeleteWhereArgsForCall(3)
+				Expect(cond).To(Equal(conditions))
+				Expect(entity).To(BeAssignableToTypeOf(&repository.Transaction{}))
+			})
+		})
+
+		When("no hashes are given", func() {
+			BeforeEach(func() {
+				txHashes = nil
+			})
+
+			It("should return immediately", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeStorage.DeleteWhereCallCount()).To(Equal(0))
+			})
+		})
+
+		When("database error occurs", func() {
+			BeforeEach(func() {
+				fakeStorage.DeleteWhereReturns(0, fakeErr)
+			})
+
+			It("should return the error", func() {
+				Expect(err).To(MatchError(fakeErr))
+			})
+		})
+	})
+
+	Describe("SaveTransactionLogs", func() {
+		var (
+			logs []repository.TransactionLog
+			err  error
+		)
+
+		BeforeEach(func() {
+			logs = []repository.TransactionLog{{TransactionHash: "0x1", LogIndex: 0}}
+		})
+
+		JustBeforeEach(func() {
+			err = repo.SaveTransactionLogs(ctx, logs)
+		})
+
+		When("insert succeeds", func() {
+			It("should insert the logs", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeStorage.UpsertCallCount()).To(Equal(1))
+				_, records, conflict := fakeStorage.UpsertArgsForCall(0)
+				Expect(records).To(Equal(&logs))
+				Expect(logs[0].ChainID).To(Equal(chainID))
+				Expect(conflict).To(Equal(db.Conflict{Columns: []string{"chain_id", "transaction_hash", "log_index"}}))
+			})
+		})
+
+		When("there are no logs", func() {
+			BeforeEach(func() {
+				logs = nil
+			})
+
+			It("should return immediately", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeStorage.UpsertCallCount()).To(Equal(0))
+			})
+		})
+
+		When("database error occurs", func() {
+			BeforeEach(func() {
+				fakeStorage.UpsertReturns(fakeErr)
+			})
+
+			It("should return the error", func() {
+				Expect(err).To(MatchError(fakeErr))
+			})
+		})
+	})
+
+	Describe("ReplaceTransactionLogs", func() {
+		var err error
+
+		JustBeforeEach(func() {
+			err = repo.ReplaceTransactionLogs(ctx, "0x1", []repository.TransactionLog{{TransactionHash: "0x1", LogIndex: 3}})
+		})
+
+		It("should delete the old logs before inserting the new ones", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeStorage.DeleteWhereCallCount()).To(Equal(1))
+			_, conditions, entity := fakeStorage.DeleteWhereArgsForCall(0)
+			Expect(conditions).To(Equal([]db.Condition{onChain, {Column: "transaction_hash", Operator: "=", Value: "0x1"}}))
+			Expect(entity).To(BeAssignableToTypeOf(&repository.TransactionLog{}))
+			Expect(fakeStorage.UpsertCallCount()).To(Equal(1))
+			Expect(fakeStorage.TransactionCallCount()).To(Equal(1))
+		})
+
+		When("deleting the old logs fails", func() {
+			BeforeEach(func() {
+				fakeStorage.DeleteWhereReturns(0, fakeErr)
+			})
+
+			It("should not insert the new logs", func() {
+				Expect(err).To(MatchError(fakeErr))
+				Expect(fakeStorage.UpsertCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("GetTransactionLogs", func() {
+		var (
+			logs []repository.TransactionLog
+			err  error
+		)
+
+		BeforeEach(func() {
+			fakeStorage.FindStub = func(ctx context.Context, query db.Query, entity any) error {
+				*entity.(*[]repository.TransactionLog) = []repository.TransactionLog{{TransactionHash: "0x1"}}
+				return nil
+			}
+		})
+
+		JustBeforeEach(func() {
+			logs, err = repo.GetTransactionLogs(ctx, []string{"0x1", "0x2"})
+		})
+
+		It("should find the logs by transaction hash", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(logs).To(HaveLen(1))
+			_, query, _ := fakeStorage.FindArgsForCall(0)
+			Expect(query.Where).To(Equal([]db.Condition{onChain, {Column: "transaction_hash", Operator: "IN", Value: []string{"0x1", "0x2"}}}))
+		})
+
+		When("database error occurs", func() {
+			BeforeEach(func() {
+				fakeStorage.FindStub = nil
+				fakeStorage.FindReturns(fakeErr)
+			})
+
+			It("should return the error", func() {
+				Expect(err).To(MatchError(fakeErr))
+			})
+		})
+	})
+
+	Describe("FindLogs", func() {
+		var (
+			filter repository.LogFilter
+			err    error
+		)
+
+		BeforeEach(func() {
+			filter = repository.LogFilter{Address: "0xabc", Limit: 50}
+		})
+
+		JustBeforeEach(func() {
+			_, err = repo.FindLogs(ctx, filter)
+		})
+
+		When("only the address is given", func() {
+			It("should filter by address only", func() {
+				Expect(err).NotTo(HaveOccurred())
+				_, query, _ := fakeStorage.FindArgsForCall(0)
+				Expect(query.Where).To(Equal([]db.Condition{onChain, {Column: "address", Operator: "=", Value: "0xabc"}}))
+				Expect(query.Limit).To(Equal(50))
+				Expect(query.OrderBy).To(Equal("block_number, log_index"))
+			})
+		})
+
+		When("address and topic0 are given", func() {
+			BeforeEach(func() {
+				filter.Topic0 = "0xddf2"
+			})
+
+			It("should filter by both", func() {
+				_, query, _ := fakeStorage.FindArgsForCall(0)
+				Expect(query.Where).To(ConsistOf(
+					onChain,
+					db.Condition{Column: "address", Operator: "=", Value: "0xabc"},
+					db.Condition{Column: "topic0", Operator: "=", Value: "0xddf2"},
+				))
+			})
+		})
+	})
+
+	Describe("FindTransactions", func() {
+		var (
+			filter repository.TransactionFilter
+			err    error
+		)
+
+		BeforeEach(func() {
+			filter = repository.TransactionFilter{Limit: 20}
+		})
+
+		JustBeforeEach(func() {
+			_, err = repo.FindTransactions(ctx, filter)
+		})
+
+		When("no filter is given", func() {
+			It("should page through every transaction, newest first", func() {
+				Expect(err).NotTo(HaveOccurred())
+				_, query, keyset, _ := fakeStorage.FindPageArgsForCall(0)
+				Expect(query).To(Equal(db.Query{Where: []db.Condition{onChain}, Limit: 20}))
+				Expect(keyset).To(Equal(db.Keyset{Columns: []string{"block_number", "transaction_hash"}, Descending: true}))
+			})
+		})
+
+		When("every filter is given", func() {
+			BeforeEach(func() {
+				status, from, to, creation := uint64(1), uint64(10), uint64(20), true
+				filter.Status, filter.FromBlock, filter.ToBlock, filter.ContractCreation = &status, &from, &to, &creation
+				filter.From, filter.To, filter.MinValue = "0xa", "0xb", "1000"
+				filter.Ascending = true
+				filter.After = &repository.TransactionCursor{BlockNumber: 15, TransactionHash: "0xf"}
+			})
+
+			It("should combine them", func() {
+				_, query, keyset, _ := fakeStorage.FindPageArgsForCall(0)
+				Expect(query.Where).To(Equal([]db.Condition{
+					onChain,
+					{Column: "transaction_status", Operator: "=", Value: uint64(1)},
+					{Column: "block_number", Operator: ">=", Value: uint64(10)},
+					{Column: "block_number", Operator: "<=", Value: uint64(20)},
+					{Column: `"from"`, Operator: "=", Value: "0xa"},
+					{Column: `"to"`, Operator: "=", Value: "0xb"},
+					{Column: "contract_address", Operator: "IS NOT NULL"},
+					{Column: "(LENGTH(value), value)", Operator: ">=", Value: []any{4, "1000"}},
+				}))
+				Expect(keyset).To(Equal(db.Keyset{
+					Columns: []string{"block_number", "transaction_hash"},
+					After:   []any{uint64(15), "0xf"},
+				}))
+			})
+		})
+
+		When("contract creations are excluded", func() {
+			BeforeEach(func() {
+				creation := false
+				filter.ContractCreation = &creation
+			})
+
+			It("should only match transactions without a contract address", func() {
+				_, query, _, _ := fakeStorage.FindPageArgsForCall(0)
+				Expect(query.Where).To(Equal([]db.Condition{onChain, {Column: "contract_address", Operator: "IS NULL"}}))
+			})
+		})
+
+		When("database error occurs", func() {
+			BeforeEach(func() {
+				fakeStorage.FindPageReturns(fakeErr)
+			})
+
+			It("should return the error", func() {
+				Expect(err).To(MatchError(fakeErr))
+			})
+		})
+	})
+
+	Describe("FindAddressTransactions", func() {
+		var (
+			filter repository.AddressFilter
+			err    error
+		)
+
+		BeforeEach(func() {
+			filter = repository.AddressFilter{Address: "0xabc", Limit: 20}
+		})
+
+		JustBeforeEach(func() {
+			_, err = repo.FindAddressTransactions(ctx, filter)
+		})
+
+		When("no direction is given", func() {
+			It("should match every address column, newest first", func() {
+				Expect(err).NotTo(HaveOccurred())
+				_, query, keyset, _ := fakeStorage.FindPageArgsForCall(0)
+				Expect(query.AnyOf).To(Equal([]db.Condition{
+					{Column: `"from"`, Operator: "=", Value: "0xabc"},
+					{Column: `"to"`, Operator: "=", Value: "0xabc"},
+					{Column: "contract_address", Operator: "=", Value: "0xabc"},
+				}))
+				Expect(query.Where).To(Equal([]db.Condition{onChain}))
+				Expect(query.Limit).To(Equal(20))
+				Expect(keyset).To(Equal(db.Keyset{Columns: []string{"block_number", "transaction_hash"}, Descending: true}))
+			})
+		})
+
+		When("only sent transactions are requested", func() {
+			BeforeEach(func() {
+				filter.Sent = true
+			})
+
+			It("should only match the sender", func() {
+				_, query, _, _ := fakeStorage.FindPageArgsForCall(0)
+				Expect(query.AnyOf).To(Equal([]db.Condition{{Column: `"from"`, Operator: "=", Value: "0xabc"}}))
+			})
+		})
+
+		When("the block range and a cursor are given", func() {
+			BeforeEach(func() {
+				from, to := uint64(10), uint64(20)
+				filter.FromBlock, filter.ToBlock = &from, &to
+				filter.After = &repository.TransactionCursor{BlockNumber: 15, TransactionHash: "0xf"}
+			})
+
+			It("should restrict the blocks and skip to the cursor", func() {
+				_, query, keyset, _ := fakeStorage.FindPageArgsForCall(0)
+				Expect(query.Where).To(Equal([]db.Condition{
+					onChain,
+					{Column: "block_number", Operator: ">=", Value: uint64(10)},
+					{Column: "block_number", Operator: "<=", Value: uint64(20)},
+				}))
+				Expect(keyset.After).To(Equal([]any{uint64(15), "0xf"}))
+			})
+		})
+
+		When("database error occurs", func() {
+			BeforeEach(func() {
+				fakeStorage.FindPageReturns(fakeErr)
+			})
+
+			It("should return the error", func() {
+				Expect(err).To(MatchError(fakeErr))
+			})
+		})
+	})
+
+	Describe("SaveTokenTransfers", func() {
+		var (
+			transfers []repository.TokenTransfer
+			err       error
+		)
+
+		BeforeEach(func() {
+			transfers = []repository.TokenTransfer{{TransactionHash: "0x1", Standard: "erc20"}}
+		})
+
+		JustBeforeEach(func() {
+			err = repo.SaveTokenTransfers(ctx, transfers)
+		})
+
+		When("insert succeeds", func() {
+			It("should insert the transfers", func() {
+				Expect(err).NotTo(HaveOccurred())
+				_, records, conflict := fakeStorage.UpsertArgsForCall(0)
+				Expect(records).To(Equal(&transfers))
+				Expect(transfers[0].ChainID).To(Equal(chainID))
+				Expect(conflict).To(Equal(db.Conflict{Columns: []string{"chain_id", "transaction_hash", "log_index", "batch_index"}}))
+			})
+		})
+
+		When("there are no transfers", func() {
+			BeforeEach(func() {
+				transfers = nil
+			})
+
+			It("should return immediately", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeStorage.UpsertCallCount()).To(Equal(0))
+			})
+		})
+
+		When("database error occurs", func() {
+			BeforeEach(func() {
+				fakeStorage.UpsertReturns(fakeErr)
+			})
+
+			It("should return the error", func() {
+				Expect(err).To(MatchError(fakeErr))
+			})
+		})
+	})
+
+	Describe("ReplaceTokenTransfers", func() {
+		var err error
+
+		JustBeforeEach(func() {
+			err = repo.ReplaceTokenTransfers(ctx, "0x1", []repository.TokenTransfer{{TransactionHash: "0x1", LogIndex: 3}})
+		})
+
+		It("should delete the old transfers before inserting the new ones", func() {
+			Expect(err).NotTo(HaveOccurred())
+			_, conditions, entity := fakeStorage.DeleteWhereArgsForCall(0)
+			Expect(conditions).To(Equal([]db.Condition{onChain, {Column: "transaction_hash", Operator: "=", Value: "0x1"}}))
+			Expect(entity).To(BeAssignableToTypeOf(&repository.TokenTransfer{}))
+			Expect(fakeStorage.UpsertCallCount()).To(Equal(1))
+			Expect(fakeStorage.TransactionCallCount()).To(Equal(1))
+		})
+
+		When("deleting the old transfers fails", func() {
+			BeforeEach(func() {
+				fakeStorage.DeleteWhereReturns(0, fakeErr)
+			})
+
+			It("should not insert the new transfers", func() {
+				Expect(err).To(MatchError(fakeErr))
+				Expect(fakeStorage.UpsertCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("GetTokenTransfers", func() {
+		var err error
+
+		JustBeforeEach(func() {
+			_, err = repo.GetTokenTransfers(ctx, []string{"0x1", "0x2"})
+		})
+
+		It("should find the transfers by transaction hash", func() {
+			Expect(err).NotTo(HaveOccurred())
+			_, query, entity := fakeStorage.FindArgsForCall(0)
+			Expect(query.Where).To(Equal([]db.Condition{onChain, {Column: "transaction_hash", Operator: "IN", Value: []string{"0x1", "0x2"}}}))
+			Expect(entity).To(BeAssignableToTypeOf(&[]repository.TokenTransfer{}))
+		})
+
+		When("database error occurs", func() {
+			BeforeEach(func() {
+				fakeStorage.FindReturns(fakeErr)
+			})
+
+			It("should return the error", func() {
+				Expect(err).To(MatchError(fakeErr))
+			})
+		})
+	})
+
+	Describe("FindTokenTransfers", func() {
+		var (
+			filter repository.TransferFilter
+			err    error
+		)
+
+		BeforeEach(func() {
+			filter = repository.TransferFilter{Token: "0xabc", Limit: 50}
+		})
+
+		JustBeforeEach(func() {
+			_, err = repo.FindTokenTransfers(ctx, filter)
+		})
+
+		When("only the token is given", func() {
+			It("should filter by token only", func() {
+				Expect(err).NotTo(HaveOccurred())
+				_, query, _ := fakeStorage.FindArgsForCall(0)
+				Expect(query.Where).To(Equal([]db.Condition{onChain, {Column: "token", Operator: "=", Value: "0xabc"}}))
+				Expect(query.AnyOf).To(BeEmpty())
+				Expect(query.Limit).To(Equal(50))
+				Expect(query.OrderBy).To(Equal("block_number, log_index, batch_index"))
+			})
+		})
+
+		When("a holder is given", func() {
+			BeforeEach(func() {
+				filter.Holder = "0xdef"
+			})
+
+			It("should match the holder as sender or recipient", func() {
+				_, query, _ := fakeStorage.FindArgsForCall(0)
+				Expect(query.AnyOf).To(Equal([]db.Condition{
+					{Column: "from_address", Operator: "=", Value: "0xdef"},
+					{Column: "to_address", Operator: "=", Value: "0xdef"},
+				}))
+			})
+		})
+
+		When("database error occurs", func() {
+			BeforeEach(func() {
+				fakeStorage.FindReturns(fakeErr)
+			})
+
+			It("should return the error", func() {
+				Expect(err).To(MatchError(fakeErr))
+			})
+		})
+	})
+
+	Describe("SaveCallTraces", func() {
+		var (
+			traces []repository.CallTrace
+			err    error
+		)
+
+		BeforeEach(func() {
+			traces = []repository.CallTrace{{TransactionHash: "0x1", CallType: "CALL"}}
+		})
+
+		JustBeforeEach(func() {
+			err = repo.SaveCallTraces(ctx, traces)
+		})
+
+		When("insert succeeds", func() {
+			It("should insert the traces", func() {
+				Expect(err).NotTo(HaveOccurred())
+				_, records, conflict := fakeStorage.UpsertArgsForCall(0)
+				Expect(records).To(Equal(&traces))
+				Expect(traces[0].ChainID).To(Equal(chainID))
+				Expect(conflict).To(Equal(db.Conflict{Columns: []string{"chain_id", "transaction_hash", "trace_index"}}))
+			})
+		})
+
+		When("there are no traces", func() {
+			BeforeEach(func() {
+				traces = nil
+			})
+
+			It("should return immediately", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeStorage.UpsertCallCount()).To(Equal(0))
+			})
+		})
+
+		When("database error occurs", func() {
+			BeforeEach(func() {
+				fakeStorage.UpsertReturns(fakeErr)
+			})
+
+			It("should return the error", func() {
+				Expect(err).To(MatchError(fakeErr))
+			})
+		})
+	})
+
+	Describe("GetCallTraces", func() {
+		var err error
+
+		JustBeforeEach(func() {
+			_, err = repo.GetCallTraces(ctx, "0x1")
+		})
+
+		It("should find the traces of the transaction in trace order", func() {
+			Expect(err).NotTo(HaveOccurred())
+			_, query, entity := fakeStorage.FindArgsForCall(0)
+			Expect(query.Where).To(Equal([]db.Condition{onChain, {Column: "transaction_hash", Operator: "=", Value: "0x1"}}))
+			Expect(query.OrderBy).To(Equal("trace_index"))
+			Expect(entity).To(BeAssignableToTypeOf(&[]repository.CallTrace{}))
+		})
+
+		When("database error occurs", func() {
+			BeforeEach(func() {
+				fakeStorage.FindReturns(fakeErr)
+			})
+
+			It("should return the error", func() {
+				Expect(err).To(MatchError(fakeErr))
+			})
+		})
+	})
+	Describe("SaveBlock", func() {
+		var (
+			block repository.Block
+			err   error
+		)
+
+		BeforeEach(func() {
+			block = repository.Block{Hash: "0xb1", Number: 100}
+		})
+
+		JustBeforeEach(func() {
+			err = repo.SaveBlock(ctx, block)
+		})
+
+		It("should upsert it on the chain of the repository", func() {
+			Expect(err).NotTo(HaveOccurred())
+			_, record, conflict := fakeStorage.UpsertArgsForCall(0)
+			block.ChainID = chainID
+			Expect(record).To(Equal(&block))
+			Expect(conflict).To(Equal(db.Conflict{Columns: []string{"chain_id", "hash"}, UpdateAll: true}))
+		})
+
+		When("database error occurs", func() {
+			BeforeEach(func() {
+				fakeStorage.UpsertReturns(fakeErr)
+			})
+
+			It("should return the error", func() {
+				Expect(err).To(MatchError(fakeErr))
+			})
+		})
+	})
+
+	Describe("GetBlockByNumber", func() {
+		var err error
+
+		JustBeforeEach(func() {
+			_, err = repo.GetBlockByNumber(ctx, 100)
+		})
+
+		When("the block is cached", func() {
+			BeforeEach(func() {
+				fakeStorage.FindStub = func(ctx context.Context, query db.Query, dest any) error {
+					*dest.(*[]repository.Block) = []repository.Block{{Hash: "0xb1", Number: 100}}
+					return nil
+				}
+			})
+
+			It("should look the block up by number", func() {
+				Expect(err).NotTo(HaveOccurred())
+				_, query, _ := fakeStorage.FindArgsForCall(0)
+				Expect(query.Where).To(Equal([]db.Condition{onChain, {Column: "number", Operator: "=", Value: uint64(100)}}))
+				Expect(query.Limit).To(Equal(1))
+			})
+		})
+
+		When("the block is not cached", func() {
+			It("should return ErrBlockNotFound", func() {
+				Expect(err).To(MatchError(repository.ErrBlockNotFound))
+			})
+		})
+
+		When("database error occurs", func() {
+			BeforeEach(func() {
+				fakeStorage.FindReturns(fakeErr)
+			})
+
+			It("should return the error", func() {
+				Expect(err).To(MatchError(fakeErr))
+			})
+		})
+	})
+
+	Describe("GetBlockByHash", func() {
+		It("should look the block up by hash", func() {
+			_, err := repo.GetBlockByHash(ctx, "0xb1")
+			Expect(err).To(MatchError(repository.ErrBlockNotFound))
+			_, query, _ := fakeStorage.FindArgsForCall(0)
+			Expect(query.Where).To(Equal([]db.Condition{onChain, {Column: "hash", Operator: "=", Value: "0xb1"}}))
+		})
+	})
+
+	Describe("SaveIndexerCheckpoint", func() {
+		var (
+			checkpoint repository.IndexerCheckpoint
+			err        error
+		)
+
+		BeforeEach(func() {
+			checkpoint = repository.IndexerCheckpoint{Name: "blocks", FromBlock: 100, NextBlock: 150}
+		})
+
+		JustBeforeEach(func() {
+			err = repo.SaveIndexerCheckpoint(ctx, checkpoint)
+		})
+
+		It("should upsert it on the chain of the repository", func() {
+			Expect(err).NotTo(HaveOccurred())
+			_, record, conflict := fakeStorage.UpsertArgsForCall(0)
+			checkpoint.ChainID = chainID
+			Expect(record).To(Equal(&checkpoint))
+			Expect(conflict).To(Equal(db.Conflict{Columns: []string{"chain_id", "name"}, UpdateAll: true}))
+		})
+
+		When("database error occurs", func() {
+			BeforeEach(func() {
+				fakeStorage.UpsertReturns(fakeErr)
+			})
+
+			It("should return the error", func() {
+				Expect(err).To(MatchError(fakeErr))
+			})
+		})
+	})
+
+	Describe("GetIndexerCheckpoint", func() {
+		var (
+			checkpoint repository.IndexerCheckpoint
+			err        error
+		)
+
+		JustBeforeEach(func() {
+			checkpoint, err = repo.GetIndexerCheckpoint(ctx, "blocks")
+		})
+
+		When("a checkpoint is stored", func() {
+			BeforeEach(func() {
+				fakeStorage.FindStub = func(ctx context.Context, query db.Query, dest any) error {
+					*dest.(*[]repository.IndexerCheckpoint) = []repository.IndexerCheckpoint{{Name: "blocks", NextBlock: 150}}
+					return nil
+				}
+			})
+
+			It("should look the checkpoint up by name", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(checkpoint.NextBlock).To(Equal(uint64(150)))
+				_, query, _ := fakeStorage.FindArgsForCall(0)
+				Expect(query.Where).To(Equal([]db.Condition{onChain, {Column: "name", Operator: "=", Value: "blocks"}}))
+			})
+		})
+
+		When("no checkpoint is stored", func() {
+			It("should return ErrCheckpointNotFound", func() {
+				Expect(err).To(MatchError(repository.ErrCheckpointNotFound))
+			})
+		})
+
+		When("database error occurs", func() {
+			BeforeEach(func() {
+				fakeStorage.FindReturns(fakeErr)
+			})
+
+			It("should return the error", func() {
+				Expect(err).To(MatchError(fakeErr))
+			})
+		})
+	})
+
+	Describe("SaveContractABI", func() {
+		var (
+			contractABI repository.ContractABI
+			err         error
+		)
+
+		BeforeEach(func() {
+			contractABI = repository.ContractABI{Address: "0xC0FfeE", ABI: "[]"}
+		})
+
+		JustBeforeEach(func() {
+			err = repo.SaveContractABI(ctx, contractABI)
+		})
+
+		It("should upsert it on the chain of the repository", func() {
+			Expect(err).NotTo(HaveOccurred())
+			_, record, conflict := fakeStorage.UpsertArgsForCall(0)
+			contractABI.ChainID = chainID
+			Expect(record).To(Equal(&contractABI))
+			Expect(conflict).To(Equal(db.Conflict{Columns: []string{"chain_id", "address"}, UpdateAll: true}))
+		})
+
+		When("database error occurs", func() {
+			BeforeEach(func() {
+				fakeStorage.UpsertReturns(fakeErr)
+			})
+
+			It("should return the error", func() {
+				Expect(err).To(MatchError(fakeErr))
+			})
+		})
+	})
+
+	Describe("GetContractABIs", func() {
+		var err error
+
+		JustBeforeEach(func() {
+			_, err = repo.GetContractABIs(ctx)
+		})
+
+		It("should load all abis of the chain", func() {
+			Expect(err).NotTo(HaveOccurred())
+			_, query, entity := fakeStorage.FindArgsForCall(0)
+			Expect(query.Where).To(Equal([]db.Condition{onChain}))
+			Expect(entity).To(BeAssignableToTypeOf(&[]repository.ContractABI{}))
+		})
+
+		When("database error occurs", func() {
+			BeforeEach(func() {
+				fakeStorage.FindReturns(fakeErr)
+			})
+
+			It("should return the error", func() {
+				Expect(err).To(MatchError(fakeErr))
+			})
+		})
+	})
+
+	Describe("ClaimUnassignedRows", func() {
+		var (
+			claimed int64
+			err     error
+		)
+
+		BeforeEach(func() {
+			fakeStorage.UpdateWhereReturns(2, nil)
+		})
+
+		JustBeforeEach(func() {
+			claimed, err = repo.ClaimUnassignedRows(ctx)
+		})
+
+		It("should move the rows of chain 0 of every table to the chain of the repository", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeStorage.TransactionCallCount()).To(Equal(1))
+			Expect(fakeStorage.UpdateWhereCallCount()).To(Equal(7))
+			Expect(claimed).To(Equal(int64(14)))
+
+			_, conditions, values, _ := fakeStorage.UpdateWhereArgsForCall(0)
+			Expect(conditions).To(Equal([]db.Condition{{Column: "chain_id", Operator: "=", Value: 0}}))
+			Expect(values).To(Equal(map[string]any{"chain_id": chainID}))
+		})
+
+		When("database error occurs", func() {
+			BeforeEach(func() {
+				fakeStorage.UpdateWhereReturns(0, fakeErr)
 			})
 
 			It("should return the error", func() {
@@ -0,0 +1,431 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"time"
+
+	"fethcher/internal/db"
+	"fethcher/internal/migrate"
+	"fethcher/internal/repository"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// postgresDSNEnvKey names the PostgreSQL database the storage behaviour runs against. Its tables are dropped after every test.
+const postgresDSNEnvKey = "TEST_POSTGRES_DSN"
+
+var _ = Describe("SQLite storage", func() {
+	storageBehaviour(func() (repository.Storage, *gorm.DB) {
+		sqliteDB, err := db.NewSQLiteDB(":memory:")
+		Expect(err).NotTo(HaveOccurred())
+		return sqliteDB, sqliteDB.DB
+	})
+})
+
+var _ = Describe("PostgreSQL storage", func() {
+	storageBehaviour(func() (repository.Storage, *gorm.DB) {
+		dsn := os.Getenv(postgresDSNEnvKey)
+		if dsn == "" {
+			Skip(postgresDSNEnvKey + " is not set")
+		}
+
+		postgresDB, err := db.NewPostgresDB(dsn)
+		Expect(err).NotTo(HaveOccurred())
+		return postgresDB, postgresDB.DB
+	})
+})
+
+// storageBehaviour runs the repository against a migrated database of the storage that open connects to, so that every storage
+// is held to the same behaviour.
+func storageBehaviour(open func() (repository.Storage, *gorm.DB)) {
+	var (
+		ctx     context.Context
+		storage repository.Storage
+		repo    *repository.TransactionRepository
+		base    *repository.TransactionRepository
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		var gormDB *gorm.DB
+		storage, gormDB = open()
+		gormDB.Logger = logger.Discard
+
+		migrator, err := migrate.NewMigrator(zap.NewNop().Sugar(), gormDB)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = migrator.Up(ctx)
+		Expect(err).NotTo(HaveOccurred())
+
+		DeferCleanup(func() {
+			_, err := migrator.To(ctx, 0)
+			Expect(err).NotTo(HaveOccurred())
+
+			sqlDB, err := gormDB.DB()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sqlDB.Close()).To(Succeed())
+		})
+
+		repo = repository.NewTransactionRepository(storage, 1)
+		base = repository.NewTransactionRepository(storage, 8453)
+	})
+
+	transaction := func(hash string, block uint64, confirmations uint64) repository.Transaction {
+		to := "0x00000000000000000000000000000000000000b0"
+		txType := uint8(2)
+		return repository.Transaction{
+			ChainID:           1,
+			TransactionHash:   hash,
+			TransactionStatus: 1,
+			BlockHash:         "0xblock",
+			BlockNumber:       block,
+			From:              "0x00000000000000000000000000000000000000a0",
+			To:                &to,
+			Input:             "0x",
+			Value:             "1000",
+			Confirmations:     confirmations,
+			TxType:            &txType,
+			AccessList:        []repository.AccessTuple{{Address: to, StorageKeys: []string{"0x01"}}},
+		}
+	}
+
+	Describe("transactions", func() {
+		It("should save and read back transactions", func() {
+			Expect(repo.SaveTransactions(ctx, []repository.Transaction{transaction("0x1", 10, 1)})).To(Succeed())
+
+			transactions, err := repo.GetTransactionsByHash(ctx, []string{"0x1", "0x2"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(transactions).To(Equal([]repository.Transaction{transaction("0x1", 10, 1)}))
+		})
+
+		It("should save and read back the failure of a failed transaction", func() {
+			failed := transaction("0x1", 10, 1)
+			message, data := "execution reverted", "0x08c379a0"
+			failed.TransactionStatus, failed.RevertMessage, failed.RevertData = 0, &message, &data
+			Expect(repo.SaveTransactions(ctx, []repository.Transaction{failed})).To(Succeed())
+
+			transactions, err := repo.GetTransactionsByHash(ctx, []string{"0x1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(transactions).To(Equal([]repository.Transaction{failed}))
+		})
+
+		It("should only overwrite a saved transaction with one that has more confirmations", func() {
+			Expect(repo.SaveTransactions(ctx, []repository.Transaction{transaction("0x1", 10, 5)})).To(Succeed())
+			Expect(repo.SaveTransactions(ctx, []repository.Transaction{transaction("0x1", 11, 3)})).To(Succeed())
+
+			transactions, err := repo.GetTransactionsByHash(ctx, []string{"0x1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(transactions).To(HaveLen(1))
+			Expect(transactions[0].BlockNumber).To(Equal(uint64(10)))
+
+			Expect(repo.SaveTransactions(ctx, []repository.Transaction{transaction("0x1", 12, 8)})).To(Succeed())
+
+			transactions, err = repo.GetTransactionsByHash(ctx, []string{"0x1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(transactions[0].BlockNumber).To(Equal(uint64(12)))
+			Expect(transactions[0].Confirmations).To(Equal(uint64(8)))
+		})
+
+		It("should page through transactions newest first", func() {
+			Expect(repo.SaveTransactions(ctx, []repository.Transaction{
+				transaction("0x1", 10, 1), transaction("0x2", 11, 1), transaction("0x3", 12, 1),
+			})).To(Succeed())
+
+			page, err := repo.FindTransactions(ctx, repository.TransactionFilter{Limit: 2})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hashes(page)).To(Equal([]string{"0x3", "0x2"}))
+
+			page, err = repo.FindTransactions(ctx, repository.TransactionFilter{
+				After: &repository.TransactionCursor{BlockNumber: 11, TransactionHash: "0x2"},
+				Limit: 2,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hashes(page)).To(Equal([]string{"0x1"}))
+		})
+
+		It("should filter transactions by a minimum value", func() {
+			small, large := transaction("0x1", 10, 1), transaction("0x2", 11, 1)
+			small.Value, large.Value = "999", "1000000000000000000"
+			Expect(repo.SaveTransactions(ctx, []repository.Transaction{small, large})).To(Succeed())
+
+			page, err := repo.FindTransactions(ctx, repository.TransactionFilter{MinValue: "1000"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hashes(page)).To(Equal([]string{"0x2"}))
+		})
+
+		It("should page through the incomplete transactions by hash", func() {
+			complete := transaction("0x4", 13, 1)
+			timestamp := uint64(1700000000)
+			complete.BlockTimestamp = &timestamp
+			Expect(repo.SaveTransactions(ctx, []repository.Transaction{
+				transaction("0x3", 12, 1), transaction("0x1", 10, 1), complete, transaction("0x2", 11, 1),
+			})).To(Succeed())
+
+			page, err := repo.GetIncompleteTransactions(ctx, "", 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hashes(page)).To(Equal([]string{"0x1", "0x2"}))
+
+			page, err = repo.GetIncompleteTransactions(ctx, "0x2", 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hashes(page)).To(Equal([]string{"0x3"}))
+		})
+
+		It("should compare values above 2^64 exactly", func() {
+			below, above := transaction("0x1", 10, 1), transaction("0x2", 11, 1)
+			below.Value, above.Value = "18446744073709551616000", "18446744073709551616001"
+			Expect(repo.SaveTransactions(ctx, []repository.Transaction{below, above})).To(Succeed())
+
+			page, err := repo.FindTransactions(ctx, repository.TransactionFilter{MinValue: "018446744073709551616001"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hashes(page)).To(Equal([]string{"0x2"}))
+
+			page, err = repo.FindTransactions(ctx, repository.TransactionFilter{MinValue: "9999999999999999999999"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hashes(page)).To(Equal([]string{"0x2", "0x1"}))
+		})
+
+		It("should delete transactions together with their logs, transfers and call traces", func() {
+			Expect(repo.SaveTransactions(ctx, []repository.Transaction{transaction("0x1", 10, 1)})).To(Succeed())
+			Expect(repo.SaveTransactionLogs(ctx, []repository.TransactionLog{{TransactionHash: "0x1", Address: "0xc", Data: "0x"}})).To(Succeed())
+			Expect(repo.SaveTokenTransfers(ctx, []repository.TokenTransfer{{TransactionHash: "0x1", Token: "0xc", Amount: "1", Standard: "erc20"}})).To(Succeed())
+			Expect(repo.SaveCallTraces(ctx, []repository.CallTrace{{TransactionHash: "0x1", CallType: "CALL", Value: "0"}})).To(Succeed())
+
+			Expect(repo.DeleteTransactions(ctx, []string{"0x1"})).To(Succeed())
+
+			transactions, err := repo.GetTransactionsByHash(ctx, []string{"0x1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(transactions).To(BeEmpty())
+			logs, err := repo.GetTransactionLogs(ctx, []string{"0x1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(logs).To(BeEmpty())
+			transfers, err := repo.GetTokenTransfers(ctx, []string{"0x1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(transfers).To(BeEmpty())
+			traces, err := repo.GetCallTraces(ctx, "0x1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(traces).To(BeEmpty())
+		})
+	})
+
+	Describe("replacing transactions", func() {
+		It("should replace a transaction together with its logs and token transfers", func() {
+			Expect(repo.SaveTransactions(ctx, []repository.Transaction{transaction("0x1", 10, 1)})).To(Succeed())
+			Expect(repo.SaveTransactionLogs(ctx, []repository.TransactionLog{{TransactionHash: "0x1", LogIndex: 0, Address: "0xc", Data: "0x"}})).To(Succeed())
+			Expect(repo.SaveTokenTransfers(ctx, []repository.TokenTransfer{{TransactionHash: "0x1", LogIndex: 0, Token: "0xc", Amount: "1", Standard: "erc20"}})).To(Succeed())
+
+			Expect(repo.ReplaceTransaction(ctx, transaction("0x1", 11, 1),
+				[]repository.TransactionLog{{TransactionHash: "0x1", LogIndex: 4, Address: "0xd", Data: "0x"}},
+				[]repository.TokenTransfer{{TransactionHash: "0x1", LogIndex: 4, Token: "0xd", Amount: "2", Standard: "erc20"}},
+			)).To(Succeed())
+
+			transactions, err := repo.GetTransactionsByHash(ctx, []string{"0x1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(transactions).To(HaveLen(1))
+			Expect(transactions[0].BlockNumber).To(Equal(uint64(11)))
+			logs, err := repo.GetTransactionLogs(ctx, []string{"0x1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(logs).To(HaveLen(1))
+			Expect(logs[0].LogIndex).To(Equal(uint(4)))
+			transfers, err := repo.GetTokenTransfers(ctx, []string{"0x1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(transfers).To(HaveLen(1))
+			Expect(transfers[0].Token).To(Equal("0xd"))
+		})
+	})
+
+	Describe("logs", func() {
+		It("should skip logs that are already saved and replace them on request", func() {
+			logs := []repository.TransactionLog{{TransactionHash: "0x1", LogIndex: 0, Address: "0xc", Data: "0x"}}
+			Expect(repo.SaveTransactionLogs(ctx, logs)).To(Succeed())
+			Expect(repo.SaveTransactionLogs(ctx, logs)).To(Succeed())
+
+			Expect(repo.ReplaceTransactionLogs(ctx, "0x1", []repository.TransactionLog{
+				{TransactionHash: "0x1", LogIndex: 4, Address: "0xd", Data: "0x"},
+			})).To(Succeed())
+
+			stored, err := repo.GetTransactionLogs(ctx, []string{"0x1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored).To(HaveLen(1))
+			Expect(stored[0].LogIndex).To(Equal(uint(4)))
+		})
+	})
+
+	Describe("call traces", func() {
+		It("should skip traces that are already saved and read them back in trace order", func() {
+			traces := []repository.CallTrace{
+				{TransactionHash: "0x1", TraceIndex: 1, TraceAddress: []int{0}, CallType: "CALL", Value: "5", Error: "execution reverted", Reverted: true},
+				{TransactionHash: "0x1", TraceIndex: 0, TraceAddress: []int{}, CallType: "CALL", Value: "10"},
+			}
+			Expect(repo.SaveCallTraces(ctx, traces)).To(Succeed())
+			Expect(repo.SaveCallTraces(ctx, traces)).To(Succeed())
+
+			stored, err := repo.GetCallTraces(ctx, "0x1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored).To(HaveLen(2))
+			Expect(stored[0].TraceAddress).To(BeEmpty())
+			Expect(stored[1].TraceAddress).To(Equal([]int{0}))
+			Expect(stored[1].Reverted).To(BeTrue())
+
+			other, err := base.GetCallTraces(ctx, "0x1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(other).To(BeEmpty())
+		})
+	})
+
+	Describe("user history", func() {
+		It("should count repeated queries and page through the history newest first", func() {
+			Expect(repo.SaveUserHistory(ctx, "user", []string{"0x1"})).To(Succeed())
+			Expect(repo.SaveUserHistory(ctx, "user", []string{"0x2"})).To(Succeed())
+			Expect(repo.SaveUserHistory(ctx, "user", []string{"0x1", "0x3"})).To(Succeed())
+
+			history, err := repo.GetUserHistory(ctx, repository.HistoryFilter{UserID: "user", Limit: 2})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(history).To(HaveLen(2))
+			Expect([]string{history[0].TransactionHash, history[1].TransactionHash}).To(ConsistOf("0x1", "0x3"))
+			for _, entry := range history {
+				if entry.TransactionHash == "0x1" {
+					Expect(entry.QueryCount).To(Equal(uint(2)))
+				}
+			}
+
+			last := history[1]
+			history, err = repo.GetUserHistory(ctx, repository.HistoryFilter{
+				UserID: "user",
+				After:  &repository.HistoryCursor{QueriedAt: last.QueriedAt, TransactionHash: last.TransactionHash},
+				Limit:  2,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(history).To(HaveLen(1))
+			Expect(history[0].TransactionHash).To(Equal("0x2"))
+		})
+
+		It("should restrict the history to a query time range", func() {
+			Expect(repo.SaveUserHistory(ctx, "user", []string{"0x1"})).To(Succeed())
+			future := time.Now().Add(time.Hour)
+
+			history, err := repo.GetUserHistory(ctx, repository.HistoryFilter{UserID: "user", QueriedFrom: &future})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(history).To(BeEmpty())
+		})
+
+		It("should delete and clear entries", func() {
+			Expect(repo.SaveUserHistory(ctx, "user", []string{"0x1", "0x2", "0x3"})).To(Succeed())
+
+			deleted, err := repo.DeleteUserHistory(ctx, "user", []string{"0x1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleted).To(Equal(int64(1)))
+
+			deleted, err = repo.ClearUserHistory(ctx, "user")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleted).To(Equal(int64(2)))
+		})
+	})
+
+	Describe("users", func() {
+		It("should seed the users once and find them by name", func() {
+			Expect(repo.SeedUserTable(ctx)).To(Succeed())
+			Expect(repo.SeedUserTable(ctx)).To(Succeed())
+
+			user, err := repo.GetUserFromDB(ctx, "alice")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(user.Username).To(Equal("alice"))
+
+			_, err = repo.GetUserFromDB(ctx, "mallory")
+			Expect(errors.Is(err, repository.ErrUserNotFound)).To(BeTrue())
+		})
+	})
+
+	Describe("blocks", func() {
+		It("should overwrite a saved block and find it by number and hash", func() {
+			block := repository.Block{Hash: "0xb", Number: 7, ParentHash: "0xa", Miner: "0xm", StateRoot: "0x1",
+				TransactionsRoot: "0x2", ReceiptsRoot: "0x3", TransactionHashes: []string{"0x1"}}
+			Expect(repo.SaveBlock(ctx, block)).To(Succeed())
+			block.GasUsed = 21000
+			Expect(repo.SaveBlock(ctx, block)).To(Succeed())
+
+			stored, err := repo.GetBlockByNumber(ctx, 7)
+			Expect(err).NotTo(HaveOccurred())
+			block.ChainID = 1
+			Expect(stored).To(Equal(block))
+
+			_, err = repo.GetBlockByHash(ctx, "0xc")
+			Expect(err).To(MatchError(repository.ErrBlockNotFound))
+		})
+	})
+
+	Describe("indexer checkpoints", func() {
+		It("should replace a saved checkpoint", func() {
+			Expect(repo.SaveIndexerCheckpoint(ctx, repository.IndexerCheckpoint{Name: "blocks", NextBlock: 1})).To(Succeed())
+			Expect(repo.SaveIndexerCheckpoint(ctx, repository.IndexerCheckpoint{Name: "blocks", NextBlock: 2})).To(Succeed())
+
+			checkpoint, err := repo.GetIndexerCheckpoint(ctx, "blocks")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(checkpoint.NextBlock).To(Equal(uint64(2)))
+		})
+	})
+
+	Describe("chains", func() {
+		It("should keep the rows of every chain apart", func() {
+			onBase := transaction("0x1", 20, 1)
+			onBase.BlockHash = "0xbase"
+			Expect(repo.SaveTransactions(ctx, []repository.Transaction{transaction("0x1", 10, 1)})).To(Succeed())
+			Expect(base.SaveTransactions(ctx, []repository.Transaction{onBase})).To(Succeed())
+
+			transactions, err := base.GetTransactionsByHash(ctx, []string{"0x1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(transactions).To(HaveLen(1))
+			Expect(transactions[0].ChainID).To(Equal(uint64(8453)))
+			Expect(transactions[0].BlockHash).To(Equal("0xbase"))
+
+			Expect(base.DeleteTransactions(ctx, []string{"0x1"})).To(Succeed())
+			transactions, err = repo.GetTransactionsByHash(ctx, []string{"0x1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(transactions).To(HaveLen(1))
+
+			Expect(repo.SaveUserHistory(ctx, "user", []string{"0x1"})).To(Succeed())
+			Expect(base.SaveUserHistory(ctx, "user", []string{"0x1"})).To(Succeed())
+			history, err := base.GetUserHistory(ctx, repository.HistoryFilter{UserID: "user"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(history).To(HaveLen(1))
+			Expect(history[0].QueryCount).To(Equal(uint(1)))
+
+			// migration 2 only rolls back once a single chain is left
+			_, err = base.ClearUserHistory(ctx, "user")
+			Expect(err).NotTo(HaveOccurred())
+
+			block := repository.Block{Hash: "0xb", Number: 7, ParentHash: "0xa", Miner: "0xm", StateRoot: "0x1",
+				TransactionsRoot: "0x2", ReceiptsRoot: "0x3"}
+			Expect(repo.SaveBlock(ctx, block)).To(Succeed())
+			_, err = base.GetBlockByNumber(ctx, 7)
+			Expect(err).To(MatchError(repository.ErrBlockNotFound))
+		})
+
+		It("should let a chain claim the rows cached before chains were configured", func() {
+			unassigned := transaction("0x1", 10, 1)
+			unassigned.ChainID = 0
+			Expect(storage.Upsert(ctx, &unassigned, db.Conflict{Columns: []string{"chain_id", "transaction_hash"}})).To(Succeed())
+
+			claimed, err := base.ClaimUnassignedRows(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(claimed).To(Equal(int64(1)))
+
+			transactions, err := base.GetTransactionsByHash(ctx, []string{"0x1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(transactions).To(HaveLen(1))
+		})
+	})
+}
+
+func hashes(transactions []repository.Transaction) []string {
+	result := make([]string, 0, len(transactions))
+	for _, tx := range transactions {
+		result = append(result, tx.TransactionHash)
+	}
+	return result
+}
@@ -7,7 +7,14 @@ import (
 )
 
 func main() {
-	if err := cmd.Start(); err != nil {
+	var err error
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		err = cmd.Migrate(os.Args[2:])
+	} else {
+		err = cmd.Start()
+	}
+
+	if err != nil {
 		fmt.Printf("server run into an error: %s", err)
 		os.Exit(1)
 	}